@@ -0,0 +1,134 @@
+package bigfloat
+
+import "math/big"
+
+// WynnEpsilon returns an estimate of the limit of the sequence of
+// partial sums s computed using Wynn's epsilon algorithm, which
+// implements the Shanks transformation. Precision is the largest
+// precision of the elements of s. The function panics if s is empty.
+//
+// The epsilon algorithm is very effective on alternating series and
+// on sequences whose error behaves like a sum of geometric terms.
+func WynnEpsilon(s []*big.Float) *big.Float {
+
+	prec := seqPrec("WynnEpsilon", s)
+	wprec := prec + 64 // guard digits
+
+	// The epsilon table is built column by column:
+	//     ε[-1][k] = 0, ε[0][k] = s[k]
+	//     ε[j+1][k] = ε[j-1][k+1] + 1/(ε[j][k+1] - ε[j][k])
+	// and only the even columns are estimates of the limit.
+	prev := make([]*big.Float, len(s)+1) // ε[j-1]
+	for k := range prev {
+		prev[k] = new(big.Float).SetPrec(wprec)
+	}
+	cur := make([]*big.Float, len(s)) // ε[j]
+	for k := range s {
+		cur[k] = new(big.Float).SetPrec(wprec).Set(s[k])
+	}
+
+	best := cur[len(cur)-1]
+	for j := 0; len(cur) > 1; j++ {
+		next := make([]*big.Float, len(cur)-1)
+		for k := range next {
+			d := new(big.Float).SetPrec(wprec).Sub(cur[k+1], cur[k])
+			if d.Sign() == 0 {
+				// an even column has converged exactly; an odd one
+				// holds no estimates, and the table can't be continued
+				if j%2 == 1 {
+					return best.SetPrec(prec)
+				}
+				return new(big.Float).SetPrec(prec).Set(cur[k+1])
+			}
+			next[k] = d.Quo(big.NewFloat(1), d).Add(d, prev[k+1])
+		}
+		prev, cur = cur, next
+		if j%2 == 1 {
+			best = cur[len(cur)-1]
+		}
+	}
+
+	return best.SetPrec(prec)
+}
+
+// LevinU returns an estimate of the limit of the sequence of partial
+// sums s computed using Levin's u-transform, where s[n] is the sum of
+// the first n+1 terms of the series. Precision is the largest precision
+// of the elements of s. The function panics if s is empty.
+//
+// The u-transform accelerates both alternating and logarithmically
+// convergent series, like Σ 1/n², for which the epsilon algorithm
+// is of little help. It's numerically unstable, and it uses a number
+// of guard digits that grows with len(s); because of that, using more
+// than a few dozen partial sums is rarely useful.
+func LevinU(s []*big.Float) *big.Float {
+
+	prec := seqPrec("LevinU", s)
+
+	k := len(s) - 1
+	if k == 0 {
+		return new(big.Float).Copy(s[0])
+	}
+
+	// guard digits to cover the cancellation in the sums below
+	wprec := prec + 64 + uint(k)*uint(big.NewInt(int64(k)).BitLen())
+
+	// With the remainder estimates ω[j] = (j+1)·a[j], where a[j] is
+	// the j-th term of the series, the u-transform is
+	//     Σ c[j]·s[j]/ω[j] / Σ c[j]/ω[j]
+	// with c[j] = (-1)ʲ·binomial(k, j)·(j+1)**(k-1).
+	num := new(big.Float).SetPrec(wprec)
+	den := new(big.Float).SetPrec(wprec)
+	a := new(big.Float).SetPrec(wprec)
+	binom := big.NewInt(1)
+	for j := 0; j <= k; j++ {
+		if j > 0 {
+			a.Sub(s[j], s[j-1])
+			binom.Mul(binom, big.NewInt(int64(k-j+1)))
+			binom.Quo(binom, big.NewInt(int64(j)))
+		} else {
+			a.Set(s[0])
+		}
+		if a.Sign() == 0 {
+			// a zero term carries no information on the
+			// remainder; skip it
+			continue
+		}
+
+		// c[j]/ω[j] = (-1)ʲ·binomial(k, j)·(j+1)**(k-2)/a[j]
+		c := new(big.Int).Exp(big.NewInt(int64(j+1)), big.NewInt(int64(k-1)), nil)
+		c.Mul(c, binom)
+		w := new(big.Float).SetPrec(wprec).SetInt(c)
+		w.Quo(w, a.Mul(a, big.NewFloat(float64(j+1))))
+		if j%2 == 1 {
+			w.Neg(w)
+		}
+
+		den.Add(den, w)
+		num.Add(num, w.Mul(w, s[j]))
+	}
+
+	if den.Sign() == 0 {
+		return new(big.Float).Copy(s[k])
+	}
+
+	return num.Quo(num, den).SetPrec(prec)
+}
+
+// seqPrec returns the largest precision of the elements of s, and
+// panics, using name as the function name, if s is empty.
+func seqPrec(name string, s []*big.Float) uint {
+
+	if len(s) == 0 {
+		panic(name + ": empty sequence")
+	}
+
+	var prec uint
+	for _, x := range s {
+		if x.Prec() > prec {
+			prec = x.Prec()
+		}
+	}
+
+	return prec
+}
@@ -0,0 +1,93 @@
+package bigfloat_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ThreeAndTwo/bigfloat"
+)
+
+// partialSums returns the first n partial sums of Σ term(k) for
+// k = 1, 2, ...
+func partialSums(n int, prec uint, term func(k int64) *big.Float) []*big.Float {
+	s := make([]*big.Float, n)
+	sum := new(big.Float).SetPrec(prec)
+	for i := range s {
+		sum.Add(sum, term(int64(i+1)))
+		s[i] = new(big.Float).Copy(sum)
+	}
+	return s
+}
+
+func TestWynnEpsilon(t *testing.T) {
+	const prec = 200
+
+	// Σ (-1)ᵏ⁺¹/k = log(2)
+	s := partialSums(40, prec, func(k int64) *big.Float {
+		x := new(big.Float).SetPrec(prec).Quo(big.NewFloat(1), big.NewFloat(float64(k)))
+		if k%2 == 0 {
+			x.Neg(x)
+		}
+		return x
+	})
+	want := bigfloat.Log(big.NewFloat(2).SetPrec(prec))
+
+	// the plain partial sums are only good to a couple of digits
+	if closeTo(s[len(s)-1], want, 10) {
+		t.Fatalf("partial sum is unexpectedly accurate")
+	}
+
+	x := bigfloat.WynnEpsilon(s)
+	if x.Prec() != prec {
+		t.Errorf("WynnEpsilon returned precision %d, want %d", x.Prec(), prec)
+	}
+	if !closeTo(x, want, 100) {
+		t.Errorf("WynnEpsilon(Σ (-1)ᵏ⁺¹/k) =\ngot  %g;\nwant %g", x, want)
+	}
+
+	// a sequence that has already converged
+	c := []*big.Float{big.NewFloat(1), big.NewFloat(2), big.NewFloat(2), big.NewFloat(2)}
+	if x := bigfloat.WynnEpsilon(c); x.Cmp(big.NewFloat(2)) != 0 {
+		t.Errorf("WynnEpsilon(1, 2, 2, 2) = %g; want 2", x)
+	}
+
+	// an arithmetic sequence, whose first column of differences is
+	// constant: the last partial sum is the best estimate
+	c = []*big.Float{big.NewFloat(1), big.NewFloat(2), big.NewFloat(3)}
+	if x := bigfloat.WynnEpsilon(c); x.Cmp(big.NewFloat(3)) != 0 {
+		t.Errorf("WynnEpsilon(1, 2, 3) = %g; want 3", x)
+	}
+}
+
+func TestLevinU(t *testing.T) {
+	const prec = 200
+
+	// Σ 1/k² = π²/6, which converges logarithmically
+	s := partialSums(30, prec, func(k int64) *big.Float {
+		x := new(big.Float).SetPrec(prec).SetInt64(k * k)
+		return x.Quo(big.NewFloat(1), x)
+	})
+	want, _, _ := new(big.Float).SetPrec(prec).Parse(piStr, 10)
+	want.Mul(want, want).Quo(want, big.NewFloat(6))
+
+	x := bigfloat.LevinU(s)
+	if x.Prec() != prec {
+		t.Errorf("LevinU returned precision %d, want %d", x.Prec(), prec)
+	}
+	if !closeTo(x, want, 60) {
+		t.Errorf("LevinU(Σ 1/k²) =\ngot  %g;\nwant %g", x, want)
+	}
+
+	// Σ (-1)ᵏ⁺¹/k = log(2)
+	s = partialSums(30, prec, func(k int64) *big.Float {
+		x := new(big.Float).SetPrec(prec).Quo(big.NewFloat(1), big.NewFloat(float64(k)))
+		if k%2 == 0 {
+			x.Neg(x)
+		}
+		return x
+	})
+	want = bigfloat.Log(big.NewFloat(2).SetPrec(prec))
+	if x := bigfloat.LevinU(s); !closeTo(x, want, 80) {
+		t.Errorf("LevinU(Σ (-1)ᵏ⁺¹/k) =\ngot  %g;\nwant %g", x, want)
+	}
+}
@@ -0,0 +1,128 @@
+package bigfloat
+
+import "math/big"
+
+// An Accumulator computes the sum, the mean and the variance of a
+// stream of values. The running sums are kept exactly, so no
+// precision is lost no matter how many values are added, and the
+// results are correctly rounded. The zero value is an empty
+// Accumulator ready to use.
+//
+// The results are computed at the largest precision of the values
+// added so far. The memory used is proportional to the difference
+// between the largest and the smallest exponent of the values, and
+// it doesn't grow with their number.
+type Accumulator struct {
+	s1, s2 exactSum // Σ xᵢ and Σ xᵢ²
+	prec   uint
+}
+
+// Add adds x to the accumulated values.
+func (a *Accumulator) Add(x *big.Float) {
+
+	if x.Prec() > a.prec {
+		a.prec = x.Prec()
+	}
+
+	a.s1.add(x)
+	if x.IsInf() || x.Sign() == 0 {
+		return
+	}
+
+	m, e := intMantExp(x)
+	a.s2.addInt(m.Mul(m, m), 2*e)
+}
+
+// AddFloat64 adds f to the accumulated values. It panics if f is NaN.
+func (a *Accumulator) AddFloat64(f float64) {
+	a.Add(big.NewFloat(f))
+}
+
+// Count returns the number of values added.
+func (a *Accumulator) Count() int {
+	return a.s1.n
+}
+
+// Reset empties a.
+func (a *Accumulator) Reset() {
+	*a = Accumulator{}
+}
+
+// Sum returns the sum of the values, correctly rounded. The sum of no
+// values is 0. The method panics if infinities of opposite sign have
+// been added.
+func (a *Accumulator) Sum() *big.Float {
+	return a.s1.float(a.prec, big.ToNearestEven)
+}
+
+// Mean returns the arithmetic mean of the values, correctly rounded.
+// The method panics if no values have been added, or if infinities
+// of opposite sign have been added.
+func (a *Accumulator) Mean() *big.Float {
+	return a.mean("Mean")
+}
+
+// Variance returns the sample variance (with Bessel's correction) of
+// the values, correctly rounded. The method panics if fewer than two
+// values have been added, or if infinite values have been added.
+func (a *Accumulator) Variance() *big.Float {
+	return new(big.Float).SetPrec(a.prec).Quo(a.varianceNum("Variance"), a.varianceDen())
+}
+
+// StdDev returns the sample standard deviation of the values, that
+// is the square root of their Variance. The method panics if fewer
+// than two values have been added, or if infinite values have been
+// added.
+func (a *Accumulator) StdDev() *big.Float {
+	v := new(big.Float).SetPrec(a.prec+64).Quo(a.varianceNum("StdDev"), a.varianceDen())
+	return Sqrt(v).SetPrec(a.prec)
+}
+
+func (a *Accumulator) mean(name string) *big.Float {
+
+	if a.s1.n == 0 {
+		panic(name + ": no values")
+	}
+
+	// both the operands are exact, so Quo rounds a single time
+	s := a.s1.float(a.s1.exactPrec(), big.ToNearestEven)
+	n := new(big.Float).SetInt64(int64(a.s1.n))
+	return new(big.Float).SetPrec(a.prec).Quo(s, n)
+}
+
+// varianceNum returns n·Σ xᵢ² - (Σ xᵢ)², computed exactly. It panics,
+// using name as the function name, if there are fewer than two finite
+// values.
+//
+// Computing the variance from the sums of the values and of their
+// squares is usually a bad idea, because of cancellation. Here the
+// sums are exact, so the result can't lose accuracy, even when the
+// deviations from the mean are many orders of magnitude smaller than
+// the mean.
+func (a *Accumulator) varianceNum(name string) *big.Float {
+
+	if a.s1.n < 2 {
+		panic(name + ": fewer than two values")
+	}
+	if a.s1.posInf || a.s1.negInf {
+		panic(name + ": infinite value")
+	}
+
+	// n·Σ xᵢ², as an integer multiple of a power of two
+	var num exactSum
+	m := new(big.Int).Mul(&a.s2.mant, big.NewInt(int64(a.s1.n)))
+	num.addInt(m, a.s2.exp)
+
+	// -(Σ xᵢ)²
+	m = new(big.Int).Mul(&a.s1.mant, &a.s1.mant)
+	num.addInt(m.Neg(m), 2*a.s1.exp)
+
+	return num.float(num.exactPrec(), big.ToNearestEven)
+}
+
+// varianceDen returns n·(n-1), exactly.
+func (a *Accumulator) varianceDen() *big.Float {
+	n := int64(a.s1.n)
+	d := new(big.Float).SetInt64(n)
+	return d.Mul(d, new(big.Float).SetInt64(n-1))
+}
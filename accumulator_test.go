@@ -0,0 +1,128 @@
+package bigfloat_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ThreeAndTwo/bigfloat"
+)
+
+func TestAccumulator(t *testing.T) {
+	var a bigfloat.Accumulator
+	if z := a.Sum(); z.Sign() != 0 {
+		t.Errorf("empty Sum = %g; want 0", z)
+	}
+
+	// 1e20 + i for i = 1, ..., 100: the deviations from the mean are
+	// below float64 precision
+	big20 := new(big.Float).SetPrec(53).SetFloat64(1e20)
+	for i := 1; i <= 100; i++ {
+		x := new(big.Float).SetPrec(100).SetInt64(int64(i))
+		a.Add(x.Add(x, big20))
+	}
+
+	if n := a.Count(); n != 100 {
+		t.Errorf("Count = %d; want 100", n)
+	}
+
+	want := new(big.Float).SetPrec(100).SetFloat64(1e22)
+	want.Add(want, big.NewFloat(5050))
+	if z := a.Sum(); z.Cmp(want) != 0 {
+		t.Errorf("Sum = %g; want %g", z, want)
+	}
+
+	want = new(big.Float).SetPrec(100).SetFloat64(1e20)
+	want.Add(want, big.NewFloat(50.5))
+	if z := a.Mean(); z.Cmp(want) != 0 {
+		t.Errorf("Mean = %g; want %g", z, want)
+	}
+
+	// the sample variance of 1, ..., n is n(n+1)/12
+	want = new(big.Float).SetPrec(100).Quo(big.NewFloat(100*101), big.NewFloat(12))
+	if z := a.Variance(); z.Cmp(want) != 0 {
+		t.Errorf("Variance = %g; want %g", z, want)
+	}
+
+	want = bigfloat.Sqrt(want.SetPrec(200)).SetPrec(100)
+	if z := a.StdDev(); !closeTo(z, want, 98) {
+		t.Errorf("StdDev = %g; want %g", z, want)
+	}
+
+	a.Reset()
+	if n := a.Count(); n != 0 {
+		t.Errorf("Count after Reset = %d; want 0", n)
+	}
+}
+
+func TestAccumulatorMatchesSlices(t *testing.T) {
+	xs := floats(80, "0.1", "-3.7e10", "2.5", "1e-30", "3.7e10", "42")
+
+	var a bigfloat.Accumulator
+	for _, x := range xs {
+		a.Add(x)
+	}
+
+	for _, test := range []struct {
+		name      string
+		got, want *big.Float
+	}{
+		{"Sum", a.Sum(), bigfloat.Sum(xs)},
+		{"Mean", a.Mean(), bigfloat.Mean(xs)},
+		{"Variance", a.Variance(), bigfloat.Variance(xs)},
+		{"StdDev", a.StdDev(), bigfloat.StdDev(xs)},
+	} {
+		if test.got.Cmp(test.want) != 0 {
+			t.Errorf("%s = %g; want %g", test.name, test.got, test.want)
+		}
+	}
+}
+
+func TestAccumulatorFloat64(t *testing.T) {
+	var a bigfloat.Accumulator
+	for i := 0; i < 10; i++ {
+		a.AddFloat64(0.1)
+	}
+
+	// the exact sum of ten float64(0.1), rounded once
+	want := new(big.Float).SetFloat64(0.1)
+	want.SetPrec(200).Mul(want, big.NewFloat(10)).SetPrec(53)
+	if z := a.Sum(); z.Cmp(want) != 0 {
+		t.Errorf("Sum = %g; want %g", z, want)
+	}
+	if z := a.Variance(); z.Sign() != 0 {
+		t.Errorf("Variance = %g; want 0", z)
+	}
+}
+
+func TestAccumulatorPanics(t *testing.T) {
+	for name, f := range map[string]func(a *bigfloat.Accumulator){
+		"Mean(empty)":   func(a *bigfloat.Accumulator) { a.Mean() },
+		"Variance(one)": func(a *bigfloat.Accumulator) { a.AddFloat64(1); a.Variance() },
+		"StdDev(Inf)":   func(a *bigfloat.Accumulator) { a.AddFloat64(1); a.Add(new(big.Float).SetInf(false)); a.StdDev() },
+		"Sum(Inf, -Inf)": func(a *bigfloat.Accumulator) {
+			a.Add(new(big.Float).SetInf(false))
+			a.Add(new(big.Float).SetInf(true))
+			a.Sum()
+		},
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%s did not panic", name)
+				}
+			}()
+			f(new(bigfloat.Accumulator))
+		}()
+	}
+}
+
+// ---------- Benchmarks ----------
+
+func BenchmarkAccumulatorAdd(b *testing.B) {
+	x := new(big.Float).SetPrec(256).SetFloat64(1.5)
+	var a bigfloat.Accumulator
+	b.ReportAllocs()
+	for n := 0; n < b.N; n++ {
+		a.Add(x)
+	}
+}
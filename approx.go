@@ -0,0 +1,103 @@
+package bigfloat
+
+import "math/big"
+
+// CmpUlp compares a and b at their common precision, the smaller of
+// the two, and returns 0 if they are at most maxUlps representable
+// values apart, and otherwise -1 if a < b and +1 if a > b. Before the
+// comparison, the more precise argument is rounded to the common
+// precision, to nearest even. Infinities are only equal to the
+// infinity of the same sign, and +0 and -0 are equal.
+func CmpUlp(a, b *big.Float, maxUlps uint) int {
+
+	if a.IsInf() || b.IsInf() {
+		return a.Cmp(b)
+	}
+
+	prec := a.Prec()
+	if b.Prec() < prec {
+		prec = b.Prec()
+	}
+	if prec == 0 {
+		// only zeros
+		return 0
+	}
+
+	d := ulpIndex(a, prec)
+	d.Sub(d, ulpIndex(b, prec))
+	if d.CmpAbs(new(big.Int).SetUint64(uint64(maxUlps))) <= 0 {
+		return 0
+	}
+
+	return d.Sign()
+}
+
+// ulpIndex returns the position of x, rounded to prec bits, in the
+// sequence of the values of precision prec, with 0 for ±0, 1 for the
+// smallest positive big.Float and -1 for its opposite.
+// Consecutive values of precision prec have consecutive indices.
+func ulpIndex(x *big.Float, prec uint) *big.Int {
+
+	y := new(big.Float).SetPrec(prec).Set(x)
+	if y.Sign() == 0 {
+		return new(big.Int)
+	}
+
+	// |y| = m·2**(e-prec), with m an integer of prec bits; each
+	// binade has 2**(prec-1) values
+	mant := new(big.Float)
+	e := y.MantExp(mant)
+	mant.Abs(mant).SetMantExp(mant, int(prec))
+	m, _ := mant.Int(nil)
+
+	half := new(big.Int).Lsh(big.NewInt(1), prec-1)
+	i := new(big.Int).Mul(big.NewInt(int64(e)-big.MinExp), half)
+	i.Add(i, m).Sub(i, half).Add(i, big.NewInt(1))
+	if y.Signbit() {
+		i.Neg(i)
+	}
+
+	return i
+}
+
+// WithinRel reports whether a and b are equal within the relative
+// tolerance relTol, that is, whether
+//
+//	|a - b| <= relTol·max(|a|, |b|)
+//
+// with both sides rounded to the common precision of a and b, the
+// smaller of the two. Infinities are only within any tolerance of
+// the infinity of the same sign. The function panics if relTol is
+// negative.
+func WithinRel(a, b, relTol *big.Float) bool {
+
+	if relTol.Sign() < 0 {
+		panic("WithinRel: negative tolerance")
+	}
+	if a.IsInf() || b.IsInf() {
+		return a.Cmp(b) == 0
+	}
+
+	prec := a.Prec()
+	if b.Prec() < prec {
+		prec = b.Prec()
+	}
+	if prec == 0 {
+		// only zeros
+		return true
+	}
+
+	d := new(big.Float).SetPrec(prec).Sub(a, b)
+	if d.Sign() == 0 {
+		return true
+	}
+	d.Abs(d)
+
+	m := new(big.Float).SetPrec(prec).Abs(a)
+	if new(big.Float).Abs(b).Cmp(m) > 0 {
+		m.Abs(b)
+	}
+	m.Mul(m, relTol)
+
+	return d.Cmp(m) <= 0
+}
@@ -0,0 +1,86 @@
+package bigfloat_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ThreeAndTwo/bigfloat"
+)
+
+func TestCmpUlp(t *testing.T) {
+	one := big.NewFloat(1)
+	next := func(x *big.Float, n int) *big.Float {
+		for i := 0; i < n; i++ {
+			x = bigfloat.NextUp(x)
+		}
+		for i := 0; i > n; i-- {
+			x = bigfloat.NextDown(x)
+		}
+		return x
+	}
+	tiny := bigfloat.NextUp(new(big.Float).SetPrec(53))
+
+	for _, test := range []struct {
+		a, b    *big.Float
+		maxUlps uint
+		want    int
+	}{
+		{one, one, 0, 0},
+		{one, next(one, 3), 3, 0},
+		{one, next(one, 3), 2, -1},
+		{next(one, 3), one, 2, +1},
+		{one, next(one, -4), 4, 0}, // across the binade boundary
+		{next(one, -2), next(one, 2), 4, 0},
+		{next(one, -2), next(one, 2), 3, -1},
+		{new(big.Float), new(big.Float).Neg(new(big.Float)), 0, 0},
+		{tiny, new(big.Float).Neg(tiny), 2, 0},
+		{tiny, new(big.Float).Neg(tiny), 1, +1},
+		{new(big.Float).SetInf(false), new(big.Float).SetInf(false), 0, 0},
+		{new(big.Float).SetInf(false), one, 1 << 30, +1},
+
+		// rounded to the common precision of 24 bits
+		{big.NewFloat(0.1), new(big.Float).SetPrec(24).SetFloat64(0.1), 0, 0},
+		{big.NewFloat(1 + 0x1p-24), new(big.Float).SetPrec(24).SetInt64(1), 0, 0},
+		{big.NewFloat(1 + 0x1p-22), new(big.Float).SetPrec(24).SetInt64(1), 1, +1},
+	} {
+		if got := bigfloat.CmpUlp(test.a, test.b, test.maxUlps); got != test.want {
+			t.Errorf("CmpUlp(%g, %g, %d) = %d; want %d", test.a, test.b, test.maxUlps, got, test.want)
+		}
+	}
+}
+
+func TestWithinRel(t *testing.T) {
+	f := big.NewFloat
+	for _, test := range []struct {
+		a, b, tol *big.Float
+		want      bool
+	}{
+		{f(1), f(1), f(0), true},
+		{f(100), f(101), f(0.01), true},
+		{f(101), f(100), f(0.01), true},
+		{f(100), f(102), f(0.01), false},
+		{f(-100), f(100), f(2), true},
+		{f(-100), f(100), f(1.5), false},
+		{f(0), f(1e-300), f(0.5), false},
+		{f(0), f(0), f(0), true},
+		{new(big.Float).SetInf(true), new(big.Float).SetInf(true), f(0), true},
+		{new(big.Float).SetInf(true), f(1), new(big.Float).SetInf(false), false},
+		{f(0), f(0), new(big.Float).SetInf(false), true},
+		{f(1), f(1e300), new(big.Float).SetInf(false), true},
+	} {
+		if got := bigfloat.WithinRel(test.a, test.b, test.tol); got != test.want {
+			t.Errorf("WithinRel(%g, %g, %g) = %v; want %v", test.a, test.b, test.tol, got, test.want)
+		}
+	}
+
+	// the difference is computed at the common precision
+	a := new(big.Float).SetPrec(1000).SetInt64(1)
+	b := new(big.Float).SetPrec(1000).SetInt64(1)
+	b.SetMantExp(b, -500).Add(b, a)
+	if bigfloat.WithinRel(a, b, new(big.Float)) {
+		t.Errorf("WithinRel(1, 1+2**-500, 0) = true")
+	}
+	if !bigfloat.WithinRel(a, b.SetPrec(53), new(big.Float)) {
+		t.Errorf("WithinRel(1, 1+2**-500 rounded to 53 bits, 0) = false")
+	}
+}
@@ -0,0 +1,66 @@
+package bigfloat
+
+import "math/big"
+
+// arenaBlock is the number of values of the blocks of an Arena.
+const arenaBlock = 64
+
+// An Arena hands out the temporaries of a computation, and takes them
+// all back at once with Reset. The values are kept in blocks, and
+// handed out again after a Reset with the memory of their mantissas,
+// so a computation repeated at the same precision with the same Arena
+// doesn't allocate its values again, and leaves only the temporaries
+// of the big.Float methods, like the ones of Quo, to the garbage
+// collector. A Context with an Arena gets its values from it:
+//
+//	var a bigfloat.Arena
+//	c := bigfloat.Context{Prec: 1000, Arena: &a}
+//	for _, x := range xs {
+//		z := c.Add(c.Mul(x, x), c.FromFloat64(1))
+//		...
+//		a.Reset()
+//	}
+//
+// The zero value is an empty Arena, ready to use. An Arena isn't safe
+// for concurrent use.
+type Arena struct {
+	blocks [][]big.Float
+	n      int // values handed out
+}
+
+// New returns a zero from a, with the precision prec and the rounding
+// mode mode. The value belongs to a, and must not be used after the
+// next Reset.
+func (a *Arena) New(prec uint, mode big.RoundingMode) *big.Float {
+
+	i, j := a.n/arenaBlock, a.n%arenaBlock
+	if i == len(a.blocks) {
+		a.blocks = append(a.blocks, make([]big.Float, arenaBlock))
+	}
+	a.n++
+
+	// SetInt64 keeps the mantissa, and sets the value to +0
+	z := &a.blocks[i][j]
+	z.SetInt64(0)
+
+	return z.SetPrec(prec).SetMode(mode)
+}
+
+// Reset takes back all the values handed out by a, which are handed
+// out again by the next calls to New.
+func (a *Arena) Reset() {
+	a.n = 0
+}
+
+// Len returns the number of values handed out by a since the last
+// Reset.
+func (a *Arena) Len() int {
+	return a.n
+}
+
+// Free drops the blocks of a, for the garbage collector to release
+// their memory, and resets it.
+func (a *Arena) Free() {
+	a.blocks = nil
+	a.n = 0
+}
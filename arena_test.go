@@ -0,0 +1,93 @@
+package bigfloat_test
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/ThreeAndTwo/bigfloat"
+)
+
+// horner returns Σ x**i/(i+1), for i from 0 to 49, computed with a.
+func horner[T any](a bigfloat.Arith[T], x T) T {
+
+	z := a.FromFloat64(0)
+	for i := 49; i >= 0; i-- {
+		z = a.Add(a.Mul(z, x), a.Quo(a.FromFloat64(1), a.FromFloat64(float64(i+1))))
+	}
+
+	return z
+}
+
+func TestArena(t *testing.T) {
+	var a bigfloat.Arena
+	c := bigfloat.Context{Prec: 1000, Mode: big.ToNearestEven}
+	ca := bigfloat.Context{Prec: 1000, Mode: big.ToNearestEven, Arena: &a}
+	x := new(big.Float).SetPrec(1000).SetFloat64(0.75)
+
+	want := horner[*big.Float](c, x)
+	for i := 0; i < 3; i++ {
+		if got := horner[*big.Float](ca, x); got.Cmp(want) != 0 || got.Prec() != 1000 {
+			t.Errorf("horner with an Arena = %g; want %g", got, want)
+		}
+		if a.Len() != 50*5+1 {
+			t.Errorf("Len() = %d; want %d", a.Len(), 50*5+1)
+		}
+		a.Reset()
+	}
+
+	// once the blocks and the mantissas are there, only the methods of
+	// big.Float allocate, once in a while
+	n := testing.AllocsPerRun(10, func() { horner[*big.Float](c, x) })
+	if na := testing.AllocsPerRun(10, func() {
+		horner[*big.Float](ca, x)
+		a.Reset()
+	}); na > n/4 {
+		t.Errorf("horner with an Arena allocates %v times, and %v times without", na, n)
+	}
+
+	// the values handed out again are zeros with the new precision and
+	// rounding mode
+	a.New(10, big.ToZero).SetInf(true)
+	a.New(10, big.ToZero).Neg(big.NewFloat(0))
+	a.New(10, big.ToZero).SetFloat64(-3)
+	a.Reset()
+	for i := 0; i < 3; i++ {
+		z := a.New(200, big.AwayFromZero)
+		if z.Sign() != 0 || z.Signbit() || z.Prec() != 200 || z.Mode() != big.AwayFromZero || z.Acc() != big.Exact {
+			t.Errorf("New(200, AwayFromZero) = %g at prec %d, mode %v", z, z.Prec(), z.Mode())
+		}
+	}
+
+	a.Free()
+	if a.Len() != 0 {
+		t.Errorf("Len() = %d after Free; want 0", a.Len())
+	}
+	if z := ca.Add(big.NewFloat(1), big.NewFloat(2)); z.Cmp(big.NewFloat(3)) != 0 || a.Len() != 1 {
+		t.Errorf("Add(1, 2) after Free = %g", z)
+	}
+}
+
+// ---------- Benchmarks ----------
+
+func BenchmarkArena(b *testing.B) {
+	for _, prec := range []uint{1e2, 1e3, 1e4} {
+		x := new(big.Float).SetPrec(prec).SetFloat64(0.75)
+		b.Run(fmt.Sprintf("%v/new", prec), func(b *testing.B) {
+			c := bigfloat.Context{Prec: prec}
+			b.ReportAllocs()
+			for n := 0; n < b.N; n++ {
+				horner[*big.Float](c, x)
+			}
+		})
+		b.Run(fmt.Sprintf("%v/arena", prec), func(b *testing.B) {
+			var a bigfloat.Arena
+			c := bigfloat.Context{Prec: prec, Arena: &a}
+			b.ReportAllocs()
+			for n := 0; n < b.N; n++ {
+				horner[*big.Float](c, x)
+				a.Reset()
+			}
+		})
+	}
+}
@@ -0,0 +1,238 @@
+package bigfloat
+
+import (
+	"math"
+	"math/big"
+)
+
+// An Arith provides the arithmetic of the number type T, so that an
+// algorithm can be written once as a generic function taking an
+// Arith, like
+//
+//	func Hypot[T any](a Arith[T], x, y T) T {
+//		return a.Sqrt(a.Add(a.Mul(x, x), a.Mul(y, y)))
+//	}
+//
+// and then run with float64 values, using Float64Arith, or with
+// big.Float values of any precision, using a Context. The results of
+// the methods never share memory with the arguments.
+type Arith[T any] interface {
+	FromFloat64(f float64) T
+	Float64(x T) float64
+	Cmp(x, y T) int
+
+	Add(x, y T) T
+	Sub(x, y T) T
+	Mul(x, y T) T
+	Quo(x, y T) T
+	Neg(x T) T
+	Abs(x T) T
+
+	Sqrt(x T) T
+	Exp(x T) T
+	Log(x T) T
+	Pow(x, y T) T
+}
+
+var (
+	_ Arith[float64]    = Float64Arith{}
+	_ Arith[*big.Float] = Context{}
+)
+
+// Float64Arith is the Arith of float64 values, which uses the
+// operators and the functions of package math.
+type Float64Arith struct{}
+
+func (Float64Arith) FromFloat64(f float64) float64 { return f }
+func (Float64Arith) Float64(x float64) float64     { return x }
+
+// Cmp returns -1, 0 or +1 depending on whether x < y, x == y or
+// x > y. It returns 0 if x or y is NaN.
+func (Float64Arith) Cmp(x, y float64) int {
+
+	switch {
+	case x < y:
+		return -1
+	case x > y:
+		return +1
+	}
+
+	return 0
+}
+
+func (Float64Arith) Add(x, y float64) float64 { return x + y }
+func (Float64Arith) Sub(x, y float64) float64 { return x - y }
+func (Float64Arith) Mul(x, y float64) float64 { return x * y }
+func (Float64Arith) Quo(x, y float64) float64 { return x / y }
+func (Float64Arith) Neg(x float64) float64    { return -x }
+func (Float64Arith) Abs(x float64) float64    { return math.Abs(x) }
+func (Float64Arith) Sqrt(x float64) float64   { return math.Sqrt(x) }
+func (Float64Arith) Exp(x float64) float64    { return math.Exp(x) }
+func (Float64Arith) Log(x float64) float64    { return math.Log(x) }
+func (Float64Arith) Pow(x, y float64) float64 { return math.Pow(x, y) }
+
+// The methods of Context make it the Arith of big.Float values: each
+// result is a new big.Float with the precision and the rounding mode
+// of c. If c.Prec is 0, the precision of the result is the largest
+// of the ones of the arguments, as for the methods of big.Float. The
+// functions Sqrt, Exp, Log and Pow are computed by the functions of
+// this package, which round to nearest, and so they're rounded twice
+// with c.Subnormal or c.Overflow.
+
+// FromFloat64 returns f at the precision of c, or 53 bits if c.Prec
+// is 0.
+func (c Context) FromFloat64(f float64) *big.Float {
+
+	if c.Prec == 0 {
+		c.Prec = 53
+	}
+
+	return c.op(func(z *big.Float) *big.Float { return z.SetFloat64(f) })
+}
+
+// Float64 returns the float64 value nearest to x.
+func (c Context) Float64(x *big.Float) float64 {
+	f, _ := x.Float64()
+	return f
+}
+
+// Cmp returns -1, 0 or +1 depending on whether x < y, x == y or
+// x > y.
+func (c Context) Cmp(x, y *big.Float) int {
+	return x.Cmp(y)
+}
+
+// Add returns x + y.
+func (c Context) Add(x, y *big.Float) *big.Float {
+	return c.op(func(z *big.Float) *big.Float { return z.Add(x, y) }, x, y)
+}
+
+// Sub returns x - y.
+func (c Context) Sub(x, y *big.Float) *big.Float {
+	return c.op(func(z *big.Float) *big.Float { return z.Sub(x, y) }, x, y)
+}
+
+// Mul returns x·y.
+func (c Context) Mul(x, y *big.Float) *big.Float {
+	return c.op(func(z *big.Float) *big.Float { return z.Mul(x, y) }, x, y)
+}
+
+// Quo returns x/y.
+func (c Context) Quo(x, y *big.Float) *big.Float {
+	return c.op(func(z *big.Float) *big.Float { return z.Quo(x, y) }, x, y)
+}
+
+// Neg returns -x.
+func (c Context) Neg(x *big.Float) *big.Float {
+	return c.op(func(z *big.Float) *big.Float { return z.Neg(x) }, x)
+}
+
+// Abs returns |x|.
+func (c Context) Abs(x *big.Float) *big.Float {
+	return c.op(func(z *big.Float) *big.Float { return z.Abs(x) }, x)
+}
+
+// Sqrt returns the square root of x. It panics if x is negative.
+func (c Context) Sqrt(x *big.Float) *big.Float {
+	return c.apply(Sqrt, x)
+}
+
+// Exp returns exp(x).
+func (c Context) Exp(x *big.Float) *big.Float {
+	return c.apply(Exp, x)
+}
+
+// Log returns the natural logarithm of x. It panics if x is negative.
+func (c Context) Log(x *big.Float) *big.Float {
+	return c.apply(Log, x)
+}
+
+// Pow returns x**y. For a negative x, y must be an integer, and the
+// power is computed with PowInt; it panics otherwise, where math.Pow
+// returns NaN.
+func (c Context) Pow(x, y *big.Float) *big.Float {
+
+	if x.Sign() < 0 {
+		if !y.IsInt() {
+			panic("Pow: argument is negative and exponent is not an integer")
+		}
+		n, _ := y.Int(nil)
+		return c.apply(func(x *big.Float) *big.Float { return PowInt(x, n) }, x)
+	}
+
+	return c.apply(func(x *big.Float) *big.Float { return Pow(x, y) }, x)
+}
+
+// apply returns f(x), where f returns a result with the precision of
+// its argument, at the precision and with the rounding mode of c.
+func (c Context) apply(f func(*big.Float) *big.Float, x *big.Float) *big.Float {
+
+	z := f(c.New().Set(x))
+	z.SetMode(c.Mode)
+	if c.Subnormal || c.Overflow {
+		z.Set(c.bound(z, z.Prec()))
+	}
+
+	return z
+}
+
+// op returns the result of f, which sets its receiver to a correctly
+// rounded result and returns it, at the precision and with the rounding
+// mode of c. With c.Subnormal or c.Overflow, f rounds toward zero to 2
+// bits more, and the result is rounded to odd and then by bound, which
+// is the same as rounding the exact result once.
+func (c Context) op(f func(z *big.Float) *big.Float, args ...*big.Float) *big.Float {
+
+	if !c.Subnormal && !c.Overflow {
+		return f(c.New())
+	}
+
+	// the precision of the results of big.Float for a precision of 0
+	prec := c.Prec
+	if prec == 0 {
+		for _, x := range args {
+			if x.Prec() > prec {
+				prec = x.Prec()
+			}
+		}
+		if prec == 0 {
+			// zeros and infinities, the results are exact
+			return f(c.New())
+		}
+	}
+
+	t := f(new(big.Float).SetPrec(prec + 2).SetMode(big.ToZero))
+	roundOdd(t)
+
+	return c.New().SetPrec(prec).Set(c.bound(t, prec))
+}
+
+// bound returns x rounded to prec bits with the rounding mode of c,
+// in the exponent range of c.
+func (c Context) bound(x *big.Float, prec uint) *big.Float {
+
+	var z *big.Float
+	if c.Subnormal {
+		z = RoundSubnormal(x, prec, c.MinExp, c.Mode)
+	} else {
+		z = new(big.Float).SetPrec(prec).SetMode(c.Mode).Set(x)
+	}
+	if !c.Overflow || z.Sign() == 0 || z.IsInf() || z.MantExp(nil) <= c.MaxExp {
+		return z
+	}
+
+	// ±Inf, or ±(1 - 2**-prec)·2**MaxExp
+	neg := z.Signbit()
+	if roundsToInf(c.Mode, neg) {
+		return z.SetInf(neg)
+	}
+	m := new(big.Int).Lsh(big.NewInt(1), prec)
+	m.Sub(m, big.NewInt(1))
+	z.SetInt(m)
+	z.SetMantExp(z, c.MaxExp-int(prec))
+	if neg {
+		z.Neg(z)
+	}
+
+	return z
+}
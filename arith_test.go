@@ -0,0 +1,137 @@
+package bigfloat_test
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/ThreeAndTwo/bigfloat"
+)
+
+// newtonSqrt computes the square root of x > 0 with Newton's
+// iteration, written once for any Arith.
+func newtonSqrt[T any](a bigfloat.Arith[T], x T) T {
+
+	half := a.FromFloat64(0.5)
+	z := a.FromFloat64(math.Sqrt(a.Float64(x)))
+	for i := 0; i < 64; i++ {
+		next := a.Mul(half, a.Add(z, a.Quo(x, z)))
+		if a.Cmp(next, z) == 0 {
+			break
+		}
+		z = next
+	}
+
+	return z
+}
+
+// logistic returns 1/(1 + exp(-x)), written once for any Arith.
+func logistic[T any](a bigfloat.Arith[T], x T) T {
+	one := a.FromFloat64(1)
+	return a.Quo(one, a.Add(one, a.Exp(a.Neg(x))))
+}
+
+func TestArithFloat64(t *testing.T) {
+	a := bigfloat.Float64Arith{}
+	for _, x := range []float64{0.25, 2, 3, 1e10, 1e-300} {
+		if got, want := newtonSqrt[float64](a, x), math.Sqrt(x); math.Abs(got-want) > 2*want*0x1p-53 {
+			t.Errorf("newtonSqrt(%g) = %g, want %g", x, got, want)
+		}
+	}
+
+	if got := a.Pow(2, 0.5); got != math.Sqrt2 {
+		t.Errorf("Pow(2, 0.5) = %g, want %g", got, math.Sqrt2)
+	}
+	if got := a.Cmp(math.NaN(), 1); got != 0 {
+		t.Errorf("Cmp(NaN, 1) = %d, want 0", got)
+	}
+}
+
+func TestArithContext(t *testing.T) {
+	for _, prec := range []uint{24, 53, 100, 1000} {
+		c := bigfloat.Context{Prec: prec, Mode: big.ToNearestEven}
+		for _, f := range []float64{0.25, 2, 3, 1e10} {
+			x := c.FromFloat64(f)
+			got := newtonSqrt[*big.Float](c, x)
+			want := bigfloat.Sqrt(x)
+			if got.Prec() != prec {
+				t.Errorf("prec = %d: newtonSqrt(%g) has precision %d", prec, f, got.Prec())
+			}
+			if e, _ := relErr(got, want).Float64(); e > math.Ldexp(2, -int(prec)) {
+				t.Errorf("prec = %d: newtonSqrt(%g) = %g, want %g", prec, f, got, want)
+			}
+		}
+	}
+}
+
+func TestArithAgree(t *testing.T) {
+	f := bigfloat.Float64Arith{}
+	c := bigfloat.Context{Prec: 200, Mode: big.ToNearestEven}
+	for _, x := range []float64{-10, -1, 0, 0.5, 1, 20} {
+		want := c.Float64(logistic[*big.Float](c, c.FromFloat64(x)))
+		if got := logistic[float64](f, x); math.Abs(got-want) > 4*want*0x1p-53 {
+			t.Errorf("logistic(%g) = %g with float64, %g with big.Float", x, got, want)
+		}
+	}
+}
+
+func TestArithContextMode(t *testing.T) {
+	c := bigfloat.Context{Prec: 10, Mode: big.ToZero}
+	x := c.FromFloat64(2)
+	for _, z := range []*big.Float{c.Add(x, x), c.Sqrt(x), c.Log(x), c.Pow(x, x)} {
+		if z.Mode() != big.ToZero || z.Prec() != 10 {
+			t.Errorf("got mode %v and precision %d, want %v and 10", z.Mode(), z.Prec(), big.ToZero)
+		}
+	}
+
+	// the results don't alias the arguments
+	if z := c.Abs(x); z == x {
+		t.Error("Abs(x) returned x")
+	}
+}
+
+func TestArithContextPow(t *testing.T) {
+	c := bigfloat.Context{Prec: 200, Mode: big.ToNearestEven}
+
+	// (-3)**41 is exact at 200 bits
+	n := new(big.Int).Exp(big.NewInt(3), big.NewInt(41), nil)
+	want := new(big.Float).SetInt(n.Neg(n))
+	if z := c.Pow(c.FromFloat64(-3), c.FromFloat64(41)); z.Cmp(want) != 0 {
+		t.Errorf("Pow(-3, 41) = %g; want %g", z, want)
+	}
+	if z := c.Pow(c.FromFloat64(-2), c.FromFloat64(-2)); z.Cmp(big.NewFloat(0.25)) != 0 {
+		t.Errorf("Pow(-2, -2) = %g; want 0.25", z)
+	}
+
+	for _, y := range []float64{0.5, math.Inf(1)} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("Pow(-2, %g) did not panic", y)
+				}
+			}()
+			c.Pow(c.FromFloat64(-2), c.FromFloat64(y))
+		}()
+	}
+}
+
+// ---------- Benchmarks ----------
+
+func BenchmarkArithNewtonSqrt(b *testing.B) {
+	b.Run("float64", func(b *testing.B) {
+		a := bigfloat.Float64Arith{}
+		for n := 0; n < b.N; n++ {
+			_ = newtonSqrt[float64](a, 2)
+		}
+	})
+	for _, prec := range []uint{53, 1e3, 1e4} {
+		c := bigfloat.Context{Prec: prec, Mode: big.ToNearestEven}
+		x := c.FromFloat64(2)
+		b.Run(fmt.Sprintf("%v", prec), func(b *testing.B) {
+			for n := 0; n < b.N; n++ {
+				_ = newtonSqrt[*big.Float](c, x)
+			}
+		})
+	}
+}
@@ -0,0 +1,140 @@
+package bigfloat
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// TextBase returns the representation of x in the given base, with
+// exactly digits digits after the radix point, correctly rounded
+// with ties to even. The digits are the ones of big.Int.Text: 0-9,
+// then a-z and, for bases above 36, A-Z. Values that round to zero
+// are formatted without a sign, and ±Inf as "+Inf" and "-Inf". The
+// function panics if base is not in [2, 62], or if digits < 0.
+func TextBase(x *big.Float, base, digits int) string {
+
+	if base < 2 || base > 62 {
+		panic("TextBase: invalid base")
+	}
+	if digits < 0 {
+		panic("TextBase: negative digits")
+	}
+
+	return formatFixed(x, base, digits, HalfEven)
+}
+
+// ParseBase parses s, which must contain a number in the given base,
+// and returns it rounded to prec bits (64 if prec is 0) using the
+// ToNearestEven rounding mode. exact reports whether the returned
+// value is exactly the number written in s. The function panics if
+// base is not in [2, 62].
+//
+// The number is an optional sign followed by digits, with an
+// optional radix point; there's no prefix, and no exponent. The
+// digits are the ones accepted by big.Int.SetString: for bases up to
+// 36 letters of either case stand for the values 10 to 35, and for
+// bases above 36 the upper case letters stand for 36 to 61. Leading
+// and trailing white space is ignored, and underscores can be used
+// to separate digits. If s isn't a valid number, the error is a
+// *ParseError.
+func ParseBase(s string, base int, prec uint) (f *big.Float, exact bool, err error) {
+
+	if base < 2 || base > 62 {
+		panic("ParseBase: invalid base")
+	}
+	if prec == 0 {
+		prec = 64
+	}
+
+	errorf := func(i int, msg string) error {
+		return &ParseError{s, i, base, msg}
+	}
+
+	end := len(s)
+	for end > 0 && isSpace(s[end-1]) {
+		end--
+	}
+	i := 0
+	for i < end && isSpace(s[i]) {
+		i++
+	}
+	if i == end {
+		return nil, false, errorf(0, "empty number")
+	}
+
+	neg := false
+	if s[i] == '+' || s[i] == '-' {
+		neg = s[i] == '-'
+		i++
+	}
+
+	var digits strings.Builder
+	frac, n, point, prev := 0, 0, false, false
+	for ; i < end; i++ {
+		c := s[i]
+		switch {
+		case c == '_':
+			if !prev || i+1 == end || baseDigitVal(s[i+1], base) >= base {
+				return nil, false, errorf(i, "misplaced underscore")
+			}
+		case c == '.':
+			if point {
+				return nil, false, errorf(i, "second radix point")
+			}
+			point, prev = true, false
+		case baseDigitVal(c, base) < base:
+			digits.WriteByte(c)
+			n++
+			if point {
+				frac++
+			}
+			prev = true
+		default:
+			return nil, false, errorf(i, fmt.Sprintf("invalid character %q", c))
+		}
+	}
+	if n == 0 {
+		return nil, false, errorf(i, "missing digits")
+	}
+
+	// the value is m/base**frac
+	m, _ := new(big.Int).SetString(digits.String(), base)
+	d := powInt64(base, frac)
+
+	z := new(big.Float).SetPrec(prec)
+	if frac == 0 {
+		z.SetInt(m)
+	} else {
+		z.Quo(new(big.Float).SetInt(m), new(big.Float).SetInt(d))
+	}
+	if neg {
+		z.Neg(z)
+	}
+
+	// exact if, in lowest terms, the denominator is a power of two
+	// and the numerator fits in prec bits
+	if m.Sign() == 0 {
+		return z, true, nil
+	}
+	g := new(big.Int).GCD(nil, nil, m, d)
+	d.Quo(d, g)
+	exact = d.BitLen()-1 == int(d.TrailingZeroBits()) && oddBitLen(m.Quo(m, g)) <= prec
+
+	return z, exact, nil
+}
+
+// baseDigitVal returns the value of the digit c in base, or base if
+// c isn't a valid digit.
+func baseDigitVal(c byte, base int) int {
+
+	v := digitVal(c)
+	if base > 36 && 'A' <= c && c <= 'Z' {
+		v += 26
+	}
+	if v >= base {
+		return base
+	}
+
+	return v
+}
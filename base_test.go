@@ -0,0 +1,129 @@
+package bigfloat_test
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ThreeAndTwo/bigfloat"
+)
+
+func TestTextBase(t *testing.T) {
+	for _, test := range []struct {
+		x      string
+		base   int
+		digits int
+		want   string
+	}{
+		{"255", 16, 0, "ff"},
+		{"-255.5", 16, 1, "-ff.8"},
+		{"0.1", 2, 10, "0.0001100110"},
+		{"0.5", 3, 3, "0.112"}, // 0.1111...,
+		{"2.5", 10, 0, "2"},    // tie, to even
+		{"3.5", 10, 0, "4"},
+		{"1295", 36, 0, "zz"},
+		{"1296", 36, 0, "100"},
+		{"61", 62, 0, "Z"},
+		{"3843.5", 62, 1, "ZZ.v"},
+		{"3.14159265358979323846264338327950288", 60, 4, "3.8tI1"}, // 3;8,29,44,0,47,
+		{"-0.001", 10, 2, "0.00"},
+		{"0", 7, 2, "0.00"},
+	} {
+		x, _, _ := new(big.Float).SetPrec(200).Parse(test.x, 10)
+		if s := bigfloat.TextBase(x, test.base, test.digits); s != test.want {
+			t.Errorf("TextBase(%s, %d, %d) = %s; want %s", test.x, test.base, test.digits, s, test.want)
+		}
+	}
+}
+
+func TestTextBaseSexagesimal(t *testing.T) {
+	// 1;24,51,10 is the Babylonian approximation of √2 on YBC 7289
+	x := bigfloat.Sqrt(new(big.Float).SetPrec(100).SetInt64(2))
+	s := bigfloat.TextBase(x, 60, 3)
+	// digit values 1; 24, 51, 10 in the big.Int alphabet
+	if want := "1.oPa"; s != want {
+		t.Errorf("TextBase(√2, 60, 3) = %s; want %s", s, want)
+	}
+}
+
+func TestParseBase(t *testing.T) {
+	for _, test := range []struct {
+		s     string
+		base  int
+		prec  uint
+		want  string
+		exact bool
+	}{
+		{"ff", 16, 53, "255", true},
+		{"FF", 16, 53, "255", true},
+		{"-ff.8", 16, 53, "-255.5", true},
+		{"0.1", 3, 53, "0.33333333333333331483", false},
+		{"0.1", 4, 53, "0.25", true},
+		{"0.3", 6, 53, "0.5", true},
+		{"zz", 36, 53, "1295", true},
+		{"Z", 62, 53, "61", true},
+		{"z", 62, 53, "35", true},
+		{"1_000", 2, 53, "8", true},
+		{"1.oPa", 60, 100, "1.4142129629629629629629629630", false},
+		{"10000000001", 2, 4, "1024", false},
+	} {
+		z, exact, err := bigfloat.ParseBase(test.s, test.base, test.prec)
+		if err != nil {
+			t.Errorf("ParseBase(%q, %d) error: %v", test.s, test.base, err)
+			continue
+		}
+		want, _, _ := new(big.Float).SetPrec(test.prec).Parse(test.want, 10)
+		if !closeTo(z, want, int(test.prec)-8) || z.Prec() != test.prec {
+			t.Errorf("ParseBase(%q, %d) = %g; want %g", test.s, test.base, z, want)
+		}
+		if exact != test.exact {
+			t.Errorf("ParseBase(%q, %d) exact = %v; want %v", test.s, test.base, exact, test.exact)
+		}
+	}
+
+	for _, test := range []struct {
+		s      string
+		base   int
+		offset int
+	}{
+		{"12", 2, 1},
+		{"1.2.3", 10, 3},
+		{"g", 16, 0},
+		{"-", 10, 1},
+		{"1e5", 10, 1},
+	} {
+		_, _, err := bigfloat.ParseBase(test.s, test.base, 53)
+		var perr *bigfloat.ParseError
+		if !errors.As(err, &perr) || perr.Offset != test.offset {
+			t.Errorf("ParseBase(%q, %d) error = %v; want offset %d", test.s, test.base, err, test.offset)
+		}
+	}
+}
+
+func TestBaseRoundTrip(t *testing.T) {
+	x := bigfloat.Sqrt(new(big.Float).SetPrec(200).SetInt64(3))
+	for base := 2; base <= 62; base++ {
+		// enough digits for 200 bits
+		digits := 0
+		for p := 1.0; p < 1e61; p *= float64(base) {
+			digits++
+		}
+		s := bigfloat.TextBase(x, base, digits)
+		z, _, err := bigfloat.ParseBase(s, base, 200)
+		if err != nil {
+			t.Fatalf("base %d: ParseBase(%q) error: %v", base, s, err)
+		}
+		if z.Cmp(x) != 0 {
+			t.Errorf("base %d: ParseBase(TextBase(x)) = %g; want %g", base, z, x)
+		}
+	}
+}
+
+// ---------- Benchmarks ----------
+
+func BenchmarkTextBase(b *testing.B) {
+	x := bigfloat.Sqrt(new(big.Float).SetPrec(1000).SetInt64(2))
+	for n := 0; n < b.N; n++ {
+		bigfloat.TextBase(x, 60, 200)
+	}
+}
@@ -0,0 +1,172 @@
+// Package bigmat provides vectors and matrices of big.Float values
+// that implement the mat.Matrix and mat.Vector interfaces of gonum,
+// and conversions from and to the float64 vectors and matrices of
+// gonum with a controlled rounding.
+//
+// This allows mixing fast float64 stages with high-precision ones:
+// for example, a linear system can be solved with gonum, and the
+// solution improved by iterative refinement, with the residuals
+// computed by Residual without rounding errors.
+//
+// bigmat is a module of its own, so that the users of bigfloat don't
+// depend on gonum.
+package bigmat
+
+import (
+	"math/big"
+
+	"github.com/ThreeAndTwo/bigfloat"
+	"gonum.org/v1/gonum/mat"
+)
+
+// A Dense is a dense matrix of big.Float values, stored in row-major
+// order. It implements mat.Matrix: At returns the elements rounded
+// to nearest float64 values.
+type Dense struct {
+	rows, cols int
+	data       []*big.Float
+}
+
+// NewDense returns a new r×c matrix with the elements of data, in
+// row-major order, which are used directly, not copied. If data is
+// nil, the elements are zeros of precision 0. The function panics if
+// r or c isn't positive, or if data has the wrong length.
+func NewDense(r, c int, data []*big.Float) *Dense {
+
+	if r <= 0 || c <= 0 {
+		panic("NewDense: non-positive dimension")
+	}
+	if data == nil {
+		data = make([]*big.Float, r*c)
+		for i := range data {
+			data[i] = new(big.Float)
+		}
+	}
+	if len(data) != r*c {
+		panic("NewDense: wrong data length")
+	}
+
+	return &Dense{r, c, data}
+}
+
+// Dims returns the number of rows and columns of d.
+func (d *Dense) Dims() (r, c int) {
+	return d.rows, d.cols
+}
+
+// At returns the element of d at row i and column j, rounded to the
+// nearest float64. The method panics if i or j is out of range.
+func (d *Dense) At(i, j int) float64 {
+	f, _ := d.BigAt(i, j).Float64()
+	return f
+}
+
+// T returns the transpose of d, as a mat.Transpose.
+func (d *Dense) T() mat.Matrix {
+	return mat.Transpose{Matrix: d}
+}
+
+// BigAt returns the element of d at row i and column j. It's the
+// element itself, not a copy, so changing it changes d. The method
+// panics if i or j is out of range.
+func (d *Dense) BigAt(i, j int) *big.Float {
+
+	if i < 0 || i >= d.rows || j < 0 || j >= d.cols {
+		panic("BigAt: index out of range")
+	}
+
+	return d.data[i*d.cols+j]
+}
+
+// SetBig sets the element of d at row i and column j to x, with the
+// precision of x. The method panics if i or j is out of range.
+func (d *Dense) SetBig(i, j int, x *big.Float) {
+	d.BigAt(i, j).Copy(x)
+}
+
+// MulVec returns the product of d and the vector x. Each element is
+// computed by bigfloat.Dot, so it's correctly rounded to the largest
+// precision of the elements it's computed from. The method panics if
+// the length of x isn't the number of columns of d.
+func (d *Dense) MulVec(x *Vector) *Vector {
+
+	if x.Len() != d.cols {
+		panic("MulVec: dimension mismatch")
+	}
+
+	z := make([]*big.Float, d.rows)
+	for i := range z {
+		z[i] = bigfloat.Dot(d.data[i*d.cols:(i+1)*d.cols], x.data)
+	}
+
+	return &Vector{z}
+}
+
+// A Vector is a column vector of big.Float values. It implements
+// mat.Vector: At and AtVec return the elements rounded to nearest
+// float64 values.
+type Vector struct {
+	data []*big.Float
+}
+
+// NewVector returns a new vector with the elements of data, which are
+// used directly, not copied. The function panics if data is empty.
+func NewVector(data []*big.Float) *Vector {
+
+	if len(data) == 0 {
+		panic("NewVector: empty vector")
+	}
+
+	return &Vector{data}
+}
+
+// Dims returns the dimensions of v, as a column vector.
+func (v *Vector) Dims() (r, c int) {
+	return len(v.data), 1
+}
+
+// At returns the element of v at row i, rounded to the nearest
+// float64. The method panics if i is out of range, or j isn't 0.
+func (v *Vector) At(i, j int) float64 {
+
+	if j != 0 {
+		panic("At: column index out of range")
+	}
+
+	return v.AtVec(i)
+}
+
+// AtVec returns the element of v at index i, rounded to the nearest
+// float64. The method panics if i is out of range.
+func (v *Vector) AtVec(i int) float64 {
+	f, _ := v.data[i].Float64()
+	return f
+}
+
+// Len returns the number of elements of v.
+func (v *Vector) Len() int {
+	return len(v.data)
+}
+
+// T returns the transpose of v, as a mat.Transpose.
+func (v *Vector) T() mat.Matrix {
+	return mat.Transpose{Matrix: v}
+}
+
+// BigAtVec returns the element of v at index i. It's the element
+// itself, not a copy, so changing it changes v. The method panics if
+// i is out of range.
+func (v *Vector) BigAtVec(i int) *big.Float {
+	return v.data[i]
+}
+
+// SetBigVec sets the element of v at index i to x, with the
+// precision of x. The method panics if i is out of range.
+func (v *Vector) SetBigVec(i int, x *big.Float) {
+	v.data[i].Copy(x)
+}
+
+var (
+	_ mat.Matrix = (*Dense)(nil)
+	_ mat.Vector = (*Vector)(nil)
+)
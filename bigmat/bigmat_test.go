@@ -0,0 +1,96 @@
+package bigmat_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ThreeAndTwo/bigfloat/bigmat"
+	"gonum.org/v1/gonum/mat"
+)
+
+// third returns 1/3 at the given precision.
+func third(prec uint) *big.Float {
+	return new(big.Float).SetPrec(prec).Quo(big.NewFloat(1), big.NewFloat(3))
+}
+
+func TestDenseMatrix(t *testing.T) {
+
+	d := bigmat.NewDense(2, 3, nil)
+	if r, c := d.Dims(); r != 2 || c != 3 {
+		t.Fatalf("Dims() = %d, %d", r, c)
+	}
+	for i := 0; i < 2; i++ {
+		for j := 0; j < 3; j++ {
+			d.SetBig(i, j, big.NewFloat(float64(3*i+j)))
+		}
+	}
+	d.SetBig(0, 0, third(200))
+	if x := d.BigAt(0, 0); x.Prec() != 200 {
+		t.Errorf("SetBig changed the precision to %d", x.Prec())
+	}
+	if f := d.At(0, 0); f != 1.0/3 {
+		t.Errorf("At(0, 0) = %g; want 1/3", f)
+	}
+
+	// gonum can use d as any other mat.Matrix
+	var p mat.Dense
+	p.Mul(d, d.T())
+	want := mat.NewDense(2, 2, []float64{
+		1.0/9 + 1 + 4, 1 + 4 + 10,
+		1 + 4 + 10, 9 + 16 + 25,
+	})
+	if !mat.EqualApprox(&p, want, 1e-15) {
+		t.Errorf("d·dᵀ = %v; want %v", mat.Formatted(&p), mat.Formatted(want))
+	}
+}
+
+func TestDenseMulVec(t *testing.T) {
+
+	// (1e20, 1, -1e20)·(1, 1, 1) = 1 exactly
+	d := bigmat.NewDense(1, 3, []*big.Float{big.NewFloat(1e20), big.NewFloat(1), big.NewFloat(-1e20)})
+	x := bigmat.NewVector([]*big.Float{big.NewFloat(1), big.NewFloat(1), big.NewFloat(1)})
+	if z := d.MulVec(x); z.BigAtVec(0).Cmp(big.NewFloat(1)) != 0 {
+		t.Errorf("MulVec = %g; want 1", z.BigAtVec(0))
+	}
+
+	// the result is at the largest precision of the elements
+	x.SetBigVec(1, third(100))
+	if z := d.MulVec(x).BigAtVec(0); z.Prec() != 100 || z.Cmp(third(100)) != 0 {
+		t.Errorf("MulVec = %g (prec %d); want 1/3 (prec 100)", z, z.Prec())
+	}
+}
+
+func TestVector(t *testing.T) {
+
+	v := bigmat.NewVector([]*big.Float{third(100), big.NewFloat(2)})
+	if r, c := v.Dims(); r != 2 || c != 1 || v.Len() != 2 {
+		t.Errorf("Dims() = %d, %d; Len() = %d", r, c, v.Len())
+	}
+	if v.AtVec(0) != 1.0/3 || v.At(1, 0) != 2 {
+		t.Errorf("AtVec(0) = %g, At(1, 0) = %g", v.AtVec(0), v.At(1, 0))
+	}
+	if d := mat.Dot(v, v); d != 1.0/9+4 {
+		t.Errorf("mat.Dot(v, v) = %g", d)
+	}
+}
+
+func TestPanics(t *testing.T) {
+	for name, f := range map[string]func(){
+		"NewDense":  func() { bigmat.NewDense(2, 2, make([]*big.Float, 3)) },
+		"NewDense0": func() { bigmat.NewDense(0, 2, nil) },
+		"NewVector": func() { bigmat.NewVector(nil) },
+		"BigAt":     func() { bigmat.NewDense(2, 2, nil).BigAt(0, 2) },
+		"MulVec": func() {
+			bigmat.NewDense(2, 2, nil).MulVec(bigmat.NewVector([]*big.Float{new(big.Float)}))
+		},
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%s did not panic", name)
+				}
+			}()
+			f()
+		}()
+	}
+}
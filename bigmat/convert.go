@@ -0,0 +1,115 @@
+package bigmat
+
+import (
+	"math/big"
+
+	"github.com/ThreeAndTwo/bigfloat"
+	"gonum.org/v1/gonum/mat"
+)
+
+// FromMatrix returns a Dense with the elements of m, rounded to prec
+// bits (53 if prec is 0) to nearest even, so that they are exact if
+// prec is at least 53.
+func FromMatrix(m mat.Matrix, prec uint) *Dense {
+
+	r, c := m.Dims()
+	d := NewDense(r, c, nil)
+	for i := 0; i < r; i++ {
+		for j := 0; j < c; j++ {
+			d.data[i*c+j] = newFloat(m.At(i, j), prec)
+		}
+	}
+
+	return d
+}
+
+// FromVector returns a Vector with the elements of v, rounded to prec
+// bits (53 if prec is 0) to nearest even, so that they are exact if
+// prec is at least 53.
+func FromVector(v mat.Vector, prec uint) *Vector {
+
+	data := make([]*big.Float, v.Len())
+	for i := range data {
+		data[i] = newFloat(v.AtVec(i), prec)
+	}
+
+	return NewVector(data)
+}
+
+// ToDense returns the elements of d rounded to float64 values with
+// the given rounding mode, as a mat.Dense. The rounding is done by
+// bigfloat.Float64, so it follows the mode also for subnormals and
+// overflows.
+func (d *Dense) ToDense(mode big.RoundingMode) *mat.Dense {
+
+	data := make([]float64, len(d.data))
+	for i, x := range d.data {
+		data[i], _ = bigfloat.Float64(x, mode)
+	}
+
+	return mat.NewDense(d.rows, d.cols, data)
+}
+
+// ToVecDense returns the elements of v rounded to float64 values with
+// the given rounding mode, as a mat.VecDense. The rounding is done
+// by bigfloat.Float64.
+func (v *Vector) ToVecDense(mode big.RoundingMode) *mat.VecDense {
+
+	data := make([]float64, len(v.data))
+	for i, x := range v.data {
+		data[i], _ = bigfloat.Float64(x, mode)
+	}
+
+	return mat.NewVecDense(len(data), data)
+}
+
+// Residual returns b - a·x, computed from the exact values of the
+// elements of a, x and b, and correctly rounded to prec bits, or 53
+// if prec is smaller. Because the products of float64 values are
+// exact at 106 bits, no precision is lost to cancellation even when
+// x is an accurate solution of a·x = b, where the residual is tiny
+// compared with b. The function panics if the dimensions don't
+// match.
+func Residual(a mat.Matrix, x, b mat.Vector, prec uint) *Vector {
+
+	r, c := a.Dims()
+	if x.Len() != c || b.Len() != r {
+		panic("Residual: dimension mismatch")
+	}
+
+	// each element is the dot product of (-a[i], b[i]) and (x, 1),
+	// and Dot rounds to the largest precision of its arguments, which
+	// is the one of the 1
+	xs := make([]*big.Float, c+1)
+	for j := 0; j < c; j++ {
+		xs[j] = new(big.Float).SetFloat64(x.AtVec(j))
+	}
+	xs[c] = new(big.Float).SetPrec(precOr53(prec)).SetInt64(1)
+
+	row := make([]*big.Float, c+1)
+	z := make([]*big.Float, r)
+	for i := range z {
+		for j := 0; j < c; j++ {
+			row[j] = new(big.Float).SetFloat64(-a.At(i, j))
+		}
+		row[c] = new(big.Float).SetFloat64(b.AtVec(i))
+		z[i] = bigfloat.Dot(row, xs)
+	}
+
+	return NewVector(z)
+}
+
+// newFloat returns f rounded to prec bits, or 53 if prec is 0.
+func newFloat(f float64, prec uint) *big.Float {
+	return new(big.Float).SetPrec(precOr53(prec)).SetFloat64(f)
+}
+
+// precOr53 returns prec, or 53 if prec is 0.
+func precOr53(prec uint) uint {
+
+	if prec == 0 {
+		return 53
+	}
+
+	return prec
+}
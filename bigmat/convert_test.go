@@ -0,0 +1,109 @@
+package bigmat_test
+
+import (
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/ThreeAndTwo/bigfloat/bigmat"
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestConvertRoundTrip(t *testing.T) {
+
+	m := mat.NewDense(2, 2, []float64{0.1, -math.MaxFloat64, 5e-324, math.Pi})
+	d := bigmat.FromMatrix(m, 0)
+	if x := d.BigAt(0, 0); x.Prec() != 53 {
+		t.Errorf("FromMatrix(m, 0) has precision %d; want 53", x.Prec())
+	}
+	if back := d.ToDense(big.ToNearestEven); !mat.Equal(back, m) {
+		t.Errorf("round trip of %v gives %v", mat.Formatted(m), mat.Formatted(back))
+	}
+
+	// at 24 bits, 0.1 is rounded
+	if x := bigmat.FromMatrix(m, 24).BigAt(0, 0); x.Cmp(new(big.Float).SetPrec(24).SetFloat64(0.1)) != 0 || x.Prec() != 24 {
+		t.Errorf("FromMatrix(m, 24) = %g (prec %d)", x, x.Prec())
+	}
+
+	v := mat.NewVecDense(3, []float64{1, -0.5, 1e300})
+	if back := bigmat.FromVector(v, 100).ToVecDense(big.ToZero); !mat.Equal(back, v) {
+		t.Errorf("round trip of %v gives %v", mat.Formatted(v), mat.Formatted(back))
+	}
+}
+
+func TestConvertRounding(t *testing.T) {
+
+	v := bigmat.NewVector([]*big.Float{third(100), new(big.Float).Neg(third(100))})
+	down := v.ToVecDense(big.ToNegativeInf)
+	up := v.ToVecDense(big.ToPositiveInf)
+	if down.AtVec(0) != 1.0/3 || up.AtVec(0) != math.Nextafter(1.0/3, 1) {
+		t.Errorf("1/3 rounded down and up = %v, %v", down.AtVec(0), up.AtVec(0))
+	}
+	if down.AtVec(1) != -math.Nextafter(1.0/3, 1) || up.AtVec(1) != -1.0/3 {
+		t.Errorf("-1/3 rounded down and up = %v, %v", down.AtVec(1), up.AtVec(1))
+	}
+
+	d := bigmat.NewDense(1, 1, []*big.Float{third(100)})
+	if f := d.ToDense(big.ToPositiveInf).At(0, 0); f != math.Nextafter(1.0/3, 1) {
+		t.Errorf("ToDense(ToPositiveInf) = %v", f)
+	}
+}
+
+// pascal returns the n×n Pascal matrix, whose elements are the
+// binomial coefficients C(i+j, i), and which is badly conditioned.
+func pascal(n int) *mat.Dense {
+
+	a := mat.NewDense(n, n, nil)
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			if i == 0 || j == 0 {
+				a.Set(i, j, 1)
+			} else {
+				a.Set(i, j, a.At(i-1, j)+a.At(i, j-1))
+			}
+		}
+	}
+
+	return a
+}
+
+func TestResidualRefinement(t *testing.T) {
+
+	// a·x = b with the exact integer solution x = (1, 2, …, n)
+	const n = 12
+	a := pascal(n)
+	want := mat.NewVecDense(n, nil)
+	for i := 0; i < n; i++ {
+		want.SetVec(i, float64(i+1))
+	}
+	var b mat.VecDense
+	b.MulVec(a, want)
+
+	var lu mat.LU
+	lu.Factorize(a)
+	var x mat.VecDense
+	if err := lu.SolveVecTo(&x, false, &b); err != nil {
+		t.Fatal(err)
+	}
+	if mat.Equal(&x, want) {
+		t.Fatalf("the float64 solution is already exact")
+	}
+
+	// iterative refinement: x += a⁻¹·(b - a·x), with exact residuals
+	for i := 0; i < 10 && !mat.Equal(&x, want); i++ {
+		r := bigmat.Residual(a, &x, &b, 0).ToVecDense(big.ToNearestEven)
+		var dx mat.VecDense
+		if err := lu.SolveVecTo(&dx, false, r); err != nil {
+			t.Fatal(err)
+		}
+		x.AddVec(&x, &dx)
+	}
+	if !mat.Equal(&x, want) {
+		t.Errorf("refined solution = %v; want %v", mat.Formatted(x.T()), mat.Formatted(want.T()))
+	}
+
+	// and the residual of the exact solution is exactly zero
+	if r := bigmat.Residual(a, want, &b, 200); r.BigAtVec(n-1).Sign() != 0 || r.BigAtVec(0).Prec() != 200 {
+		t.Errorf("Residual of the exact solution = %g (prec %d)", r.BigAtVec(n-1), r.BigAtVec(n-1).Prec())
+	}
+}
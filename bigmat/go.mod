@@ -0,0 +1,8 @@
+module github.com/ThreeAndTwo/bigfloat/bigmat
+
+go 1.18
+
+require (
+	github.com/ThreeAndTwo/bigfloat v0.0.0-20261014105150-7f5271ff412d
+	gonum.org/v1/gonum v0.8.2
+)
@@ -0,0 +1,131 @@
+package bigfloat
+
+import (
+	"math/big"
+	"math/bits"
+)
+
+// SinCosBinaryAngle returns the sine and the cosine of the angle
+// k·π/2**n, to prec bits of precision (64 if prec is 0). The angle
+// isn't rounded, as k·π would be: it's reduced exactly to the first
+// octant with the symmetries of sin and cos, and then built, bit after
+// bit of k, with the addition formulas from the values at the angles
+// π/2**i, which come from the exact ones at π/2 with the half-angle
+// formulas
+//
+//	cos(θ/2) = √((1 + cos(θ))/2)
+//	sin(θ/2) = sin(θ)/(2·cos(θ/2))
+//
+// so the results are accurate to a few ulps, the same on every
+// platform, and exact when the values are: 0 and ±1. The twiddle
+// factors of an FFT of size 2**n are the values at 2·j·π/2**n:
+//
+//	s, c := bigfloat.SinCosBinaryAngle(big.NewInt(2*j), n, prec)
+func SinCosBinaryAngle(k *big.Int, n uint, prec uint) (sin, cos *big.Float) {
+
+	if prec == 0 {
+		prec = 64
+	}
+
+	// the angle is m·π/2**n, with 0 <= m < 2**(n+1), in the quadrant q
+	// of the circle, and r·π/2**n from its start
+	m := new(big.Int).Lsh(big.NewInt(1), n+1)
+	m.Mod(k, m)
+	var q uint
+	r := m
+	if n > 0 {
+		q = uint(new(big.Int).Rsh(m, n-1).Uint64())
+		r.SetBit(r, int(n), 0)
+		r.SetBit(r, int(n-1), 0)
+	} else {
+		q, r = 2*uint(m.Uint64()), new(big.Int)
+	}
+
+	// the angles past the octant are the ones of their complement,
+	// with the sine and the cosine swapped
+	swap := false
+	if n >= 2 && r.Cmp(new(big.Int).Lsh(big.NewInt(1), n-2)) > 0 {
+		r.Sub(new(big.Int).Lsh(big.NewInt(1), n-1), r)
+		swap = true
+	}
+
+	s, c := sincosOctant(r, n, prec)
+	if swap {
+		s, c = c, s
+	}
+
+	// sin and cos of q·π/2 + θ
+	switch q {
+	case 1:
+		s, c = c, s.Neg(s)
+	case 2:
+		s, c = s.Neg(s), c.Neg(c)
+	case 3:
+		s, c = c.Neg(c), s
+	}
+	if s.Sign() == 0 {
+		s.Abs(s)
+	}
+	if c.Sign() == 0 {
+		c.Abs(c)
+	}
+
+	return s, c
+}
+
+// SinBinaryAngle returns the sine of the angle k·π/2**n, to prec bits
+// of precision (64 if prec is 0), as SinCosBinaryAngle does.
+func SinBinaryAngle(k *big.Int, n uint, prec uint) *big.Float {
+	s, _ := SinCosBinaryAngle(k, n, prec)
+	return s
+}
+
+// CosBinaryAngle returns the cosine of the angle k·π/2**n, to prec
+// bits of precision (64 if prec is 0), as SinCosBinaryAngle does.
+func CosBinaryAngle(k *big.Int, n uint, prec uint) *big.Float {
+	_, c := SinCosBinaryAngle(k, n, prec)
+	return c
+}
+
+// sincosOctant returns sin(θ) and cos(θ) to prec bits, for θ = r·π/2**n
+// in [0, π/4].
+func sincosOctant(r *big.Int, n, prec uint) (sin, cos *big.Float) {
+
+	if r.Sign() == 0 {
+		return new(big.Float).SetPrec(prec), new(big.Float).SetPrec(prec).SetInt64(1)
+	}
+
+	// every step rounds a few times, and there are 2·n of them
+	wprec := prec + 2*uint(bits.Len(n)) + 64
+	one := big.NewFloat(1)
+	half := big.NewFloat(0.5)
+
+	// (si, ci) goes from the values at π/2 down to the ones at
+	// π/2**n, and (s, c) adds up, from θ = 0, the angles π/2**i of
+	// the bits of r, the bit n-i
+	si := new(big.Float).SetPrec(wprec).SetInt64(1)
+	ci := new(big.Float).SetPrec(wprec)
+	s := new(big.Float).SetPrec(wprec)
+	c := new(big.Float).SetPrec(wprec).SetInt64(1)
+	t := new(big.Float).SetPrec(wprec)
+	u := new(big.Float).SetPrec(wprec)
+	for i := uint(2); i <= n; i++ {
+		ci.Add(ci, one).Mul(ci, half)
+		ci.Sqrt(ci)
+		si.Quo(si, ci).Mul(si, half)
+		if r.Bit(int(n-i)) == 0 {
+			continue
+		}
+
+		// cos(a + b) = cos(a)·cos(b) - sin(a)·sin(b), with no
+		// cancellation as a + b <= π/4
+		t.Mul(c, ci)
+		u.Mul(s, si)
+		s.Mul(s, ci)
+		c.Mul(c, si)
+		s.Add(s, c)
+		c.Sub(t, u)
+	}
+
+	return s.SetPrec(prec), c.SetPrec(prec)
+}
@@ -0,0 +1,151 @@
+package bigfloat_test
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/ThreeAndTwo/bigfloat"
+)
+
+func TestSinCosBinaryAngleExact(t *testing.T) {
+	for _, test := range []struct {
+		k        int64
+		n        uint
+		sin, cos float64
+	}{
+		{0, 0, 0, 1},
+		{1, 0, 0, -1},
+		{-1, 0, 0, -1},
+		{1, 1, 1, 0},
+		{3, 1, -1, 0},
+		{-1, 1, -1, 0},
+		{5, 1, 1, 0},
+		{2, 2, 1, 0},
+		{-4, 2, 0, -1},
+		{64, 5, 0, 1},
+		{96, 5, 0, -1},
+		{48, 5, -1, 0},
+	} {
+		for _, prec := range []uint{1, 53, 1000} {
+			s, c := bigfloat.SinCosBinaryAngle(big.NewInt(test.k), test.n, prec)
+			// the zeros are +0
+			if s.Cmp(big.NewFloat(test.sin)) != 0 || c.Cmp(big.NewFloat(test.cos)) != 0 ||
+				s.Sign() == 0 && s.Signbit() || c.Sign() == 0 && c.Signbit() {
+				t.Errorf("SinCosBinaryAngle(%d, %d, %d) = %g, %g; want %g, %g", test.k, test.n, prec, s, c, test.sin, test.cos)
+			}
+			if s.Prec() != prec || c.Prec() != prec {
+				t.Errorf("SinCosBinaryAngle(%d, %d, %d) has precisions %d, %d", test.k, test.n, prec, s.Prec(), c.Prec())
+			}
+		}
+	}
+}
+
+func TestSinCosBinaryAngle(t *testing.T) {
+	for _, prec := range []uint{53, 200, 1000} {
+		wprec := prec + 64
+		two := new(big.Float).SetPrec(wprec).SetInt64(2)
+		r2 := new(big.Float).SetPrec(wprec).Sqrt(two)
+		h := new(big.Float).SetPrec(wprec).Quo(r2, two)
+
+		// cos(π/8) = √(2 + √2)/2, sin(π/8) = √(2 - √2)/2
+		c8 := new(big.Float).SetPrec(wprec).Add(two, r2)
+		c8.Sqrt(c8).Quo(c8, two)
+		s8 := new(big.Float).SetPrec(wprec).Sub(two, r2)
+		s8.Sqrt(s8).Quo(s8, two)
+		neg := func(x *big.Float) *big.Float { return new(big.Float).Neg(x) }
+
+		for _, test := range []struct {
+			k        int64
+			n        uint
+			sin, cos *big.Float
+		}{
+			{1, 2, h, h},
+			{3, 2, h, neg(h)},
+			{-3, 2, neg(h), neg(h)},
+			{7, 2, neg(h), h},
+			{1, 3, s8, c8},
+			{3, 3, c8, s8},
+			{-5, 3, neg(c8), neg(s8)},
+			{13, 3, neg(c8), s8},
+			{4, 5, s8, c8},
+		} {
+			s, c := bigfloat.SinCosBinaryAngle(big.NewInt(test.k), test.n, prec)
+			want := new(big.Float).SetPrec(prec)
+			if bigfloat.CmpUlp(s, want.Set(test.sin), 1) != 0 {
+				t.Errorf("prec = %d, sin(%d·π/2**%d) =\ngot  %g;\nwant %g", prec, test.k, test.n, s, want)
+			}
+			if bigfloat.CmpUlp(c, want.Set(test.cos), 1) != 0 {
+				t.Errorf("prec = %d, cos(%d·π/2**%d) =\ngot  %g;\nwant %g", prec, test.k, test.n, c, want)
+			}
+		}
+	}
+}
+
+func TestSinCosBinaryAngleFloat64(t *testing.T) {
+	for n := uint(0); n <= 10; n++ {
+		for k := -int64(3) << n; k <= 3<<n; k++ {
+			s, c := bigfloat.SinCosBinaryAngle(big.NewInt(k), n, 53)
+			x := float64(k) * math.Pi / float64(uint(1)<<n)
+			s64, _ := s.Float64()
+			c64, _ := c.Float64()
+			if math.Abs(s64-math.Sin(x)) > 1e-14 || math.Abs(c64-math.Cos(x)) > 1e-14 {
+				t.Errorf("SinCosBinaryAngle(%d, %d) = %g, %g; want %g, %g", k, n, s64, c64, math.Sin(x), math.Cos(x))
+			}
+		}
+	}
+}
+
+func TestSinCosBinaryAngleSmall(t *testing.T) {
+	// sin(π/2**n) = π/2**n·(1 - O(4**-n)), cos(π/2**n) = 1 - O(4**-n)
+	const prec = 300
+	for _, n := range []uint{200, 400} {
+		want := bigfloat.Pi.Value(prec)
+		want.SetMantExp(want, -int(n))
+		s, c := bigfloat.SinCosBinaryAngle(big.NewInt(1), n, prec)
+		if bigfloat.CmpUlp(s, want, 2) != 0 {
+			t.Errorf("sin(π/2**%d) =\ngot  %g;\nwant %g", n, s, want)
+		}
+		if n == 400 && c.Cmp(big.NewFloat(1)) != 0 {
+			t.Errorf("cos(π/2**%d) = %g; want 1", n, c)
+		}
+	}
+
+	// the angle of a k beyond int64 is reduced exactly: k = 2**100 + 1
+	k := new(big.Int).Lsh(big.NewInt(1), 100)
+	k.Add(k, big.NewInt(1))
+	s := bigfloat.SinBinaryAngle(k, 3, 100)
+	want := bigfloat.SinBinaryAngle(big.NewInt(1), 3, 100)
+	if s.Cmp(want) != 0 {
+		t.Errorf("SinBinaryAngle(2**100 + 1, 3) = %g; want %g", s, want)
+	}
+}
+
+func TestSinCosBinaryAnglePythagoras(t *testing.T) {
+	const prec = 500
+	for k := int64(1); k < 1<<20; k = 3*k + 7 {
+		s := bigfloat.SinBinaryAngle(big.NewInt(k), 30, prec)
+		c := bigfloat.CosBinaryAngle(big.NewInt(k), 30, prec)
+		r := new(big.Float).SetPrec(2*prec).Mul(s, s)
+		r.Add(r, new(big.Float).SetPrec(2*prec).Mul(c, c))
+		r.SetPrec(prec)
+		if bigfloat.CmpUlp(r, big.NewFloat(1), 4) != 0 {
+			t.Errorf("sin² + cos² of %d·π/2**30 = %g", k, r)
+		}
+	}
+}
+
+// ---------- Benchmarks ----------
+
+func BenchmarkSinCosBinaryAngle(b *testing.B) {
+	k := big.NewInt(12345)
+	for _, prec := range []uint{53, 1e3, 1e4} {
+		b.Run(fmt.Sprintf("%v", prec), func(b *testing.B) {
+			b.ReportAllocs()
+			for n := 0; n < b.N; n++ {
+				bigfloat.SinCosBinaryAngle(k, 16, prec)
+			}
+		})
+	}
+}
@@ -0,0 +1,142 @@
+package bigfloat
+
+import (
+	"encoding/binary"
+	"errors"
+	"math/big"
+)
+
+// binaryVersion is the version of the encoding written by
+// Float.MarshalBinary.
+const binaryVersion = 1
+
+// kinds of values, in the flags byte of the binary encoding
+const (
+	binaryZero   = 0
+	binaryFinite = 1
+	binaryInf    = 2
+)
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface.
+// The encoding is compact and exact, and it's made of:
+//
+//	a version byte, currently 1
+//	a flags byte: the rounding mode in bits 0-2, the sign in bit 3,
+//	    and in bits 4-5 the kind of value (0 for ±0, 1 for finite
+//	    non-zero values, 2 for ±Inf)
+//	the precision, as an unsigned varint
+//
+// followed, for finite non-zero values, by the exponent e, as a
+// signed varint, and the odd integer m, in big-endian order, such that
+// the absolute value is m·2**e. The varints are the ones of the
+// encoding/binary package.
+func (f *Float) MarshalBinary() ([]byte, error) {
+
+	kind := binaryFinite
+	switch {
+	case f.Sign() == 0:
+		kind = binaryZero
+	case f.IsInf():
+		kind = binaryInf
+	}
+	flags := byte(f.Mode()) | byte(kind)<<4
+	if f.Signbit() {
+		flags |= 1 << 3
+	}
+
+	b := []byte{binaryVersion, flags}
+	b = appendUvarint(b, uint64(f.Prec()))
+	if kind != binaryFinite {
+		return b, nil
+	}
+
+	m, e := intMantExp(&f.Float)
+	b = appendVarint(b, int64(e))
+	b = append(b, m.Abs(m).Bytes()...)
+
+	return b, nil
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler
+// interface, reading the encoding written by MarshalBinary.
+func (f *Float) UnmarshalBinary(data []byte) error {
+
+	if len(data) < 2 {
+		return errors.New("bigfloat: binary encoding too short")
+	}
+	if data[0] != binaryVersion {
+		return errors.New("bigfloat: unsupported binary encoding version")
+	}
+	flags := data[1]
+	mode := big.RoundingMode(flags & 7)
+	neg := flags&(1<<3) != 0
+	kind := flags >> 4
+	if mode > big.ToPositiveInf || kind > binaryInf {
+		return errors.New("bigfloat: invalid binary encoding flags")
+	}
+	data = data[2:]
+
+	prec, n := binary.Uvarint(data)
+	if n <= 0 || prec > big.MaxPrec {
+		return errors.New("bigfloat: invalid precision in binary encoding")
+	}
+	data = data[n:]
+
+	z := new(big.Float).SetPrec(uint(prec)).SetMode(mode)
+	switch kind {
+	case binaryZero:
+		if len(data) != 0 {
+			return errors.New("bigfloat: invalid binary encoding")
+		}
+		if neg {
+			z.Neg(z)
+		}
+	case binaryInf:
+		if len(data) != 0 {
+			return errors.New("bigfloat: invalid binary encoding")
+		}
+		z.SetInf(neg)
+	default:
+		e, n := binary.Varint(data)
+		if n <= 0 || len(data) == n {
+			return errors.New("bigfloat: invalid exponent in binary encoding")
+		}
+		m := new(big.Int).SetBytes(data[n:])
+		if m.Bit(0) == 0 || uint64(m.BitLen()) > prec {
+			return errors.New("bigfloat: invalid mantissa in binary encoding")
+		}
+		if e < big.MinExp-int64(m.BitLen()) || e > big.MaxExp-int64(m.BitLen()) {
+			return errors.New("bigfloat: exponent out of range in binary encoding")
+		}
+		if neg {
+			m.Neg(m)
+		}
+		z.SetInt(m)
+		z.SetMantExp(z, int(e))
+	}
+
+	f.Copy(z)
+	return nil
+}
+
+// GobEncode implements the gob.GobEncoder interface, using the
+// encoding of MarshalBinary.
+func (f *Float) GobEncode() ([]byte, error) {
+	return f.MarshalBinary()
+}
+
+// GobDecode implements the gob.GobDecoder interface, reading the
+// encoding written by GobEncode.
+func (f *Float) GobDecode(data []byte) error {
+	return f.UnmarshalBinary(data)
+}
+
+func appendUvarint(b []byte, u uint64) []byte {
+	var buf [binary.MaxVarintLen64]byte
+	return append(b, buf[:binary.PutUvarint(buf[:], u)]...)
+}
+
+func appendVarint(b []byte, i int64) []byte {
+	var buf [binary.MaxVarintLen64]byte
+	return append(b, buf[:binary.PutVarint(buf[:], i)]...)
+}
@@ -0,0 +1,110 @@
+package bigfloat_test
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/ThreeAndTwo/bigfloat"
+)
+
+func TestFloatMarshalBinary(t *testing.T) {
+	for _, test := range []struct {
+		x    *big.Float
+		want string
+	}{
+		{big.NewFloat(1.5), "011035" + "01" + "03"},                                           // 3·2**-1
+		{new(big.Float).SetPrec(8).SetMode(big.ToZero).SetInt64(-40), "011a08" + "06" + "05"}, // -5·2**3
+		{new(big.Float), "0100" + "00"},
+		{new(big.Float).Neg(new(big.Float).SetPrec(24)), "0108" + "18"},
+		{new(big.Float).SetPrec(1000).SetInf(false), "0120" + "e807"},
+	} {
+		b, err := bigfloat.NewFloat(test.x).MarshalBinary()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if s := hex.EncodeToString(b); s != test.want {
+			t.Errorf("MarshalBinary(%g) = %s; want %s", test.x, s, test.want)
+		}
+	}
+}
+
+func TestFloatBinaryRoundTrip(t *testing.T) {
+	for _, x := range []*big.Float{
+		big.NewFloat(-0.1),
+		new(big.Float).SetPrec(3).SetMode(big.ToPositiveInf).SetInt64(7),
+		new(big.Float).SetMantExp(big.NewFloat(1), -1000000),
+		bigfloat.Sqrt(new(big.Float).SetPrec(100000).SetMode(big.AwayFromZero).SetInt64(2)),
+		new(big.Float).SetInf(true),
+	} {
+		b, _ := bigfloat.NewFloat(x).MarshalBinary()
+		var f bigfloat.Float
+		if err := f.UnmarshalBinary(b); err != nil {
+			t.Fatalf("UnmarshalBinary error: %v", err)
+		}
+		if f.Cmp(x) != 0 || f.Signbit() != x.Signbit() || f.Prec() != x.Prec() || f.Mode() != x.Mode() {
+			t.Errorf("binary round trip of %.20g gave %.20g (prec %d, %v)", x, &f.Float, f.Prec(), f.Mode())
+		}
+	}
+}
+
+func TestFloatGob(t *testing.T) {
+	type record struct {
+		Name  string
+		Value bigfloat.Float
+		Ptr   *bigfloat.Float
+	}
+	in := record{
+		Name: "pi",
+		Ptr:  bigfloat.NewFloat(bigfloat.Sqrt(new(big.Float).SetPrec(500).SetInt64(5))),
+	}
+	in.Value.SetPrec(300).SetMode(big.ToZero).SetFloat64(3.25)
+
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(&in); err != nil {
+		t.Fatal(err)
+	}
+	var out record
+	if err := gob.NewDecoder(&buf).Decode(&out); err != nil {
+		t.Fatal(err)
+	}
+
+	if out.Value.Cmp(&in.Value.Float) != 0 || out.Value.Prec() != 300 || out.Value.Mode() != big.ToZero {
+		t.Errorf("gob Value = %g (prec %d, %v)", &out.Value.Float, out.Value.Prec(), out.Value.Mode())
+	}
+	if out.Ptr.Cmp(&in.Ptr.Float) != 0 || out.Ptr.Prec() != 500 {
+		t.Errorf("gob Ptr = %g (prec %d)", &out.Ptr.Float, out.Ptr.Prec())
+	}
+}
+
+func TestFloatUnmarshalBinaryErrors(t *testing.T) {
+	for _, data := range []string{
+		"",
+		"01",
+		"0210" + "35" + "02" + "03", // version
+		"0117" + "35",               // mode
+		"0130" + "35",               // kind
+		"0110",                      // no precision
+		"011035" + "02",             // no mantissa
+		"011035" + "02" + "02",      // even mantissa
+		"011002" + "02" + "07",      // mantissa larger than the precision
+		"010035" + "00",             // data after a zero
+	} {
+		b, _ := hex.DecodeString(data)
+		var f bigfloat.Float
+		if err := f.UnmarshalBinary(b); err == nil {
+			t.Errorf("UnmarshalBinary(%s) did not fail", data)
+		}
+	}
+}
+
+// ---------- Benchmarks ----------
+
+func BenchmarkFloatMarshalBinary(b *testing.B) {
+	f := bigfloat.NewFloat(bigfloat.Sqrt(new(big.Float).SetPrec(1000).SetInt64(2)))
+	for n := 0; n < b.N; n++ {
+		f.MarshalBinary()
+	}
+}
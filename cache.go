@@ -0,0 +1,158 @@
+package bigfloat
+
+import (
+	"container/list"
+	"math/big"
+	"sync"
+)
+
+// DefaultCacheLimit is the initial limit of the cache, in bits.
+const DefaultCacheLimit = 1 << 26
+
+// A valueCache keeps the values that the functions compute again and
+// again at the same precision, like the constants of their argument
+// reductions, so that a program doing many computations at a given
+// precision pays for them once. The values are keyed by name and
+// precision, and the least recently used ones are dropped when the
+// sum of their precisions exceeds the limit. The cached values are
+// the ones the functions would compute, so the cache never changes a
+// result.
+type valueCache struct {
+	mu    sync.Mutex
+	limit uint64
+	size  uint64
+	lru   list.List // of *cacheEntry, the most recently used first
+	m     map[cacheKey]*list.Element
+}
+
+type cacheKey struct {
+	name string
+	prec uint
+}
+
+type cacheEntry struct {
+	key cacheKey
+	x   *big.Float
+}
+
+var cache = valueCache{
+	limit: DefaultCacheLimit,
+	m:     make(map[cacheKey]*list.Element),
+}
+
+// SetCacheLimit sets the limit of the cache of the values computed
+// by the functions of the package, and returns the previous one. The
+// limit is the sum of the precisions of the values, in bits, so
+// about 8 times their size in bytes; 0 disables the cache. The least
+// recently used values are dropped to bring the cache within the new
+// limit.
+//
+// The cache is shared by all the goroutines, and holds the constants
+// of Constant.Value, and π at the largest precision used, for the
+// reductions of Log and of the trigonometric functions.
+func SetCacheLimit(bits uint64) uint64 {
+
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+	old := cache.limit
+	cache.limit = bits
+	cache.evict()
+
+	return old
+}
+
+// ClearCache drops all the values of the cache of the package, to
+// release their memory.
+func ClearCache() {
+
+	cache.mu.Lock()
+	cache.lru.Init()
+	cache.m = make(map[cacheKey]*list.Element)
+	cache.size = 0
+	cache.mu.Unlock()
+
+	piMu.Lock()
+	piCache, piCachePrec = builtinPi()
+	piMu.Unlock()
+}
+
+// CacheSize returns the sum of the precisions of the values in the
+// cache, in bits, not counting the 1024 bits of π built in the
+// package.
+func CacheSize() uint64 {
+
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	return cache.size + piCacheSize()
+}
+
+// piCacheSize returns the precision of the cached π, if it's larger
+// than the built-in one, and 0 otherwise.
+func piCacheSize() uint64 {
+
+	piMu.Lock()
+	defer piMu.Unlock()
+	if piCachePrec <= piBuiltinPrec {
+		return 0
+	}
+
+	return uint64(piCachePrec)
+}
+
+// cacheLimit returns the limit of the cache.
+func cacheLimit() uint64 {
+
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	return cache.limit
+}
+
+// cached returns the value named name at precision prec, from the
+// cache, or from f, whose result is then added to the cache. f is
+// called without holding the lock of the cache, so concurrent calls
+// can compute the same value.
+func cached(name string, prec uint, f func() *big.Float) *big.Float {
+
+	key := cacheKey{name, prec}
+	c := &cache
+	c.mu.Lock()
+	if e, ok := c.m[key]; ok {
+		c.lru.MoveToFront(e)
+		x := new(big.Float).Copy(e.Value.(*cacheEntry).x)
+		c.mu.Unlock()
+		return x
+	}
+	c.mu.Unlock()
+
+	x := f()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.m[key]; !ok && uint64(prec) <= c.limit {
+		c.m[key] = c.lru.PushFront(&cacheEntry{key, new(big.Float).Copy(x)})
+		c.size += uint64(prec)
+		c.evict()
+	}
+
+	return x
+}
+
+// evict drops the least recently used values until c is within its
+// limit, and then π if it's still needed. The lock of c must be
+// held; it's taken before the one of π.
+func (c *valueCache) evict() {
+
+	pis := piCacheSize()
+	for c.size+pis > c.limit && c.lru.Len() > 0 {
+		e := c.lru.Remove(c.lru.Back()).(*cacheEntry)
+		delete(c.m, e.key)
+		c.size -= uint64(e.key.prec)
+	}
+	if c.size+pis > c.limit {
+		piMu.Lock()
+		piCache, piCachePrec = builtinPi()
+		piMu.Unlock()
+	}
+}
@@ -0,0 +1,107 @@
+package bigfloat_test
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/ThreeAndTwo/bigfloat"
+)
+
+func TestCache(t *testing.T) {
+	defer bigfloat.SetCacheLimit(bigfloat.DefaultCacheLimit)
+
+	bigfloat.ClearCache()
+	if n := bigfloat.CacheSize(); n != 0 {
+		t.Fatalf("CacheSize() = %d after ClearCache; want 0", n)
+	}
+
+	// π, ln2 and γ within their tables aren't cached
+	bigfloat.Pi.Value(8000)
+	if n := bigfloat.CacheSize(); n != 0 {
+		t.Errorf("CacheSize() = %d after π; want 0", n)
+	}
+
+	// the cached values are the computed ones
+	for _, c := range []bigfloat.Constant{bigfloat.E, bigfloat.Ln2, bigfloat.EulerGamma, bigfloat.Phi} {
+		for _, prec := range []uint{53, 1000, 3000} {
+			x := c.Value(prec)
+			y := c.Value(prec)
+			if x.Cmp(y) != 0 || y.Prec() != prec {
+				t.Errorf("%v.Value(%d) = %g, then %g (prec %d)", c, prec, x, y, y.Prec())
+			}
+			// the copies are independent
+			x.SetInt64(0)
+			if z := c.Value(prec); z.Cmp(y) != 0 {
+				t.Errorf("%v.Value(%d) changed with its result", c, prec)
+			}
+		}
+	}
+	if n, want := bigfloat.CacheSize(), uint64(2*(53+1000+3000)); n != want {
+		t.Errorf("CacheSize() = %d; want %d", n, want)
+	}
+
+	// lowering the limit drops the least recently used values, and
+	// then π
+	if old := bigfloat.SetCacheLimit(5000); old != bigfloat.DefaultCacheLimit {
+		t.Errorf("SetCacheLimit returned %d; want %d", old, bigfloat.DefaultCacheLimit)
+	}
+	if n := bigfloat.CacheSize(); n > 5000 {
+		t.Errorf("CacheSize() = %d; want <= 5000", n)
+	}
+	for _, prec := range []uint{1000, 2000, 3000} {
+		bigfloat.Ln10.Value(prec)
+		if n := bigfloat.CacheSize(); n > 5000 {
+			t.Errorf("CacheSize() = %d; want <= 5000", n)
+		}
+	}
+
+	// a disabled cache keeps nothing
+	bigfloat.SetCacheLimit(0)
+	bigfloat.Sqrt2.Value(100)
+	if n := bigfloat.CacheSize(); n != 0 {
+		t.Errorf("CacheSize() = %d with limit 0; want 0", n)
+	}
+}
+
+func TestCacheConcurrent(t *testing.T) {
+	defer bigfloat.SetCacheLimit(bigfloat.DefaultCacheLimit)
+	bigfloat.ClearCache()
+	bigfloat.SetCacheLimit(3000)
+
+	want := make(map[uint]string)
+	for _, prec := range []uint{100, 500, 1000, 2000} {
+		want[prec] = bigfloat.Ln2.Value(prec).Text('g', -1)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 20; j++ {
+				prec := []uint{100, 500, 1000, 2000}[(i+j)%4]
+				if got := bigfloat.Ln2.Value(prec).Text('g', -1); got != want[prec] {
+					t.Errorf("Ln2.Value(%d) = %s; want %s", prec, got, want[prec])
+				}
+				if j%7 == 0 {
+					bigfloat.ClearCache()
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+}
+
+// ---------- Benchmarks ----------
+
+func BenchmarkCachedValue(b *testing.B) {
+	for _, prec := range []uint{1e2, 1e3, 4096} {
+		b.Run(fmt.Sprintf("%v", prec), func(b *testing.B) {
+			b.ReportAllocs()
+			for n := 0; n < b.N; n++ {
+				bigfloat.Ln10.Value(prec)
+			}
+		})
+	}
+}
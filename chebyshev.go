@@ -0,0 +1,277 @@
+package bigfloat
+
+import "math/big"
+
+// chebMaxDegree is the maximum degree of the interpolants built by
+// NewChebyshev.
+const chebMaxDegree = 1 << 12
+
+// A Chebyshev is a polynomial on an interval [a, b], represented by
+// its coefficients in the basis of the Chebyshev polynomials, mapped
+// from [-1, 1] to [a, b]:
+//
+//	p(x) = Σ c[k]·T[k](u), with u = (2x - a - b)/(b - a)
+//
+// A Chebyshev is immutable, and it's safe for concurrent use.
+type Chebyshev struct {
+	a, b *big.Float
+	c    []*big.Float
+	prec uint
+}
+
+// NewChebyshev returns the Chebyshev interpolant of f on [a, b],
+// with a degree sufficient to represent f to the precision of a.
+// The degree of the interpolant is doubled until the trailing
+// Chebyshev coefficients become negligible, and the coefficients
+// below the precision are then chopped off. f is called with
+// arguments having a few guard digits more than the precision. The
+// function panics if a >= b.
+//
+// Convergence is fast when f is smooth on [a, b], and geometric when
+// f is analytic. The degree is limited to 4096; if the coefficients
+// are still not negligible at that degree, the interpolant will be
+// less accurate than the precision.
+func NewChebyshev(f func(*big.Float) *big.Float, a, b *big.Float) *Chebyshev {
+
+	if a.Cmp(b) >= 0 {
+		panic("NewChebyshev: a >= b")
+	}
+
+	prec := a.Prec()
+	wprec := prec + 32 // guard digits
+
+	var c []*big.Float
+	for n := 16; n <= chebMaxDegree; n *= 2 {
+		c = chebCoeffs(f, a, b, n, wprec)
+		if m := chebChop(c, prec); m < len(c)-2 || n == chebMaxDegree {
+			c = c[:m]
+			break
+		}
+	}
+
+	return &Chebyshev{
+		a:    new(big.Float).SetPrec(wprec).Set(a),
+		b:    new(big.Float).SetPrec(wprec).Set(b),
+		c:    c,
+		prec: prec,
+	}
+}
+
+// chebCoeffs returns the Chebyshev coefficients of the polynomial
+// interpolating f at the n+1 Chebyshev points of the second kind
+// x[j] = cos(πj/n) (mapped to [a, b]). They can be computed using
+// the discrete cosine transform
+//
+//	c[k] = (2/n)·Σ″ f(x[j])·cos(πjk/n)
+//
+// where Σ″ means that the first and last terms are halved. c[0] and
+// c[n] are also halved.
+func chebCoeffs(f func(*big.Float) *big.Float, a, b *big.Float, n int, prec uint) []*big.Float {
+
+	// cos(πm/n) for m = 0, ..., n; cos(π(2n-m)/n) = cos(πm/n)
+	// gives the other values we need.
+	cosTab := make([]*big.Float, n+1)
+	p := pi(prec)
+	for m := range cosTab {
+		t := new(big.Float).SetPrec(prec).SetInt64(int64(m))
+		t.Mul(t, p).Quo(t, big.NewFloat(float64(n)))
+		_, cosTab[m] = sincos(t)
+	}
+	cosPi := func(m int) *big.Float {
+		m %= 2 * n
+		if m > n {
+			m = 2*n - m
+		}
+		return cosTab[m]
+	}
+
+	// mid = (a+b)/2, rad = (b-a)/2
+	mid := new(big.Float).SetPrec(prec).Add(a, b)
+	mid.Mul(mid, big.NewFloat(0.5))
+	rad := new(big.Float).SetPrec(prec).Sub(b, a)
+	rad.Mul(rad, big.NewFloat(0.5))
+
+	fx := make([]*big.Float, n+1)
+	for j := range fx {
+		x := new(big.Float).SetPrec(prec).Mul(rad, cosTab[j])
+		fx[j] = new(big.Float).SetPrec(prec).Set(f(x.Add(x, mid)))
+	}
+	fx[0].Mul(fx[0], big.NewFloat(0.5))
+	fx[n].Mul(fx[n], big.NewFloat(0.5))
+
+	c := make([]*big.Float, n+1)
+	scale := new(big.Float).SetPrec(prec).Quo(big.NewFloat(2), big.NewFloat(float64(n)))
+	t := new(big.Float).SetPrec(prec)
+	for k := range c {
+		s := new(big.Float).SetPrec(prec)
+		for j := range fx {
+			s.Add(s, t.Mul(fx[j], cosPi(j*k)))
+		}
+		c[k] = s.Mul(s, scale)
+	}
+	c[0].Mul(c[0], big.NewFloat(0.5))
+	c[n].Mul(c[n], big.NewFloat(0.5))
+
+	return c
+}
+
+// chebChop returns the number of coefficients of c that are needed
+// to represent the interpolant to prec bits, relative to the largest
+// coefficient.
+func chebChop(c []*big.Float, prec uint) int {
+
+	max := new(big.Float)
+	for _, ck := range c {
+		if a := new(big.Float).Abs(ck); a.Cmp(max) > 0 {
+			max = a
+		}
+	}
+	if max.Sign() == 0 {
+		return 1
+	}
+	lim := max.MantExp(nil) - int(prec) - 1
+
+	m := len(c)
+	for m > 1 && (c[m-1].Sign() == 0 || c[m-1].MantExp(nil) < lim) {
+		m--
+	}
+
+	return m
+}
+
+// Degree returns the degree of the interpolant.
+func (p *Chebyshev) Degree() int {
+	return len(p.c) - 1
+}
+
+// Coeffs returns a copy of the Chebyshev coefficients of p.
+func (p *Chebyshev) Coeffs() []*big.Float {
+
+	c := make([]*big.Float, len(p.c))
+	for k := range c {
+		c[k] = new(big.Float).SetPrec(p.prec).Set(p.c[k])
+	}
+
+	return c
+}
+
+// Eval returns p(x), computed using Clenshaw's algorithm. Precision
+// is the same as the one used to build p.
+func (p *Chebyshev) Eval(x *big.Float) *big.Float {
+
+	prec := p.a.Prec()
+
+	// u = (2x - a - b)/(b - a)
+	u := new(big.Float).SetPrec(prec).Mul(x, big.NewFloat(2))
+	u.Sub(u, p.a).Sub(u, p.b)
+	u.Quo(u, new(big.Float).Sub(p.b, p.a))
+
+	// b[k] = c[k] + 2u·b[k+1] - b[k+2], and p = c[0] + u·b[1] - b[2]
+	u2 := new(big.Float).SetPrec(prec).Mul(u, big.NewFloat(2))
+	b1 := new(big.Float).SetPrec(prec)
+	b2 := new(big.Float).SetPrec(prec)
+	for k := len(p.c) - 1; k >= 1; k-- {
+		t := new(big.Float).SetPrec(prec).Mul(u2, b1)
+		t.Sub(t, b2).Add(t, p.c[k])
+		b1, b2 = t, b1
+	}
+
+	y := new(big.Float).SetPrec(prec).Mul(u, b1)
+	y.Sub(y, b2).Add(y, p.c[0])
+
+	return y.SetPrec(p.prec)
+}
+
+// Deriv returns the derivative of p.
+func (p *Chebyshev) Deriv() *Chebyshev {
+
+	prec := p.a.Prec()
+	n := len(p.c) - 1
+	if n == 0 {
+		return &Chebyshev{a: p.a, b: p.b, c: []*big.Float{new(big.Float).SetPrec(prec)}, prec: p.prec}
+	}
+
+	// d[n] = 0, d[n-1] = 2n·c[n], d[k-1] = d[k+1] + 2k·c[k]
+	// and d[0] is halved.
+	d := make([]*big.Float, n+1)
+	d[n] = new(big.Float).SetPrec(prec)
+	for k := n; k >= 1; k-- {
+		t := new(big.Float).SetPrec(prec).Mul(p.c[k], big.NewFloat(float64(2*k)))
+		if k+1 <= n {
+			t.Add(t, d[k+1])
+		}
+		d[k-1] = t
+	}
+	d[0].Mul(d[0], big.NewFloat(0.5))
+
+	// chain rule for the mapping of [a, b] to [-1, 1]
+	s := new(big.Float).SetPrec(prec).Sub(p.b, p.a)
+	s.Quo(big.NewFloat(2), s)
+	for k := range d {
+		d[k].Mul(d[k], s)
+	}
+
+	return &Chebyshev{a: p.a, b: p.b, c: d[:n], prec: p.prec}
+}
+
+// Integral returns the antiderivative of p that vanishes at a.
+func (p *Chebyshev) Integral() *Chebyshev {
+
+	prec := p.a.Prec()
+	n := len(p.c) - 1
+
+	coeff := func(k int) *big.Float {
+		if k > n {
+			return new(big.Float)
+		}
+		return p.c[k]
+	}
+
+	// I[1] = c[0] - c[2]/2, I[k] = (c[k-1] - c[k+1])/2k for k >= 2
+	in := make([]*big.Float, n+2)
+	half := big.NewFloat(0.5)
+	in[1] = new(big.Float).SetPrec(prec).Mul(coeff(2), half)
+	in[1].Sub(p.c[0], in[1])
+	for k := 2; k <= n+1; k++ {
+		t := new(big.Float).SetPrec(prec).Sub(coeff(k-1), coeff(k+1))
+		in[k] = t.Quo(t, big.NewFloat(float64(2*k)))
+	}
+
+	// chain rule for the mapping of [a, b] to [-1, 1]
+	s := new(big.Float).SetPrec(prec).Sub(p.b, p.a)
+	s.Mul(s, half)
+	for k := 1; k < len(in); k++ {
+		in[k].Mul(in[k], s)
+	}
+
+	// choose I[0] so that the value at u = -1 is zero:
+	// T[k](-1) = (-1)ᵏ.
+	in[0] = new(big.Float).SetPrec(prec)
+	for k := 1; k < len(in); k++ {
+		if k%2 == 0 {
+			in[0].Sub(in[0], in[k])
+		} else {
+			in[0].Add(in[0], in[k])
+		}
+	}
+
+	return &Chebyshev{a: p.a, b: p.b, c: in, prec: p.prec}
+}
+
+// Definite returns the integral of p over [a, b].
+func (p *Chebyshev) Definite() *big.Float {
+
+	prec := p.a.Prec()
+
+	// ∫ T[k] over [-1, 1] is 2/(1-k²) for even k, and 0 for odd k
+	sum := new(big.Float).SetPrec(prec)
+	t := new(big.Float).SetPrec(prec)
+	for k := 0; k < len(p.c); k += 2 {
+		t.Quo(big.NewFloat(2), big.NewFloat(float64(1-k*k)))
+		sum.Add(sum, t.Mul(t, p.c[k]))
+	}
+
+	t.Sub(p.b, p.a).Mul(t, big.NewFloat(0.5))
+
+	return sum.Mul(sum, t).SetPrec(p.prec)
+}
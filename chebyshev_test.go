@@ -0,0 +1,86 @@
+package bigfloat_test
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/ThreeAndTwo/bigfloat"
+)
+
+func TestChebyshevExp(t *testing.T) {
+	for _, prec := range []uint{53, 100, 200} {
+		a := big.NewFloat(-1).SetPrec(prec)
+		b := big.NewFloat(2).SetPrec(prec)
+		p := bigfloat.NewChebyshev(bigfloat.Exp, a, b)
+
+		for _, f := range []float64{-1, -0.3, 0, 0.5, 1.75, 2} {
+			x := big.NewFloat(f).SetPrec(prec)
+			want := bigfloat.Exp(x)
+
+			if y := p.Eval(x); !closeTo(y, want, int(prec)-6) {
+				t.Errorf("prec = %d, p(%g) =\ngot  %g;\nwant %g", prec, f, y, want)
+			}
+			if y := p.Deriv().Eval(x); !closeTo(y, want, int(prec)-16) {
+				t.Errorf("prec = %d, p'(%g) =\ngot  %g;\nwant %g", prec, f, y, want)
+			}
+
+			// ∫ exp over [a, x] = exp(x) - exp(a)
+			want.Sub(want, bigfloat.Exp(a))
+			if y := p.Integral().Eval(x); f != -1 && !closeTo(y, want, int(prec)-6) {
+				t.Errorf("prec = %d, ∫p over [-1, %g] =\ngot  %g;\nwant %g", prec, f, y, want)
+			}
+		}
+
+		want := new(big.Float).Sub(bigfloat.Exp(b), bigfloat.Exp(a))
+		if y := p.Definite(); !closeTo(y, want, int(prec)-6) {
+			t.Errorf("prec = %d, ∫p over [-1, 2] =\ngot  %g;\nwant %g", prec, y, want)
+		}
+	}
+}
+
+func TestChebyshevPolynomial(t *testing.T) {
+	// 2x³ - x is represented exactly by a cubic
+	f := func(x *big.Float) *big.Float {
+		y := new(big.Float).Mul(x, x)
+		y.Mul(y, x).Mul(y, big.NewFloat(2))
+		return y.Sub(y, x)
+	}
+
+	a := big.NewFloat(0).SetPrec(100)
+	b := big.NewFloat(4).SetPrec(100)
+	p := bigfloat.NewChebyshev(f, a, b)
+	if d := p.Degree(); d != 3 {
+		t.Errorf("Degree() = %d; want 3", d)
+	}
+	if c := p.Coeffs(); len(c) != 4 {
+		t.Errorf("len(Coeffs()) = %d; want 4", len(c))
+	}
+
+	x := big.NewFloat(3)
+	if y := p.Eval(x); !closeTo(y, big.NewFloat(51), 90) {
+		t.Errorf("p(3) = %g; want 51", y)
+	}
+	if y := p.Deriv().Eval(x); !closeTo(y, big.NewFloat(53), 90) {
+		t.Errorf("p'(3) = %g; want 53", y)
+	}
+	// ∫ 2x³ - x over [0, 4] = 128 - 8
+	if y := p.Definite(); !closeTo(y, big.NewFloat(120), 90) {
+		t.Errorf("∫p over [0, 4] = %g; want 120", y)
+	}
+}
+
+// ---------- Benchmarks ----------
+
+func BenchmarkNewChebyshev(b *testing.B) {
+	for _, prec := range []uint{53, 100, 200} {
+		x := big.NewFloat(-1).SetPrec(prec)
+		y := big.NewFloat(1).SetPrec(prec)
+		b.Run(fmt.Sprintf("%v", prec), func(b *testing.B) {
+			b.ReportAllocs()
+			for n := 0; n < b.N; n++ {
+				bigfloat.NewChebyshev(bigfloat.Exp, x, y)
+			}
+		})
+	}
+}
@@ -0,0 +1,222 @@
+// Command bigfloat is a calculator that evaluates arithmetic
+// expressions to a requested number of digits, using the bigfloat
+// package.
+//
+// Usage:
+//
+//	bigfloat [flags] [expression]
+//	bigfloat -i [flags]
+//	bigfloat -dump constant [-digits n]
+//
+// The expression is made of the arguments, joined by spaces. Without
+// arguments, each non-empty line of the standard input is evaluated
+// as an expression. See bigfloat.Eval for the syntax of the
+// expressions.
+//
+// With -i, bigfloat runs an interactive session, in which the results
+// can be stored in variables with "name = expr", and the precision
+// and the format can be changed with the :digits, :prec and :format
+// commands; :help lists the commands. Each result that had to be
+// rounded to the requested precision is flagged as such.
+//
+// With -dump, bigfloat writes the first -digits digits of one of the
+// constants pi, e, gamma, ln2, ln10, sqrt2 and phi, truncated. The
+// digits are written as they are converted, so dumps of millions of
+// digits don't need to be held in memory as a string.
+//
+// The flags are:
+//
+//	-digits n
+//		compute the results to n significant decimal digits (default 30)
+//	-prec n
+//		compute the results to n bits; overrides -digits
+//	-i
+//		run an interactive session
+//	-dump constant
+//		write the digits of constant
+//	-format f
+//		write the results in the format f (default "g"):
+//		g         like %g, with the requested digits
+//		e         like %e, with the requested digits
+//		sig       the requested digits, keeping trailing zeros
+//		shortest  the fewest digits that read back to the result
+//		hex       hexadecimal, like %a
+//		si        the fewest digits, with an SI prefix
+//		iec       the fewest digits, with an IEC binary prefix
+//
+// For example,
+//
+//	$ bigfloat -digits 50 'sqrt(2)'
+//	1.4142135623730950488016887242096980785696718753769
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"strings"
+
+	"github.com/ThreeAndTwo/bigfloat"
+)
+
+// formats are the formats of the results, by name; digits is the
+// number of significant decimal digits requested.
+var formats = map[string]func(x *big.Float, digits int) string{
+	"g": func(x *big.Float, digits int) string { return x.Text('g', digits) },
+	"e": func(x *big.Float, digits int) string { return x.Text('e', digits-1) },
+	"sig": func(x *big.Float, digits int) string {
+		return bigfloat.RoundSigDigitsString(x, digits)
+	},
+	"shortest": func(x *big.Float, _ int) string { return bigfloat.FormatShortest(x) },
+	"hex":      func(x *big.Float, _ int) string { return bigfloat.FormatHex(x) },
+	"si":       func(x *big.Float, _ int) string { return bigfloat.FormatSI(x) },
+	"iec":      func(x *big.Float, _ int) string { return bigfloat.FormatSIBinary(x) },
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+// run runs the command with the arguments args, and returns its exit
+// code.
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+
+	fs := flag.NewFlagSet("bigfloat", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	digits := fs.Int("digits", 30, "compute the results to `n` significant decimal digits")
+	prec := fs.Uint("prec", 0, "compute the results to `n` bits; overrides -digits")
+	format := fs.String("format", "g", "write the results in the `format` g, e, sig, shortest, hex, si or iec")
+	interactive := fs.Bool("i", false, "run an interactive session")
+	dump := fs.String("dump", "", "write the digits of `constant`")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	c, err := newCalc(*digits, *prec, *format)
+	if err != nil {
+		fmt.Fprintf(stderr, "bigfloat: %v\n", err)
+		return 2
+	}
+
+	if *dump != "" {
+		if err := dumpDigits(stdout, *dump, c.digits); err != nil {
+			fmt.Fprintf(stderr, "bigfloat: %v\n", err)
+			return 1
+		}
+		return 0
+	}
+
+	if *interactive {
+		if err := c.repl(stdin, stdout); err != nil {
+			fmt.Fprintf(stderr, "bigfloat: %v\n", err)
+			return 1
+		}
+		return 0
+	}
+
+	if fs.NArg() > 0 {
+		if !c.print(stdout, stderr, strings.Join(fs.Args(), " ")) {
+			return 1
+		}
+		return 0
+	}
+
+	code := 0
+	sc := bufio.NewScanner(stdin)
+	for sc.Scan() {
+		if line := strings.TrimSpace(sc.Text()); line != "" && !c.print(stdout, stderr, line) {
+			code = 1
+		}
+	}
+	if err := sc.Err(); err != nil {
+		fmt.Fprintf(stderr, "bigfloat: %v\n", err)
+		return 1
+	}
+
+	return code
+}
+
+// A calc evaluates expressions to a precision, and formats their
+// results.
+type calc struct {
+	digits int
+	prec   uint
+	format func(x *big.Float, digits int) string
+}
+
+// newCalc returns a calc for the given flags.
+func newCalc(digits int, prec uint, format string) (*calc, error) {
+
+	f, ok := formats[format]
+	if !ok {
+		return nil, fmt.Errorf("unknown format %q", format)
+	}
+
+	c := &calc{format: f}
+	if err := c.setPrecision(digits, prec); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+// setPrecision sets the precision of c to prec bits, or to digits
+// decimal digits if prec is 0.
+func (c *calc) setPrecision(digits int, prec uint) error {
+
+	switch {
+	case prec > 0:
+		digits = bigfloat.DigitsForPrec(prec)
+		if digits < 1 {
+			digits = 1
+		}
+	case digits < 1:
+		return fmt.Errorf("invalid number of digits %d", digits)
+	default:
+		prec = bigfloat.PrecForDigits(digits)
+	}
+	c.digits, c.prec = digits, prec
+
+	return nil
+}
+
+// print evaluates the expression expr and writes its result to w, or
+// the error to errw, and reports whether it succeeded.
+func (c *calc) print(w, errw io.Writer, expr string) bool {
+
+	x, err := bigfloat.Eval(expr, nil, c.prec)
+	if err != nil {
+		fmt.Fprintf(errw, "bigfloat: %v\n", err)
+		return false
+	}
+	fmt.Fprintln(w, c.format(x, c.digits))
+
+	return true
+}
+
+// dumpDigits writes the first digits digits of the constant name to
+// w, with a decimal point after the first one.
+func dumpDigits(w io.Writer, name string, digits int) error {
+
+	k, ok := bigfloat.ParseConstant(name)
+	if !ok {
+		return fmt.Errorf("unknown constant %q", name)
+	}
+
+	bw := bufio.NewWriter(w)
+	if _, err := bigfloat.WriteDigits(bw, k, 0, 1); err != nil {
+		return err
+	}
+	if digits > 1 {
+		bw.WriteByte('.')
+		if _, err := bigfloat.WriteDigits(bw, k, 1, digits-1); err != nil {
+			return err
+		}
+	}
+	bw.WriteByte('\n')
+
+	return bw.Flush()
+}
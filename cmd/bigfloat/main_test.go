@@ -0,0 +1,45 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestRun(t *testing.T) {
+	for _, test := range []struct {
+		args  []string
+		stdin string
+		want  string
+		code  int
+	}{
+		{[]string{"-digits", "50", "sqrt(2)"}, "", "1.4142135623730950488016887242096980785696718753769\n", 0},
+		{[]string{"1", "+", "2"}, "", "3\n", 0},
+		{[]string{"-format", "e", "-digits", "5", "pi"}, "", "3.1416e+00\n", 0},
+		{[]string{"-format", "sig", "-digits", "5", "1/4"}, "", "0.25000\n", 0},
+		{[]string{"-format", "hex", "-prec", "24", "1/3"}, "", "0x1.555556p-2\n", 0},
+		{[]string{"-format", "shortest", "-prec", "53", "0.1"}, "", "0.1\n", 0},
+		{[]string{"-format", "si", "1500"}, "", "1.5k\n", 0},
+		{[]string{"-format", "iec", "2048"}, "", "2Ki\n", 0},
+		{[]string{"-digits", "10"}, "1/3\n\n  2^0.5\n", "0.3333333333\n1.414213562\n", 0},
+		{nil, "1/3\nsqrt(-1)\n2\n", "0.333333333333333333333333333333\n2\n", 1},
+		{[]string{"-i", "-digits", "5"}, "1/4\nx = 2\nx^x\n", "> 0.25\n> 2\n> 4\n> \n", 0},
+		{[]string{"-dump", "pi", "-digits", "20"}, "", "3.1415926535897932384\n", 0},
+		{[]string{"-dump", "ln2", "-digits", "1"}, "", "0\n", 0},
+		{[]string{"-dump", "tau"}, "", "", 1},
+		{[]string{"1 +"}, "", "", 1},
+		{[]string{"-format", "xyz", "1"}, "", "", 2},
+		{[]string{"-digits", "0", "1"}, "", "", 2},
+		{[]string{"-nosuchflag"}, "", "", 2},
+	} {
+		var stdout, stderr bytes.Buffer
+		code := run(test.args, strings.NewReader(test.stdin), &stdout, &stderr)
+		if code != test.code || stdout.String() != test.want {
+			t.Errorf("run(%q) = %d, %q; want %d, %q (stderr %q)",
+				test.args, code, stdout.String(), test.code, test.want, stderr.String())
+		}
+		if (code != 0) != (stderr.Len() > 0) {
+			t.Errorf("run(%q) wrote %q to stderr with exit code %d", test.args, stderr.String(), code)
+		}
+	}
+}
@@ -0,0 +1,145 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math/big"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ThreeAndTwo/bigfloat"
+)
+
+const replHelp = `  expr           evaluate expr, and store its result in ans
+  name = expr    evaluate expr, and store its result in name
+  :digits n      compute the results to n significant decimal digits
+  :prec n        compute the results to n bits
+  :format f      write the results in the format f
+  :vars          list the variables
+  :help          show this help
+  :quit          exit
+A result that had to be rounded to the requested precision is
+followed by "(rounded down)" or "(rounded up)".
+`
+
+// repl reads the lines of r and runs them as the statements of an
+// interactive session, writing a prompt, the results and the errors
+// to w. It returns when r is exhausted or after a :quit command.
+func (c *calc) repl(r io.Reader, w io.Writer) error {
+
+	vars := make(map[string]*big.Float)
+	sc := bufio.NewScanner(r)
+	for {
+		fmt.Fprint(w, "> ")
+		if !sc.Scan() {
+			fmt.Fprintln(w)
+			return sc.Err()
+		}
+		line := strings.TrimSpace(sc.Text())
+		switch {
+		case line == "":
+			continue
+		case line == ":quit" || line == ":q":
+			return nil
+		case strings.HasPrefix(line, ":"):
+			if err := c.command(w, line[1:], vars); err != nil {
+				fmt.Fprintf(w, "error: %v\n", err)
+			}
+			continue
+		}
+
+		name, expr := "ans", line
+		if i := strings.IndexByte(line, '='); i >= 0 {
+			name, expr = strings.TrimSpace(line[:i]), line[i+1:]
+			if !isName(name) {
+				fmt.Fprintf(w, "error: invalid variable name %q\n", name)
+				continue
+			}
+		}
+		x, err := bigfloat.Eval(expr, vars, c.prec)
+		if err != nil {
+			fmt.Fprintf(w, "error: %v\n", err)
+			continue
+		}
+		vars[name] = x
+		fmt.Fprintln(w, c.format(x, c.digits)+accuracyFlag(x.Acc()))
+	}
+}
+
+// command runs the REPL command cmd, without its leading colon.
+func (c *calc) command(w io.Writer, cmd string, vars map[string]*big.Float) error {
+
+	fields := strings.Fields(cmd)
+	if len(fields) == 0 {
+		return fmt.Errorf("missing command")
+	}
+
+	switch arg := strings.Join(fields[1:], " "); fields[0] {
+	case "digits", "prec":
+		n, err := strconv.Atoi(arg)
+		if err != nil || n < 1 {
+			return fmt.Errorf("invalid %s %q", fields[0], arg)
+		}
+		if fields[0] == "digits" {
+			err = c.setPrecision(n, 0)
+		} else {
+			err = c.setPrecision(0, uint(n))
+		}
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(w, "%d digits, %d bits\n", c.digits, c.prec)
+	case "format":
+		f, ok := formats[arg]
+		if !ok {
+			return fmt.Errorf("unknown format %q", arg)
+		}
+		c.format = f
+	case "vars":
+		names := make([]string, 0, len(vars))
+		for name := range vars {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			fmt.Fprintf(w, "%s = %s\n", name, c.format(vars[name], c.digits))
+		}
+	case "help":
+		fmt.Fprint(w, replHelp)
+	default:
+		return fmt.Errorf("unknown command %q; try :help", fields[0])
+	}
+
+	return nil
+}
+
+// accuracyFlag returns the note written after a result with the
+// accuracy acc.
+func accuracyFlag(acc big.Accuracy) string {
+
+	switch acc {
+	case big.Below:
+		return " (rounded down)"
+	case big.Above:
+		return " (rounded up)"
+	}
+
+	return ""
+}
+
+// isName reports whether s can be the name of a variable.
+func isName(s string) bool {
+
+	if s == "" || '0' <= s[0] && s[0] <= '9' {
+		return false
+	}
+	for _, c := range s {
+		if !('a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || '0' <= c && c <= '9' || c == '_') {
+			return false
+		}
+	}
+
+	return true
+}
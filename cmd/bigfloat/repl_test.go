@@ -0,0 +1,62 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestREPL(t *testing.T) {
+
+	in := strings.Join([]string{
+		"1 + 2",
+		"ans * 2",
+		"x = 1/3",
+		"",
+		":digits 5",
+		"x * 3",
+		"y = sqrt(2)",
+		":format e",
+		"y^2",
+		":prec 24",
+		":vars",
+		"2 = 3",
+		"sqrt(-1)",
+		":nosuchcommand",
+		":digits zero",
+		":format xyz",
+		":quit",
+		"1",
+	}, "\n")
+	want := strings.Join([]string{
+		"> 3",
+		"> 6",
+		"> 0.333333333333333333333333333333 (rounded down)",
+		"> > 5 digits, 18 bits",
+		"> 1",
+		"> 1.4142 (rounded up)",
+		"> > 2.0000e+00 (rounded down)",
+		"> 6 digits, 24 bits",
+		"> ans = 2.00000e+00",
+		"x = 3.33333e-01",
+		"y = 1.41422e+00",
+		"> error: invalid variable name \"2\"",
+		"> error: bigfloat.Eval: Sqrt: argument is negative at offset 0 in \"sqrt(-1)\"",
+		"> error: unknown command \"nosuchcommand\"; try :help",
+		"> error: invalid digits \"zero\"",
+		"> error: unknown format \"xyz\"",
+		"> ",
+	}, "\n")
+
+	c, err := newCalc(30, 0, "g")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var out bytes.Buffer
+	if err := c.repl(strings.NewReader(in), &out); err != nil {
+		t.Fatal(err)
+	}
+	if got := out.String(); got != want {
+		t.Errorf("got\n%s\nwant\n%s", out.String(), want)
+	}
+}
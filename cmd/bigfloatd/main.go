@@ -0,0 +1,235 @@
+// Command bigfloatd is an HTTP service that evaluates arithmetic
+// expressions to a requested number of digits, for the programs that
+// need high-precision results without linking the bigfloat package.
+//
+// Usage:
+//
+//	bigfloatd [flags]
+//
+// The expressions are evaluated with bigfloat.Eval, by a pool of
+// workers, and sent in the body of a POST request to /eval, as a JSON
+// object with the expression and the number of significant decimal
+// digits of the result (30 if it's missing):
+//
+//	$ curl -d '{"expr": "sqrt(2)", "digits": 50}' localhost:8080/eval
+//	{"result":"1.4142135623730950488016887242096980785696718753769"}
+//
+// The result is formatted like %g. If the expression can't be
+// evaluated, the response has the status 400 and an object with a
+// description of the problem, and the position of the offending token
+// in the expression:
+//
+//	{"error":"bigfloat.Eval: ...","offset":5}
+//
+// A request that asks for more digits than -max-digits is rejected
+// with the status 400, as is one that takes the sine, the cosine or
+// the tangent of a number of 2**(4·b) or more, for the b bits of
+// -max-digits digits, whose reduction modulo π would take a time that
+// grows with the number. A request that isn't completed in the time
+// given by -timeout gets the status 503; its computation isn't
+// interrupted, but the number of computations running at once is
+// bounded by -workers.
+//
+// The flags are:
+//
+//	-addr address
+//		listen on address (default ":8080")
+//	-workers n
+//		evaluate up to n expressions at once (default the number of CPUs)
+//	-max-digits n
+//		reject the requests for more than n digits (default 10000)
+//	-timeout d
+//		give up on the requests not completed in the duration d (default 10s)
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"runtime"
+	"sync"
+	"time"
+
+	"github.com/ThreeAndTwo/bigfloat"
+)
+
+// maxBody is the largest size of the body of a request, in bytes.
+const maxBody = 1 << 16
+
+// maxExpRatio is the ratio of the largest exponent of the arguments
+// of sin, cos and tan to the precision of -max-digits.
+const maxExpRatio = 4
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stderr))
+}
+
+// run runs the command with the arguments args, and returns its exit
+// code.
+func run(args []string, stderr io.Writer) int {
+
+	fs := flag.NewFlagSet("bigfloatd", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	addr := fs.String("addr", ":8080", "listen on `address`")
+	workers := fs.Int("workers", runtime.NumCPU(), "evaluate up to `n` expressions at once")
+	maxDigits := fs.Int("max-digits", 10000, "reject the requests for more than `n` digits")
+	timeout := fs.Duration("timeout", 10*time.Second, "give up on the requests not completed in the duration `d`")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if fs.NArg() > 0 || *workers < 1 || *maxDigits < 1 || *timeout <= 0 {
+		fmt.Fprintln(stderr, "bigfloatd: invalid arguments")
+		fs.Usage()
+		return 2
+	}
+
+	s := newServer(*workers, *maxDigits, *timeout)
+	defer s.close()
+	mux := http.NewServeMux()
+	mux.Handle("/eval", s)
+	if err := http.ListenAndServe(*addr, mux); err != nil {
+		fmt.Fprintf(stderr, "bigfloatd: %v\n", err)
+		return 1
+	}
+
+	return 0
+}
+
+// A server evaluates the expressions of the requests with a pool, and
+// routes the results of the pool to the requests waiting for them.
+type server struct {
+	pool      *bigfloat.Pool
+	maxDigits int
+	maxExp    int // the MaxExp of the jobs
+	timeout   time.Duration
+
+	mu      sync.Mutex
+	nextID  int
+	waiting map[int]chan bigfloat.Result
+}
+
+// newServer returns a server with a pool of the given number of
+// workers.
+func newServer(workers, maxDigits int, timeout time.Duration) *server {
+
+	s := &server{
+		pool:      bigfloat.NewPool(workers),
+		maxDigits: maxDigits,
+		maxExp:    maxExpRatio * int(bigfloat.PrecForDigits(maxDigits)),
+		timeout:   timeout,
+		waiting:   make(map[int]chan bigfloat.Result),
+	}
+	go func() {
+		for r := range s.pool.Results() {
+			s.mu.Lock()
+			c := s.waiting[r.ID]
+			delete(s.waiting, r.ID)
+			s.mu.Unlock()
+			// buffered, so abandoned requests don't block the pool
+			c <- r
+		}
+	}()
+
+	return s
+}
+
+// close stops the pool of s.
+func (s *server) close() {
+	s.pool.Close()
+}
+
+// request and response are the JSON bodies of the requests and of the
+// responses.
+type request struct {
+	Expr   string `json:"expr"`
+	Digits int    `json:"digits"`
+}
+
+type response struct {
+	Result string `json:"result,omitempty"`
+	Error  string `json:"error,omitempty"`
+	Offset *int   `json:"offset,omitempty"`
+}
+
+func (s *server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		reply(w, http.StatusMethodNotAllowed, response{Error: "method not allowed"})
+		return
+	}
+
+	var req request
+	dec := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxBody))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&req); err != nil {
+		reply(w, http.StatusBadRequest, response{Error: "invalid request: " + err.Error()})
+		return
+	}
+	if req.Digits == 0 {
+		req.Digits = 30
+	}
+	if req.Digits < 0 || req.Digits > s.maxDigits {
+		msg := fmt.Sprintf("digits must be between 1 and %d", s.maxDigits)
+		reply(w, http.StatusBadRequest, response{Error: msg})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), s.timeout)
+	defer cancel()
+	res, err := s.eval(ctx, req.Expr, req.Digits)
+	if err != nil {
+		reply(w, http.StatusServiceUnavailable, response{Error: err.Error()})
+		return
+	}
+
+	var e *bigfloat.EvalError
+	switch {
+	case errors.As(res.Err, &e):
+		reply(w, http.StatusBadRequest, response{Error: e.Error(), Offset: &e.Offset})
+	case res.Err != nil:
+		reply(w, http.StatusBadRequest, response{Error: res.Err.Error()})
+	default:
+		reply(w, http.StatusOK, response{Result: res.Value.Text('g', req.Digits)})
+	}
+}
+
+// eval evaluates expr to the given number of digits with the pool of
+// s, and returns its result, or the error of ctx if it's done first.
+func (s *server) eval(ctx context.Context, expr string, digits int) (bigfloat.Result, error) {
+
+	c := make(chan bigfloat.Result, 1)
+	s.mu.Lock()
+	id := s.nextID
+	s.nextID++
+	s.waiting[id] = c
+	s.mu.Unlock()
+
+	job := bigfloat.Job{ID: id, Expr: expr, Prec: bigfloat.PrecForDigits(digits), MaxExp: s.maxExp}
+	if err := s.pool.SubmitContext(ctx, job); err != nil {
+		s.mu.Lock()
+		delete(s.waiting, id)
+		s.mu.Unlock()
+		return bigfloat.Result{}, err
+	}
+
+	select {
+	case r := <-c:
+		return r, nil
+	case <-ctx.Done():
+		return bigfloat.Result{}, ctx.Err()
+	}
+}
+
+// reply writes resp as the JSON body of a response with the given
+// status.
+func reply(w http.ResponseWriter, status int, resp response) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(resp)
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestServer(t *testing.T) {
+	s := newServer(2, 100, time.Minute)
+	defer s.close()
+
+	for _, test := range []struct {
+		method string
+		body   string
+		status int
+		want   string
+	}{
+		{"POST", `{"expr": "sqrt(2)", "digits": 50}`, 200, `{"result":"1.4142135623730950488016887242096980785696718753769"}`},
+		{"POST", `{"expr": "1/3"}`, 200, `{"result":"0.333333333333333333333333333333"}`},
+		{"POST", `{"expr": "2^10", "digits": 5}`, 200, `{"result":"1024"}`},
+		{"POST", `{"expr": "1 +", "digits": 5}`, 400, `{"error":"bigfloat.Eval: unexpected end of expression at offset 3 in \"1 +\"","offset":3}`},
+		{"POST", `{"expr": "1", "digits": 101}`, 400, `{"error":"digits must be between 1 and 100"}`},
+		{"POST", `{"expr": "1", "digits": -1}`, 400, `{"error":"digits must be between 1 and 100"}`},
+		{"POST", `{"expr": "sin(1e1000000)"}`, 400, `{"error":"bigfloat.Eval: sin: argument too large at offset 0 in \"sin(1e1000000)\"","offset":0}`},
+		{"POST", `{"expr": "sin(2^1000)", "digits": 5}`, 200, ""},
+		{"POST", `{"expr": 1}`, 400, ""},
+		{"POST", `{"expression": "1"}`, 400, ""},
+		{"POST", `{"expr": "` + strings.Repeat("1", maxBody) + `"}`, 400, ""},
+		{"GET", "", 405, `{"error":"method not allowed"}`},
+	} {
+		req := httptest.NewRequest(test.method, "/eval", strings.NewReader(test.body))
+		rec := httptest.NewRecorder()
+		s.ServeHTTP(rec, req)
+		got := strings.TrimSpace(rec.Body.String())
+		if rec.Code != test.status || test.want != "" && got != test.want {
+			t.Errorf("%s %s: %d %s; want %d %s", test.method, test.body, rec.Code, got, test.status, test.want)
+		}
+		if ct := rec.Header().Get("Content-Type"); ct != "application/json" {
+			t.Errorf("%s %s: Content-Type %q", test.method, test.body, ct)
+		}
+	}
+}
+
+func TestServerTimeout(t *testing.T) {
+	s := newServer(1, 1000000, time.Millisecond)
+	defer s.close()
+
+	req := httptest.NewRequest("POST", "/eval", strings.NewReader(`{"expr": "exp(pi)", "digits": 1000000}`))
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, req)
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Errorf("status %d %s; want %d", rec.Code, rec.Body, http.StatusServiceUnavailable)
+	}
+}
+
+func TestRun(t *testing.T) {
+	for _, args := range [][]string{
+		{"-workers", "0"},
+		{"-max-digits", "0"},
+		{"-timeout", "0s"},
+		{"extra"},
+		{"-nosuchflag"},
+	} {
+		var stderr bytes.Buffer
+		if code := run(args, &stderr); code != 2 || stderr.Len() == 0 {
+			t.Errorf("run(%q) = %d, stderr %q; want 2", args, code, stderr.String())
+		}
+	}
+}
@@ -0,0 +1,93 @@
+// Command bigfloatjs exposes the functions of the bigfloat package to
+// JavaScript, when built for WebAssembly:
+//
+//	GOOS=js GOARCH=wasm go build -o bigfloat.wasm ./cmd/bigfloatjs
+//
+// and loaded with the wasm_exec.js support file of the Go
+// distribution. Once the module is running, it defines a global object bigfloat
+// with the methods
+//
+//	sqrt(x, digits)
+//	exp(x, digits)
+//	log(x, digits)
+//	pow(x, y, digits)
+//	eval(expr, digits)
+//	constant(name, digits)
+//
+// where the arguments x and y are decimal numbers passed as strings,
+// so that they're not rounded to float64, expr is an expression in
+// the syntax of bigfloat.Eval, name is one of pi, e, gamma, ln2,
+// ln10, sqrt2 and phi, and digits is the number of significant
+// decimal digits of the result. Each method returns an object with
+// the result, formatted like %g, in the value property, or with a
+// description of the problem in the error property:
+//
+//	bigfloat.sqrt("2", 30)   // {value: "1.41421356237309504880168872421"}
+//	bigfloat.log("-1", 30)   // {error: "..."}
+package main
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+
+	"github.com/ThreeAndTwo/bigfloat"
+)
+
+// funcs are the expressions that compute the functions exposed to
+// JavaScript, by name, with the arguments x and y.
+var funcs = map[string]string{
+	"sqrt": "sqrt(x)",
+	"exp":  "exp(x)",
+	"log":  "log(x)",
+	"pow":  "pow(x, y)",
+}
+
+// call returns the result of the function name, with the arguments
+// args, the last of which is the number of digits.
+func call(name string, args []string) (string, error) {
+
+	want := 2
+	switch name {
+	case "pow":
+		want = 3
+	case "eval", "constant", "sqrt", "exp", "log":
+	default:
+		return "", fmt.Errorf("unknown function %q", name)
+	}
+	if len(args) != want {
+		return "", fmt.Errorf("%s takes %d arguments, not %d", name, want, len(args))
+	}
+
+	digits, err := strconv.Atoi(args[len(args)-1])
+	if err != nil || digits < 1 {
+		return "", fmt.Errorf("invalid number of digits %q", args[len(args)-1])
+	}
+	prec := bigfloat.PrecForDigits(digits)
+
+	var x *big.Float
+	switch name {
+	case "eval":
+		x, err = bigfloat.Eval(args[0], nil, prec)
+	case "constant":
+		c, ok := bigfloat.ParseConstant(args[0])
+		if !ok {
+			return "", fmt.Errorf("unknown constant %q", args[0])
+		}
+		x = c.Value(prec)
+	default:
+		// the arguments are parsed with the guard bits of Eval
+		vars := make(map[string]*big.Float)
+		for i, v := range []string{"x", "y"}[:len(args)-1] {
+			if vars[v], _, err = bigfloat.Parse(args[i], prec+64); err != nil {
+				return "", err
+			}
+		}
+		x, err = bigfloat.Eval(funcs[name], vars, prec)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	return x.Text('g', digits), nil
+}
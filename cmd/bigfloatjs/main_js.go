@@ -0,0 +1,37 @@
+//go:build js && wasm
+// +build js,wasm
+
+package main
+
+import (
+	"strconv"
+	"syscall/js"
+)
+
+func main() {
+
+	obj := js.Global().Get("Object").New()
+	for _, name := range []string{"sqrt", "exp", "log", "pow", "eval", "constant"} {
+		name := name
+		obj.Set(name, js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+			s := make([]string, len(args))
+			for i, a := range args {
+				// digits may be passed as a number
+				if a.Type() == js.TypeNumber {
+					s[i] = strconv.FormatFloat(a.Float(), 'f', -1, 64)
+				} else {
+					s[i] = a.String()
+				}
+			}
+			v, err := call(name, s)
+			if err != nil {
+				return map[string]interface{}{"error": err.Error()}
+			}
+			return map[string]interface{}{"value": v}
+		}))
+	}
+	js.Global().Set("bigfloat", obj)
+
+	// keep the functions alive
+	select {}
+}
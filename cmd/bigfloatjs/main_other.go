@@ -0,0 +1,14 @@
+//go:build !(js && wasm)
+// +build !js !wasm
+
+package main
+
+import (
+	"fmt"
+	"os"
+)
+
+func main() {
+	fmt.Fprintln(os.Stderr, "bigfloatjs: build with GOOS=js GOARCH=wasm")
+	os.Exit(2)
+}
@@ -0,0 +1,45 @@
+package main
+
+import "testing"
+
+func TestCall(t *testing.T) {
+	for _, test := range []struct {
+		name string
+		args []string
+		want string
+	}{
+		{"sqrt", []string{"2", "30"}, "1.41421356237309504880168872421"},
+		{"exp", []string{"1", "20"}, "2.7182818284590452354"},
+		{"log", []string{"10", "20"}, "2.302585092994045684"},
+		{"pow", []string{"2", "0.5", "20"}, "1.4142135623730950488"},
+		{"pow", []string{"0.1", "2", "5"}, "0.01"},
+		{"eval", []string{"1/3 + 1/6", "10"}, "0.5"},
+		{"constant", []string{"phi", "15"}, "1.61803398874989"},
+	} {
+		got, err := call(test.name, test.args)
+		if err != nil || got != test.want {
+			t.Errorf("%s(%q) = %q, %v; want %q", test.name, test.args, got, err, test.want)
+		}
+	}
+}
+
+func TestCallErrors(t *testing.T) {
+	for _, test := range []struct {
+		name string
+		args []string
+	}{
+		{"cbrt", []string{"2", "30"}},
+		{"sqrt", []string{"2"}},
+		{"pow", []string{"2", "30"}},
+		{"sqrt", []string{"2", "0"}},
+		{"sqrt", []string{"2", "many"}},
+		{"sqrt", []string{"two", "30"}},
+		{"log", []string{"-1", "30"}},
+		{"eval", []string{"1 +", "30"}},
+		{"constant", []string{"tau", "30"}},
+	} {
+		if got, err := call(test.name, test.args); err == nil {
+			t.Errorf("%s(%q) = %q; want an error", test.name, test.args, got)
+		}
+	}
+}
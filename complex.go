@@ -0,0 +1,43 @@
+package bigfloat
+
+import (
+	"math/big"
+)
+
+// A Complex is the complex number Re + Im·i.
+type Complex struct {
+	Re, Im *big.Float
+}
+
+// String formats z like fmt formats a complex128, as "(1+2i)", with
+// the shortest decimal representations of its parts that round to
+// them.
+func (z Complex) String() string {
+
+	im := z.Im.Text('g', -1)
+	if !z.Im.Signbit() && !z.Im.IsInf() {
+		im = "+" + im
+	}
+
+	return "(" + z.Re.Text('g', -1) + im + "i)"
+}
+
+// SqrtComplex returns the principal square root of x, which is the
+// real √x for x >= 0, and the imaginary i·√-x for x < 0, so that
+// SqrtComplex(-4) is 2i. The parts of the result have the precision
+// of x, and the other one is +0, but for x = -0, whose root is -0.
+func SqrtComplex(x *big.Float) Complex {
+
+	prec := x.Prec()
+	z := Complex{new(big.Float).SetPrec(prec), new(big.Float).SetPrec(prec)}
+	if x.Sign() >= 0 {
+		z.Re.Set(Sqrt(x))
+		if x.Sign() == 0 {
+			z.Re.Set(x)
+		}
+		return z
+	}
+	z.Im.Set(Sqrt(new(big.Float).Neg(x)))
+
+	return z
+}
@@ -0,0 +1,54 @@
+package bigfloat_test
+
+import (
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/ThreeAndTwo/bigfloat"
+)
+
+func TestSqrtComplex(t *testing.T) {
+	for _, test := range []struct {
+		x    float64
+		want string
+	}{
+		{4, "(2+0i)"},
+		{-4, "(0+2i)"},
+		{2, "(1.4142135623730951+0i)"},
+		{-2, "(0+1.4142135623730951i)"},
+		{0, "(0+0i)"},
+		{math.Copysign(0, -1), "(-0+0i)"},
+		{math.Inf(+1), "(+Inf+0i)"},
+		{math.Inf(-1), "(0+Infi)"},
+	} {
+		z := bigfloat.SqrtComplex(big.NewFloat(test.x))
+		if got := z.String(); got != test.want || z.Re.Prec() != 53 || z.Im.Prec() != 53 {
+			t.Errorf("SqrtComplex(%g) = %s (prec %d, %d); want %s", test.x, got, z.Re.Prec(), z.Im.Prec(), test.want)
+		}
+	}
+
+	// the roots of x² + 2x + 5, -1 ± 2i, from a negative discriminant
+	d := bigfloat.SqrtComplex(new(big.Float).SetPrec(200).SetInt64(4 - 20))
+	if d.Re.Sign() != 0 || d.Im.Cmp(big.NewFloat(4)) != 0 || d.Im.Prec() != 200 {
+		t.Errorf("SqrtComplex(-16) = %v; want (0+4i)", d)
+	}
+}
+
+func TestComplexString(t *testing.T) {
+	for _, test := range []struct {
+		re, im float64
+		want   string
+	}{
+		{1, 2, "(1+2i)"},
+		{1, -2, "(1-2i)"},
+		{-1.5, math.Copysign(0, -1), "(-1.5-0i)"},
+		{0, math.Inf(-1), "(0-Infi)"},
+		{1e100, 1e-100, "(1e+100+1e-100i)"},
+	} {
+		z := bigfloat.Complex{Re: big.NewFloat(test.re), Im: big.NewFloat(test.im)}
+		if got := z.String(); got != test.want {
+			t.Errorf("Complex{%g, %g}.String() = %s; want %s", test.re, test.im, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package bigfloat
+
+import "math/big"
+
+// The condition number of a function f at x, |x·f′(x)/f(x)|, is the
+// factor κ by which f amplifies a relative error of its argument: if x
+// has a relative error δ, f(x) has a relative error of about κ·δ.
+// The Cond functions return the condition numbers of the functions of
+// this package, and CondPrec turns one into the precision that the
+// argument needs for a result of a given precision.
+
+// CondSqrt returns the condition number of Sqrt at x, which is 1/2
+// for any x. Precision is the same as the one of the argument.
+// The function panics if x is negative.
+func CondSqrt(x *big.Float) *big.Float {
+
+	if x.Sign() < 0 {
+		panic("CondSqrt: argument is negative")
+	}
+
+	return new(big.Float).SetPrec(x.Prec()).SetFloat64(0.5)
+}
+
+// CondExp returns the condition number of Exp at x, |x|. Precision is
+// the same as the one of the argument.
+func CondExp(x *big.Float) *big.Float {
+	return new(big.Float).SetPrec(x.Prec()).Abs(x)
+}
+
+// CondLog returns the condition number of Log at x, 1/|log(x)|. It's
+// +Inf at x = 1, where any error of x gives an infinite relative
+// error of the result, and 0 at x = 0 and x = +Inf. Precision is the
+// same as the one of the argument. The function panics if x is
+// negative.
+func CondLog(x *big.Float) *big.Float {
+
+	if x.Sign() < 0 {
+		panic("CondLog: argument is negative")
+	}
+
+	prec := x.Prec()
+	z := new(big.Float).SetPrec(prec)
+	switch {
+	case x.Sign() == 0 || x.IsInf():
+		return z
+	case x.Cmp(big.NewFloat(1)) == 0:
+		return z.SetInf(false)
+	}
+
+	l := Log(new(big.Float).SetPrec(prec + 32).Set(x))
+
+	return z.Quo(big.NewFloat(1), l.Abs(l))
+}
+
+// CondPow returns the condition numbers of Pow(x, y) with respect to
+// x, |y|, and to y, |y·log(x)|. Precision is the same as the one of
+// x. The function panics if x is negative.
+func CondPow(x, y *big.Float) (cx, cy *big.Float) {
+
+	if x.Sign() < 0 {
+		panic("CondPow: base is negative")
+	}
+
+	prec := x.Prec()
+	cx = new(big.Float).SetPrec(prec).Abs(y)
+	cy = new(big.Float).SetPrec(prec)
+	switch {
+	case y.Sign() == 0:
+		return cx, cy
+	case x.Sign() == 0 || x.IsInf():
+		// |log(x)| = +Inf
+		return cx, cy.SetInf(false)
+	}
+
+	l := Log(new(big.Float).SetPrec(prec + 32).Set(x))
+	cy.Mul(l.Abs(l), cx)
+
+	return cx, cy
+}
+
+// CondPrec returns the precision that the argument of a function with
+// the condition number cond needs for the result to be accurate to
+// prec bits: prec plus the number of bits lost, ⌈log₂(cond)⌉ when
+// cond > 1. The function panics if cond is negative or infinite.
+func CondPrec(cond *big.Float, prec uint) uint {
+
+	if cond.Sign() < 0 || cond.IsInf() {
+		panic("CondPrec: condition number is negative or infinite")
+	}
+	if cond.Cmp(big.NewFloat(1)) <= 0 {
+		return prec
+	}
+
+	// cond = mant·2**e, with 0.5 <= mant < 1, so ⌈log₂(cond)⌉ = e,
+	// or e-1 when cond is a power of two
+	e := cond.MantExp(nil)
+	if IsPowerOfTwo(cond) {
+		e--
+	}
+
+	return prec + uint(e)
+}
@@ -0,0 +1,95 @@
+package bigfloat_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ThreeAndTwo/bigfloat"
+)
+
+func TestCond(t *testing.T) {
+
+	x := new(big.Float).SetPrec(100).SetFloat64(-3.5)
+	if c := bigfloat.CondExp(x); c.Cmp(big.NewFloat(3.5)) != 0 || c.Prec() != 100 {
+		t.Errorf("CondExp(-3.5) = %g", c)
+	}
+	if c := bigfloat.CondSqrt(big.NewFloat(2)); c.Cmp(big.NewFloat(0.5)) != 0 {
+		t.Errorf("CondSqrt(2) = %g", c)
+	}
+
+	// 1/log(e) = 1
+	e := bigfloat.Exp(new(big.Float).SetPrec(200).SetInt64(1))
+	if c := bigfloat.CondLog(e); bigfloat.CmpUlp(c, new(big.Float).SetPrec(200).SetInt64(1), 1) != 0 {
+		t.Errorf("CondLog(e) = %g", c)
+	}
+	for _, test := range []struct {
+		x    float64
+		want float64
+	}{
+		{1.0001, 1 / 9.9995000333308e-05},
+		{0.5, 1.4426950408889634},
+		{1e100, 0.004342944819032518},
+	} {
+		c := bigfloat.CondLog(big.NewFloat(test.x))
+		if relErr(c, big.NewFloat(test.want)).Cmp(big.NewFloat(1e-12)) > 0 {
+			t.Errorf("CondLog(%g) = %g; want %g", test.x, c, test.want)
+		}
+	}
+	if c := bigfloat.CondLog(big.NewFloat(1)); !c.IsInf() {
+		t.Errorf("CondLog(1) = %g; want +Inf", c)
+	}
+	if c := bigfloat.CondLog(big.NewFloat(0)); c.Sign() != 0 {
+		t.Errorf("CondLog(0) = %g; want 0", c)
+	}
+}
+
+func TestCondPow(t *testing.T) {
+
+	cx, cy := bigfloat.CondPow(big.NewFloat(8), big.NewFloat(-2))
+	if cx.Cmp(big.NewFloat(2)) != 0 {
+		t.Errorf("CondPow(8, -2) = %g for x; want 2", cx)
+	}
+	if relErr(cy, big.NewFloat(6*0.6931471805599453)).Cmp(big.NewFloat(1e-15)) > 0 {
+		t.Errorf("CondPow(8, -2) = %g for y; want 6·log(2)", cy)
+	}
+
+	if _, cy := bigfloat.CondPow(big.NewFloat(0), big.NewFloat(2)); !cy.IsInf() {
+		t.Errorf("CondPow(0, 2) = %g for y; want +Inf", cy)
+	}
+	if _, cy := bigfloat.CondPow(big.NewFloat(0), big.NewFloat(0)); cy.Sign() != 0 {
+		t.Errorf("CondPow(0, 0) = %g for y; want 0", cy)
+	}
+}
+
+func TestCondPrec(t *testing.T) {
+	for _, test := range []struct {
+		cond float64
+		want uint
+	}{
+		{0, 100},
+		{0.5, 100},
+		{1, 100},
+		{1.5, 101},
+		{2, 101},
+		{3, 102},
+		{1024, 110},
+		{1025, 111},
+	} {
+		if got := bigfloat.CondPrec(big.NewFloat(test.cond), 100); got != test.want {
+			t.Errorf("CondPrec(%g, 100) = %d; want %d", test.cond, got, test.want)
+		}
+	}
+
+	// exp(1000/3) accurate to 100 bits needs 109 bits of 1000/3
+	x := new(big.Float).SetPrec(1000).SetInt64(1000)
+	x.Quo(x, big.NewFloat(3))
+	prec := bigfloat.CondPrec(bigfloat.CondExp(x), 100)
+	if prec != 109 {
+		t.Errorf("CondPrec(CondExp(1000/3), 100) = %d; want 109", prec)
+	}
+	got := bigfloat.Exp(new(big.Float).SetPrec(prec).Set(x)).SetPrec(100)
+	want := bigfloat.Exp(x).SetPrec(100)
+	if bigfloat.CmpUlp(got, want, 2) != 0 {
+		t.Errorf("exp(1000/3) from %d bits = %g; want %g", prec, got, want)
+	}
+}
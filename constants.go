@@ -0,0 +1,242 @@
+package bigfloat
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"math/big"
+	"strings"
+)
+
+// A Constant is a mathematical constant that can be computed to any
+// precision.
+type Constant int
+
+const (
+	Pi         Constant = iota // π, the ratio of a circle's circumference to its diameter
+	E                          // e, the base of the natural logarithm
+	EulerGamma                 // γ, the Euler–Mascheroni constant
+	Ln2                        // the natural logarithm of 2
+	Ln10                       // the natural logarithm of 10
+	Sqrt2                      // the square root of 2
+	Phi                        // φ, the golden ratio
+)
+
+var constantNames = [...]string{"pi", "e", "gamma", "ln2", "ln10", "sqrt2", "phi"}
+
+func (c Constant) String() string {
+
+	if c < 0 || int(c) >= len(constantNames) {
+		return fmt.Sprintf("Constant(%d)", int(c))
+	}
+
+	return constantNames[c]
+}
+
+// ParseConstant returns the Constant whose String method returns
+// name.
+func ParseConstant(name string) (Constant, bool) {
+
+	for i, n := range constantNames {
+		if n == name {
+			return Constant(i), true
+		}
+	}
+
+	return 0, false
+}
+
+// Value returns c to prec bits of precision (64 if prec is 0). π, ln2
+// and γ are read from the tables of the package up to TablePrec bits,
+// and the values computed are kept in the cache of the package, so a
+// constant is only computed once at a given precision. The method
+// panics if c isn't one of the constants defined in this package.
+func (c Constant) Value(prec uint) *big.Float {
+
+	if prec == 0 {
+		prec = 64
+	}
+	if c == Pi {
+		return pi(prec)
+	}
+	if c < 0 || int(c) >= len(constantNames) {
+		panic("Value: unknown constant " + c.String())
+	}
+	if t := c.table(); t != nil && prec <= t.prec() {
+		return t.value(prec)
+	}
+
+	return cached(c.String(), prec, func() *big.Float { return c.compute(prec) })
+}
+
+// compute returns c, other than π, to prec bits.
+func (c Constant) compute(prec uint) *big.Float {
+
+	// 64 guard bits, for the functions that use the precision of
+	// their argument
+	x := new(big.Float).SetPrec(prec + 64)
+	switch c {
+	case E:
+		x = Exp(x.SetInt64(1))
+	case EulerGamma:
+		x = eulerGamma(prec + 64)
+	case Ln2:
+		x = Log(x.SetInt64(2))
+	case Ln10:
+		x = Log(x.SetInt64(10))
+	case Sqrt2:
+		x = Sqrt(x.SetInt64(2))
+	case Phi:
+		x = Sqrt(x.SetInt64(5))
+		x.Add(x, big.NewFloat(1))
+		x.SetMantExp(x, -1)
+	}
+
+	return x.SetPrec(prec)
+}
+
+// eulerGamma returns the Euler–Mascheroni constant γ to prec bits,
+// with the Brent–McMillan algorithm (B1 in R. P. Brent and E. M.
+// McMillan, Some new algorithms for high-precision computation of
+// Euler's constant, Math. Comp. 34, 1980):
+//
+//	γ = U/V - log(n) + O(exp(-4n))
+//
+// with U = Σ Aₖ and V = Σ Bₖ, where B₀ = 1, A₀ = -log(n), and
+//
+//	Bₖ = Bₖ₋₁·n²/k²,  Aₖ = (Aₖ₋₁·n²/k + Bₖ)/k
+func eulerGamma(prec uint) *big.Float {
+
+	// exp(-4n) < 2**-prec
+	wprec := prec + 64
+	n := int64(float64(wprec)*math.Ln2/4) + 1
+	n2 := new(big.Float).SetInt64(n * n)
+
+	logn := Log(new(big.Float).SetPrec(wprec).SetInt64(n))
+	a := new(big.Float).SetPrec(wprec).Neg(logn)
+	b := new(big.Float).SetPrec(wprec).SetInt64(1)
+	u := new(big.Float).SetPrec(wprec).Set(a)
+	v := new(big.Float).SetPrec(wprec).SetInt64(1)
+
+	k2 := new(big.Float)
+	kf := new(big.Float)
+	for k := int64(1); ; k++ {
+		kf.SetInt64(k)
+		k2.SetInt64(k * k)
+		b.Mul(b, n2).Quo(b, k2)
+		a.Mul(a, n2).Quo(a, kf).Add(a, b).Quo(a, kf)
+		u.Add(u, a)
+		v.Add(v, b)
+
+		// the terms grow up to k = n, and then decrease
+		if k > n && b.MantExp(nil) < v.MantExp(nil)-int(wprec) &&
+			(a.Sign() == 0 || a.MantExp(nil) < u.MantExp(nil)-int(wprec)) {
+			break
+		}
+	}
+
+	return u.Quo(u, v).SetPrec(prec)
+}
+
+// digitChunk is the number of digits converted at once by
+// WriteDigits.
+const digitChunk = 1 << 12
+
+// WriteDigits writes to w the decimal digits of c from start to
+// start+n-1, counting from 0 for the first digit of the integer part,
+// which is the only one (and is 0 for ln2 and γ). The decimal point
+// isn't written, so, for example
+//
+//	WriteDigits(w, Pi, 0, 10)
+//
+// writes "3141592653". The digits are truncated, not rounded, and
+// they are exact: the precision of c is increased until no digit
+// depends on the error of its computation.
+//
+// The digits are written in chunks as they are converted, so the
+// memory used is about the one of c at the required precision, not
+// the one of the whole string. The function returns the number of
+// digits written; after an error, a dump can be resumed by calling
+// it again with start increased by that number. The function panics
+// if start or n is negative.
+func WriteDigits(w io.Writer, c Constant, start, n int) (int, error) {
+
+	if start < 0 || n < 0 {
+		panic("WriteDigits: negative start or count")
+	}
+	if n == 0 {
+		return 0, nil
+	}
+
+	// d = ⌊c·10**(start+n-1)⌋, whose last n digits are the ones to
+	// write; c has a single digit before the decimal point
+	total := start + n
+	scale := pow10(total - 1)
+	prec := PrecForDigits(total) + 64
+	var d *big.Int
+	for {
+		x := c.Value(prec)
+		ulp := Ulp(x)
+		ulp.SetMantExp(ulp, 2)
+		lo := new(big.Float).SetPrec(prec+8).Sub(x, ulp)
+		hi := new(big.Float).SetPrec(prec+8).Add(x, ulp)
+		d = floorScaled(lo, scale)
+		if d.Cmp(floorScaled(hi, scale)) == 0 {
+			break
+		}
+		prec += 64
+	}
+	d.Mod(d, pow10(n))
+
+	cw := &countWriter{w: w}
+	err := writeIntDigits(cw, d, n, make(map[int]*big.Int))
+
+	return cw.n, err
+}
+
+// floorScaled returns ⌊x·s⌋, for x >= 0.
+func floorScaled(x *big.Float, s *big.Int) *big.Int {
+
+	y := new(big.Float).SetPrec(x.Prec() + uint(s.BitLen())).SetInt(s)
+	y.Mul(y, x)
+	i, _ := y.Int(nil)
+
+	return i
+}
+
+// writeIntDigits writes d, with leading zeros to make it n digits
+// long, splitting it in halves until they are small enough to be
+// converted directly. pow caches the powers of ten of the splits.
+func writeIntDigits(w io.Writer, d *big.Int, n int, pow map[int]*big.Int) error {
+
+	if n <= digitChunk {
+		s := d.String()
+		_, err := io.WriteString(w, strings.Repeat("0", n-len(s))+s)
+		return err
+	}
+
+	h := n / 2
+	p, ok := pow[h]
+	if !ok {
+		p = pow10(h)
+		pow[h] = p
+	}
+	q, r := new(big.Int).QuoRem(d, p, new(big.Int))
+	if err := writeIntDigits(w, q, n-h, pow); err != nil {
+		return err
+	}
+
+	return writeIntDigits(w, r, h, pow)
+}
+
+// A countWriter counts the bytes written to w.
+type countWriter struct {
+	w io.Writer
+	n int
+}
+
+func (cw *countWriter) Write(p []byte) (int, error) {
+	n, err := cw.w.Write(p)
+	cw.n += n
+	return n, err
+}
@@ -0,0 +1,148 @@
+package bigfloat_test
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/ThreeAndTwo/bigfloat"
+)
+
+var constantDigits = []struct {
+	c      bigfloat.Constant
+	digits string
+}{
+	{bigfloat.Pi, "31415926535897932384626433832795028841971693993751058209749445923078164062862"},
+	{bigfloat.E, "27182818284590452353602874713526624977572470936999595749669676277240766303535"},
+	{bigfloat.EulerGamma, "05772156649015328606065120900824024310421593359399235988057672348848677267776"},
+	{bigfloat.Ln2, "06931471805599453094172321214581765680755001343602552541206800094933936219696"},
+	{bigfloat.Ln10, "23025850929940456840179914546843642076011014886287729760333279009675726096773"},
+	{bigfloat.Sqrt2, "14142135623730950488016887242096980785696718753769480731766797379907324784621"},
+	{bigfloat.Phi, "16180339887498948482045868343656381177203091798057628621354486227052604628189"},
+}
+
+func TestConstantValue(t *testing.T) {
+	for _, test := range constantDigits {
+		for _, prec := range []uint{0, 10, 53, 200} {
+			x := test.c.Value(prec)
+			if prec == 0 {
+				prec = 64
+			}
+			if x.Prec() != prec {
+				t.Errorf("%v.Value(%d) has precision %d", test.c, prec, x.Prec())
+			}
+			d := strings.Replace(x.Text('f', 60), ".", "", 1)
+			if n := bigfloat.DigitsForPrec(prec) - 1; d[:n] != test.digits[:n] {
+				t.Errorf("%v.Value(%d) = %s; want %s", test.c, prec, d[:n], test.digits[:n])
+			}
+		}
+	}
+}
+
+func TestConstantString(t *testing.T) {
+	for _, test := range constantDigits {
+		c, ok := bigfloat.ParseConstant(test.c.String())
+		if !ok || c != test.c {
+			t.Errorf("ParseConstant(%q) = %v, %v", test.c.String(), c, ok)
+		}
+	}
+	if _, ok := bigfloat.ParseConstant("tau"); ok {
+		t.Errorf("ParseConstant(tau) succeeded")
+	}
+	if s := bigfloat.Constant(99).String(); s != "Constant(99)" {
+		t.Errorf("Constant(99).String() = %q", s)
+	}
+}
+
+func TestWriteDigits(t *testing.T) {
+	for _, test := range constantDigits {
+		var b bytes.Buffer
+		n, err := bigfloat.WriteDigits(&b, test.c, 0, len(test.digits))
+		if err != nil || n != len(test.digits) || b.String() != test.digits {
+			t.Errorf("WriteDigits(%v) = %d, %v, %q; want %q", test.c, n, err, b.String(), test.digits)
+		}
+
+		// resuming gives the same digits
+		for _, start := range []int{1, 17, 50} {
+			b.Reset()
+			bigfloat.WriteDigits(&b, test.c, 0, start)
+			bigfloat.WriteDigits(&b, test.c, start, len(test.digits)-start)
+			if b.String() != test.digits {
+				t.Errorf("WriteDigits(%v) from %d gives %q", test.c, start, b.String())
+			}
+		}
+	}
+}
+
+func TestWriteDigitsChunks(t *testing.T) {
+
+	// more digits than in a chunk
+	var b bytes.Buffer
+	n, err := bigfloat.WriteDigits(&b, bigfloat.Pi, 0, 10010)
+	if err != nil || n != 10010 {
+		t.Fatalf("WriteDigits = %d, %v", n, err)
+	}
+	s := b.String()
+	if !strings.HasPrefix(s, constantDigits[0].digits) {
+		t.Errorf("WriteDigits(Pi, 0, 10010) starts with %s", s[:50])
+	}
+
+	b.Reset()
+	bigfloat.WriteDigits(&b, bigfloat.Pi, 9990, 20)
+	if b.String() != s[9990:] {
+		t.Errorf("WriteDigits(Pi, 9990, 20) = %s; want %s", b.String(), s[9990:])
+	}
+}
+
+// failingWriter accepts n bytes, and then fails.
+type failingWriter struct {
+	w *bytes.Buffer
+	n int
+}
+
+func (f *failingWriter) Write(p []byte) (int, error) {
+
+	if len(p) > f.n {
+		f.w.Write(p[:f.n])
+		n := f.n
+		f.n = 0
+		return n, errors.New("disk full")
+	}
+	f.n -= len(p)
+
+	return f.w.Write(p)
+}
+
+func TestWriteDigitsResume(t *testing.T) {
+
+	var b bytes.Buffer
+	n, err := bigfloat.WriteDigits(&failingWriter{&b, 5000}, bigfloat.E, 0, 9000)
+	if err == nil || n != 5000 {
+		t.Fatalf("WriteDigits = %d, %v; want 5000 and an error", n, err)
+	}
+	if _, err := bigfloat.WriteDigits(&b, bigfloat.E, n, 9000-n); err != nil {
+		t.Fatal(err)
+	}
+
+	var want bytes.Buffer
+	bigfloat.WriteDigits(&want, bigfloat.E, 0, 9000)
+	if b.String() != want.String() {
+		t.Errorf("the resumed digits are different")
+	}
+}
+
+// ---------- Benchmarks ----------
+
+func BenchmarkConstantValue(b *testing.B) {
+	for _, c := range []bigfloat.Constant{bigfloat.E, bigfloat.EulerGamma, bigfloat.Ln2} {
+		for _, prec := range []uint{1e3, 1e4} {
+			b.Run(fmt.Sprintf("%v/%v", c, prec), func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					c.Value(prec)
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,120 @@
+package bigfloat
+
+import (
+	"math"
+	"math/big"
+)
+
+// A Context holds the precision and the rounding mode of the results
+// of a computation, and the Arena they're allocated from, if any. The
+// zero value has precision 0, rounds to nearest even, and allocates
+// its values with new.
+//
+// With Subnormal set, the results of the methods of a Context have
+// the gradual underflow of a hardware format, instead of the exponent
+// range of big.Float: they're rounded by RoundSubnormal, and have
+// fewer bits below 2**(MinExp-1). With Overflow set, the results of
+// 2**MaxExp or more, after rounding, overflow: they're ±Inf, or the
+// largest finite value of that sign if the rounding mode goes toward
+// zero from it, as in IEEE 754. The operators and Round are then
+// rounded once: the exact result is rounded directly to the bits it
+// keeps, so Binary32Context and Binary64Context give the same results
+// as float32 and float64 hardware. The operations without a result,
+// like Inf - Inf, panic as for big.Float, where IEEE 754 gives a NaN.
+type Context struct {
+	Prec  uint             // precision of the results, in bits
+	Mode  big.RoundingMode // rounding mode of the results
+	Arena *Arena           // if not nil, the allocator of New
+
+	Subnormal bool // if set, the results underflow gradually below MinExp
+	MinExp    int  // the smallest exponent of a normal result, as the ones of MantExp
+	Overflow  bool // if set, the results overflow from MaxExp
+	MaxExp    int  // the largest exponent of a finite result, as the ones of MantExp
+}
+
+// ContextForDigits returns a Context, rounding to nearest even, with
+// a precision of PrecForDigits(digits) bits. The function panics if
+// digits is not positive.
+func ContextForDigits(digits int) Context {
+	return Context{Prec: PrecForDigits(digits), Mode: big.ToNearestEven}
+}
+
+// Binary32Context returns the Context of the IEEE 754 binary32 format,
+// the one of float32, with the rounding mode mode: 24 bits, with
+// subnormals below 2**-126, and overflowing from 2**128.
+func Binary32Context(mode big.RoundingMode) Context {
+	return binary32.context(mode)
+}
+
+// Binary64Context returns the Context of the IEEE 754 binary64 format,
+// the one of float64, with the rounding mode mode: 53 bits, with
+// subnormals below 2**-1022, and overflowing from 2**1024.
+func Binary64Context(mode big.RoundingMode) Context {
+	return binary64.context(mode)
+}
+
+// New returns a new zero with the precision and the rounding mode of
+// c, from c.Arena if it's not nil.
+func (c Context) New() *big.Float {
+
+	if c.Arena != nil {
+		return c.Arena.New(c.Prec, c.Mode)
+	}
+
+	return new(big.Float).SetPrec(c.Prec).SetMode(c.Mode)
+}
+
+// Round returns x rounded to the precision of c, with the rounding
+// mode of c.
+func (c Context) Round(x *big.Float) *big.Float {
+	return c.op(func(z *big.Float) *big.Float { return z.Set(x) }, x)
+}
+
+// Digits returns DigitsForPrec(c.Prec), the number of decimal digits
+// that are always preserved by the values of c.
+func (c Context) Digits() int {
+	return DigitsForPrec(c.Prec)
+}
+
+// PrecForDigits returns the smallest precision, in bits, such that
+// any decimal number with the given number of significant digits,
+// rounded to nearest to that precision and then back to that number
+// of digits, gives the same number. It's ⌈digits·log₂(10)⌉ + 1, as
+// in 51 for 15 digits, computed exactly. The function panics if
+// digits is not positive.
+func PrecForDigits(digits int) uint {
+
+	if digits <= 0 {
+		panic("PrecForDigits: non-positive digits")
+	}
+
+	// 10**digits is never a power of two, so it has ⌈digits·log₂(10)⌉
+	// bits
+	return uint(pow10(digits).BitLen()) + 1
+}
+
+// DigitsForPrec returns the largest number of decimal digits that are
+// preserved by a binary precision of prec bits, that is, the largest
+// d with PrecForDigits(d) <= prec, or 0 if there's none. It's
+// ⌊(prec-1)·log₁₀(2)⌋, as in 15 for 53 bits, computed exactly.
+//
+// Note that the number of digits needed to write a value of precision
+// prec so that it reads back unchanged is larger: 17 for 53 bits.
+func DigitsForPrec(prec uint) int {
+
+	if prec < 2 {
+		return 0
+	}
+
+	// an estimate that's at most 1 off, then fixed with the exact
+	// comparison 10**d < 2**(prec-1)
+	d := int(float64(prec-1) * math.Log10(2))
+	for d > 0 && PrecForDigits(d) > prec {
+		d--
+	}
+	for PrecForDigits(d+1) <= prec {
+		d++
+	}
+
+	return d
+}
@@ -0,0 +1,230 @@
+package bigfloat_test
+
+import (
+	"math"
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/ThreeAndTwo/bigfloat"
+)
+
+func TestPrecForDigits(t *testing.T) {
+	for _, test := range []struct {
+		digits int
+		want   uint
+	}{
+		{1, 5},
+		{2, 8},
+		{3, 11},
+		{6, 21},
+		{7, 25},
+		{15, 51},
+		{16, 55},
+		{17, 58},
+		{34, 114},
+		{100, 334},
+		{1000, 3323},
+	} {
+		if got := bigfloat.PrecForDigits(test.digits); got != test.want {
+			t.Errorf("PrecForDigits(%d) = %d; want %d", test.digits, got, test.want)
+		}
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("PrecForDigits(0) did not panic")
+		}
+	}()
+	bigfloat.PrecForDigits(0)
+}
+
+func TestDigitsForPrec(t *testing.T) {
+	for _, test := range []struct {
+		prec uint
+		want int
+	}{
+		{0, 0},
+		{1, 0},
+		{4, 0},
+		{5, 1},
+		{24, 6},
+		{53, 15},
+		{64, 18},
+		{113, 33},
+		{237, 71},
+		{3323, 1000},
+		{3322, 999},
+	} {
+		if got := bigfloat.DigitsForPrec(test.prec); got != test.want {
+			t.Errorf("DigitsForPrec(%d) = %d; want %d", test.prec, got, test.want)
+		}
+	}
+
+	// DigitsForPrec is the inverse of PrecForDigits
+	for prec := uint(5); prec < 2000; prec++ {
+		d := bigfloat.DigitsForPrec(prec)
+		if bigfloat.PrecForDigits(d) > prec || bigfloat.PrecForDigits(d+1) <= prec {
+			t.Fatalf("DigitsForPrec(%d) = %d, but PrecForDigits(%d) = %d and PrecForDigits(%d) = %d",
+				prec, d, d, bigfloat.PrecForDigits(d), d+1, bigfloat.PrecForDigits(d+1))
+		}
+	}
+}
+
+func TestPrecForDigitsRoundTrip(t *testing.T) {
+	// the worst cases are the numbers with all digits equal to 9, just
+	// below a power of ten, where the gaps between binary values are
+	// the largest
+	for digits := 1; digits <= 40; digits++ {
+		prec := bigfloat.PrecForDigits(digits)
+		for _, s := range []string{"9", "1", "5"} {
+			m := ""
+			for i := 0; i < digits; i++ {
+				m += s
+			}
+			x, _, err := new(big.Float).SetPrec(prec).Parse(m+"e-7", 10)
+			if err != nil {
+				t.Fatal(err)
+			}
+			r, _, _ := new(big.Float).SetPrec(2000).Parse(x.Text('e', digits-1), 10)
+			w, _, _ := new(big.Float).SetPrec(2000).Parse(m+"e-7", 10)
+			if r.Cmp(w) != 0 {
+				t.Errorf("%s·10**-7 at %d bits gives back %s", m, prec, x.Text('e', digits-1))
+			}
+		}
+	}
+}
+
+func TestContext(t *testing.T) {
+
+	c := bigfloat.ContextForDigits(50)
+	if c.Prec != 168 || c.Mode != big.ToNearestEven {
+		t.Errorf("ContextForDigits(50) = %+v", c)
+	}
+	if d := c.Digits(); d != 50 {
+		t.Errorf("Digits() = %d; want 50", d)
+	}
+
+	c = bigfloat.Context{Prec: 10, Mode: big.ToZero}
+	if z := c.New(); z.Prec() != 10 || z.Mode() != big.ToZero || z.Sign() != 0 {
+		t.Errorf("New() = %g, prec %d, mode %v", z, z.Prec(), z.Mode())
+	}
+	x := new(big.Float).SetPrec(100).Quo(big.NewFloat(2), big.NewFloat(3))
+	if z := c.Round(x); z.Prec() != 10 || z.Cmp(big.NewFloat(682.0/1024)) != 0 {
+		t.Errorf("Round(2/3) = %s", z.Text('p', 0))
+	}
+}
+
+// binaryOps are the operators checked against the hardware.
+var binaryOps = []string{"+", "-", "*", "/"}
+
+func binaryOp(c bigfloat.Context, op string, x, y *big.Float) *big.Float {
+
+	switch op {
+	case "+":
+		return c.Add(x, y)
+	case "-":
+		return c.Sub(x, y)
+	case "*":
+		return c.Mul(x, y)
+	}
+
+	return c.Quo(x, y)
+}
+
+func TestBinary64Context(t *testing.T) {
+	c := bigfloat.Binary64Context(big.ToNearestEven)
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 20000; i++ {
+		x := math.Ldexp(r.Float64()+0.5, r.Intn(2090)-1070)
+		y := math.Ldexp(r.Float64()+0.5, r.Intn(2090)-1070)
+		if r.Intn(2) == 0 {
+			y = -y
+		}
+		var want float64
+		for _, op := range binaryOps {
+			switch op {
+			case "+":
+				want = x + y
+			case "-":
+				want = x - y
+			case "*":
+				want = x * y
+			case "/":
+				want = x / y
+			}
+			z := binaryOp(c, op, big.NewFloat(x), big.NewFloat(y))
+			if f, _ := z.Float64(); f != want || z.Prec() != 53 {
+				t.Fatalf("%g %s %g = %g; want %g", x, op, y, f, want)
+			}
+		}
+	}
+}
+
+func TestBinary32Context(t *testing.T) {
+	c := bigfloat.Binary32Context(big.ToNearestEven)
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 20000; i++ {
+		x := float32(math.Ldexp(r.Float64()+0.5, r.Intn(270)-145))
+		y := float32(math.Ldexp(r.Float64()+0.5, r.Intn(270)-145))
+		if r.Intn(2) == 0 {
+			y = -y
+		}
+		var want float32
+		for _, op := range binaryOps {
+			switch op {
+			case "+":
+				want = x + y
+			case "-":
+				want = x - y
+			case "*":
+				want = x * y
+			case "/":
+				want = x / y
+			}
+			z := binaryOp(c, op, big.NewFloat(float64(x)), big.NewFloat(float64(y)))
+			if f, _ := z.Float32(); f != want || z.Prec() != 24 {
+				t.Fatalf("%g %s %g = %g; want %g", x, op, y, f, want)
+			}
+		}
+	}
+
+	// the conversion of a float64 rounds once
+	if f, _ := c.FromFloat64(1e-40).Float32(); f != float32(1e-40) {
+		t.Errorf("FromFloat64(1e-40) = %g; want %g", f, float32(1e-40))
+	}
+}
+
+func TestContextOverflow(t *testing.T) {
+	huge := big.NewFloat(math.MaxFloat64)
+	for _, test := range []struct {
+		mode big.RoundingMode
+		x    *big.Float
+		want float64
+	}{
+		{big.ToNearestEven, huge, math.Inf(1)},
+		{big.ToZero, huge, math.MaxFloat64},
+		{big.ToNegativeInf, huge, math.MaxFloat64},
+		{big.ToPositiveInf, huge, math.Inf(1)},
+		{big.ToNegativeInf, new(big.Float).Neg(huge), math.Inf(-1)},
+		{big.ToPositiveInf, new(big.Float).Neg(huge), -math.MaxFloat64},
+		{big.AwayFromZero, new(big.Float).Neg(huge), math.Inf(-1)},
+	} {
+		c := bigfloat.Binary64Context(test.mode)
+		z := c.Add(test.x, test.x)
+		if f, _ := z.Float64(); f != test.want {
+			t.Errorf("%v: %g + %g = %g; want %g", test.mode, test.x, test.x, f, test.want)
+		}
+	}
+
+	// the exponent range is checked after rounding: the largest finite
+	// value plus less than half an ulp doesn't overflow
+	c := bigfloat.Binary64Context(big.ToNearestEven)
+	z := c.Add(huge, big.NewFloat(math.Ldexp(1, 969)))
+	if f, _ := z.Float64(); f != math.MaxFloat64 {
+		t.Errorf("MaxFloat64 + 2**969 = %g; want MaxFloat64", f)
+	}
+	if z := c.Exp(big.NewFloat(710)); !z.IsInf() {
+		t.Errorf("Exp(710) = %g; want +Inf", z)
+	}
+}
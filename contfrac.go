@@ -0,0 +1,133 @@
+package bigfloat
+
+import "math/big"
+
+// ContinuedFraction returns the first n terms a[0], a[1], ... of the
+// regular continued fraction expansion
+//
+//	x = a[0] + 1/(a[1] + 1/(a[2] + ...))
+//
+// of x, together with the corresponding convergents, the rationals
+// given by the expansion truncated after each term. a[0] is the floor
+// of x, and the other terms are positive. Since x is rational, its
+// expansion is finite, and fewer than n terms are returned if it ends
+// earlier; the last convergent is then exactly x. The function panics
+// if x is infinite, or if n < 1.
+//
+// The expansion is the one of the exact value of x. When x is an
+// approximation of an irrational number, only the terms whose
+// convergents approximate x better than its precision are meaningful.
+func ContinuedFraction(x *big.Float, n int) (terms []*big.Int, convergents []*big.Rat) {
+
+	if x.IsInf() {
+		panic("ContinuedFraction: argument is infinite")
+	}
+	if n < 1 {
+		panic("ContinuedFraction: n < 1")
+	}
+
+	cf := newContFrac(x)
+	for len(terms) < n {
+		a := cf.next()
+		if a == nil {
+			break
+		}
+		terms = append(terms, a)
+		convergents = append(convergents, new(big.Rat).SetFrac(cf.h, cf.k))
+	}
+
+	return terms, convergents
+}
+
+// BestRat returns the rational number closest to x among the ones
+// with a denominator not larger than maxDen; of two equally close
+// rationals, the one with the smaller denominator is returned. If x
+// itself has a denominator not larger than maxDen, the result is
+// exactly x. The function panics if x is infinite, or if maxDen < 1.
+//
+// The best approximation is either a convergent of the continued
+// fraction expansion of x or a semiconvergent between the last two
+// convergents with denominators within the bound, so only those are
+// considered.
+func BestRat(x *big.Float, maxDen *big.Int) *big.Rat {
+
+	if x.IsInf() {
+		panic("BestRat: argument is infinite")
+	}
+	if maxDen.Sign() < 1 {
+		panic("BestRat: maxDen < 1")
+	}
+
+	cf := newContFrac(x)
+	for {
+		h, k, h1, k1 := cf.h, cf.k, cf.h1, cf.k1
+		if cf.next() == nil {
+			// the expansion ended: x itself fits the bound
+			return new(big.Rat).SetFrac(cf.h, cf.k)
+		}
+		if cf.k.Cmp(maxDen) > 0 {
+			// the largest semiconvergent (m·h + h1)/(m·k + k1)
+			// within the bound
+			m := new(big.Int).Sub(maxDen, k1)
+			m.Quo(m, k)
+			sh := new(big.Int).Mul(m, h)
+			sh.Add(sh, h1)
+			sk := new(big.Int).Mul(m, k)
+			sk.Add(sk, k1)
+
+			c := new(big.Rat).SetFrac(h, k)
+			s := new(big.Rat).SetFrac(sh, sk)
+			xr, _ := x.Rat(nil)
+			dc := new(big.Rat).Sub(xr, c)
+			ds := new(big.Rat).Sub(xr, s)
+			if ds.Abs(ds).Cmp(dc.Abs(dc)) < 0 {
+				return s
+			}
+			return c
+		}
+	}
+}
+
+// contFrac computes the continued fraction expansion of a rational
+// number, one term at a time, together with the convergents.
+type contFrac struct {
+	p, q   *big.Int // remainder of the expansion, p/q
+	h, k   *big.Int // last convergent h/k
+	h1, k1 *big.Int // previous convergent
+}
+
+func newContFrac(x *big.Float) *contFrac {
+
+	r, _ := x.Rat(nil)
+	return &contFrac{
+		p:  new(big.Int).Set(r.Num()),
+		q:  new(big.Int).Set(r.Denom()),
+		h:  big.NewInt(1),
+		k:  big.NewInt(0),
+		h1: big.NewInt(0),
+		k1: big.NewInt(1),
+	}
+}
+
+// next returns the next term of the expansion, or nil if it has
+// ended, and updates the convergents.
+func (cf *contFrac) next() *big.Int {
+
+	if cf.q.Sign() == 0 {
+		return nil
+	}
+
+	// a = floor(p/q); Euclidean division rounds down for positive q
+	a, r := new(big.Int).DivMod(cf.p, cf.q, new(big.Int))
+	cf.p, cf.q = cf.q, r
+
+	// h = a·h + h1, k = a·k + k1
+	h := new(big.Int).Mul(a, cf.h)
+	h.Add(h, cf.h1)
+	k := new(big.Int).Mul(a, cf.k)
+	k.Add(k, cf.k1)
+	cf.h1, cf.k1 = cf.h, cf.k
+	cf.h, cf.k = h, k
+
+	return a
+}
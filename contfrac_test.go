@@ -0,0 +1,129 @@
+package bigfloat_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ThreeAndTwo/bigfloat"
+)
+
+func TestContinuedFraction(t *testing.T) {
+	for _, test := range []struct {
+		x     string
+		prec  uint
+		n     int
+		terms []int64
+		last  string
+	}{
+		{"3.25", 53, 10, []int64{3, 4}, "13/4"},
+		{"-3.25", 53, 10, []int64{-4, 1, 3}, "-13/4"},
+		{"0.5", 53, 10, []int64{0, 2}, "1/2"},
+		{"7", 53, 10, []int64{7}, "7/1"},
+		{"0", 53, 10, []int64{0}, "0/1"},
+		{piStr, 300, 5, []int64{3, 7, 15, 1, 292}, "103993/33102"},
+	} {
+		x, _, _ := new(big.Float).SetPrec(test.prec).Parse(test.x, 10)
+		terms, conv := bigfloat.ContinuedFraction(x, test.n)
+		if len(terms) != len(test.terms) || len(conv) != len(terms) {
+			t.Errorf("ContinuedFraction(%.10s) has %d terms; want %d", test.x, len(terms), len(test.terms))
+			continue
+		}
+		for i := range terms {
+			if terms[i].Int64() != test.terms[i] {
+				t.Errorf("ContinuedFraction(%.10s) term %d = %v; want %d", test.x, i, terms[i], test.terms[i])
+			}
+		}
+		if s := conv[len(conv)-1].String(); s != test.last {
+			t.Errorf("ContinuedFraction(%.10s) last convergent = %s; want %s", test.x, s, test.last)
+		}
+	}
+}
+
+func TestContinuedFractionExact(t *testing.T) {
+	// the expansion of a binary number is finite, and its last
+	// convergent is the number itself
+	x := bigfloat.Sqrt(new(big.Float).SetPrec(100).SetInt64(2))
+	terms, conv := bigfloat.ContinuedFraction(x, 1000)
+	if len(terms) >= 1000 {
+		t.Fatalf("expansion of a 100-bit number has %d terms", len(terms))
+	}
+	want, _ := x.Rat(nil)
+	if conv[len(conv)-1].Cmp(want) != 0 {
+		t.Errorf("last convergent = %v; want %v", conv[len(conv)-1], want)
+	}
+
+	// √2 = [1; 2, 2, 2, ...], as far as the precision allows
+	for i := 1; i < 30; i++ {
+		if terms[i].Int64() != 2 {
+			t.Errorf("term %d of √2 = %v; want 2", i, terms[i])
+		}
+	}
+}
+
+func TestBestRat(t *testing.T) {
+	pi, _, _ := new(big.Float).SetPrec(300).Parse(piStr, 10)
+	for _, test := range []struct {
+		x      *big.Float
+		maxDen int64
+		want   string
+	}{
+		{pi, 1, "3/1"},
+		{pi, 10, "22/7"},
+		{pi, 100, "311/99"},
+		{pi, 1000, "355/113"},
+		{pi, 100000, "312689/99532"},
+		{big.NewFloat(0.75), 2, "1/1"}, // tie with 1/2
+		{big.NewFloat(0.75), 4, "3/4"},
+		{big.NewFloat(-0.3), 10, "-3/10"},
+		{big.NewFloat(0), 5, "0/1"},
+	} {
+		if r := bigfloat.BestRat(test.x, big.NewInt(test.maxDen)); r.String() != test.want {
+			t.Errorf("BestRat(%.10g, %d) = %v; want %s", test.x, test.maxDen, r, test.want)
+		}
+	}
+}
+
+func TestBestRatExhaustive(t *testing.T) {
+	// compare with a search over all the denominators
+	for _, f := range []float64{0.1, 0.2718281828, 1.41421356, -2.6457513, 0.9999, 12.34567} {
+		x := big.NewFloat(f)
+		xr, _ := x.Rat(nil)
+		for maxDen := int64(1); maxDen <= 60; maxDen++ {
+			var best *big.Rat
+			var bestDist *big.Rat
+			for d := int64(1); d <= maxDen; d++ {
+				// the closest numerators are floor(x·d) and floor(x·d)+1
+				xd := new(big.Rat).Mul(xr, big.NewRat(d, 1))
+				fl := new(big.Int).Div(xd.Num(), xd.Denom())
+				for _, n := range []*big.Int{fl, new(big.Int).Add(fl, big.NewInt(1))} {
+					r := new(big.Rat).SetFrac(n, big.NewInt(d))
+					dist := new(big.Rat).Sub(xr, r)
+					dist.Abs(dist)
+					if best == nil || dist.Cmp(bestDist) < 0 {
+						best, bestDist = r, dist
+					}
+				}
+			}
+			if r := bigfloat.BestRat(x, big.NewInt(maxDen)); r.Cmp(best) != 0 {
+				t.Errorf("BestRat(%g, %d) = %v; want %v", f, maxDen, r, best)
+			}
+		}
+	}
+}
+
+// ---------- Benchmarks ----------
+
+func BenchmarkContinuedFraction(b *testing.B) {
+	x, _, _ := new(big.Float).SetPrec(1000).Parse(piStr, 10)
+	for n := 0; n < b.N; n++ {
+		bigfloat.ContinuedFraction(x, 100)
+	}
+}
+
+func BenchmarkBestRat(b *testing.B) {
+	x, _, _ := new(big.Float).SetPrec(1000).Parse(piStr, 10)
+	maxDen := new(big.Int).Lsh(big.NewInt(1), 200)
+	for n := 0; n < b.N; n++ {
+		bigfloat.BestRat(x, maxDen)
+	}
+}
@@ -0,0 +1,72 @@
+package bigfloat
+
+import (
+	crand "crypto/rand"
+	"encoding/binary"
+	"math/big"
+	"math/rand"
+)
+
+// CryptoSource is a rand.Source that draws its bits from crypto/rand,
+// so that the values returned by Rand, NormalRand and RandRange can't
+// be predicted or biased by an attacker. The zero value is ready to
+// use, and it's safe for concurrent use.
+//
+// Seed is a no-op. The methods panic if crypto/rand fails to return
+// random bytes.
+type CryptoSource struct{}
+
+var _ rand.Source64 = CryptoSource{}
+
+// Uint64 returns 64 random bits.
+func (CryptoSource) Uint64() uint64 {
+
+	var b [8]byte
+	if _, err := crand.Read(b[:]); err != nil {
+		panic("CryptoSource: " + err.Error())
+	}
+
+	return binary.LittleEndian.Uint64(b[:])
+}
+
+// Int63 returns a random non-negative int64.
+func (s CryptoSource) Int63() int64 {
+	return int64(s.Uint64() >> 1)
+}
+
+// Seed does nothing: a CryptoSource can't be seeded.
+func (CryptoSource) Seed(int64) {}
+
+// RandRange returns a uniformly distributed random number in [a, b),
+// using src as the source of randomness. Precision is the largest of
+// the precisions of a and b. The function panics if a or b are
+// infinite, or if a >= b.
+//
+// The result is a + (b-a)·u, with u given by Rand with a few guard
+// digits, rounded to the result precision. Values that round to b
+// are rejected and drawn again.
+func RandRange(a, b *big.Float, src rand.Source) *big.Float {
+
+	if a.IsInf() || b.IsInf() {
+		panic("RandRange: infinite bound")
+	}
+	if a.Cmp(b) >= 0 {
+		panic("RandRange: a >= b")
+	}
+
+	prec := a.Prec()
+	if b.Prec() > prec {
+		prec = b.Prec()
+	}
+	wprec := prec + 32 // guard digits
+
+	w := new(big.Float).SetPrec(wprec).Sub(b, a)
+
+	for {
+		z := Rand(wprec, src)
+		z.Mul(z, w).Add(z, a).SetPrec(prec)
+		if z.Cmp(b) < 0 {
+			return z
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package bigfloat_test
+
+import (
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/ThreeAndTwo/bigfloat"
+)
+
+func TestCryptoSource(t *testing.T) {
+	var src bigfloat.CryptoSource
+	src.Seed(1) // no-op
+
+	one := big.NewFloat(1)
+	seen := make(map[string]bool)
+	for i := 0; i < 100; i++ {
+		if n := src.Int63(); n < 0 {
+			t.Fatalf("Int63() = %d", n)
+		}
+		z := bigfloat.Rand(128, src)
+		if z.Sign() < 0 || z.Cmp(one) >= 0 {
+			t.Fatalf("Rand(128, CryptoSource) = %g, not in [0, 1)", z)
+		}
+		seen[z.Text('p', 0)] = true
+	}
+	if len(seen) != 100 {
+		t.Errorf("%d distinct values in 100 draws", len(seen))
+	}
+}
+
+func TestRandRange(t *testing.T) {
+	for _, test := range []struct {
+		a, b string
+		prec uint
+	}{
+		{"0", "1", 53},
+		{"-3", "5", 100},
+		{"1e10", "1.0000001e10", 64},
+		{"-1e-20", "-1e-21", 200},
+		{"1", "1.0000000000000002", 53}, // only two values
+	} {
+		a, _, _ := new(big.Float).SetPrec(test.prec).Parse(test.a, 10)
+		b, _, _ := new(big.Float).SetPrec(test.prec).Parse(test.b, 10)
+		src := rand.NewSource(3)
+		for i := 0; i < 500; i++ {
+			z := bigfloat.RandRange(a, b, src)
+			if z.Prec() != test.prec {
+				t.Fatalf("RandRange(%s, %s).Prec() = %d", test.a, test.b, z.Prec())
+			}
+			if z.Cmp(a) < 0 || z.Cmp(b) >= 0 {
+				t.Fatalf("RandRange(%s, %s) = %g, out of range", test.a, test.b, z)
+			}
+		}
+	}
+}
+
+func TestRandRangePanics(t *testing.T) {
+	src := rand.NewSource(1)
+	for name, f := range map[string]func(){
+		"a == b": func() { bigfloat.RandRange(big.NewFloat(1), big.NewFloat(1), src) },
+		"a > b":  func() { bigfloat.RandRange(big.NewFloat(2), big.NewFloat(1), src) },
+		"Inf":    func() { bigfloat.RandRange(big.NewFloat(0), new(big.Float).SetInf(false), src) },
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("RandRange(%s) did not panic", name)
+				}
+			}()
+			f()
+		}()
+	}
+}
+
+// ---------- Benchmarks ----------
+
+func BenchmarkRandCrypto(b *testing.B) {
+	var src bigfloat.CryptoSource
+	for n := 0; n < b.N; n++ {
+		bigfloat.Rand(256, src)
+	}
+}
@@ -0,0 +1,42 @@
+package dd
+
+import "github.com/ThreeAndTwo/bigfloat"
+
+var (
+	_ bigfloat.Arith[DD] = DDArith{}
+	_ bigfloat.Arith[QD] = QDArith{}
+)
+
+// DDArith is the bigfloat.Arith of DD values.
+type DDArith struct{}
+
+func (DDArith) FromFloat64(f float64) DD { return DD{f, 0} }
+func (DDArith) Float64(x DD) float64     { return x.Float64() }
+func (DDArith) Cmp(x, y DD) int          { return x.Cmp(y) }
+func (DDArith) Add(x, y DD) DD           { return x.Add(y) }
+func (DDArith) Sub(x, y DD) DD           { return x.Sub(y) }
+func (DDArith) Mul(x, y DD) DD           { return x.Mul(y) }
+func (DDArith) Quo(x, y DD) DD           { return x.Quo(y) }
+func (DDArith) Neg(x DD) DD              { return x.Neg() }
+func (DDArith) Abs(x DD) DD              { return x.Abs() }
+func (DDArith) Sqrt(x DD) DD             { return x.Sqrt() }
+func (DDArith) Exp(x DD) DD              { return x.Exp() }
+func (DDArith) Log(x DD) DD              { return x.Log() }
+func (DDArith) Pow(x, y DD) DD           { return x.Pow(y) }
+
+// QDArith is the bigfloat.Arith of QD values.
+type QDArith struct{}
+
+func (QDArith) FromFloat64(f float64) QD { return QD{f} }
+func (QDArith) Float64(x QD) float64     { return x.Float64() }
+func (QDArith) Cmp(x, y QD) int          { return x.Cmp(y) }
+func (QDArith) Add(x, y QD) QD           { return x.Add(y) }
+func (QDArith) Sub(x, y QD) QD           { return x.Sub(y) }
+func (QDArith) Mul(x, y QD) QD           { return x.Mul(y) }
+func (QDArith) Quo(x, y QD) QD           { return x.Quo(y) }
+func (QDArith) Neg(x QD) QD              { return x.Neg() }
+func (QDArith) Abs(x QD) QD              { return x.Abs() }
+func (QDArith) Sqrt(x QD) QD             { return x.Sqrt() }
+func (QDArith) Exp(x QD) QD              { return x.Exp() }
+func (QDArith) Log(x QD) QD              { return x.Log() }
+func (QDArith) Pow(x, y QD) QD           { return x.Pow(y) }
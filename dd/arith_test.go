@@ -0,0 +1,47 @@
+package dd_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ThreeAndTwo/bigfloat"
+	"github.com/ThreeAndTwo/bigfloat/dd"
+)
+
+// series returns Σ 1/k² for k from 1 to n, written once for any
+// Arith.
+func series[T any](a bigfloat.Arith[T], n int) T {
+
+	one := a.FromFloat64(1)
+	s := a.FromFloat64(0)
+	for k := n; k >= 1; k-- {
+		kf := a.FromFloat64(float64(k))
+		s = a.Add(s, a.Quo(one, a.Mul(kf, kf)))
+	}
+
+	return s
+}
+
+func TestArith(t *testing.T) {
+	c := bigfloat.Context{Prec: refPrec, Mode: big.ToNearestEven}
+	want := series[*big.Float](c, 1000)
+
+	d := series[dd.DD](dd.DDArith{}, 1000)
+	if e := relErr(d.Big(), want); e > 0x1p-100 {
+		t.Errorf("DD series = %v, want %.35g (relative error %g)", d, want, e)
+	}
+	q := series[dd.QD](dd.QDArith{}, 1000)
+	if e := relErr(q.Big(), want); e > 0x1p-200 {
+		t.Errorf("QD series = %v, want %.70g (relative error %g)", q, want, e)
+	}
+
+	// the functions are the methods
+	x := dd.DDArith{}.FromFloat64(2)
+	if got, want := (dd.DDArith{}).Pow(x, x), x.Pow(x); got != want {
+		t.Errorf("DDArith.Pow(2, 2) = %v, want %v", got, want)
+	}
+	y := dd.QDArith{}.FromFloat64(2)
+	if got, want := (dd.QDArith{}).Sqrt(y), y.Sqrt(); got != want {
+		t.Errorf("QDArith.Sqrt(2) = %v, want %v", got, want)
+	}
+}
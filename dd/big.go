@@ -0,0 +1,142 @@
+package dd
+
+import (
+	"math"
+	"math/big"
+
+	"github.com/ThreeAndTwo/bigfloat"
+)
+
+// bigPrec is the precision of the constants computed with big.Float,
+// which is more than the one of a QD.
+const bigPrec = 320
+
+var (
+	ln2Big     = bigfloat.Ln2.Value(bigPrec)
+	ln2Parts   = parts(ln2Big, 5) // log(2) as a sum of float64 values, for exact products
+	invFactBig = invFactorials(24)
+)
+
+// parts returns x as the sum of n float64 values.
+func parts(x *big.Float, n int) []float64 {
+
+	c := make([]float64, n)
+	fromBig(c, x)
+
+	return c
+}
+
+// invFactorials returns 1/n! for n from 0 to n-1.
+func invFactorials(n int) []*big.Float {
+
+	f := make([]*big.Float, n)
+	f[0] = new(big.Float).SetPrec(bigPrec).SetInt64(1)
+	for i := 1; i < n; i++ {
+		f[i] = new(big.Float).SetPrec(bigPrec).Quo(f[i-1], big.NewFloat(float64(i)))
+	}
+
+	return f
+}
+
+// fromBig sets the elements of c to float64 values whose sum is x,
+// each one the rounding of what the previous ones leave of x.
+func fromBig(c []float64, x *big.Float) {
+
+	prec := x.Prec()
+	if prec < 64 {
+		prec = 64
+	}
+	r := new(big.Float).SetPrec(prec).Set(x)
+	for i := range c {
+		c[i], _ = r.Float64()
+		if math.IsInf(c[i], 0) {
+			break
+		}
+		// exact: the difference has the bits of r after the first 53
+		r.Sub(r, new(big.Float).SetFloat64(c[i]))
+	}
+}
+
+// toBig returns the exact sum of the parts, which are finite or the
+// infinities of multi-part values. It panics if a part is NaN.
+func toBig(parts ...float64) *big.Float {
+
+	minExp, maxExp := math.MaxInt32, math.MinInt32
+	for _, f := range parts {
+		if math.IsNaN(f) {
+			panic("Big: NaN argument")
+		}
+		if math.IsInf(f, 0) {
+			return new(big.Float).SetInf(f < 0)
+		}
+		if f != 0 {
+			_, e := math.Frexp(f)
+			if e > maxExp {
+				maxExp = e
+			}
+			if e < minExp {
+				minExp = e
+			}
+		}
+	}
+
+	// the sum of the parts has at most a bit more than their span
+	prec := uint(53)
+	if maxExp > minExp {
+		prec += uint(maxExp - minExp + 1)
+	}
+	z := new(big.Float).SetPrec(prec)
+	for _, f := range parts {
+		z.Add(z, big.NewFloat(f))
+	}
+
+	return z
+}
+
+// powSpecial returns x**y for the special cases of math.Pow and the
+// negative x with non-integer y, for x = xh + xl and y = yh + yl, and
+// reports whether it's one of them.
+func powSpecial(xh, xl, yh, yl float64) (float64, bool) {
+
+	switch {
+	case yh == 0, xh == 1 && xl == 0:
+		return 1, true
+	case math.IsNaN(xh) || math.IsNaN(yh):
+		return math.NaN(), true
+	case math.IsInf(yh, 0):
+		// the sign of |x| - 1
+		c := cmp(math.Abs(xh), 1)
+		if c == 0 {
+			c = sign(xl) * sign(xh)
+		}
+		switch {
+		case c == 0:
+			return 1, true
+		case (c > 0) == (yh > 0):
+			return math.Inf(+1), true
+		}
+		return 0, true
+	case xh == 0, math.IsInf(xh, 0):
+		// the result of math.Pow with a y of the same sign and
+		// parity
+		p := 2.0
+		if isOdd(yh, yl) {
+			p = 1
+		}
+		return math.Pow(xh, math.Copysign(p, yh)), true
+	case xh < 0 && !isInt(yh, yl):
+		return math.NaN(), true
+	}
+
+	return 0, false
+}
+
+// isInt reports whether yh + yl is an integer.
+func isInt(yh, yl float64) bool {
+	return yh == math.Trunc(yh) && yl == math.Trunc(yl)
+}
+
+// isOdd reports whether yh + yl is an odd integer.
+func isOdd(yh, yl float64) bool {
+	return isInt(yh, yl) && (math.Mod(yh, 2) != 0) != (math.Mod(yl, 2) != 0)
+}
@@ -0,0 +1,350 @@
+// Package dd provides double-double and quad-double numbers: the
+// unevaluated sums of two or four float64 values, which have about
+// 106 and 212 bits of precision and the exponent range of float64.
+//
+// They're much faster than big.Float values of the same precision,
+// since their operations are a few float64 operations, without
+// allocations, but they're only accurate to a few ulps of their
+// precision, they don't round in a chosen direction, and they lose
+// precision where their last parts are subnormal, below 2**-969 for
+// a DD and 2**-862 for a QD.
+// The types have the arithmetic operations and the Sqrt, Exp, Log
+// and Pow functions of bigfloat as methods, conversions from and to
+// big.Float, and implementations of bigfloat.Arith, so algorithms
+// written for it can run with them.
+//
+// The algorithms are the ones of Y. Hida, X. S. Li and D. H. Bailey,
+// Library for Double-Double and Quad-Double Arithmetic, 2007.
+package dd
+
+import (
+	"math"
+	"math/big"
+)
+
+// A DD is a double-double number, Hi + Lo, with |Lo| <= ulp(Hi)/2.
+// The zero value is 0. The infinities and NaN have Lo = 0.
+type DD struct {
+	Hi, Lo float64
+}
+
+// NewDD returns hi + lo as a DD, rounded if it isn't representable.
+func NewDD(hi, lo float64) DD {
+	return norm(twoSum(hi, lo))
+}
+
+// DDFromBig returns x rounded to a DD.
+func DDFromBig(x *big.Float) DD {
+
+	var c [2]float64
+	fromBig(c[:], x)
+
+	return DD{c[0], c[1]}
+}
+
+// Big returns the exact value of x as a big.Float. The method panics
+// if x is NaN.
+func (x DD) Big() *big.Float {
+	return toBig(x.Hi, x.Lo)
+}
+
+// Float64 returns x rounded to a float64.
+func (x DD) Float64() float64 {
+	return x.Hi
+}
+
+// String returns x in the %g format, with 32 significant digits.
+func (x DD) String() string {
+	if math.IsNaN(x.Hi) {
+		return "NaN"
+	}
+	return x.Big().Text('g', 32)
+}
+
+// Sign returns -1, 0 or +1 depending on the sign of x, and 0 for NaN.
+func (x DD) Sign() int {
+	return sign(x.Hi)
+}
+
+// Cmp returns -1, 0 or +1 depending on whether x < y, x == y or
+// x > y. It returns 0 if x or y is NaN.
+func (x DD) Cmp(y DD) int {
+
+	if c := cmp(x.Hi, y.Hi); c != 0 || x.Hi != y.Hi {
+		return c
+	}
+
+	return cmp(x.Lo, y.Lo)
+}
+
+// Neg returns -x.
+func (x DD) Neg() DD {
+	return DD{-x.Hi, -x.Lo}
+}
+
+// Abs returns |x|.
+func (x DD) Abs() DD {
+	if x.Hi < 0 {
+		return x.Neg()
+	}
+	return x
+}
+
+// Add returns x + y.
+func (x DD) Add(y DD) DD {
+
+	s, e := twoSum(x.Hi, y.Hi)
+	if !isFinite(s) {
+		return DD{s, 0}
+	}
+	t, f := twoSum(x.Lo, y.Lo)
+	e += t
+	s, e = fastTwoSum(s, e)
+	e += f
+
+	return norm(fastTwoSum(s, e))
+}
+
+// Sub returns x - y.
+func (x DD) Sub(y DD) DD {
+	return x.Add(y.Neg())
+}
+
+// Mul returns x·y.
+func (x DD) Mul(y DD) DD {
+
+	p, e := twoProd(x.Hi, y.Hi)
+	if !isFinite(p) {
+		return DD{p, 0}
+	}
+	e += x.Hi*y.Lo + x.Lo*y.Hi
+
+	return norm(fastTwoSum(p, e))
+}
+
+// mulFloat returns x·f.
+func (x DD) mulFloat(f float64) DD {
+
+	p, e := twoProd(x.Hi, f)
+	if !isFinite(p) {
+		return DD{p, 0}
+	}
+	e += x.Lo * f
+
+	return norm(fastTwoSum(p, e))
+}
+
+// Quo returns x/y. Division by zero gives an infinity, or NaN for
+// 0/0.
+func (x DD) Quo(y DD) DD {
+
+	if y.Hi == 0 || math.IsInf(y.Hi, 0) || math.IsInf(x.Hi, 0) {
+		return DD{x.Hi / y.Hi, 0}
+	}
+
+	// long division, with a third quotient digit for the rounding
+	q1 := x.Hi / y.Hi
+	r := x.Sub(y.mulFloat(q1))
+	q2 := r.Hi / y.Hi
+	r = r.Sub(y.mulFloat(q2))
+	q3 := r.Hi / y.Hi
+	q1, q2 = fastTwoSum(q1, q2)
+
+	return DD{q1, q2}.Add(DD{q3, 0})
+}
+
+// Sqrt returns the square root of x, or NaN if x is negative, as
+// math.Sqrt does.
+func (x DD) Sqrt() DD {
+
+	if x.Hi <= 0 || math.IsInf(x.Hi, 0) || math.IsNaN(x.Hi) {
+		return DD{math.Sqrt(x.Hi), 0}
+	}
+
+	// one Newton step from the float64 square root s:
+	// √x = s + (x - s²)/2s
+	s := math.Sqrt(x.Hi)
+	p, e := twoProd(s, s)
+	r := x.Sub(DD{p, e})
+
+	return norm(twoSum(s, r.Hi/(2*s)))
+}
+
+// Exp returns exp(x).
+func (x DD) Exp() DD {
+
+	switch {
+	case math.IsNaN(x.Hi):
+		return x
+	case x.Hi > 709.79:
+		return DD{math.Inf(+1), 0}
+	case x.Hi < -745.2:
+		return DD{}
+	case x.Hi == 0:
+		return DD{1, 0}
+	}
+
+	// exp(x) = exp(r)·2**k, with k = round(x/log(2)); exp(r) - 1 is
+	// computed on r/1024 by its series, and squared back with
+	// (e**2r - 1) = (e**r - 1)·(e**r + 1)
+	k := math.Round(x.Hi / math.Ln2)
+	r := x
+	for _, c := range ln2Parts[:3] {
+		r = r.Sub(NewDD(twoProd(k, c)))
+	}
+	r = DD{math.Ldexp(r.Hi, -ddExpHalvings), math.Ldexp(r.Lo, -ddExpHalvings)}
+
+	s, p := r, r
+	for n := 2; n < len(invFactDD); n++ {
+		p = p.Mul(r)
+		t := p.Mul(invFactDD[n])
+		s = s.Add(t)
+		if math.Abs(t.Hi) < ddEps*math.Abs(s.Hi) {
+			break
+		}
+	}
+	for i := 0; i < ddExpHalvings; i++ {
+		s = s.Mul(s.Add(DD{2, 0}))
+	}
+	s = s.Add(DD{1, 0})
+
+	return ldexp2(s.Hi, s.Lo, int(k))
+}
+
+// Log returns the natural logarithm of x: -Inf for 0, and NaN if x
+// is negative, as math.Log does. Near 1, the error is a few ulps of 1
+// rather than of the result.
+func (x DD) Log() DD {
+
+	if x.Hi <= 0 || math.IsInf(x.Hi, 0) || math.IsNaN(x.Hi) {
+		return DD{math.Log(x.Hi), 0}
+	}
+
+	// log(x) = log(m) + e·log(2), with 1/√2 <= m < √2, and one Newton
+	// step for exp(y) = m from the float64 logarithm: y + m·exp(-y) - 1
+	m, e := frexp(x.Hi)
+	xm := ldexp2(x.Hi, x.Lo, -e)
+	y := DD{math.Log(m), 0}
+	y = y.Add(xm.Mul(y.Neg().Exp())).Sub(DD{1, 0})
+
+	return y.Add(ln2DD.mulFloat(float64(e)))
+}
+
+// Pow returns x**y, computed as exp(y·log(x)). Negative values of x
+// give NaN unless y is an integer, and the special cases are the ones
+// of math.Pow for zero and infinite values.
+func (x DD) Pow(y DD) DD {
+
+	if f, ok := powSpecial(x.Hi, x.Lo, y.Hi, y.Lo); ok {
+		return DD{f, 0}
+	}
+
+	z := x.Abs().Log().Mul(y).Exp()
+	if x.Hi < 0 && isOdd(y.Hi, y.Lo) {
+		z = z.Neg()
+	}
+
+	return z
+}
+
+// ddExpHalvings is the number of halvings of the reduced argument of
+// DD.Exp, and ddEps the precision of the DD values.
+const (
+	ddExpHalvings = 10
+	ddEps         = 0x1p-106
+)
+
+var (
+	ln2DD     = DD{ln2Parts[0], ln2Parts[1]}
+	invFactDD [20]DD // 1/n!
+)
+
+func init() {
+	for n := range invFactDD {
+		invFactDD[n] = DDFromBig(invFactBig[n])
+	}
+}
+
+// norm returns the DD hi + lo, where |lo| <= ulp(hi)/2 is the
+// rounding error of hi, and replaces lo by 0 if hi isn't finite.
+func norm(hi, lo float64) DD {
+
+	if !isFinite(hi) {
+		return DD{hi, 0}
+	}
+
+	return DD{hi, lo}
+}
+
+func isFinite(f float64) bool {
+	return !math.IsInf(f, 0) && !math.IsNaN(f)
+}
+
+// ldexp2 returns (hi + lo)·2**k.
+func ldexp2(hi, lo float64, k int) DD {
+	return norm(math.Ldexp(hi, k), math.Ldexp(lo, k))
+}
+
+// frexp returns m and e with f = m·2**e and 1/√2 <= m < √2, for a
+// finite f > 0.
+func frexp(f float64) (float64, int) {
+
+	m, e := math.Frexp(f)
+	if m < math.Sqrt2/2 {
+		m, e = 2*m, e-1
+	}
+
+	return m, e
+}
+
+// twoSum returns the sum a + b rounded, and its rounding error.
+func twoSum(a, b float64) (s, e float64) {
+
+	s = a + b
+	bb := s - a
+	e = (a - (s - bb)) + (b - bb)
+
+	return s, e
+}
+
+// fastTwoSum returns twoSum(a, b), for |a| >= |b|.
+func fastTwoSum(a, b float64) (s, e float64) {
+
+	s = a + b
+	e = b - (s - a)
+
+	return s, e
+}
+
+// twoProd returns the product a·b rounded, and its rounding error.
+func twoProd(a, b float64) (p, e float64) {
+
+	p = a * b
+	e = math.FMA(a, b, -p)
+
+	return p, e
+}
+
+func sign(f float64) int {
+
+	switch {
+	case f < 0:
+		return -1
+	case f > 0:
+		return +1
+	}
+
+	return 0
+}
+
+func cmp(x, y float64) int {
+
+	switch {
+	case x < y:
+		return -1
+	case x > y:
+		return +1
+	}
+
+	return 0
+}
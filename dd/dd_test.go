@@ -0,0 +1,207 @@
+package dd_test
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/ThreeAndTwo/bigfloat"
+	"github.com/ThreeAndTwo/bigfloat/dd"
+)
+
+// refPrec is the precision of the reference results.
+const refPrec = 400
+
+// ref returns x at the reference precision.
+func ref(x *big.Float) *big.Float {
+	return new(big.Float).SetPrec(refPrec).Set(x)
+}
+
+// relErr returns |got - want|/|want|, or |got| if want is 0.
+func relErr(got, want *big.Float) float64 {
+
+	d := new(big.Float).SetPrec(refPrec).Sub(got, want)
+	if want.Sign() != 0 {
+		d.Quo(d, want)
+	}
+	f, _ := d.Abs(d).Float64()
+
+	return f
+}
+
+func parse(s string) *big.Float {
+	x, _, err := big.ParseFloat(s, 10, refPrec, big.ToNearestEven)
+	if err != nil {
+		panic(err)
+	}
+	return x
+}
+
+var ddArgs = []string{"1", "2", "0.1", "3.14159265358979323846264338327950288", "1e-30", "123456.789", "1e300", "7e-310"}
+
+func TestDDConvert(t *testing.T) {
+	for _, s := range ddArgs {
+		x := parse(s)
+		d := dd.DDFromBig(x)
+		if e := relErr(d.Big(), x); e > 0x1p-106 && s != "7e-310" {
+			t.Errorf("DDFromBig(%s) = %v, relative error %g", s, d, e)
+		}
+		if d.Hi != d.Hi+d.Lo {
+			t.Errorf("DDFromBig(%s) = %#v isn't normalized", s, d)
+		}
+		if dd.DDFromBig(d.Big()) != d {
+			t.Errorf("DDFromBig(%s).Big() doesn't convert back", s)
+		}
+	}
+
+	if d := dd.NewDD(1, 0x1p-60); d.Big().Cmp(parse("1.000000000000000000867361737988403547205962240695953369140625")) != 0 {
+		t.Errorf("NewDD(1, 2**-60) = %v", d)
+	}
+	if s := dd.NewDD(1, 0x1p-60).String(); s != "1.0000000000000000008673617379884" {
+		t.Errorf("String() = %s", s)
+	}
+}
+
+func TestDDArithmetic(t *testing.T) {
+
+	type op struct {
+		name string
+		dd   func(x, y dd.DD) dd.DD
+		big  func(z, x, y *big.Float) *big.Float
+	}
+	ops := []op{
+		{"Add", dd.DD.Add, (*big.Float).Add},
+		{"Sub", dd.DD.Sub, (*big.Float).Sub},
+		{"Mul", dd.DD.Mul, (*big.Float).Mul},
+		{"Quo", dd.DD.Quo, (*big.Float).Quo},
+	}
+	for _, o := range ops {
+		for _, xs := range ddArgs[:6] {
+			for _, ys := range ddArgs[:6] {
+				x, y := dd.DDFromBig(parse(xs)), dd.DDFromBig(parse(ys))
+				want := o.big(new(big.Float).SetPrec(refPrec), x.Big(), y.Big())
+				got := o.dd(x, y)
+				if e := relErr(got.Big(), want); e > 0x1p-104 && want.Sign() != 0 {
+					t.Errorf("%s(%s, %s) = %v, want %.35g (relative error %g)", o.name, xs, ys, got, want, e)
+				}
+			}
+		}
+	}
+
+	// the special cases are the float64 ones
+	inf := dd.DD{Hi: math.Inf(+1)}
+	for _, test := range []struct {
+		got  dd.DD
+		want float64
+	}{
+		{dd.DD{Hi: 1}.Quo(dd.DD{}), math.Inf(+1)},
+		{inf.Add(dd.DD{Hi: 1}), math.Inf(+1)},
+		{inf.Mul(dd.DD{Hi: 2}), math.Inf(+1)},
+		{dd.DD{Hi: 1e300}.Mul(dd.DD{Hi: 1e300}), math.Inf(+1)},
+	} {
+		if test.got.Hi != test.want || test.got.Lo != 0 {
+			t.Errorf("got %#v, want %g", test.got, test.want)
+		}
+	}
+	if q := (dd.DD{}).Quo(dd.DD{}); !math.IsNaN(q.Hi) {
+		t.Errorf("0/0 = %v, want NaN", q)
+	}
+}
+
+// checkFunc checks f against bigf, computed at the reference
+// precision, to the relative error tol.
+func checkFunc(t *testing.T, name string, f func(dd.DD) dd.DD, bigf func(*big.Float) *big.Float, args []string, tol float64) {
+	for _, s := range args {
+		x := dd.DDFromBig(parse(s))
+		want := bigf(ref(x.Big()))
+		got := f(x)
+		if e := relErr(got.Big(), want); e > tol {
+			t.Errorf("%s(%s) = %v, want %.35g (relative error %g)", name, s, got, want, e)
+		}
+	}
+}
+
+func TestDDFunctions(t *testing.T) {
+	checkFunc(t, "Sqrt", dd.DD.Sqrt, bigfloat.Sqrt, ddArgs[:7], 0x1p-104)
+	checkFunc(t, "Exp", dd.DD.Exp, bigfloat.Exp,
+		[]string{"1", "-1", "0.1", "1e-20", "-20.5", "100", "700", "-600"}, 0x1p-103)
+	checkFunc(t, "Log", dd.DD.Log, bigfloat.Log,
+		[]string{"2", "0.1", "10", "1e-300", "1e300", "123456.789", "7e-310"}, 0x1p-103)
+
+	y := dd.DDFromBig(parse("1.5"))
+	checkFunc(t, "Pow(x, 1.5)", func(x dd.DD) dd.DD { return x.Pow(y) },
+		func(x *big.Float) *big.Float { return bigfloat.Pow(x, y.Big()) },
+		[]string{"2", "0.1", "10", "1e100"}, 0x1p-96)
+
+	for _, test := range []struct {
+		x, y, want float64
+	}{
+		{0, 2, 0},
+		{0, -2, math.Inf(+1)},
+		{-2, 3, -8},
+		{-2, 2, 4},
+		{math.Inf(-1), 3, math.Inf(-1)},
+		{math.Inf(-1), -3, math.Copysign(0, -1)},
+		{-2, 0.5, math.NaN()},
+		{0.5, math.Inf(+1), 0},
+		{2, math.Inf(-1), 0},
+		{-1, math.Inf(+1), 1},
+		{7, 0, 1},
+	} {
+		got := dd.DD{Hi: test.x}.Pow(dd.DD{Hi: test.y})
+		if !(got.Hi == test.want && math.Signbit(got.Hi) == math.Signbit(test.want)) &&
+			!(math.IsNaN(got.Hi) && math.IsNaN(test.want)) {
+			t.Errorf("Pow(%g, %g) = %v, want %g", test.x, test.y, got, test.want)
+		}
+	}
+	if got := (dd.DD{Hi: 1, Lo: 0x1p-60}).Pow(dd.DD{Hi: math.Inf(+1)}); !math.IsInf(got.Hi, +1) {
+		t.Errorf("Pow(1 + 2**-60, +Inf) = %v, want +Inf", got)
+	}
+
+	if got := (dd.DD{Hi: -1}).Sqrt(); !math.IsNaN(got.Hi) {
+		t.Errorf("Sqrt(-1) = %v, want NaN", got)
+	}
+	if got := (dd.DD{}).Log(); !math.IsInf(got.Hi, -1) {
+		t.Errorf("Log(0) = %v, want -Inf", got)
+	}
+	if got := (dd.DD{Hi: 710}).Exp(); !math.IsInf(got.Hi, +1) {
+		t.Errorf("Exp(710) = %v, want +Inf", got)
+	}
+}
+
+func TestDDCmp(t *testing.T) {
+	a, b := dd.NewDD(1, 0x1p-60), dd.NewDD(1, -0x1p-60)
+	if a.Cmp(b) != 1 || b.Cmp(a) != -1 || a.Cmp(a) != 0 {
+		t.Errorf("Cmp of %v and %v is wrong", a, b)
+	}
+	if nan := (dd.DD{Hi: math.NaN()}); nan.Cmp(a) != 0 || a.Cmp(nan) != 0 {
+		t.Error("Cmp with NaN isn't 0")
+	}
+	if b.Neg().Sign() != -1 || b.Neg().Abs() != b {
+		t.Error("Neg or Abs is wrong")
+	}
+}
+
+// ---------- Benchmarks ----------
+
+func BenchmarkDD(b *testing.B) {
+	x := dd.DDFromBig(parse("1.2345678901234567890123456789"))
+	y := dd.DDFromBig(parse("0.98765432109876543210987654321"))
+	for _, f := range []struct {
+		name string
+		f    func() dd.DD
+	}{
+		{"Mul", func() dd.DD { return x.Mul(y) }},
+		{"Quo", func() dd.DD { return x.Quo(y) }},
+		{"Sqrt", func() dd.DD { return x.Sqrt() }},
+		{"Exp", func() dd.DD { return x.Exp() }},
+		{"Log", func() dd.DD { return x.Log() }},
+	} {
+		b.Run(fmt.Sprintf("%v", f.name), func(b *testing.B) {
+			for n := 0; n < b.N; n++ {
+				_ = f.f()
+			}
+		})
+	}
+}
@@ -0,0 +1,385 @@
+package dd
+
+import (
+	"math"
+	"math/big"
+)
+
+// A QD is a quad-double number, the sum of its elements, which are
+// in decreasing order of magnitude and don't overlap: each one is at
+// most half an ulp of the previous one. The zero value is 0. The
+// infinities and NaN have zeros after the first element.
+type QD [4]float64
+
+// QDFromFloat64 returns f as a QD.
+func QDFromFloat64(f float64) QD {
+	return QD{f}
+}
+
+// QDFromDD returns x as a QD.
+func QDFromDD(x DD) QD {
+	return QD{x.Hi, x.Lo}
+}
+
+// QDFromBig returns x rounded to a QD.
+func QDFromBig(x *big.Float) QD {
+
+	var z QD
+	fromBig(z[:], x)
+
+	return z
+}
+
+// Big returns the exact value of x as a big.Float. The method panics
+// if x is NaN.
+func (x QD) Big() *big.Float {
+	return toBig(x[:]...)
+}
+
+// Float64 returns x rounded to a float64.
+func (x QD) Float64() float64 {
+	return x[0]
+}
+
+// DD returns x rounded to a DD.
+func (x QD) DD() DD {
+	return norm(fastTwoSum(x[0], x[1]+x[2]))
+}
+
+// String returns x in the %g format, with 64 significant digits.
+func (x QD) String() string {
+	if math.IsNaN(x[0]) {
+		return "NaN"
+	}
+	return x.Big().Text('g', 64)
+}
+
+// Sign returns -1, 0 or +1 depending on the sign of x, and 0 for NaN.
+func (x QD) Sign() int {
+	return sign(x[0])
+}
+
+// Cmp returns -1, 0 or +1 depending on whether x < y, x == y or
+// x > y. It returns 0 if x or y is NaN.
+func (x QD) Cmp(y QD) int {
+
+	for i := range x {
+		if c := cmp(x[i], y[i]); c != 0 || x[i] != y[i] {
+			return c
+		}
+	}
+
+	return 0
+}
+
+// Neg returns -x.
+func (x QD) Neg() QD {
+	return QD{-x[0], -x[1], -x[2], -x[3]}
+}
+
+// Abs returns |x|.
+func (x QD) Abs() QD {
+	if x[0] < 0 {
+		return x.Neg()
+	}
+	return x
+}
+
+// Add returns x + y.
+func (x QD) Add(y QD) QD {
+
+	if math.IsInf(x[0], 0) || math.IsInf(y[0], 0) || math.IsNaN(x[0]) || math.IsNaN(y[0]) {
+		return QD{x[0] + y[0]}
+	}
+
+	// merge the elements in decreasing order of magnitude,
+	// accumulating them in u + v, and keep the non-zero outputs
+	var z QD
+	i, j, k := 0, 0, 0
+	next := func() float64 {
+		var t float64
+		if j >= 4 || i < 4 && math.Abs(x[i]) > math.Abs(y[j]) {
+			t, i = x[i], i+1
+		} else {
+			t, j = y[j], j+1
+		}
+		return t
+	}
+	u := next()
+	v := next()
+	u, v = fastTwoSum(u, v)
+	for k < 4 {
+		if i >= 4 && j >= 4 {
+			z[k] = u
+			if k < 3 {
+				z[k+1] = v
+			}
+			u, v = 0, 0
+			break
+		}
+		var s float64
+		s, u, v = threeAccum(u, v, next())
+		if s != 0 {
+			z[k] = s
+			k++
+		}
+	}
+
+	// the rest is below the last element
+	rest := u + v
+	for ; i < 4; i++ {
+		rest += x[i]
+	}
+	for ; j < 4; j++ {
+		rest += y[j]
+	}
+
+	return renorm(z[0], z[1], z[2], z[3], rest)
+}
+
+// Sub returns x - y.
+func (x QD) Sub(y QD) QD {
+	return x.Add(y.Neg())
+}
+
+// Mul returns x·y.
+func (x QD) Mul(y QD) QD {
+
+	if math.IsInf(x[0], 0) || math.IsInf(y[0], 0) || math.IsNaN(x[0]) || math.IsNaN(y[0]) ||
+		x[0] == 0 || y[0] == 0 {
+		return QD{x[0] * y[0]}
+	}
+
+	// the products of order ε (p1, p2) and ε² (p3, p4, p5)
+	p0, q0 := twoProd(x[0], y[0])
+	p1, q1 := twoProd(x[0], y[1])
+	p2, q2 := twoProd(x[1], y[0])
+	p3, q3 := twoProd(x[0], y[2])
+	p4, q4 := twoProd(x[1], y[1])
+	p5, q5 := twoProd(x[2], y[0])
+
+	p1, p2, q0 = threeSum(p1, p2, q0)
+
+	// (s0, s1, s2) = (p2, q1, q2) + (p3, p4, p5)
+	p2, q1, q2 = threeSum(p2, q1, q2)
+	p3, p4, p5 = threeSum(p3, p4, p5)
+	s0, t0 := twoSum(p2, p3)
+	s1, t1 := twoSum(q1, p4)
+	s2 := q2 + p5
+	s1, t0 = twoSum(s1, t0)
+	s2 += t0 + t1
+
+	// the terms of order ε³
+	s1 += x[0]*y[3] + x[1]*y[2] + x[2]*y[1] + x[3]*y[0] + q0 + q3 + q4 + q5
+
+	return renorm(p0, p1, s0, s1, s2)
+}
+
+// mulFloat returns x·f.
+func (x QD) mulFloat(f float64) QD {
+	return x.Mul(QD{f})
+}
+
+// Quo returns x/y. Division by zero gives an infinity, or NaN for
+// 0/0.
+func (x QD) Quo(y QD) QD {
+
+	if y[0] == 0 || math.IsInf(y[0], 0) || math.IsInf(x[0], 0) || math.IsNaN(x[0]) || math.IsNaN(y[0]) {
+		return QD{x[0] / y[0]}
+	}
+
+	// long division, with a fifth quotient digit for the rounding
+	var q [5]float64
+	r := x
+	for i := range q {
+		q[i] = r[0] / y[0]
+		if i < 4 {
+			r = r.Sub(y.mulFloat(q[i]))
+		}
+	}
+
+	return renorm(q[0], q[1], q[2], q[3], q[4])
+}
+
+// Sqrt returns the square root of x, or NaN if x is negative, as
+// math.Sqrt does.
+func (x QD) Sqrt() QD {
+
+	if x[0] <= 0 || math.IsInf(x[0], 0) || math.IsNaN(x[0]) {
+		return QD{math.Sqrt(x[0])}
+	}
+
+	// one Newton step from the DD square root s: √x = s + (x - s²)/2s,
+	// where the correction only needs the precision of a DD
+	sd := x.DD().Sqrt()
+	s := QDFromDD(sd)
+	r := x.Sub(s.Mul(s)).DD()
+
+	return s.Add(QDFromDD(r.Quo(sd.mulFloat(2))))
+}
+
+// Exp returns exp(x).
+func (x QD) Exp() QD {
+
+	switch {
+	case math.IsNaN(x[0]):
+		return x
+	case x[0] > 709.79:
+		return QD{math.Inf(+1)}
+	case x[0] < -745.2:
+		return QD{}
+	case x[0] == 0:
+		return QD{1}
+	}
+
+	// as in DD.Exp, with more halvings
+	k := math.Round(x[0] / math.Ln2)
+	r := x
+	for _, c := range ln2Parts {
+		p, e := twoProd(k, c)
+		r = r.Sub(QD{p, e})
+	}
+	for i := range r {
+		r[i] = math.Ldexp(r[i], -qdExpHalvings)
+	}
+
+	s, p := r, r
+	for n := 2; n < len(invFactQD); n++ {
+		p = p.Mul(r)
+		t := p.Mul(invFactQD[n])
+		s = s.Add(t)
+		if math.Abs(t[0]) < qdEps*math.Abs(s[0]) {
+			break
+		}
+	}
+	for i := 0; i < qdExpHalvings; i++ {
+		s = s.Mul(s.Add(QD{2}))
+	}
+	s = s.Add(QD{1})
+
+	for i := range s {
+		s[i] = math.Ldexp(s[i], int(k))
+	}
+	if math.IsInf(s[0], 0) {
+		return QD{s[0]}
+	}
+
+	return s
+}
+
+// Log returns the natural logarithm of x: -Inf for 0, and NaN if x
+// is negative, as math.Log does. Near 1, the error is a few ulps of 1
+// rather than of the result.
+func (x QD) Log() QD {
+
+	if x[0] <= 0 || math.IsInf(x[0], 0) || math.IsNaN(x[0]) {
+		return QD{math.Log(x[0])}
+	}
+
+	// as in DD.Log, with a Newton step from the DD logarithm
+	_, e := frexp(x[0])
+	xm := x
+	for i := range xm {
+		xm[i] = math.Ldexp(xm[i], -e)
+	}
+	y := QDFromDD(xm.DD().Log())
+	y = y.Add(xm.Mul(y.Neg().Exp())).Sub(QD{1})
+
+	return y.Add(ln2QD.mulFloat(float64(e)))
+}
+
+// Pow returns x**y, computed as exp(y·log(x)). Negative values of x
+// give NaN unless y is an integer, and the special cases are the ones
+// of math.Pow for zero and infinite values.
+func (x QD) Pow(y QD) QD {
+
+	if f, ok := powSpecial(x[0], x[1], y[0], y[1]+y[2]+y[3]); ok {
+		return QD{f}
+	}
+
+	z := x.Abs().Log().Mul(y).Exp()
+	if x[0] < 0 && isOdd(y[0], y[1]+y[2]+y[3]) {
+		z = z.Neg()
+	}
+
+	return z
+}
+
+// qdExpHalvings is the number of halvings of the reduced argument of
+// QD.Exp, and qdEps the precision of the QD values.
+const (
+	qdExpHalvings = 16
+	qdEps         = 0x1p-212
+)
+
+var (
+	ln2QD     = QD{ln2Parts[0], ln2Parts[1], ln2Parts[2], ln2Parts[3]}
+	invFactQD [24]QD // 1/n!
+)
+
+func init() {
+	for n := range invFactQD {
+		invFactQD[n] = QDFromBig(invFactBig[n])
+	}
+}
+
+// threeSum returns a + b + c as the sum of three values, the first of
+// which is the rounded sum.
+func threeSum(a, b, c float64) (float64, float64, float64) {
+
+	t1, t2 := twoSum(a, b)
+	a, t3 := twoSum(c, t1)
+	b, c = twoSum(t2, t3)
+
+	return a, b, c
+}
+
+// threeAccum adds c to the accumulator u + v, and returns the new
+// accumulator, with s, the part of the sum that has to leave it, or
+// 0 if the sum fits in the accumulator.
+func threeAccum(u, v, c float64) (s, nu, nv float64) {
+
+	s, b := twoSum(v, c)
+	s, a := twoSum(u, s)
+	switch {
+	case a != 0 && b != 0:
+		return s, a, b
+	case b == 0:
+		return 0, s, a
+	}
+
+	return 0, s, b
+}
+
+// renorm returns the sum of the five values, which are about in
+// decreasing order of magnitude, as a QD.
+func renorm(c0, c1, c2, c3, c4 float64) QD {
+
+	if math.IsInf(c0, 0) || math.IsNaN(c0) {
+		return QD{c0}
+	}
+
+	s0, c4 := fastTwoSum(c3, c4)
+	s0, c3 = fastTwoSum(c2, s0)
+	s0, c2 = fastTwoSum(c1, s0)
+	c0, c1 = fastTwoSum(c0, s0)
+
+	// propagate the non-zero values
+	var s QD
+	s[0] = c0
+	k := 0
+	for _, c := range [...]float64{c1, c2, c3, c4} {
+		if k == 3 {
+			s[3] += c
+			continue
+		}
+		var e float64
+		s[k], e = fastTwoSum(s[k], c)
+		if e != 0 {
+			k++
+			s[k] = e
+		}
+	}
+
+	return s
+}
@@ -0,0 +1,155 @@
+package dd_test
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/ThreeAndTwo/bigfloat"
+	"github.com/ThreeAndTwo/bigfloat/dd"
+)
+
+var qdArgs = []string{
+	"1", "2", "0.1", "3.141592653589793238462643383279502884197169399375105820974944592",
+	"1e-30", "123456.789", "1e300", "-7.5",
+}
+
+func TestQDConvert(t *testing.T) {
+	for _, s := range qdArgs {
+		x := parse(s)
+		q := dd.QDFromBig(x)
+		if e := relErr(q.Big(), x); e > 0x1p-212 {
+			t.Errorf("QDFromBig(%s) = %v, relative error %g", s, q, e)
+		}
+		if dd.QDFromBig(q.Big()) != q {
+			t.Errorf("QDFromBig(%s).Big() doesn't convert back", s)
+		}
+		if d := q.DD(); d != dd.DDFromBig(x) {
+			t.Errorf("QDFromBig(%s).DD() = %#v, want %#v", s, d, dd.DDFromBig(x))
+		}
+	}
+
+	if q := dd.QDFromDD(dd.NewDD(1, 0x1p-60)); q != (dd.QD{1, 0x1p-60}) {
+		t.Errorf("QDFromDD = %v", q)
+	}
+}
+
+func TestQDArithmetic(t *testing.T) {
+
+	type op struct {
+		name string
+		qd   func(x, y dd.QD) dd.QD
+		big  func(z, x, y *big.Float) *big.Float
+	}
+	ops := []op{
+		{"Add", dd.QD.Add, (*big.Float).Add},
+		{"Sub", dd.QD.Sub, (*big.Float).Sub},
+		{"Mul", dd.QD.Mul, (*big.Float).Mul},
+		{"Quo", dd.QD.Quo, (*big.Float).Quo},
+	}
+	for _, o := range ops {
+		for _, xs := range qdArgs {
+			for _, ys := range qdArgs {
+				x, y := dd.QDFromBig(parse(xs)), dd.QDFromBig(parse(ys))
+				want := o.big(new(big.Float).SetPrec(refPrec), x.Big(), y.Big())
+				if e := want.MantExp(nil); e > 1024 || e < -860 {
+					// outside of the range with the full precision
+					continue
+				}
+				got := o.qd(x, y)
+				if e := relErr(got.Big(), want); e > 0x1p-208 && want.Sign() != 0 {
+					t.Errorf("%s(%s, %s) = %v, want %.70g (relative error %g)", o.name, xs, ys, got, want, e)
+				}
+			}
+		}
+	}
+
+	// a cancellation leaves the low parts
+	x := dd.QDFromBig(parse("1.000000000000000000000000000000000000000000000000000000001"))
+	if got, want := x.Sub(dd.QD{1}), parse("1e-57"); relErr(got.Big(), want) > 0x1p-30 {
+		t.Errorf("x - 1 = %v, want %g", got, want)
+	}
+
+	if got := (dd.QD{1e300}).Mul(dd.QD{1e300}); !math.IsInf(got[0], +1) || got[1] != 0 {
+		t.Errorf("1e300·1e300 = %v, want +Inf", got)
+	}
+	if got := (dd.QD{math.Inf(+1)}).Add(dd.QD{1}); !math.IsInf(got[0], +1) {
+		t.Errorf("Inf + 1 = %v, want +Inf", got)
+	}
+	if got := (dd.QD{}).Quo(dd.QD{}); !math.IsNaN(got[0]) {
+		t.Errorf("0/0 = %v, want NaN", got)
+	}
+}
+
+// checkFuncQD checks f against bigf, computed at the reference
+// precision, to the relative error tol.
+func checkFuncQD(t *testing.T, name string, f func(dd.QD) dd.QD, bigf func(*big.Float) *big.Float, args []string, tol float64) {
+	for _, s := range args {
+		x := dd.QDFromBig(parse(s))
+		want := bigf(ref(x.Big()))
+		got := f(x)
+		if e := relErr(got.Big(), want); e > tol {
+			t.Errorf("%s(%s) = %v, want %.70g (relative error %g)", name, s, got, want, e)
+		}
+	}
+}
+
+func TestQDFunctions(t *testing.T) {
+	checkFuncQD(t, "Sqrt", dd.QD.Sqrt, bigfloat.Sqrt, qdArgs[:7], 0x1p-208)
+	checkFuncQD(t, "Exp", dd.QD.Exp, bigfloat.Exp,
+		[]string{"1", "-1", "0.1", "1e-20", "-20.5", "100", "700", "-600"}, 0x1p-204)
+	checkFuncQD(t, "Log", dd.QD.Log, bigfloat.Log,
+		[]string{"2", "0.1", "10", "1e-300", "1e300", "123456.789"}, 0x1p-205)
+
+	y := dd.QDFromBig(parse("1.5"))
+	checkFuncQD(t, "Pow(x, 1.5)", func(x dd.QD) dd.QD { return x.Pow(y) },
+		func(x *big.Float) *big.Float { return bigfloat.Pow(x, y.Big()) },
+		[]string{"2", "0.1", "10", "1e100"}, 0x1p-198)
+
+	if got := (dd.QD{-2}).Pow(dd.QD{3}); relErr(got.Big(), big.NewFloat(-8)) > 0x1p-205 {
+		t.Errorf("Pow(-2, 3) = %v, want -8", got)
+	}
+	if got := (dd.QD{-1}).Sqrt(); !math.IsNaN(got[0]) {
+		t.Errorf("Sqrt(-1) = %v, want NaN", got)
+	}
+	if got := (dd.QD{-1}).Log(); !math.IsNaN(got[0]) {
+		t.Errorf("Log(-1) = %v, want NaN", got)
+	}
+	if got := (dd.QD{-800}).Exp(); got != (dd.QD{}) {
+		t.Errorf("Exp(-800) = %v, want 0", got)
+	}
+}
+
+func TestQDCmp(t *testing.T) {
+	a, b := dd.QD{1, 0, 0x1p-120}, dd.QD{1, 0, -0x1p-120}
+	if a.Cmp(b) != 1 || b.Cmp(a) != -1 || a.Cmp(a) != 0 {
+		t.Errorf("Cmp of %v and %v is wrong", a, b)
+	}
+	if a.Neg().Sign() != -1 || a.Neg().Abs() != a {
+		t.Error("Neg or Abs is wrong")
+	}
+}
+
+// ---------- Benchmarks ----------
+
+func BenchmarkQD(b *testing.B) {
+	x := dd.QDFromBig(parse("1.2345678901234567890123456789"))
+	y := dd.QDFromBig(parse("0.98765432109876543210987654321"))
+	for _, f := range []struct {
+		name string
+		f    func() dd.QD
+	}{
+		{"Mul", func() dd.QD { return x.Mul(y) }},
+		{"Quo", func() dd.QD { return x.Quo(y) }},
+		{"Sqrt", func() dd.QD { return x.Sqrt() }},
+		{"Exp", func() dd.QD { return x.Exp() }},
+		{"Log", func() dd.QD { return x.Log() }},
+	} {
+		b.Run(fmt.Sprintf("%v", f.name), func(b *testing.B) {
+			for n := 0; n < b.N; n++ {
+				_ = f.f()
+			}
+		})
+	}
+}
@@ -0,0 +1,396 @@
+package decimal
+
+import (
+	"math"
+	"math/big"
+	"math/bits"
+
+	"github.com/ThreeAndTwo/bigfloat"
+)
+
+// A Context holds the number of significant digits and the rounding
+// rule of the results of the operations that can't be exact. The
+// methods panic if Digits isn't positive.
+type Context struct {
+	Digits int                   // significant digits of the results
+	Rule   bigfloat.RoundingRule // rounding rule of the results
+}
+
+// Round returns x rounded to c.Digits significant digits. If x has
+// fewer digits, it's returned unchanged.
+func (c Context) Round(x *Decimal) *Decimal {
+
+	c.check("Round")
+	n := numDigits(&x.coef)
+	if n <= c.Digits {
+		return x
+	}
+
+	z := &Decimal{exp: x.exp + n - c.Digits}
+	z.coef.Set(roundShift(&x.coef, n-c.Digits, c.Rule))
+	if numDigits(&z.coef) > c.Digits {
+		// rounded up to a power of ten
+		z.coef.Quo(&z.coef, big.NewInt(10))
+		z.exp++
+	}
+
+	return z
+}
+
+// FromFloat returns x correctly rounded to c.Digits significant
+// digits. The method panics if x is infinite.
+func (c Context) FromFloat(x *big.Float) *Decimal {
+
+	c.check("FromFloat")
+	q, e := bigfloat.SigDigits(x, c.Digits, c.Rule)
+
+	return New(q, e)
+}
+
+// Quo returns x/y, correctly rounded. An exact quotient has no more
+// trailing zeros than needed for the exponent of x minus the one of y,
+// so 6/2 is 3 and 6.0/2 is 3.0. The method panics if y is 0.
+func (c Context) Quo(x, y *Decimal) *Decimal {
+
+	c.check("Quo")
+	if y.Sign() == 0 {
+		panic("Quo: division by zero")
+	}
+	if x.Sign() == 0 {
+		return new(Decimal)
+	}
+
+	// a quotient with more digits than needed, and a last digit 1 if
+	// there's a remainder, rounds like the exact one
+	n := c.Digits + 1 + numDigits(&y.coef) - numDigits(&x.coef)
+	if n < 0 {
+		n = 0
+	}
+	num := new(big.Int).Mul(&x.coef, pow10(n))
+	q, r := num.QuoRem(num, &y.coef, new(big.Int))
+	z := &Decimal{exp: x.exp - y.exp - n}
+	z.coef.Set(q)
+	if r.Sign() != 0 {
+		return c.Round(sticky(z, r.Sign()*y.coef.Sign()))
+	}
+
+	return trim(c.Round(z), x.exp-y.exp)
+}
+
+// Sqrt returns the square root of x, correctly rounded. An exact root
+// has no more trailing zeros than needed for half the exponent of x,
+// rounded down. The method panics if x is negative.
+func (c Context) Sqrt(x *Decimal) *Decimal {
+
+	c.check("Sqrt")
+	if x.Sign() < 0 {
+		panic("Sqrt: argument is negative")
+	}
+	if x.Sign() == 0 {
+		return new(Decimal)
+	}
+
+	// √(m·10**2k) = √m·10**k, with m scaled to have at least twice as
+	// many digits as the result
+	m, e := &x.coef, x.exp
+	if e%2 != 0 {
+		m, e = new(big.Int).Mul(m, big.NewInt(10)), e-1
+	}
+	if n := 2*(c.Digits+1) - numDigits(m); n > 0 {
+		n += n % 2
+		m, e = new(big.Int).Mul(m, pow10(n)), e-n
+	}
+	s := new(big.Int).Sqrt(m)
+	z := &Decimal{exp: e / 2}
+	z.coef.Set(s)
+	if s.Mul(s, s).Cmp(m) != 0 {
+		return c.Round(sticky(z, +1))
+	}
+
+	return trim(c.Round(z), floorDiv2(x.exp))
+}
+
+// Exp returns exp(x), correctly rounded. The method panics if the
+// exponent of the result overflows.
+func (c Context) Exp(x *Decimal) *Decimal {
+
+	c.check("Exp")
+	if x.Sign() == 0 {
+		return NewInt64(1, 0)
+	}
+	if x.adjExp() > 15 {
+		panic("Exp: overflow")
+	}
+
+	// the condition number of exp is |x|
+	extra := magBits(x)
+
+	return c.ziv(func(prec uint) (*big.Float, int) {
+		return expScaled(x.Float(prec+extra), prec)
+	})
+}
+
+// Log returns the natural logarithm of x, correctly rounded. The
+// method panics if x isn't positive.
+func (c Context) Log(x *Decimal) *Decimal {
+
+	c.check("Log")
+	if x.Sign() <= 0 {
+		panic("Log: argument is not positive")
+	}
+
+	m, a := logReduce(x)
+	one := NewInt64(1, 0)
+	if a == 0 && m.Cmp(one) == 0 {
+		return new(Decimal)
+	}
+
+	// if a is 0, log(m) can be small, and the condition number of
+	// log at m, 1/|log(m)| <= max(m, 1)/|m - 1|, large
+	var extra uint
+	if d := m.Sub(one).adjExp(); a == 0 && d < 0 {
+		extra = uint(-d*4) + 4
+	}
+
+	return c.ziv(func(prec uint) (*big.Float, int) {
+		return logFloat(m, a, prec+extra), 0
+	})
+}
+
+// Pow returns x**y, correctly rounded. The powers with an integer y
+// that don't have many more digits than the result are computed
+// exactly before the rounding, so Pow(-2, 3) is -8 and Pow(1.5, 2) is
+// 2.25, as products are. The method panics if x is
+// negative and y isn't an integer, if x is 0 and y is negative, or if
+// the exponent of the result overflows.
+func (c Context) Pow(x, y *Decimal) *Decimal {
+
+	c.check("Pow")
+	switch {
+	case y.Sign() == 0:
+		return NewInt64(1, 0)
+	case x.Sign() == 0 && y.Sign() < 0:
+		panic("Pow: zero base with negative exponent")
+	case x.Sign() == 0:
+		return new(Decimal)
+	case x.Sign() < 0 && !y.IsInt():
+		panic("Pow: negative base with non-integer exponent")
+	}
+
+	if z := c.powInt(x, y); z != nil {
+		return z
+	}
+
+	neg := x.Sign() < 0 && isOdd(y)
+
+	// the absolute error of y·log|x| is the relative one of the result
+	m, a := logReduce(x.Abs())
+	extra := magBits(y)
+
+	z := c.ziv(func(prec uint) (*big.Float, int) {
+		p := prec + extra
+		l := logFloat(m, a, p)
+		return expScaled(l.Mul(l, y.Float(p)), prec)
+	})
+	if neg {
+		z = z.Neg()
+	}
+
+	return z
+}
+
+// powInt returns x**y for an integer y, or nil if y isn't an integer
+// or the exact power is too large.
+func (c Context) powInt(x, y *Decimal) *Decimal {
+
+	if !y.IsInt() || y.adjExp() > 6 {
+		return nil
+	}
+	n := new(big.Int).Mul(&y.coef, pow10(maxInt(y.exp, 0)))
+	n.Quo(n, pow10(maxInt(-y.exp, 0)))
+	k := n.Int64()
+	if k < 0 {
+		k = -k
+	}
+	if int64(numDigits(&x.coef))*k > int64(4*c.Digits+1000) {
+		return nil
+	}
+
+	z := &Decimal{exp: x.exp * int(k)}
+	z.coef.Exp(&x.coef, big.NewInt(k), nil)
+	if n.Sign() < 0 {
+		return c.Quo(NewInt64(1, 0), z)
+	}
+
+	return c.Round(z)
+}
+
+// ziv returns the value z·10**k, correctly rounded, where f returns z
+// and k with z accurate to a few ulps at a precision of prec bits. f
+// is called with increasing precisions until the rounding of z is
+// known, which requires Digits plus 1 digits if the result is a tie,
+// or has fewer digits; such results are recognized when the
+// precision gets large.
+func (c Context) ziv(f func(prec uint) (*big.Float, int)) *Decimal {
+
+	prec := bigfloat.PrecForDigits(c.Digits+1) + 32
+	for i := 0; ; i++ {
+		z, k := f(prec)
+		if z.Sign() == 0 {
+			return new(Decimal)
+		}
+
+		// z is within 2**8 ulps of the result
+		err := bigfloat.Ulp(z)
+		err.SetMantExp(err, 8)
+		lo := new(big.Float).SetPrec(z.Prec()+16).Sub(z, err)
+		hi := new(big.Float).SetPrec(z.Prec()+16).Add(z, err)
+		qlo, elo := bigfloat.SigDigits(lo, c.Digits, c.Rule)
+		qhi, ehi := bigfloat.SigDigits(hi, c.Digits, c.Rule)
+		if elo == ehi && qlo.Cmp(qhi) == 0 {
+			return New(qlo, elo+k)
+		}
+
+		if i == zivRounds {
+			// the result is assumed to be the one at Digits + 1
+			q, e := bigfloat.SigDigits(z, c.Digits+1, bigfloat.HalfEven)
+			return c.Round(New(q, e+k))
+		}
+		prec *= 2
+	}
+}
+
+// zivRounds is the number of times ziv doubles the precision before
+// assuming that the result is exact or a tie.
+const zivRounds = 4
+
+// expScaled returns z and k with exp(t) = z·10**k, and z correct to a
+// few ulps at prec bits, for a t with an absolute error of about
+// 2**-prec.
+func expScaled(t *big.Float, prec uint) (*big.Float, int) {
+
+	// exp(t) = exp(r)·10**k, with k = round(t/log(10))
+	tf, _ := t.Float64()
+	k := math.Round(tf / math.Ln10)
+	if math.Abs(k) > 1e15 {
+		panic("Exp: overflow")
+	}
+	kb := uint(bits.Len64(uint64(math.Abs(k))))
+	ln10 := bigfloat.Ln10.Value(t.Prec() + kb)
+	r := new(big.Float).SetPrec(t.Prec() + kb).SetFloat64(k)
+	r.Mul(r, ln10)
+	r.Sub(t, r)
+
+	return bigfloat.Exp(r.SetPrec(prec + 8)), int(k)
+}
+
+// logReduce returns m and a with x = m·10**a and 1/√10 <= m < √10,
+// for x > 0.
+func logReduce(x *Decimal) (*Decimal, int) {
+
+	a := x.adjExp()
+	m := New(&x.coef, x.exp-a)
+	if m.Mul(m).Cmp(NewInt64(10, 0)) >= 0 {
+		a++
+		m = New(&x.coef, x.exp-a)
+	}
+
+	return m, a
+}
+
+// logFloat returns log(m) + a·log(10), with an absolute error of about
+// 2**-prec.
+func logFloat(m *Decimal, a int, prec uint) *big.Float {
+
+	ab := uint(bits.Len(uint(absInt(a))))
+	z := bigfloat.Log(m.Float(prec + 8))
+	if a == 0 {
+		return z
+	}
+	t := new(big.Float).SetPrec(prec + ab + 8).SetInt64(int64(a))
+	t.Mul(t, bigfloat.Ln10.Value(prec+ab+8))
+
+	return t.Add(t, z)
+}
+
+// trim returns z without the trailing zeros of its coefficient that
+// can be removed with an exponent not above ideal.
+func trim(z *Decimal, ideal int) *Decimal {
+
+	if z.Sign() == 0 {
+		return z
+	}
+
+	t := New(&z.coef, z.exp)
+	ten, r := big.NewInt(10), new(big.Int)
+	for t.exp < ideal {
+		q, _ := new(big.Int).QuoRem(&t.coef, ten, r)
+		if r.Sign() != 0 {
+			break
+		}
+		t.coef.Set(q)
+		t.exp++
+	}
+
+	return t
+}
+
+// sticky returns z with a last digit 1 appended, which makes it round
+// like a value a bit larger in absolute value if sign is the one of z,
+// or smaller otherwise.
+func sticky(z *Decimal, sign int) *Decimal {
+
+	s := &Decimal{exp: z.exp - 1}
+	s.coef.Mul(&z.coef, big.NewInt(10))
+	s.coef.Add(&s.coef, big.NewInt(int64(sign)))
+
+	return s
+}
+
+// magBits returns the number of bits of the integer part of |x|, plus
+// a few.
+func magBits(x *Decimal) uint {
+
+	if a := x.adjExp(); a >= 0 {
+		return uint(a+1)*4 + 4
+	}
+
+	return 4
+}
+
+// isOdd reports whether the integer y is odd.
+func isOdd(y *Decimal) bool {
+
+	if y.exp > 0 || !y.IsInt() {
+		return false
+	}
+
+	return new(big.Int).Quo(&y.coef, pow10(-y.exp)).Bit(0) == 1
+}
+
+func (c Context) check(name string) {
+	if c.Digits < 1 {
+		panic(name + ": non-positive digits")
+	}
+}
+
+func floorDiv2(x int) int {
+	if x < 0 {
+		return -((-x + 1) / 2)
+	}
+	return x / 2
+}
+
+func maxInt(x, y int) int {
+	if x > y {
+		return x
+	}
+	return y
+}
+
+func absInt(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
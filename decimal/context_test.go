@@ -0,0 +1,195 @@
+package decimal_test
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/ThreeAndTwo/bigfloat"
+	"github.com/ThreeAndTwo/bigfloat/decimal"
+)
+
+func TestContextRound(t *testing.T) {
+	for _, test := range []struct {
+		x      string
+		digits int
+		rule   bigfloat.RoundingRule
+		want   string
+	}{
+		{"123.456", 4, bigfloat.HalfEven, "123.5"},
+		{"123.45", 4, bigfloat.HalfEven, "123.4"},
+		{"123.45", 4, bigfloat.HalfUp, "123.5"},
+		{"-123.45", 4, bigfloat.HalfUp, "-123.4"},
+		{"-123.45", 4, bigfloat.HalfAwayFromZero, "-123.5"},
+		{"999.96", 4, bigfloat.HalfEven, "1000"},
+		{"999.96", 3, bigfloat.HalfEven, "1.00e+3"},
+		{"1.5", 4, bigfloat.HalfEven, "1.5"},
+		{"0.00012345", 2, bigfloat.TowardZero, "0.00012"},
+	} {
+		c := decimal.Context{Digits: test.digits, Rule: test.rule}
+		if got := c.Round(parse(test.x)).String(); got != test.want {
+			t.Errorf("Round(%s, %d, %v) = %s; want %s", test.x, test.digits, test.rule, got, test.want)
+		}
+	}
+
+	c := decimal.Context{Digits: 3, Rule: bigfloat.HalfEven}
+	if got := c.FromFloat(big.NewFloat(0.15)).String(); got != "0.150" {
+		t.Errorf("FromFloat(0.15) = %s; want 0.150", got)
+	}
+	c.Digits = 1
+	if got := c.FromFloat(big.NewFloat(0.15)).String(); got != "0.1" {
+		t.Errorf("FromFloat(0.15) to one digit = %s; want 0.1", got)
+	}
+}
+
+func TestContextQuo(t *testing.T) {
+	for _, test := range []struct {
+		x, y   string
+		digits int
+		rule   bigfloat.RoundingRule
+		want   string
+	}{
+		{"1", "3", 10, bigfloat.HalfEven, "0.3333333333"},
+		{"2", "3", 10, bigfloat.HalfEven, "0.6666666667"},
+		{"2", "3", 10, bigfloat.TowardZero, "0.6666666666"},
+		{"-2", "3", 5, bigfloat.HalfUp, "-0.66667"},
+		{"1", "8", 2, bigfloat.HalfEven, "0.12"},
+		{"1", "8", 2, bigfloat.HalfUp, "0.13"},
+		{"1.0000001", "8", 2, bigfloat.HalfEven, "0.13"}, // above the tie
+		{"-1", "-8", 2, bigfloat.HalfEven, "0.12"},
+		{"1", "-8", 2, bigfloat.HalfAwayFromZero, "-0.13"},
+		{"6", "2", 10, bigfloat.HalfEven, "3"},
+		{"6.0", "2", 10, bigfloat.HalfEven, "3.0"},
+		{"100", "4", 10, bigfloat.HalfEven, "25"},
+		{"1e30", "7", 5, bigfloat.HalfEven, "1.4286e+29"},
+		{"0", "7", 5, bigfloat.HalfEven, "0"},
+	} {
+		c := decimal.Context{Digits: test.digits, Rule: test.rule}
+		if got := c.Quo(parse(test.x), parse(test.y)).String(); got != test.want {
+			t.Errorf("Quo(%s, %s, %d, %v) = %s; want %s", test.x, test.y, test.digits, test.rule, got, test.want)
+		}
+	}
+}
+
+func TestContextSqrt(t *testing.T) {
+	for _, test := range []struct {
+		x      string
+		digits int
+		want   string
+	}{
+		{"2", 30, "1.41421356237309504880168872421"},
+		{"4", 10, "2"},
+		{"0.25", 10, "0.5"},
+		{"4.00", 10, "2.0"},
+		{"1e4", 10, "1e+2"},
+		{"1e-7", 5, "0.00031623"},
+		{"1.44e10", 5, "1.2e+5"},
+		{"0", 5, "0"},
+	} {
+		c := decimal.Context{Digits: test.digits, Rule: bigfloat.HalfEven}
+		if got := c.Sqrt(parse(test.x)).String(); got != test.want {
+			t.Errorf("Sqrt(%s, %d) = %s; want %s", test.x, test.digits, got, test.want)
+		}
+	}
+
+	// √0.0625 is 0.25, a tie at one digit
+	c := decimal.Context{Digits: 1, Rule: bigfloat.HalfUp}
+	if got := c.Sqrt(parse("0.0625")).String(); got != "0.3" {
+		t.Errorf("Sqrt(0.0625, 1, HalfUp) = %s; want 0.3", got)
+	}
+}
+
+func TestContextFunctions(t *testing.T) {
+	c := decimal.Context{Digits: 30, Rule: bigfloat.HalfEven}
+	for _, test := range []struct {
+		name string
+		f    func() *decimal.Decimal
+		want string
+	}{
+		{"Exp(1)", func() *decimal.Decimal { return c.Exp(parse("1")) }, "2.71828182845904523536028747135"},
+		{"Exp(-1)", func() *decimal.Decimal { return c.Exp(parse("-1")) }, "0.367879441171442321595523770161"},
+		{"Exp(100)", func() *decimal.Decimal { return c.Exp(parse("100")) }, "2.68811714181613544841262555158e+43"},
+		{"Exp(1e-20)", func() *decimal.Decimal { return c.Exp(parse("1e-20")) }, "1.00000000000000000001000000000"},
+		{"Exp(0)", func() *decimal.Decimal { return c.Exp(parse("0")) }, "1"},
+		{"Log(10)", func() *decimal.Decimal { return c.Log(parse("10")) }, "2.30258509299404568401799145468"},
+		{"Log(0.5)", func() *decimal.Decimal { return c.Log(parse("0.5")) }, "-0.693147180559945309417232121458"},
+		{"Log(1.000001)", func() *decimal.Decimal { return c.Log(parse("1.000001")) }, "9.99999500000333333083333533333e-7"},
+		{"Log(1e-100)", func() *decimal.Decimal { return c.Log(parse("1e-100")) }, "-230.258509299404568401799145468"},
+		{"Log(1.000)", func() *decimal.Decimal { return c.Log(parse("1.000")) }, "0"},
+		{"Pow(2, 0.5)", func() *decimal.Decimal { return c.Pow(parse("2"), parse("0.5")) }, "1.41421356237309504880168872421"},
+		{"Pow(2, 100)", func() *decimal.Decimal { return c.Pow(parse("2"), parse("100")) }, "1.26765060022822940149670320538e+30"},
+		{"Pow(-2, 3)", func() *decimal.Decimal { return c.Pow(parse("-2"), parse("3")) }, "-8"},
+		{"Pow(-2, 2.0)", func() *decimal.Decimal { return c.Pow(parse("-2"), parse("2.0")) }, "4"},
+		{"Pow(1.05, -10)", func() *decimal.Decimal { return c.Pow(parse("1.05"), parse("-10")) }, "0.613913253540759374358546898604"},
+		{"Pow(0, 3)", func() *decimal.Decimal { return c.Pow(parse("0"), parse("3")) }, "0"},
+	} {
+		if got := test.f().String(); got != test.want {
+			t.Errorf("%s = %s; want %s", test.name, got, test.want)
+		}
+	}
+
+	// exact results that are ties are rounded with the rule
+	c = decimal.Context{Digits: 1, Rule: bigfloat.HalfEven}
+	if got := c.Pow(parse("6.25"), parse("0.5")).String(); got != "2" {
+		t.Errorf("Pow(6.25, 0.5) to one digit = %s; want 2", got)
+	}
+	if got := c.Pow(parse("2.5"), parse("1")).String(); got != "2" {
+		t.Errorf("Pow(2.5, 1) to one digit = %s; want 2", got)
+	}
+	c.Digits = 10
+	if got := c.Pow(parse("1.5"), parse("2")).String(); got != "2.25" {
+		t.Errorf("Pow(1.5, 2) = %s; want 2.25", got)
+	}
+}
+
+func TestContextPanics(t *testing.T) {
+	c := decimal.Context{Digits: 10, Rule: bigfloat.HalfEven}
+	for _, test := range []struct {
+		name string
+		f    func()
+	}{
+		{"Quo(1, 0)", func() { c.Quo(parse("1"), parse("0")) }},
+		{"Sqrt(-1)", func() { c.Sqrt(parse("-1")) }},
+		{"Log(0)", func() { c.Log(parse("0")) }},
+		{"Log(-1)", func() { c.Log(parse("-1")) }},
+		{"Pow(-2, 0.5)", func() { c.Pow(parse("-2"), parse("0.5")) }},
+		{"Pow(0, -1)", func() { c.Pow(parse("0"), parse("-1")) }},
+		{"Exp(1e20)", func() { c.Exp(parse("1e20")) }},
+		{"zero digits", func() { decimal.Context{}.Round(parse("1")) }},
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%s didn't panic", test.name)
+				}
+			}()
+			test.f()
+		}()
+	}
+}
+
+// ---------- Benchmarks ----------
+
+func BenchmarkContextExp(b *testing.B) {
+	x := parse("1.2345")
+	for _, digits := range []int{20, 100, 1000} {
+		c := decimal.Context{Digits: digits, Rule: bigfloat.HalfEven}
+		b.Run(fmt.Sprintf("%v", digits), func(b *testing.B) {
+			for n := 0; n < b.N; n++ {
+				_ = c.Exp(x)
+			}
+		})
+	}
+}
+
+func BenchmarkContextQuo(b *testing.B) {
+	x, y := parse("1"), parse("7")
+	for _, digits := range []int{20, 100, 1000} {
+		c := decimal.Context{Digits: digits, Rule: bigfloat.HalfEven}
+		b.Run(fmt.Sprintf("%v", digits), func(b *testing.B) {
+			for n := 0; n < b.N; n++ {
+				_ = c.Quo(x, y)
+			}
+		})
+	}
+}
@@ -0,0 +1,405 @@
+// Package decimal provides an arbitrary-precision decimal
+// floating-point type, for the domains where decimal inputs must not
+// be rounded to binary, as in financial and legal computations.
+//
+// A Decimal is a coefficient, an integer of any size, times a power
+// of ten. Additions, subtractions and multiplications are exact; the
+// divisions and the elementary functions are correctly rounded to the
+// number of significant digits of a Context, using one of the
+// bigfloat rounding rules, and Quantize rounds to a fixed number of
+// decimal places:
+//
+//	price, _ := decimal.Parse("19.99")
+//	rate, _ := decimal.Parse("0.0825")
+//	tax := price.Mul(rate).Quantize(-2, bigfloat.HalfEven) // 1.65
+//
+// The elementary functions are computed with the functions of
+// bigfloat, at increasing precisions until the decimal rounding of the
+// result is known.
+package decimal
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/ThreeAndTwo/bigfloat"
+)
+
+// A Decimal is the number coef·10**exp. The zero value is 0. A
+// Decimal is never modified after its creation, and its methods
+// return new values.
+type Decimal struct {
+	coef big.Int
+	exp  int
+}
+
+// New returns coef·10**exp.
+func New(coef *big.Int, exp int) *Decimal {
+
+	z := &Decimal{exp: exp}
+	z.coef.Set(coef)
+
+	return z
+}
+
+// NewInt64 returns coef·10**exp; for example, NewInt64(1999, -2) is
+// 19.99.
+func NewInt64(coef int64, exp int) *Decimal {
+
+	z := &Decimal{exp: exp}
+	z.coef.SetInt64(coef)
+
+	return z
+}
+
+// FromFloat returns the exact value of x, which is always a decimal
+// number, with as many digits as it needs: FromFloat of the float64
+// 0.1 has 55 significant digits. Context.FromFloat rounds it instead.
+// The function panics if x is infinite.
+func FromFloat(x *big.Float) *Decimal {
+
+	if x.IsInf() {
+		panic("FromFloat: infinite argument")
+	}
+
+	z := new(Decimal)
+	if x.Sign() == 0 {
+		return z
+	}
+
+	// x = m·2**e = m·5**-e·10**e
+	mant := new(big.Float)
+	e := x.MantExp(mant) - int(x.MinPrec())
+	mant.SetMantExp(mant, int(x.MinPrec()))
+	mant.Int(&z.coef)
+	if e >= 0 {
+		z.coef.Lsh(&z.coef, uint(e))
+	} else {
+		z.coef.Mul(&z.coef, new(big.Int).Exp(big.NewInt(5), big.NewInt(int64(-e)), nil))
+		z.exp = e
+	}
+
+	return z
+}
+
+// A ParseError records a failed call to Parse.
+type ParseError struct {
+	Input  string // the string given to Parse
+	Offset int    // byte offset in Input of the offending character
+	Msg    string // description of the problem
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("decimal.Parse: %s at offset %d in %q", e.Msg, e.Offset, e.Input)
+}
+
+// Parse returns the exact value of the decimal number in s: an
+// optional sign, digits with an optional decimal point, and an
+// optional exponent, "e" or "E" followed by an optionally signed
+// integer, as in "-12.50" or "1.5e-7". The coefficient keeps the
+// digits of s, so Parse("12.50") has the coefficient 1250 and the
+// exponent -2.
+//
+// If s isn't a valid number, the error is a *ParseError reporting the
+// position of the first invalid character.
+func Parse(s string) (*Decimal, error) {
+
+	i := 0
+	if i < len(s) && (s[i] == '+' || s[i] == '-') {
+		i++
+	}
+
+	var digits strings.Builder
+	digits.WriteString(s[:i])
+	point, exp, n := false, 0, 0
+	for ; i < len(s); i++ {
+		switch c := s[i]; {
+		case '0' <= c && c <= '9':
+			digits.WriteByte(c)
+			n++
+			if point {
+				exp--
+			}
+			continue
+		case c == '.' && !point:
+			point = true
+			continue
+		}
+		break
+	}
+	if n == 0 {
+		return nil, &ParseError{s, i, "missing digits"}
+	}
+
+	if i < len(s) && (s[i] == 'e' || s[i] == 'E') {
+		j := i + 1
+		if j < len(s) && (s[j] == '+' || s[j] == '-') {
+			j++
+		}
+		for j < len(s) && '0' <= s[j] && s[j] <= '9' {
+			j++
+		}
+		e, err := strconv.Atoi(s[i+1 : j])
+		if err != nil || e > math.MaxInt32 || e < math.MinInt32 {
+			return nil, &ParseError{s, i + 1, "invalid exponent"}
+		}
+		exp += e
+		i = j
+	}
+	if i < len(s) {
+		return nil, &ParseError{s, i, fmt.Sprintf("unexpected %q", s[i])}
+	}
+
+	z := &Decimal{exp: exp}
+	z.coef.SetString(digits.String(), 10)
+
+	return z, nil
+}
+
+// Coef returns the coefficient of x.
+func (x *Decimal) Coef() *big.Int {
+	return new(big.Int).Set(&x.coef)
+}
+
+// Exp returns the exponent of x.
+func (x *Decimal) Exp() int {
+	return x.exp
+}
+
+// Sign returns -1, 0 or +1 depending on the sign of x.
+func (x *Decimal) Sign() int {
+	return x.coef.Sign()
+}
+
+// IsInt reports whether x is an integer.
+func (x *Decimal) IsInt() bool {
+
+	if x.exp >= 0 || x.coef.Sign() == 0 {
+		return true
+	}
+	if numDigits(&x.coef) <= -x.exp {
+		return false
+	}
+
+	return new(big.Int).Rem(&x.coef, pow10(-x.exp)).Sign() == 0
+}
+
+// Cmp returns -1, 0 or +1 depending on whether x < y, x == y or
+// x > y. Values with different coefficients can be equal, as 1.5 and
+// 1.50 are.
+func (x *Decimal) Cmp(y *Decimal) int {
+
+	if sx, sy := x.Sign(), y.Sign(); sx != sy || sx == 0 {
+		return cmpInt(sx, sy)
+	}
+
+	// compare the positions of the leading digits first, to avoid
+	// scaling by large powers of ten
+	if ax, ay := x.adjExp(), y.adjExp(); ax != ay {
+		return cmpInt(ax, ay) * x.Sign()
+	}
+	cx, cy := align(x, y)
+
+	return cx.Cmp(cy)
+}
+
+// Neg returns -x.
+func (x *Decimal) Neg() *Decimal {
+
+	z := &Decimal{exp: x.exp}
+	z.coef.Neg(&x.coef)
+
+	return z
+}
+
+// Abs returns |x|.
+func (x *Decimal) Abs() *Decimal {
+
+	z := &Decimal{exp: x.exp}
+	z.coef.Abs(&x.coef)
+
+	return z
+}
+
+// Add returns x + y, exactly, with the smallest exponent of the two.
+func (x *Decimal) Add(y *Decimal) *Decimal {
+
+	cx, cy := align(x, y)
+	z := &Decimal{exp: minInt(x.exp, y.exp)}
+	z.coef.Add(cx, cy)
+
+	return z
+}
+
+// Sub returns x - y, exactly, with the smallest exponent of the two.
+func (x *Decimal) Sub(y *Decimal) *Decimal {
+	return x.Add(y.Neg())
+}
+
+// Mul returns x·y, exactly.
+func (x *Decimal) Mul(y *Decimal) *Decimal {
+
+	z := &Decimal{exp: x.exp + y.exp}
+	z.coef.Mul(&x.coef, &y.coef)
+
+	return z
+}
+
+// Quantize returns x rounded to a multiple of 10**exp, using the
+// given rule, with the exponent exp: Quantize(-2, rule) rounds to
+// cents, and gives 2.50 for 2.5.
+func (x *Decimal) Quantize(exp int, rule bigfloat.RoundingRule) *Decimal {
+
+	z := &Decimal{exp: exp}
+	if x.exp >= exp {
+		z.coef.Mul(&x.coef, pow10(x.exp-exp))
+		return z
+	}
+	z.coef.Set(roundShift(&x.coef, exp-x.exp, rule))
+
+	return z
+}
+
+// Float returns x rounded to nearest even to prec bits (64 if prec is
+// 0).
+func (x *Decimal) Float(prec uint) *big.Float {
+
+	if prec == 0 {
+		prec = 64
+	}
+
+	z := new(big.Float).SetPrec(prec)
+	if x.exp >= 0 {
+		return z.SetInt(new(big.Int).Mul(&x.coef, pow10(x.exp)))
+	}
+	f, _ := bigfloat.FromRat(x.Rat(), prec)
+
+	return f
+}
+
+// Rat returns x as a big.Rat.
+func (x *Decimal) Rat() *big.Rat {
+
+	if x.exp >= 0 {
+		return new(big.Rat).SetInt(new(big.Int).Mul(&x.coef, pow10(x.exp)))
+	}
+
+	return new(big.Rat).SetFrac(&x.coef, pow10(-x.exp))
+}
+
+// String returns x in the notation of the General Decimal Arithmetic
+// specification: without an exponent if it's not positive and the
+// leading digit isn't beyond the sixth decimal place, as in "12.50"
+// and "0.000001", and in the exponent notation otherwise, as in
+// "1.5e+7" and "1.2e-8".
+func (x *Decimal) String() string {
+
+	var b strings.Builder
+	s := x.coef.String()
+	if s[0] == '-' {
+		b.WriteByte('-')
+		s = s[1:]
+	}
+
+	adj := x.exp + len(s) - 1
+	switch {
+	case x.exp <= 0 && adj >= -6:
+		if x.exp == 0 {
+			b.WriteString(s)
+			break
+		}
+		if point := len(s) + x.exp; point > 0 {
+			b.WriteString(s[:point])
+			b.WriteByte('.')
+			b.WriteString(s[point:])
+		} else {
+			b.WriteString("0.")
+			b.WriteString(strings.Repeat("0", -point))
+			b.WriteString(s)
+		}
+	default:
+		b.WriteByte(s[0])
+		if len(s) > 1 {
+			b.WriteByte('.')
+			b.WriteString(s[1:])
+		}
+		b.WriteByte('e')
+		if adj >= 0 {
+			b.WriteByte('+')
+		}
+		b.WriteString(strconv.Itoa(adj))
+	}
+
+	return b.String()
+}
+
+// adjExp returns the exponent of the leading digit of x, which must
+// not be 0.
+func (x *Decimal) adjExp() int {
+	return x.exp + numDigits(&x.coef) - 1
+}
+
+// align returns the coefficients of x and y scaled to the smallest of
+// their exponents.
+func align(x, y *Decimal) (cx, cy *big.Int) {
+
+	cx, cy = &x.coef, &y.coef
+	switch {
+	case x.exp > y.exp:
+		cx = new(big.Int).Mul(cx, pow10(x.exp-y.exp))
+	case y.exp > x.exp:
+		cy = new(big.Int).Mul(cy, pow10(y.exp-x.exp))
+	}
+
+	return cx, cy
+}
+
+// roundShift returns c/10**n rounded to an integer with the given
+// rule, for n > 0.
+func roundShift(c *big.Int, n int, rule bigfloat.RoundingRule) *big.Int {
+	return bigfloat.RoundQuo(c, pow10(n), rule)
+}
+
+// numDigits returns the number of decimal digits of |c|, 1 for 0.
+func numDigits(c *big.Int) int {
+
+	if c.Sign() == 0 {
+		return 1
+	}
+
+	// the estimate from the number of bits is exact or one too small
+	const log10of2 = 0.30102999566398120
+	d := int(float64(c.BitLen()-1)*log10of2) + 1
+	if new(big.Int).Abs(c).Cmp(pow10(d)) >= 0 {
+		d++
+	}
+
+	return d
+}
+
+// pow10 returns 10**n, for n >= 0.
+func pow10(n int) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+}
+
+func cmpInt(x, y int) int {
+
+	switch {
+	case x < y:
+		return -1
+	case x > y:
+		return +1
+	}
+
+	return 0
+}
+
+func minInt(x, y int) int {
+	if x < y {
+		return x
+	}
+	return y
+}
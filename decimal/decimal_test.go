@@ -0,0 +1,194 @@
+package decimal_test
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ThreeAndTwo/bigfloat"
+	"github.com/ThreeAndTwo/bigfloat/decimal"
+)
+
+func parse(s string) *decimal.Decimal {
+	x, err := decimal.Parse(s)
+	if err != nil {
+		panic(err)
+	}
+	return x
+}
+
+func TestParse(t *testing.T) {
+	for _, test := range []struct {
+		s    string
+		coef string
+		exp  int
+		str  string
+	}{
+		{"0", "0", 0, "0"},
+		{"12.50", "1250", -2, "12.50"},
+		{"-12.50", "-1250", -2, "-12.50"},
+		{"+7", "7", 0, "7"},
+		{".5", "5", -1, "0.5"},
+		{"5.", "5", 0, "5"},
+		{"1.5e-7", "15", -8, "1.5e-7"},
+		{"1.5E7", "15", 6, "1.5e+7"},
+		{"0.000001", "1", -6, "0.000001"},
+		{"0.0000001", "1", -7, "1e-7"},
+		{"100e-2", "100", -2, "1.00"},
+		{"0.00", "0", -2, "0.00"},
+		{"123456789012345678901234567890", "123456789012345678901234567890", 0, "123456789012345678901234567890"},
+	} {
+		x, err := decimal.Parse(test.s)
+		if err != nil {
+			t.Errorf("Parse(%q) failed: %v", test.s, err)
+			continue
+		}
+		if x.Coef().String() != test.coef || x.Exp() != test.exp {
+			t.Errorf("Parse(%q) = %s·10**%d; want %s·10**%d", test.s, x.Coef(), x.Exp(), test.coef, test.exp)
+		}
+		if s := x.String(); s != test.str {
+			t.Errorf("Parse(%q).String() = %s; want %s", test.s, s, test.str)
+		}
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, test := range []struct {
+		s      string
+		offset int
+	}{
+		{"", 0},
+		{"-", 1},
+		{".", 1},
+		{"1.2.3", 3},
+		{"12x", 2},
+		{"1e", 2},
+		{"1e+", 2},
+		{"1e99999999999", 2},
+		{"1e5 ", 3},
+	} {
+		_, err := decimal.Parse(test.s)
+		var perr *decimal.ParseError
+		if !errors.As(err, &perr) {
+			t.Errorf("Parse(%q) error = %v; want a *ParseError", test.s, err)
+			continue
+		}
+		if perr.Offset != test.offset || perr.Input != test.s {
+			t.Errorf("Parse(%q) error at offset %d; want %d", test.s, perr.Offset, test.offset)
+		}
+	}
+}
+
+func TestArithmetic(t *testing.T) {
+	for _, test := range []struct {
+		x, y          string
+		add, sub, mul string
+		cmp           int
+	}{
+		{"1.5", "1.50", "3.00", "0.00", "2.250", 0},
+		{"0.1", "0.2", "0.3", "-0.1", "0.02", -1},
+		{"19.99", "-0.01", "19.98", "20.00", "-0.1999", 1},
+		{"1e10", "1e-10", "10000000000.0000000001", "9999999999.9999999999", "1", 1},
+		{"-3", "-20", "-23", "17", "60", 1},
+		{"0", "-0.5", "-0.5", "0.5", "0.0", 1},
+	} {
+		x, y := parse(test.x), parse(test.y)
+		if got := x.Add(y).String(); got != test.add {
+			t.Errorf("%s + %s = %s; want %s", test.x, test.y, got, test.add)
+		}
+		if got := x.Sub(y).String(); got != test.sub {
+			t.Errorf("%s - %s = %s; want %s", test.x, test.y, got, test.sub)
+		}
+		if got := x.Mul(y).String(); got != test.mul {
+			t.Errorf("%s · %s = %s; want %s", test.x, test.y, got, test.mul)
+		}
+		if got := x.Cmp(y); got != test.cmp {
+			t.Errorf("Cmp(%s, %s) = %d; want %d", test.x, test.y, got, test.cmp)
+		}
+		if got := y.Cmp(x); got != -test.cmp {
+			t.Errorf("Cmp(%s, %s) = %d; want %d", test.y, test.x, got, -test.cmp)
+		}
+	}
+
+	// the operands aren't modified
+	x := parse("1.25")
+	_, _, _ = x.Neg(), x.Add(x), x.Mul(x)
+	if x.String() != "1.25" {
+		t.Errorf("x = %s after the operations; want 1.25", x)
+	}
+}
+
+func TestQuantize(t *testing.T) {
+	for _, test := range []struct {
+		x    string
+		exp  int
+		rule bigfloat.RoundingRule
+		want string
+	}{
+		{"2.5", -2, bigfloat.HalfEven, "2.50"},
+		{"1.645", -2, bigfloat.HalfEven, "1.64"},
+		{"1.655", -2, bigfloat.HalfEven, "1.66"},
+		{"1.645", -2, bigfloat.HalfUp, "1.65"},
+		{"-1.645", -2, bigfloat.HalfUp, "-1.64"},
+		{"-1.645", -2, bigfloat.HalfAwayFromZero, "-1.65"},
+		{"-1.649", -2, bigfloat.TowardZero, "-1.64"},
+		{"1.6451", -2, bigfloat.HalfEven, "1.65"},
+		{"0.004", -2, bigfloat.HalfEven, "0.00"},
+		{"99.995", -2, bigfloat.HalfEven, "100.00"},
+		{"1234.5", 2, bigfloat.HalfEven, "1.2e+3"},
+		{"-0.5", 0, bigfloat.HalfEven, "0"},
+	} {
+		got := parse(test.x).Quantize(test.exp, test.rule)
+		if got.String() != test.want || got.Exp() != test.exp {
+			t.Errorf("Quantize(%s, %d, %v) = %s (exponent %d); want %s", test.x, test.exp, test.rule, got, got.Exp(), test.want)
+		}
+	}
+
+	// the example of the package documentation
+	tax := parse("19.99").Mul(parse("0.0825")).Quantize(-2, bigfloat.HalfEven)
+	if tax.String() != "1.65" {
+		t.Errorf("tax = %s; want 1.65", tax)
+	}
+}
+
+func TestIsInt(t *testing.T) {
+	for _, test := range []struct {
+		x    string
+		want bool
+	}{
+		{"0", true}, {"0.000", true}, {"12", true}, {"12.00", true},
+		{"1e3", true}, {"12.01", false}, {"0.5", false}, {"-300e-2", true},
+	} {
+		if got := parse(test.x).IsInt(); got != test.want {
+			t.Errorf("IsInt(%s) = %v; want %v", test.x, got, test.want)
+		}
+	}
+}
+
+func TestFloatConversions(t *testing.T) {
+
+	// the float64 0.1 is exactly a decimal with 55 digits
+	x := decimal.FromFloat(big.NewFloat(0.1))
+	want := "0.1000000000000000055511151231257827021181583404541015625"
+	if x.String() != want {
+		t.Errorf("FromFloat(0.1) = %s; want %s", x, want)
+	}
+	if f := x.Float(53); f.Cmp(big.NewFloat(0.1)) != 0 {
+		t.Errorf("FromFloat(0.1).Float(53) = %g; want 0.1", f)
+	}
+
+	for _, f := range []float64{0, 1, -2.5, 1e300, 0x1p-1074, 123456.789} {
+		x := decimal.FromFloat(big.NewFloat(f))
+		if g, _ := x.Float(53).Float64(); g != f {
+			t.Errorf("FromFloat(%g).Float(53) = %g", f, g)
+		}
+	}
+
+	// Float rounds to nearest even
+	if f := parse("0.1").Float(24); f.Cmp(new(big.Float).SetPrec(24).SetFloat64(0.1)) != 0 {
+		t.Errorf("Float(0.1, 24) = %g", f)
+	}
+	if r := parse("-12.50").Rat(); r.Cmp(big.NewRat(-25, 2)) != 0 {
+		t.Errorf("Rat(-12.50) = %v; want -25/2", r)
+	}
+}
@@ -0,0 +1,322 @@
+package bigfloat
+
+import (
+	"errors"
+	"math/big"
+)
+
+// A DecimalEncoding selects one of the two encodings of the IEEE
+// 754-2008 decimal interchange formats.
+type DecimalEncoding int
+
+const (
+	BID DecimalEncoding = iota // binary integer decimal, the coefficient as a binary integer
+	DPD                        // densely packed decimal, the coefficient as groups of 3 digits in 10 bits
+)
+
+// decimal128 parameters
+const (
+	dec128Digits = 34
+	dec128Bias   = 6176
+	dec128QMin   = -dec128Bias             // smallest exponent of the coefficient
+	dec128QMax   = 6144 - dec128Digits + 1 // largest exponent of the coefficient
+)
+
+// EncodeDecimal128 returns the IEEE 754-2008 decimal128 encoding of
+// x, as 16 bytes in big-endian order, using the given encoding.
+//
+// x is rounded to 34 significant decimal digits using the given rule,
+// or to fewer for values below 10**-6143, which are encoded as
+// subnormals. Values too large are encoded as infinities, or as the
+// largest finite value if the rule is TowardZero. Of the possible
+// encodings of the rounded value, the one with the largest exponent
+// is used; zero is encoded with exponent 0. The accuracy reports
+// whether the encoded value is exactly x, or if it's smaller or
+// larger.
+func EncodeDecimal128(x *big.Float, enc DecimalEncoding, rule RoundingRule) ([]byte, big.Accuracy) {
+
+	neg := x.Signbit()
+	c := new(big.Int)
+	q := 0
+	inf := x.IsInf()
+
+	if !inf && x.Sign() != 0 {
+		// |x| >= 2**20414 > 10**6145 overflows; |x| < 2**-20530 is
+		// less than half the smallest subnormal, and it's replaced by
+		// a number with the same sign and rounding, and a much smaller
+		// exponent to work with
+		switch e := x.MantExp(nil); {
+		case e > 20414:
+			x = new(big.Float).SetMantExp(big.NewFloat(0.5), 20415)
+			if neg {
+				x.Neg(x)
+			}
+		case e < -20530:
+			x = new(big.Float).SetMantExp(big.NewFloat(0.5), -20530)
+			if neg {
+				x.Neg(x)
+			}
+		}
+
+		c, q = sigDigits(x, dec128Digits, rule)
+		if q < dec128QMin {
+			// subnormal: fewer digits, at the smallest exponent
+			num, den := scaleInt(x, 10, -dec128QMin)
+			c, q = roundQuo(num, den, rule, neg), dec128QMin
+		}
+
+		// remove the trailing zeros, or add them to bring the
+		// exponent in range
+		ten := big.NewInt(10)
+		r := new(big.Int)
+		for c.Sign() != 0 && q < dec128QMax {
+			t, _ := new(big.Int).QuoRem(c, ten, r)
+			if r.Sign() != 0 {
+				break
+			}
+			c, q = t, q+1
+		}
+		for q > dec128QMax && c.Cmp(pow10(dec128Digits-1)) < 0 {
+			c.Mul(c, ten)
+			q--
+		}
+		if q > dec128QMax {
+			if rule == TowardZero {
+				c, q = c.Sub(pow10(dec128Digits), big.NewInt(1)), dec128QMax
+			} else {
+				inf = true
+			}
+		}
+		if c.Sign() == 0 {
+			q = 0
+		}
+	}
+
+	var bits *big.Int
+	switch {
+	case inf:
+		// 11110 in the combination field
+		bits = big.NewInt(0x1e)
+		bits.Lsh(bits, 122)
+	case enc == DPD:
+		bits = dpdEncode(c, q+dec128Bias)
+	default:
+		bits = big.NewInt(int64(q + dec128Bias))
+		bits.Lsh(bits, 113).Or(bits, c)
+	}
+	if neg {
+		bits.SetBit(bits, 127, 1)
+	}
+	b := bits.FillBytes(make([]byte, 16))
+
+	// the accuracy is found by comparing the encoded value with x
+	var acc big.Accuracy
+	if inf {
+		if !x.IsInf() {
+			acc = big.Above
+			if neg {
+				acc = big.Below
+			}
+		}
+		return b, acc
+	}
+	v := new(big.Rat).SetInt(c)
+	if q >= 0 {
+		v.Mul(v, new(big.Rat).SetInt(pow10(q)))
+	} else {
+		v.Quo(v, new(big.Rat).SetInt(pow10(-q)))
+	}
+	if neg {
+		v.Neg(v)
+	}
+	switch v.Cmp(ToRat(x)) {
+	case -1:
+		acc = big.Below
+	case 1:
+		acc = big.Above
+	}
+
+	return b, acc
+}
+
+// DecodeDecimal128 returns the value of the IEEE 754-2008 decimal128
+// encoding b, given as 16 bytes in big-endian order with the given
+// encoding, rounded to prec bits using the ToNearestEven rounding
+// mode, and the accuracy of the result. Non-canonical encodings are
+// decoded as specified by the standard: BID coefficients larger than
+// 10**34 - 1 are taken as 0, and the redundant DPD declets give the
+// digits 8 or 9 they stand for. The function returns ErrNaN if b
+// encodes a NaN.
+func DecodeDecimal128(b []byte, enc DecimalEncoding, prec uint) (*big.Float, big.Accuracy, error) {
+
+	if len(b) != 16 {
+		return nil, big.Exact, errors.New("bigfloat: invalid decimal128 length")
+	}
+
+	bits := new(big.Int).SetBytes(b)
+	neg := bits.Bit(127) == 1
+	g := new(big.Int).Rsh(bits, 122).Int64() & 0x1f // top of the combination field
+
+	z := new(big.Float).SetPrec(prec)
+	switch g {
+	case 0x1f:
+		return nil, big.Exact, ErrNaN
+	case 0x1e:
+		return z.SetInf(neg), big.Exact, nil
+	}
+
+	var c *big.Int
+	var e int
+	if enc == DPD {
+		c, e = dpdDecode(bits, g)
+	} else {
+		mask := new(big.Int).Lsh(big.NewInt(1), 113)
+		mask.Sub(mask, big.NewInt(1))
+		if g>>3 == 3 {
+			// the coefficient would be at least 2**113: non-canonical
+			e = int(new(big.Int).Rsh(bits, 111).Int64() & 0x3fff)
+			c = new(big.Int)
+		} else {
+			e = int(new(big.Int).Rsh(bits, 113).Int64() & 0x3fff)
+			c = mask.And(mask, bits)
+		}
+		if c.Cmp(pow10(dec128Digits)) >= 0 {
+			c.SetInt64(0)
+		}
+	}
+	q := e - dec128Bias
+
+	if neg {
+		c.Neg(c)
+	}
+	switch {
+	case c.Sign() == 0:
+		if neg {
+			z.Neg(z)
+		}
+		return z, big.Exact, nil
+	case q >= 0:
+		z.SetInt(c.Mul(c, pow10(q)))
+	default:
+		// both operands are exact, so Quo rounds a single time
+		n := new(big.Float).SetInt(c)
+		z.Quo(n, new(big.Float).SetInt(pow10(-q)))
+	}
+
+	return z, z.Acc(), nil
+}
+
+// dpdEncode returns the DPD encoding (without the sign) of the
+// coefficient c, of at most 34 digits, with biased exponent e.
+func dpdEncode(c *big.Int, e int) *big.Int {
+
+	// the digits of c, most significant first
+	var d [dec128Digits]int
+	t, r := new(big.Int).Set(c), new(big.Int)
+	ten := big.NewInt(10)
+	for i := dec128Digits - 1; i >= 0; i-- {
+		t.QuoRem(t, ten, r)
+		d[i] = int(r.Int64())
+	}
+
+	// the combination field holds the leading digit and the two top
+	// bits of the exponent
+	var comb int
+	if d[0] < 8 {
+		comb = (e>>12)<<3 | d[0]
+	} else {
+		comb = 0x18 | (e>>12)<<1 | (d[0] - 8)
+	}
+
+	bits := big.NewInt(int64(comb<<12 | e&0xfff))
+	for i := 1; i < dec128Digits; i += 3 {
+		bits.Lsh(bits, 10)
+		bits.Or(bits, big.NewInt(int64(dpdDeclet(d[i], d[i+1], d[i+2]))))
+	}
+
+	return bits
+}
+
+// dpdDecode returns the coefficient and the biased exponent of the
+// DPD encoding bits, with g the top 5 bits of the combination field.
+func dpdDecode(bits *big.Int, g int64) (*big.Int, int) {
+
+	var lead, etop int64
+	if g>>3 == 3 {
+		lead, etop = 8+g&1, (g>>1)&3
+	} else {
+		lead, etop = g&7, g>>3
+	}
+	e := int(etop<<12 | new(big.Int).Rsh(bits, 110).Int64()&0xfff)
+
+	c := big.NewInt(lead)
+	t := new(big.Int)
+	thousand := big.NewInt(1000)
+	for i := 10; i >= 0; i-- {
+		declet := t.Rsh(bits, uint(10*i)).Int64() & 0x3ff
+		a, b, d := dpdDigits(int(declet))
+		c.Mul(c, thousand)
+		c.Add(c, big.NewInt(int64(a*100+b*10+d)))
+	}
+
+	return c, e
+}
+
+// dpdDeclet returns the 10-bit densely packed encoding of the decimal
+// digits a, b, c.
+func dpdDeclet(a, b, c int) int {
+
+	// the three low bits of the digits, and which are 8 or 9
+	al, bl, cl := a&7, b&7, c&7
+	switch a>>3<<2 | b>>3<<1 | c>>3 {
+	case 0: // all small
+		return al<<7 | bl<<4 | cl
+	case 1: // c large
+		return al<<7 | bl<<4 | 0x8 | c&1
+	case 2: // b large
+		return al<<7 | (c&6)<<4 | (b&1)<<4 | 0xa | c&1
+	case 4: // a large
+		return (c&6)<<7 | (a&1)<<7 | bl<<4 | 0xc | c&1
+	case 6: // a, b large
+		return (c&6)<<7 | (a&1)<<7 | (b&1)<<4 | 0xe | c&1
+	case 5: // a, c large
+		return (b&6)<<7 | (a&1)<<7 | 0x20 | (b&1)<<4 | 0xe | c&1
+	case 3: // b, c large
+		return al<<7 | 0x40 | (b&1)<<4 | 0xe | c&1
+	}
+
+	// all large
+	return (a&1)<<7 | 0x60 | (b&1)<<4 | 0xe | c&1
+}
+
+// dpdDigits returns the three decimal digits encoded by the 10-bit
+// declet d, canonical or not.
+func dpdDigits(d int) (a, b, c int) {
+
+	// bits p q r s t u v w x y, from the most significant
+	pqr, stu, wxy := d>>7, (d>>4)&7, d&7
+	r, u, y := pqr&1, stu&1, wxy&1
+	pq, st := pqr&6, stu&6
+
+	if d&8 == 0 {
+		return pqr, stu, wxy
+	}
+	switch (d >> 1) & 3 { // wx
+	case 0:
+		return pqr, stu, 8 + y
+	case 1:
+		return pqr, 8 + u, st | y
+	case 2:
+		return 8 + r, stu, pq | y
+	}
+	switch st >> 1 {
+	case 0:
+		return 8 + r, 8 + u, pq | y
+	case 1:
+		return 8 + r, pq | u, 8 + y
+	case 2:
+		return pq | r, 8 + u, 8 + y
+	}
+
+	return 8 + r, 8 + u, 8 + y
+}
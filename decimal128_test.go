@@ -0,0 +1,145 @@
+package bigfloat
+
+import (
+	"encoding/hex"
+	"math/big"
+	"testing"
+)
+
+func TestDPDDeclets(t *testing.T) {
+	// every 3-digit group round trips
+	for n := 0; n < 1000; n++ {
+		d := dpdDeclet(n/100, n/10%10, n%10)
+		if a, b, c := dpdDigits(d); a*100+b*10+c != n {
+			t.Errorf("dpdDigits(dpdDeclet(%03d)) = %d%d%d (declet %03x)", n, a, b, c, d)
+		}
+	}
+
+	// every declet, canonical or not, decodes to valid digits
+	seen := make(map[[3]int]int)
+	for d := 0; d < 1024; d++ {
+		a, b, c := dpdDigits(d)
+		if a > 9 || b > 9 || c > 9 {
+			t.Errorf("dpdDigits(%03x) = %d, %d, %d", d, a, b, c)
+		}
+		seen[[3]int{a, b, c}]++
+	}
+	if len(seen) != 1000 {
+		t.Errorf("declets decode to %d digit groups; want 1000", len(seen))
+	}
+
+	// known encodings
+	for _, test := range []struct{ a, b, c, d int }{
+		{0, 0, 5, 0x005}, {0, 0, 8, 0x008}, {0, 7, 5, 0x075}, {9, 9, 9, 0x0ff}, {1, 2, 3, 0x0a3},
+	} {
+		if d := dpdDeclet(test.a, test.b, test.c); d != test.d {
+			t.Errorf("dpdDeclet(%d%d%d) = %03x; want %03x", test.a, test.b, test.c, d, test.d)
+		}
+	}
+}
+
+func TestEncodeDecimal128(t *testing.T) {
+	parse := func(s string) *big.Float {
+		// slightly below the decimal value
+		x, _, _ := new(big.Float).SetPrec(200).SetMode(big.ToNegativeInf).Parse(s, 10)
+		return x
+	}
+	max := "9.999999999999999999999999999999999e6144"
+
+	for _, test := range []struct {
+		x        *big.Float
+		rule     RoundingRule
+		bid, dpd string
+		acc      big.Accuracy
+	}{
+		{big.NewFloat(1), HalfEven, "30400000000000000000000000000001", "22080000000000000000000000000001", big.Exact},
+		{big.NewFloat(-7.5), HalfEven, "b03e000000000000000000000000004b", "a207c000000000000000000000000075", big.Exact},
+		{big.NewFloat(100), HalfEven, "30440000000000000000000000000001", "22088000000000000000000000000001", big.Exact},
+		{big.NewFloat(0), HalfEven, "30400000000000000000000000000000", "22080000000000000000000000000000", big.Exact},
+		{new(big.Float).SetInf(true), HalfEven, "f8000000000000000000000000000000", "f8000000000000000000000000000000", big.Exact},
+		{parse(max), HalfEven, "", "77ffcff3fcff3fcff3fcff3fcff3fcff", big.Above},
+		{parse("1e6145"), HalfEven, "78000000000000000000000000000000", "78000000000000000000000000000000", big.Above},
+		{parse("1e6145"), TowardZero, "", "77ffcff3fcff3fcff3fcff3fcff3fcff", big.Below},
+		{parse("1e-6176"), HalfEven, "00000000000000000000000000000001", "00000000000000000000000000000001", big.Above},
+		{parse("4e-6177"), HalfEven, "30400000000000000000000000000000", "22080000000000000000000000000000", big.Below},
+		{new(big.Float).SetMantExp(big.NewFloat(-1), -100000), HalfEven, "b0400000000000000000000000000000", "a2080000000000000000000000000000", big.Above},
+		{new(big.Float).SetMantExp(big.NewFloat(1), 100000), HalfEven, "78000000000000000000000000000000", "78000000000000000000000000000000", big.Above},
+	} {
+		for _, enc := range []DecimalEncoding{BID, DPD} {
+			want := test.bid
+			if enc == DPD {
+				want = test.dpd
+			}
+			if want == "" {
+				continue
+			}
+			b, acc := EncodeDecimal128(test.x, enc, test.rule)
+			if s := hex.EncodeToString(b); s != want || acc != test.acc {
+				t.Errorf("EncodeDecimal128(%.10g, %d, %v) = %s (%v); want %s (%v)", test.x, enc, test.rule, s, acc, want, test.acc)
+			}
+		}
+	}
+}
+
+func TestDecimal128RoundTrip(t *testing.T) {
+	for _, s := range []string{
+		"0.1", "-123.456", "3.141592653589793238462643383279503", "1e-6176", "1.5e-6170",
+		"9.999999999999999999999999999999999e6144", "1e-6143", "42",
+	} {
+		x, _, _ := new(big.Float).SetPrec(300).Parse(s, 10)
+		for _, enc := range []DecimalEncoding{BID, DPD} {
+			b, _ := EncodeDecimal128(x, enc, HalfEven)
+			z, _, err := DecodeDecimal128(b, enc, 300)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// both rounded to 34 digits, so they're the same
+			if RoundSigDigitsString(z, 34) != RoundSigDigitsString(x, 34) {
+				t.Errorf("enc %d: DecodeDecimal128(EncodeDecimal128(%s)) = %g", enc, s, z)
+			}
+		}
+	}
+}
+
+func TestDecodeDecimal128(t *testing.T) {
+	for _, test := range []struct {
+		s    string
+		enc  DecimalEncoding
+		want string
+		acc  big.Accuracy
+	}{
+		{"30400000000000000000000000000001", BID, "1", big.Exact},
+		{"303e0000000000000000000000000001", BID, "0.1", big.Above},
+		{"2207c000000000000000000000000075", DPD, "7.5", big.Exact},
+		{"220800000000000000000000000003ff", DPD, "999", big.Exact}, // non-canonical declet
+		{"3041ed09bead87c0378d8e6400000000", BID, "0", big.Exact},   // coefficient 10**34
+		{"6c000000000000000000000000000001", BID, "0", big.Exact},   // 11 form
+		{"f8000000000000000000000000000000", DPD, "-Inf", big.Exact},
+	} {
+		b, _ := hex.DecodeString(test.s)
+		z, acc, err := DecodeDecimal128(b, test.enc, 53)
+		if err != nil {
+			t.Errorf("DecodeDecimal128(%s) error: %v", test.s, err)
+			continue
+		}
+		want, _, _ := new(big.Float).SetPrec(53).Parse(test.want, 10)
+		if z.Cmp(want) != 0 || acc != test.acc {
+			t.Errorf("DecodeDecimal128(%s, %d) = %g (%v); want %s (%v)", test.s, test.enc, z, acc, test.want, test.acc)
+		}
+	}
+
+	nan, _ := hex.DecodeString("7c000000000000000000000000000000")
+	if _, _, err := DecodeDecimal128(nan, BID, 53); err != ErrNaN {
+		t.Errorf("DecodeDecimal128(NaN) error = %v; want ErrNaN", err)
+	}
+}
+
+// ---------- Benchmarks ----------
+
+func BenchmarkEncodeDecimal128(b *testing.B) {
+	x := Sqrt(new(big.Float).SetPrec(200).SetInt64(2))
+	for n := 0; n < b.N; n++ {
+		EncodeDecimal128(x, DPD, HalfEven)
+	}
+}
@@ -0,0 +1,22 @@
+package bigfloat
+
+import "math/big"
+
+// A Diagnostics reports how an iterative function computed its result,
+// to tune the precisions and the limits given to it, or to find out
+// why it didn't converge. It's filled by the functions that take one,
+// such as SumSeries with SeriesDiagnostics and RombergDiagnostics.
+type Diagnostics struct {
+	Iterations int        // iterations, or terms, of the last pass
+	Correction *big.Float // magnitude of the last correction, or term
+	Prec       uint       // working precision of the last pass
+	Passes     int        // passes, at increasing working precisions
+	Converged  bool       // false if the result is the one at the limit
+}
+
+// set fills d, if it's not nil.
+func (d *Diagnostics) set(iterations int, correction *big.Float, prec uint, passes int, converged bool) {
+	if d != nil {
+		*d = Diagnostics{iterations, correction, prec, passes, converged}
+	}
+}
@@ -0,0 +1,127 @@
+package dist
+
+import (
+	"math/big"
+
+	"github.com/ThreeAndTwo/bigfloat"
+)
+
+// BetaCDF returns the distribution function P(X <= x) of the beta
+// distribution with the positive shapes a and b, the regularized
+// incomplete beta function I(x; a, b). Precision is the same as the
+// one of x (64 if it is 0). It is 0 for x <= 0 and 1 for x >= 1. The
+// function panics if a or b is not positive and finite.
+func BetaCDF(a, b, x *big.Float) *big.Float {
+
+	prec := precOf(x)
+	checkBeta("BetaCDF", a, b)
+	z := new(big.Float).SetPrec(prec)
+	switch {
+	case x.Sign() <= 0:
+		return z
+	case x.Cmp(big.NewFloat(1)) >= 0:
+		return z.SetInt64(1)
+	}
+
+	return bigfloat.BetaInc(a, b, x)
+}
+
+// BetaSF returns the survival function P(X > x) = 1 - BetaCDF(a, b, x)
+// of the beta distribution, which keeps its relative accuracy for x
+// near 1. Precision is the same as the one of x (64 if it is 0). The
+// function panics as BetaCDF.
+func BetaSF(a, b, x *big.Float) *big.Float {
+
+	prec := precOf(x)
+	checkBeta("BetaSF", a, b)
+	z := new(big.Float).SetPrec(prec)
+	switch {
+	case x.Sign() <= 0:
+		return z.SetInt64(1)
+	case x.Cmp(big.NewFloat(1)) >= 0:
+		return z
+	}
+
+	return bigfloat.BetaIncC(a, b, x)
+}
+
+// BetaQuantile returns the x with BetaCDF(a, b, x) = p, for p in
+// [0, 1]. Precision is the same as the one of p (64 if it is 0). The
+// quantiles of p = 0 and 1 are 0 and 1. The quantiles above 1/2 are
+// found as 1 - y, with y the quantile of 1 - p, which is exact, for
+// the beta distribution of 1 - X, with the shapes swapped, so that
+// both x and 1 - x keep their relative accuracy when they are small.
+// The function panics if a or b is not positive and finite, or if p
+// is not in [0, 1].
+func BetaQuantile(a, b, p *big.Float) *big.Float {
+
+	prec := precOf(p)
+	checkBeta("BetaQuantile", a, b)
+	checkProb("BetaQuantile", p)
+	z := new(big.Float).SetPrec(prec)
+	one := big.NewFloat(1)
+	switch {
+	case p.Sign() == 0:
+		return z
+	case p.Cmp(one) == 0:
+		return z.SetInt64(1)
+	}
+
+	// the quantile is above 1/2 if p is above the median I(1/2; a, b)
+	wprec := prec + 64
+	half := new(big.Float).SetPrec(wprec).SetFloat64(0.5)
+	if p.Cmp(bigfloat.BetaInc(a, b, half)) <= 0 {
+		return z.Set(betaQuantile(a, b, p, prec+2))
+	}
+	q := new(big.Float).SetPrec(wprec).Sub(one, p)
+	y := betaQuantile(b, a, q, prec+2)
+
+	return z.Sub(one, y)
+}
+
+// betaQuantile returns the x <= 1/2 with I(x; a, b) = p, for p in
+// (0, I(1/2; a, b)], to prec bits, by Newton's method on log(I).
+func betaQuantile(a, b, p *big.Float, prec uint) *big.Float {
+
+	wprec := prec + 64
+	logP := bigfloat.Log(new(big.Float).SetPrec(wprec).Set(p))
+	one := big.NewFloat(1)
+
+	// log(x·f(x)) = a·log(x) + (b-1)·log(1-x) - log B(a, b), with
+	// log B(a, b) = log Γ(a) + log Γ(b) - log Γ(a+b)
+	aw := new(big.Float).SetPrec(wprec).Set(a)
+	bw := new(big.Float).SetPrec(wprec).Set(b)
+	lb, _ := bigfloat.Lgamma(aw)
+	lg, _ := bigfloat.Lgamma(bw)
+	lb.Add(lb, lg)
+	lg, _ = bigfloat.Lgamma(new(big.Float).SetPrec(wprec).Add(aw, bw))
+	lb.Sub(lb, lg)
+	b1 := new(big.Float).SetPrec(wprec).Sub(bw, one)
+
+	// u <= 0, since g(0) = -log(p) >= 0, and the solver keeps to the
+	// bracket below it
+	return solveLog(func(u *big.Float) (v, step *big.Float) {
+		x := bigfloat.Exp(u)
+		if x.Cmp(one) > 0 {
+			x.Set(one)
+		}
+		logF := bigfloat.Log(bigfloat.BetaInc(aw, bw, x))
+		v = new(big.Float).SetPrec(wprec).Sub(logF, logP)
+
+		lxf := new(big.Float).SetPrec(wprec).Mul(aw, u)
+		if y := new(big.Float).SetPrec(wprec+1).Sub(one, x); y.Sign() > 0 {
+			l := bigfloat.Log(y)
+			lxf.Add(lxf, l.Mul(l, b1))
+		}
+		lxf.Sub(lxf, lb)
+
+		return v, newtonStep(v, lxf, logF)
+	}, prec)
+}
+
+// checkBeta panics if a or b is not positive and finite.
+func checkBeta(name string, a, b *big.Float) {
+	if a.Sign() <= 0 || a.IsInf() || b.Sign() <= 0 || b.IsInf() {
+		panic(name + ": a or b is not positive and finite")
+	}
+}
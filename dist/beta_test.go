@@ -0,0 +1,124 @@
+package dist_test
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/ThreeAndTwo/bigfloat"
+	"github.com/ThreeAndTwo/bigfloat/dist"
+)
+
+func TestBetaCDF(t *testing.T) {
+	// for a = 2 and b = 2, P(X <= x) = x²·(3 - 2x)
+	two := big.NewFloat(2)
+	for _, prec := range []uint{53, 100, 300} {
+		for _, xs := range []string{"1e-30", "0.1", "0.5", "0.8"} {
+			x, _ := new(big.Float).SetPrec(prec).SetString(xs)
+			w := new(big.Float).SetPrec(prec+64).Mul(x, two)
+			w.Sub(big.NewFloat(3), w).Mul(w, x).Mul(w, x)
+			want := new(big.Float).SetPrec(prec).Set(w)
+			if got := dist.BetaCDF(two, two, x); got.Prec() != prec || bigfloat.CmpUlp(got, want, 2) != 0 {
+				t.Errorf("BetaCDF(2, 2, %s) at prec %d = %g; want %g", xs, prec, got, want)
+			}
+
+			// P(X > 1-x) = P(X <= x), by symmetry
+			y := new(big.Float).SetPrec(prec+128).Sub(big.NewFloat(1), x)
+			if got := dist.BetaSF(two, two, y).SetPrec(prec); bigfloat.CmpUlp(got, want, 2) != 0 {
+				t.Errorf("BetaSF(2, 2, 1-%s) at prec %d = %g; want %g", xs, prec, got, want)
+			}
+		}
+	}
+
+	for _, test := range []struct {
+		x, cdf, sf float64
+	}{
+		{-1, 0, 1},
+		{0, 0, 1},
+		{1, 1, 0},
+		{2, 1, 0},
+	} {
+		cdf, _ := dist.BetaCDF(two, two, big.NewFloat(test.x)).Float64()
+		sf, _ := dist.BetaSF(two, two, big.NewFloat(test.x)).Float64()
+		if cdf != test.cdf || sf != test.sf {
+			t.Errorf("BetaCDF, BetaSF(2, 2, %g) = %g, %g; want %g, %g", test.x, cdf, sf, test.cdf, test.sf)
+		}
+	}
+}
+
+func TestBetaQuantile(t *testing.T) {
+	// for a = 1 and b = 2, x = 1 - √(1-p) = p/(1 + √(1-p))
+	one, two := big.NewFloat(1), big.NewFloat(2)
+	for _, prec := range []uint{53, 100, 300} {
+		for _, p := range []*big.Float{
+			new(big.Float).SetMantExp(one, -1000),
+			big.NewFloat(1e-10),
+			big.NewFloat(0.3),
+			big.NewFloat(0.75),
+			big.NewFloat(0.999),
+			new(big.Float).SetPrec(prec).Sub(one, new(big.Float).SetMantExp(one, -int(prec))),
+		} {
+			p := new(big.Float).SetPrec(prec).Set(p)
+			s := bigfloat.Sqrt(new(big.Float).SetPrec(prec+64).Sub(one, p))
+			want := new(big.Float).SetPrec(prec+64).Add(one, s)
+			want.Quo(p, want).SetPrec(prec)
+			if got := dist.BetaQuantile(one, two, p); got.Prec() != prec || bigfloat.CmpUlp(got, want, 2) != 0 {
+				t.Errorf("BetaQuantile(1, 2, %g) at prec %d = %g; want %g", p, prec, got, want)
+			}
+
+			// and √(1-p) for the swapped shapes and 1 - p
+			q := new(big.Float).SetPrec(prec+1100).Sub(one, p)
+			want = bigfloat.Sqrt(new(big.Float).SetPrec(prec).Set(q))
+			if got := dist.BetaQuantile(two, one, q).SetPrec(prec); bigfloat.CmpUlp(got, want, 2) != 0 {
+				t.Errorf("BetaQuantile(2, 1, 1-%g) at prec %d = %g; want %g", p, prec, got, want)
+			}
+		}
+	}
+
+	// the round trip of tail probabilities of 1e-300, for shapes
+	// below and above 1, where x can be as close to 1 as 1e-600
+	const prec = 200
+	p, _ := new(big.Float).SetPrec(prec).SetString("1e-300")
+	pc := new(big.Float).SetPrec(2200).Sub(one, p)
+	for _, ab := range [][2]float64{{0.5, 0.5}, {3.5, 40}, {40, 3.5}} {
+		a, b := big.NewFloat(ab[0]), big.NewFloat(ab[1])
+		x := dist.BetaQuantile(a, b, p).SetPrec(prec + 64)
+		if got := dist.BetaCDF(a, b, x).SetPrec(prec); bigfloat.CmpUlp(got, p, 8) != 0 {
+			t.Errorf("BetaCDF(%v, BetaQuantile(1e-300)) = %g", ab, got)
+		}
+		x = dist.BetaQuantile(a, b, pc)
+		if got := dist.BetaSF(a, b, x).SetPrec(prec); bigfloat.CmpUlp(got, p, 8) != 0 {
+			t.Errorf("BetaSF(%v, BetaQuantile(1 - 1e-300)) = %g", ab, got)
+		}
+	}
+
+	for _, f := range []func(){
+		func() { dist.BetaQuantile(big.NewFloat(0), one, big.NewFloat(0.5)) },
+		func() { dist.BetaCDF(one, big.NewFloat(-1), big.NewFloat(0.5)) },
+		func() { dist.BetaQuantile(one, one, big.NewFloat(2)) },
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Error("invalid arguments didn't panic")
+				}
+			}()
+			f()
+		}()
+	}
+}
+
+// ---------- Benchmarks ----------
+
+func BenchmarkBetaQuantile(b *testing.B) {
+	for _, prec := range []uint{1e2, 1e3} {
+		a, c := big.NewFloat(2.5), big.NewFloat(7)
+		p := new(big.Float).SetPrec(prec).SetFloat64(0.95)
+		b.Run(fmt.Sprintf("%v", prec), func(b *testing.B) {
+			b.ReportAllocs()
+			for n := 0; n < b.N; n++ {
+				dist.BetaQuantile(a, c, p)
+			}
+		})
+	}
+}
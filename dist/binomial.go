@@ -0,0 +1,38 @@
+package dist
+
+import (
+	"math/big"
+
+	"github.com/ThreeAndTwo/bigfloat"
+)
+
+// BinomialCDF returns the distribution function P(X <= k) of the
+// binomial distribution of n trials with the success probability p, as
+// 1 - I(p; k+1, n-k) with the regularized incomplete beta function, for
+// k < n, and 1 for k >= n. Precision is the same as the one of p (64
+// if it is 0). The function panics if p is not in [0, 1].
+func BinomialCDF(n, k uint64, p *big.Float) *big.Float {
+
+	checkProb("BinomialCDF", p)
+	if k >= n {
+		return new(big.Float).SetPrec(precOf(p)).SetInt64(1)
+	}
+
+	return bigfloat.BetaIncC(succ(k), new(big.Float).SetUint64(n-k), p)
+}
+
+// BinomialSF returns the survival function P(X > k) = 1 -
+// BinomialCDF(n, k, p) of the binomial distribution, the p-value of
+// more than k successes, as I(p; k+1, n-k) for k < n, and 0 for
+// k >= n. Precision is the same as the one of p (64 if it is 0). Both
+// tails keep their relative accuracy, however small. The function
+// panics if p is not in [0, 1].
+func BinomialSF(n, k uint64, p *big.Float) *big.Float {
+
+	checkProb("BinomialSF", p)
+	if k >= n {
+		return new(big.Float).SetPrec(precOf(p))
+	}
+
+	return bigfloat.BetaInc(succ(k), new(big.Float).SetUint64(n-k), p)
+}
@@ -0,0 +1,67 @@
+package dist_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ThreeAndTwo/bigfloat"
+	"github.com/ThreeAndTwo/bigfloat/dist"
+)
+
+// binomialRef returns P(X <= k) and P(X > k) for n trials with the
+// success probability p, exactly.
+func binomialRef(n, k int64, p *big.Rat) (cdf, sf *big.Rat) {
+
+	q := new(big.Rat).Sub(big.NewRat(1, 1), p)
+	cdf, sf = new(big.Rat), new(big.Rat)
+	for j := int64(0); j <= n; j++ {
+		t := new(big.Rat).SetInt(new(big.Int).Binomial(n, j))
+		t.Mul(t, new(big.Rat).SetFrac(new(big.Int).Exp(p.Num(), big.NewInt(j), nil), new(big.Int).Exp(p.Denom(), big.NewInt(j), nil)))
+		t.Mul(t, new(big.Rat).SetFrac(new(big.Int).Exp(q.Num(), big.NewInt(n-j), nil), new(big.Int).Exp(q.Denom(), big.NewInt(n-j), nil)))
+		if j <= k {
+			cdf.Add(cdf, t)
+		} else {
+			sf.Add(sf, t)
+		}
+	}
+
+	return cdf, sf
+}
+
+func TestBinomial(t *testing.T) {
+	// dyadic probabilities, exact at any precision
+	for _, pr := range []*big.Rat{big.NewRat(1, 1024), big.NewRat(1, 2), big.NewRat(7, 8)} {
+		for _, nk := range [][2]uint64{{1, 0}, {10, 3}, {100, 2}, {100, 50}, {1000, 900}, {1000, 999}, {5, 5}, {5, 7}} {
+			n, k := nk[0], nk[1]
+			cdf, sf := binomialRef(int64(n), int64(k), pr)
+			for _, prec := range []uint{53, 100, 300} {
+				p, _ := bigfloat.FromRat(pr, prec)
+				want, _ := bigfloat.FromRat(cdf, prec)
+				if got := dist.BinomialCDF(n, k, p); got.Prec() != prec || bigfloat.CmpUlp(got, want, 2) != 0 {
+					t.Errorf("BinomialCDF(%d, %d, %v) at prec %d = %g; want %g", n, k, pr, prec, got, want)
+				}
+				want, _ = bigfloat.FromRat(sf, prec)
+				if got := dist.BinomialSF(n, k, p); got.Prec() != prec || bigfloat.CmpUlp(got, want, 2) != 0 {
+					t.Errorf("BinomialSF(%d, %d, %v) at prec %d = %g; want %g", n, k, pr, prec, got, want)
+				}
+			}
+		}
+	}
+
+	for _, p := range []float64{0, 1} {
+		cdf, _ := dist.BinomialCDF(10, 4, big.NewFloat(p)).Float64()
+		sf, _ := dist.BinomialSF(10, 4, big.NewFloat(p)).Float64()
+		if cdf != 1-p || sf != p {
+			t.Errorf("BinomialCDF, BinomialSF(10, 4, %g) = %g, %g; want %g, %g", p, cdf, sf, 1-p, p)
+		}
+	}
+
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Error("BinomialSF(10, 4, 1.5) didn't panic")
+			}
+		}()
+		dist.BinomialSF(10, 4, big.NewFloat(1.5))
+	}()
+}
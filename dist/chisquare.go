@@ -0,0 +1,74 @@
+package dist
+
+import (
+	"math/big"
+
+	"github.com/ThreeAndTwo/bigfloat"
+)
+
+// ChiSquareCDF returns the distribution function P(X <= x) of the
+// chi-square distribution with k > 0 degrees of freedom, which needn't
+// be an integer, as P(k/2, x/2) with the regularized incomplete gamma
+// function. Precision is the same as the one of x (64 if it is 0). It
+// is 0 for x <= 0. The function panics if k is not positive and
+// finite.
+func ChiSquareCDF(k, x *big.Float) *big.Float {
+
+	prec := precOf(x)
+	checkDF("ChiSquareCDF", k)
+	if x.Sign() <= 0 {
+		return new(big.Float).SetPrec(prec)
+	}
+
+	return bigfloat.GammaInc(halve(k, prec+64), halve(x, prec))
+}
+
+// ChiSquareSF returns the survival function P(X > x) = 1 -
+// ChiSquareCDF(k, x) of the chi-square distribution, the p-value of
+// the statistic x, as Q(k/2, x/2), which keeps its relative accuracy
+// in the upper tail. Precision is the same as the one of x (64 if it
+// is 0). The function panics if k is not positive and finite.
+func ChiSquareSF(k, x *big.Float) *big.Float {
+
+	prec := precOf(x)
+	checkDF("ChiSquareSF", k)
+	if x.Sign() <= 0 {
+		return new(big.Float).SetPrec(prec).SetInt64(1)
+	}
+
+	return bigfloat.GammaIncC(halve(k, prec+64), halve(x, prec))
+}
+
+// ChiSquareQuantile returns the x with ChiSquareCDF(k, x) = p, for p
+// in [0, 1]. Precision is the same as the one of p (64 if it is 0).
+// The quantile of p = 1 is +Inf. As for GammaQuantile, of which it is
+// twice the quantile with shape k/2, the quantiles of p > 1/2 are
+// found from the upper tail probability 1 - p, so that the thresholds
+// of tests at tiny significance levels are accurate. The
+// function panics if k is not positive and finite, or if p is not in
+// [0, 1].
+func ChiSquareQuantile(k, p *big.Float) *big.Float {
+
+	prec := precOf(p)
+	checkDF("ChiSquareQuantile", k)
+	checkProb("ChiSquareQuantile", p)
+	z := new(big.Float).SetPrec(prec)
+	switch {
+	case p.Sign() == 0:
+		return z
+	case p.Cmp(big.NewFloat(1)) == 0:
+		return z.SetInf(false)
+	}
+
+	x := gammaQuantile(halve(k, prec+64), p, prec+2)
+
+	return z.Set(x.SetMantExp(x, 1))
+}
+
+// halve returns x/2 at precision prec.
+func halve(x *big.Float, prec uint) *big.Float {
+
+	z := new(big.Float).SetPrec(prec).Set(x)
+
+	return z.SetMantExp(z, -1)
+}
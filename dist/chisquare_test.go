@@ -0,0 +1,127 @@
+package dist_test
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/ThreeAndTwo/bigfloat"
+	"github.com/ThreeAndTwo/bigfloat/dist"
+)
+
+func TestChiSquareCDF(t *testing.T) {
+	// for 4 degrees of freedom, P(X > x) = exp(-x/2)·(1 + x/2)
+	four := big.NewFloat(4)
+	for _, prec := range []uint{53, 100, 300} {
+		for _, xs := range []string{"1e-30", "0.5", "3", "10", "2000", "1e6"} {
+			x, _ := new(big.Float).SetPrec(prec).SetString(xs)
+			h := new(big.Float).SetPrec(prec+64).Quo(x, big.NewFloat(2))
+			sf := bigfloat.Exp(new(big.Float).Neg(h))
+			sf.Mul(sf, h.Add(h, big.NewFloat(1)))
+			want := new(big.Float).SetPrec(prec).Set(sf)
+			if got := dist.ChiSquareSF(four, x); got.Prec() != prec || bigfloat.CmpUlp(got, want, 2) != 0 {
+				t.Errorf("ChiSquareSF(4, %s) at prec %d = %g; want %g", xs, prec, got, want)
+			}
+			if xs == "1e-30" {
+				// 1 - sf cancels: P(X <= x) = x²/8·(1 - x/3 + ...)
+				continue
+			}
+			want.Sub(big.NewFloat(1), sf)
+			if got := dist.ChiSquareCDF(four, x); got.Prec() != prec || bigfloat.CmpUlp(got, want, 2) != 0 {
+				t.Errorf("ChiSquareCDF(4, %s) at prec %d = %g; want %g", xs, prec, got, want)
+			}
+		}
+	}
+
+	for _, test := range []struct {
+		x, cdf, sf float64
+	}{
+		{-1, 0, 1},
+		{0, 0, 1},
+		{math.Inf(1), 1, 0},
+	} {
+		cdf, _ := dist.ChiSquareCDF(four, big.NewFloat(test.x)).Float64()
+		sf, _ := dist.ChiSquareSF(four, big.NewFloat(test.x)).Float64()
+		if cdf != test.cdf || sf != test.sf {
+			t.Errorf("ChiSquareCDF, ChiSquareSF(4, %g) = %g, %g; want %g, %g", test.x, cdf, sf, test.cdf, test.sf)
+		}
+	}
+}
+
+func TestChiSquareQuantile(t *testing.T) {
+	// for 2 degrees of freedom, x = -2·log(1-p)
+	two := big.NewFloat(2)
+	one := big.NewFloat(1)
+	for _, prec := range []uint{53, 100, 300} {
+		for _, p := range []*big.Float{
+			big.NewFloat(1e-10),
+			big.NewFloat(0.3),
+			big.NewFloat(0.75),
+			big.NewFloat(0.999),
+			new(big.Float).SetPrec(prec).Sub(one, new(big.Float).SetMantExp(one, -int(prec))),
+		} {
+			p := new(big.Float).SetPrec(prec).Set(p)
+			q := new(big.Float).SetPrec(prec+64).Sub(one, p)
+			want := bigfloat.Log(q)
+			want.Mul(want, big.NewFloat(-2)).SetPrec(prec)
+			if got := dist.ChiSquareQuantile(two, p); got.Prec() != prec || bigfloat.CmpUlp(got, want, 2) != 0 {
+				t.Errorf("ChiSquareQuantile(2, %g) at prec %d = %g; want %g", p, prec, got, want)
+			}
+		}
+	}
+
+	// the 5% threshold for 10 degrees of freedom, from 0.95 rounded
+	// past the 200 bits of the result
+	const prec = 200
+	p, _ := new(big.Float).SetPrec(300).SetString("0.95")
+	want, _ := new(big.Float).SetPrec(prec).SetString("18.307038053275146871803030996959033197584488417843895143510648619450074")
+	if got := dist.ChiSquareQuantile(big.NewFloat(10), p).SetPrec(prec); bigfloat.CmpUlp(got, want, 2) != 0 {
+		t.Errorf("ChiSquareQuantile(10, 0.95) = %g; want %g", got, want)
+	}
+
+	// the quantiles of tail probabilities of 1e-300
+	p, _ = p.SetPrec(prec).SetString("1e-300")
+	for _, k := range []float64{1, 7.5} {
+		k := big.NewFloat(k)
+		x := dist.ChiSquareQuantile(k, p).SetPrec(prec + 64)
+		if got := dist.ChiSquareCDF(k, x).SetPrec(prec); bigfloat.CmpUlp(got, p, 8) != 0 {
+			t.Errorf("ChiSquareCDF(%g, ChiSquareQuantile(1e-300)) = %g", k, got)
+		}
+		pc := new(big.Float).SetPrec(1200).Sub(one, p)
+		x = dist.ChiSquareQuantile(k, pc).SetPrec(prec + 64)
+		if got := dist.ChiSquareSF(k, x).SetPrec(prec); bigfloat.CmpUlp(got, p, 8) != 0 {
+			t.Errorf("ChiSquareSF(%g, ChiSquareQuantile(1 - 1e-300)) = %g", k, got)
+		}
+	}
+
+	for _, f := range []func(){
+		func() { dist.ChiSquareQuantile(big.NewFloat(0), big.NewFloat(0.5)) },
+		func() { dist.ChiSquareQuantile(two, big.NewFloat(-0.5)) },
+		func() { dist.ChiSquareCDF(big.NewFloat(math.Inf(1)), big.NewFloat(1)) },
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Error("invalid arguments didn't panic")
+				}
+			}()
+			f()
+		}()
+	}
+}
+
+// ---------- Benchmarks ----------
+
+func BenchmarkChiSquareQuantile(b *testing.B) {
+	for _, prec := range []uint{1e2, 1e3} {
+		k := big.NewFloat(10)
+		p := new(big.Float).SetPrec(prec).SetFloat64(0.95)
+		b.Run(fmt.Sprintf("%v", prec), func(b *testing.B) {
+			b.ReportAllocs()
+			for n := 0; n < b.N; n++ {
+				dist.ChiSquareQuantile(k, p)
+			}
+		})
+	}
+}
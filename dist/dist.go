@@ -0,0 +1,112 @@
+// Package dist provides the distribution functions and the quantiles
+// of the common statistical distributions at any precision, built on
+// the incomplete gamma and beta functions of bigfloat.
+//
+// The functions keep the relative accuracy of the small probabilities
+// of both tails, far below the range of float64, so that the p-values
+// of extreme statistics and the thresholds of statistical tests can be
+// certified. The quantiles are computed by Newton's method on the
+// logarithm of the tail probability, which converges from anywhere for
+// the tails that decay as powers or exponentials.
+package dist
+
+import (
+	"math/big"
+
+	"github.com/ThreeAndTwo/bigfloat"
+)
+
+// solveLog returns the x > 0 with g(log(x)) = 0 to prec bits, for an
+// increasing function g that changes sign. g returns its value at u
+// and the Newton step g(u)/g'(u). The root is bracketed by steps of
+// growing size from u = 0, then found by Newton's method, with a
+// bisection whenever a step leaves the bracket.
+func solveLog(g func(u *big.Float) (v, step *big.Float), prec uint) *big.Float {
+
+	wprec := prec + 64
+	u := new(big.Float).SetPrec(wprec)
+	v, _ := g(u)
+	if v.Sign() == 0 {
+		return bigfloat.Exp(u).SetPrec(prec)
+	}
+
+	// a bracket lo < root < hi, from u = 0 by ±1, ±2, ±4, ...
+	lo, hi := new(big.Float).SetPrec(wprec), new(big.Float).SetPrec(wprec)
+	dir := -v.Sign()
+	for d := int64(1); ; d *= 2 {
+		b := new(big.Float).SetPrec(wprec).SetInt64(int64(dir) * d)
+		if bv, _ := g(b); bv.Sign() != v.Sign() {
+			if dir > 0 {
+				hi.Set(b)
+				lo.SetInt64(d / 2)
+			} else {
+				lo.Set(b)
+				hi.SetInt64(-d / 2)
+			}
+			break
+		}
+	}
+
+	u.Add(lo, hi)
+	u.SetMantExp(u, -1)
+	half := big.NewFloat(0.5)
+	for {
+		v, step := g(u)
+		switch v.Sign() {
+		case 0:
+			return bigfloat.Exp(u).SetPrec(prec)
+		case 1:
+			hi.Set(u)
+		default:
+			lo.Set(u)
+		}
+
+		un := new(big.Float).SetPrec(wprec).Sub(u, step)
+		if !step.IsInf() && step.MantExp(nil) < -int(prec)-8 {
+			return bigfloat.Exp(un).SetPrec(prec)
+		}
+		if un.Cmp(lo) <= 0 || un.Cmp(hi) >= 0 || step.IsInf() {
+			un.Add(lo, hi).Mul(un, half)
+		}
+		u = un
+		if w := new(big.Float).Sub(hi, lo); w.MantExp(nil) < -int(prec)-8 {
+			return bigfloat.Exp(u).SetPrec(prec)
+		}
+	}
+}
+
+// newtonStep returns the Newton step g/g' for g(u) = ±(log(F(x)) - c),
+// with x = exp(u), where F is a distribution function or its
+// complement, f is the density and |dg/du| = x·f(x)/F(x); lxf is
+// log(x·f(x)) and logF is log(F(x)). The step is infinite where F is
+// out of range, so that the solver bisects.
+func newtonStep(g, lxf, logF *big.Float) *big.Float {
+
+	r := new(big.Float).SetPrec(g.Prec())
+	if g.Sign() == 0 {
+		return r
+	}
+	if g.IsInf() || logF.IsInf() || lxf.IsInf() {
+		return r.SetInf(false)
+	}
+	r.Sub(lxf, logF)
+
+	return r.Quo(g, bigfloat.Exp(r))
+}
+
+// precOf returns the precision of x, or 64 if it is 0.
+func precOf(x *big.Float) uint {
+
+	if x.Prec() == 0 {
+		return 64
+	}
+
+	return x.Prec()
+}
+
+// checkProb panics if p is not in [0, 1].
+func checkProb(name string, p *big.Float) {
+	if p.Sign() < 0 || p.Cmp(big.NewFloat(1)) > 0 {
+		panic(name + ": probability is not in [0, 1]")
+	}
+}
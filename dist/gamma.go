@@ -0,0 +1,111 @@
+package dist
+
+import (
+	"math/big"
+
+	"github.com/ThreeAndTwo/bigfloat"
+)
+
+// GammaCDF returns the distribution function P(X <= x) of the gamma
+// distribution with the given shape and scale, both positive, as
+// P(shape, x/scale) with the regularized incomplete gamma function.
+// Precision is the same as the one of x (64 if it is 0). It is 0 for
+// x <= 0. The function panics if shape or scale is not positive and
+// finite.
+func GammaCDF(shape, scale, x *big.Float) *big.Float {
+
+	prec := precOf(x)
+	checkGamma("GammaCDF", shape, scale)
+	z := new(big.Float).SetPrec(prec)
+	if x.Sign() <= 0 {
+		return z
+	}
+
+	return z.Set(bigfloat.GammaInc(shape, unscale(x, scale, prec+64)))
+}
+
+// GammaSF returns the survival function P(X > x) = 1 - GammaCDF(shape,
+// scale, x) of the gamma distribution, as Q(shape, x/scale), which
+// keeps its relative accuracy in the upper tail. Precision is the same
+// as the one of x (64 if it is 0). The function panics as GammaCDF.
+func GammaSF(shape, scale, x *big.Float) *big.Float {
+
+	prec := precOf(x)
+	checkGamma("GammaSF", shape, scale)
+	z := new(big.Float).SetPrec(prec)
+	if x.Sign() <= 0 {
+		return z.SetInt64(1)
+	}
+
+	return z.Set(bigfloat.GammaIncC(shape, unscale(x, scale, prec+64)))
+}
+
+// GammaQuantile returns the x with GammaCDF(shape, scale, x) = p, for
+// p in [0, 1]. Precision is the same as the one of p (64 if it is 0).
+// The quantile of p = 1 is +Inf. The quantiles of p > 1/2 are found
+// from the upper tail probability 1 - p, which is exact. The function
+// panics if shape or scale is not positive and finite, or if p is not
+// in [0, 1].
+func GammaQuantile(shape, scale, p *big.Float) *big.Float {
+
+	prec := precOf(p)
+	checkGamma("GammaQuantile", shape, scale)
+	checkProb("GammaQuantile", p)
+	z := new(big.Float).SetPrec(prec)
+	switch {
+	case p.Sign() == 0:
+		return z
+	case p.Cmp(big.NewFloat(1)) == 0:
+		return z.SetInf(false)
+	}
+
+	return z.Mul(gammaQuantile(shape, p, prec+2), scale)
+}
+
+// gammaQuantile returns the x with P(a, x) = p, for p in (0, 1), to
+// prec bits, by Newton's method on log(P), or on log(Q) for p > 1/2.
+func gammaQuantile(a, p *big.Float, prec uint) *big.Float {
+
+	// the lower tail for p <= 1/2, the upper one otherwise
+	wprec := prec + 64
+	lower := p.Cmp(big.NewFloat(0.5)) <= 0
+	q := new(big.Float).SetPrec(wprec).Set(p)
+	if !lower {
+		q.Sub(big.NewFloat(1), q)
+	}
+	logQ := bigfloat.Log(q)
+
+	// log(x·f(x)) = a·log(x) - x - log Γ(a)
+	aw := new(big.Float).SetPrec(wprec).Set(a)
+	lg, _ := bigfloat.Lgamma(aw)
+
+	return solveLog(func(u *big.Float) (v, step *big.Float) {
+		x := bigfloat.Exp(u)
+		var logF *big.Float
+		v = new(big.Float).SetPrec(wprec)
+		if lower {
+			logF = bigfloat.Log(bigfloat.GammaInc(aw, x))
+			v.Sub(logF, logQ)
+		} else {
+			logF = bigfloat.Log(bigfloat.GammaIncC(aw, x))
+			v.Sub(logQ, logF)
+		}
+
+		lxf := new(big.Float).SetPrec(wprec).Mul(aw, u)
+		lxf.Sub(lxf, x).Sub(lxf, lg)
+
+		return v, newtonStep(v, lxf, logF)
+	}, prec)
+}
+
+// unscale returns x/scale at precision prec.
+func unscale(x, scale *big.Float, prec uint) *big.Float {
+	return new(big.Float).SetPrec(prec).Quo(x, scale)
+}
+
+// checkGamma panics if shape or scale is not positive and finite.
+func checkGamma(name string, shape, scale *big.Float) {
+	if shape.Sign() <= 0 || shape.IsInf() || scale.Sign() <= 0 || scale.IsInf() {
+		panic(name + ": shape or scale is not positive and finite")
+	}
+}
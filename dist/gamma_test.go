@@ -0,0 +1,109 @@
+package dist_test
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/ThreeAndTwo/bigfloat"
+	"github.com/ThreeAndTwo/bigfloat/dist"
+)
+
+func TestGammaCDF(t *testing.T) {
+	// for shape 3, P(X > x) = exp(-t)·(1 + t + t²/2), with t = x/scale
+	shape, scale := big.NewFloat(3), big.NewFloat(0.25)
+	for _, prec := range []uint{53, 100, 300} {
+		for _, xs := range []string{"0.01", "0.5", "2", "100", "1e5"} {
+			x, _ := new(big.Float).SetPrec(prec).SetString(xs)
+			u := new(big.Float).SetPrec(prec+64).Quo(x, scale)
+			s := new(big.Float).SetPrec(prec+64).Mul(u, u)
+			s.Quo(s, big.NewFloat(2)).Add(s, u).Add(s, big.NewFloat(1))
+			sf := s.Mul(s, bigfloat.Exp(u.Neg(u)))
+			want := new(big.Float).SetPrec(prec).Set(sf)
+			if got := dist.GammaSF(shape, scale, x); got.Prec() != prec || bigfloat.CmpUlp(got, want, 2) != 0 {
+				t.Errorf("GammaSF(3, 0.25, %s) at prec %d = %g; want %g", xs, prec, got, want)
+			}
+			want.Sub(big.NewFloat(1), sf)
+			if got := dist.GammaCDF(shape, scale, x); got.Prec() != prec || bigfloat.CmpUlp(got, want, 2) != 0 {
+				t.Errorf("GammaCDF(3, 0.25, %s) at prec %d = %g; want %g", xs, prec, got, want)
+			}
+		}
+	}
+
+	if got := dist.GammaCDF(shape, scale, big.NewFloat(-1)); got.Sign() != 0 {
+		t.Errorf("GammaCDF(3, 0.25, -1) = %g; want 0", got)
+	}
+	if got := dist.GammaSF(shape, scale, big.NewFloat(0)); got.Cmp(big.NewFloat(1)) != 0 {
+		t.Errorf("GammaSF(3, 0.25, 0) = %g; want 1", got)
+	}
+}
+
+func TestGammaQuantile(t *testing.T) {
+	// for shape 1, x = -scale·log(1-p)
+	one := big.NewFloat(1)
+	scale := big.NewFloat(3)
+	for _, prec := range []uint{53, 100, 300} {
+		for _, p := range []*big.Float{
+			big.NewFloat(1e-10),
+			big.NewFloat(0.3),
+			big.NewFloat(0.75),
+			new(big.Float).SetPrec(prec).Sub(one, new(big.Float).SetMantExp(one, -int(prec))),
+		} {
+			p := new(big.Float).SetPrec(prec).Set(p)
+			q := new(big.Float).SetPrec(prec+64).Sub(one, p)
+			want := bigfloat.Log(q)
+			want.Mul(want, scale).Neg(want).SetPrec(prec)
+			if got := dist.GammaQuantile(one, scale, p); got.Prec() != prec || bigfloat.CmpUlp(got, want, 2) != 0 {
+				t.Errorf("GammaQuantile(1, 3, %g) at prec %d = %g; want %g", p, prec, got, want)
+			}
+		}
+	}
+
+	// the quantiles of tail probabilities of 1e-300, for shape 0.3
+	const prec = 200
+	shape := big.NewFloat(0.3)
+	p, _ := new(big.Float).SetPrec(prec).SetString("1e-300")
+	x := dist.GammaQuantile(shape, scale, p).SetPrec(prec + 64)
+	if got := dist.GammaCDF(shape, scale, x).SetPrec(prec); bigfloat.CmpUlp(got, p, 8) != 0 {
+		t.Errorf("GammaCDF(GammaQuantile(1e-300)) = %g", got)
+	}
+	pc := new(big.Float).SetPrec(1200).Sub(one, p)
+	x = dist.GammaQuantile(shape, scale, pc).SetPrec(prec + 64)
+	if got := dist.GammaSF(shape, scale, x).SetPrec(prec); bigfloat.CmpUlp(got, p, 8) != 0 {
+		t.Errorf("GammaSF(GammaQuantile(1 - 1e-300)) = %g", got)
+	}
+
+	if got := dist.GammaQuantile(shape, scale, big.NewFloat(1)); !got.IsInf() {
+		t.Errorf("GammaQuantile(0.3, 3, 1) = %g; want +Inf", got)
+	}
+
+	for _, f := range []func(){
+		func() { dist.GammaQuantile(big.NewFloat(0), one, big.NewFloat(0.5)) },
+		func() { dist.GammaCDF(one, big.NewFloat(-1), big.NewFloat(0.5)) },
+		func() { dist.GammaQuantile(one, one, big.NewFloat(2)) },
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Error("invalid arguments didn't panic")
+				}
+			}()
+			f()
+		}()
+	}
+}
+
+// ---------- Benchmarks ----------
+
+func BenchmarkGammaQuantile(b *testing.B) {
+	for _, prec := range []uint{1e2, 1e3} {
+		shape, scale := big.NewFloat(2.5), big.NewFloat(1)
+		p := new(big.Float).SetPrec(prec).SetFloat64(0.95)
+		b.Run(fmt.Sprintf("%v", prec), func(b *testing.B) {
+			b.ReportAllocs()
+			for n := 0; n < b.N; n++ {
+				dist.GammaQuantile(shape, scale, p)
+			}
+		})
+	}
+}
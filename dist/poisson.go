@@ -0,0 +1,43 @@
+package dist
+
+import (
+	"math/big"
+
+	"github.com/ThreeAndTwo/bigfloat"
+)
+
+// PoissonCDF returns the distribution function P(X <= k) of the
+// Poisson distribution with mean lambda >= 0, as Q(k+1, λ) with the
+// regularized incomplete gamma function. Precision is the same as the
+// one of lambda (64 if it is 0). The function panics if lambda is
+// negative or infinite.
+func PoissonCDF(lambda *big.Float, k uint64) *big.Float {
+	checkMean("PoissonCDF", lambda)
+	return bigfloat.GammaIncC(succ(k), lambda)
+}
+
+// PoissonSF returns the survival function P(X > k) = 1 -
+// PoissonCDF(lambda, k) of the Poisson distribution, the p-value of k
+// events, as P(k+1, λ). Precision is the same as the one of lambda (64
+// if it is 0). It keeps its relative accuracy far out in the tail, as
+// the 2.3e-378 of more than 200 events for a mean of 1. The function
+// panics if lambda is negative or infinite.
+func PoissonSF(lambda *big.Float, k uint64) *big.Float {
+	checkMean("PoissonSF", lambda)
+	return bigfloat.GammaInc(succ(k), lambda)
+}
+
+// checkMean panics if lambda is negative or infinite.
+func checkMean(name string, lambda *big.Float) {
+	if lambda.Sign() < 0 || lambda.IsInf() {
+		panic(name + ": mean is negative or infinite")
+	}
+}
+
+// succ returns k+1, exactly.
+func succ(k uint64) *big.Float {
+
+	z := new(big.Float).SetPrec(65).SetUint64(k)
+
+	return z.Add(z, big.NewFloat(1))
+}
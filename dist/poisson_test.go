@@ -0,0 +1,77 @@
+package dist_test
+
+import (
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/ThreeAndTwo/bigfloat"
+	"github.com/ThreeAndTwo/bigfloat/dist"
+)
+
+// poissonRef returns P(X <= k) and P(X > k) for the mean lambda, as
+// exp(-λ) times the sums of λ**j/j!, for j up to k and from k+1.
+func poissonRef(lambda *big.Float, k uint64, prec uint) (cdf, sf *big.Float) {
+
+	wprec := prec + 64
+	cdf = new(big.Float).SetPrec(wprec)
+	sf = new(big.Float).SetPrec(wprec)
+	t := new(big.Float).SetPrec(wprec).SetInt64(1)
+	for j := uint64(0); ; j++ {
+		if j > 0 {
+			t.Mul(t, lambda).Quo(t, new(big.Float).SetUint64(j))
+		}
+		if j <= k {
+			cdf.Add(cdf, t)
+			continue
+		}
+		sf.Add(sf, t)
+		if t.MantExp(nil) < sf.MantExp(nil)-int(wprec) {
+			break
+		}
+	}
+	e := bigfloat.Exp(new(big.Float).SetPrec(wprec).Neg(lambda))
+	cdf.Mul(cdf, e).SetPrec(prec)
+	sf.Mul(sf, e).SetPrec(prec)
+
+	return cdf, sf
+}
+
+func TestPoisson(t *testing.T) {
+	for _, prec := range []uint{53, 100, 300} {
+		for _, ls := range []string{"1e-10", "1", "7.5", "100"} {
+			lambda, _ := new(big.Float).SetPrec(prec).SetString(ls)
+			for _, k := range []uint64{0, 1, 5, 50, 200} {
+				cdf, sf := poissonRef(lambda, k, prec)
+				if got := dist.PoissonCDF(lambda, k); got.Prec() != prec || bigfloat.CmpUlp(got, cdf, 2) != 0 {
+					t.Errorf("PoissonCDF(%s, %d) at prec %d = %g; want %g", ls, k, prec, got, cdf)
+				}
+				if got := dist.PoissonSF(lambda, k); got.Prec() != prec || bigfloat.CmpUlp(got, sf, 2) != 0 {
+					t.Errorf("PoissonSF(%s, %d) at prec %d = %g; want %g", ls, k, prec, got, sf)
+				}
+			}
+		}
+	}
+
+	zero := new(big.Float)
+	if cdf, sf := dist.PoissonCDF(zero, 3), dist.PoissonSF(zero, 3); cdf.Cmp(big.NewFloat(1)) != 0 || sf.Sign() != 0 {
+		t.Errorf("PoissonCDF, PoissonSF(0, 3) = %g, %g; want 1, 0", cdf, sf)
+	}
+	if got := dist.PoissonCDF(big.NewFloat(2), math.MaxUint64); got.Cmp(big.NewFloat(1)) != 0 {
+		t.Errorf("PoissonCDF(2, MaxUint64) = %g; want 1", got)
+	}
+
+	for _, f := range []func(){
+		func() { dist.PoissonCDF(big.NewFloat(-1), 1) },
+		func() { dist.PoissonSF(big.NewFloat(math.Inf(1)), 1) },
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Error("invalid arguments didn't panic")
+				}
+			}()
+			f()
+		}()
+	}
+}
@@ -0,0 +1,130 @@
+package dist
+
+import (
+	"math/big"
+
+	"github.com/ThreeAndTwo/bigfloat"
+)
+
+// StudentTCDF returns the distribution function P(T <= t) of
+// Student's t distribution with nu > 0 degrees of freedom, which
+// needn't be an integer. Precision is the same as the one of t (64 if
+// it is 0). The tail probability is
+//
+//	P(T > |t|) = I(ν/(ν+t²); ν/2, 1/2)/2
+//
+// and it keeps its relative accuracy for t < 0, however far out. The
+// function panics if nu is not positive and finite.
+func StudentTCDF(nu, t *big.Float) *big.Float {
+
+	prec := precOf(t)
+	checkDF("StudentTCDF", nu)
+	z := new(big.Float).SetPrec(prec)
+	switch {
+	case t.IsInf():
+		if t.Sign() > 0 {
+			z.SetInt64(1)
+		}
+		return z
+	case t.Sign() == 0:
+		return z.SetFloat64(0.5)
+	}
+
+	s := studentTTail(nu, t, prec+64)
+	if t.Sign() > 0 {
+		s.Sub(big.NewFloat(1), s)
+	}
+
+	return z.Set(s)
+}
+
+// StudentTQuantile returns the t with StudentTCDF(nu, t) = p, for p
+// in [0, 1]. Precision is the same as the one of p (64 if it is 0).
+// The quantiles of p = 0 and 1 are -Inf and +Inf. The quantiles of p
+// near 1 are found from the upper tail probability 1 - p, which is
+// exact, so they are as accurate as the ones near 0. The function
+// panics if nu is not positive and finite, or if p is not in [0, 1].
+func StudentTQuantile(nu, p *big.Float) *big.Float {
+
+	prec := precOf(p)
+	checkDF("StudentTQuantile", nu)
+	checkProb("StudentTQuantile", p)
+	z := new(big.Float).SetPrec(prec)
+	half := big.NewFloat(0.5)
+	switch c := p.Cmp(half); {
+	case p.Sign() == 0:
+		return z.SetInf(true)
+	case p.Cmp(big.NewFloat(1)) == 0:
+		return z.SetInf(false)
+	case c == 0:
+		return z
+	}
+
+	// the tail probability q = min(p, 1-p), with 1 - p exact
+	wprec := prec + 64
+	q := new(big.Float).SetPrec(wprec).Set(p)
+	if p.Cmp(half) > 0 {
+		q.Sub(big.NewFloat(1), q)
+	}
+	logQ := bigfloat.Log(q)
+
+	// log(t·f(t)) = log(t) + c - (ν+1)/2·log(1 + t²/ν), with
+	// c = log Γ((ν+1)/2) - log Γ(ν/2) - log(νπ)/2
+	n := new(big.Float).SetPrec(wprec).Set(nu)
+	h := new(big.Float).SetPrec(wprec).Quo(n, big.NewFloat(2))
+	h1 := new(big.Float).SetPrec(wprec).Add(h, half)
+	c, _ := bigfloat.Lgamma(h1)
+	lg, _ := bigfloat.Lgamma(h)
+	c.Sub(c, lg)
+	l := bigfloat.Log(new(big.Float).SetPrec(wprec).Mul(n, bigfloat.Pi.Value(wprec)))
+	c.Sub(c, l.Quo(l, big.NewFloat(2)))
+
+	// g(u) = log(q) - log(P(T > t)), for t = exp(u)
+	t := solveLog(func(u *big.Float) (v, step *big.Float) {
+		t := bigfloat.Exp(u)
+		logS := bigfloat.Log(studentTTail(n, t, wprec))
+		v = new(big.Float).SetPrec(wprec).Sub(logQ, logS)
+
+		r := new(big.Float).SetPrec(wprec).Mul(t, t)
+		r.Quo(r, n).Add(r, big.NewFloat(1))
+		lxf := bigfloat.Log(r)
+		lxf.Mul(lxf, h1).Neg(lxf).Add(lxf, c).Add(lxf, u)
+
+		return v, newtonStep(v, lxf, logS)
+	}, prec)
+
+	if p.Cmp(half) < 0 {
+		t.Neg(t)
+	}
+
+	return z.Set(t)
+}
+
+// studentTTail returns P(T > |t|) at precision prec, for a finite
+// t != 0, from I(x; ν/2, 1/2) with x = ν/(ν+t²) if x < 1/2, and from
+// 1 - I(y; 1/2, ν/2) with y = t²/(ν+t²) otherwise, so that the small
+// one of x and 1 - x is rounded, and not the other.
+func studentTTail(nu, t *big.Float, prec uint) *big.Float {
+
+	t2 := new(big.Float).SetPrec(prec).Mul(t, t)
+	d := new(big.Float).SetPrec(prec).Add(nu, t2)
+	half := big.NewFloat(0.5)
+	h := new(big.Float).SetPrec(prec).Quo(nu, big.NewFloat(2))
+
+	var s *big.Float
+	if x := new(big.Float).SetPrec(prec).Quo(nu, d); x.Cmp(half) < 0 {
+		s = bigfloat.BetaInc(h, half, x)
+	} else {
+		s = bigfloat.BetaIncC(half, h, t2.Quo(t2, d))
+	}
+
+	return s.SetMantExp(s, -1)
+}
+
+// checkDF panics if the degrees of freedom nu are not positive and
+// finite.
+func checkDF(name string, nu *big.Float) {
+	if nu.Sign() <= 0 || nu.IsInf() {
+		panic(name + ": degrees of freedom are not positive and finite")
+	}
+}
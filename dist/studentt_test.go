@@ -0,0 +1,145 @@
+package dist_test
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/ThreeAndTwo/bigfloat"
+	"github.com/ThreeAndTwo/bigfloat/dist"
+)
+
+// tail2 returns P(T > |t|) for 2 degrees of freedom, as
+// 1/(s·(s + |t|)) with s = √(2 + t²).
+func tail2(t *big.Float, prec uint) *big.Float {
+
+	a := new(big.Float).SetPrec(prec + 64).Abs(t)
+	s := new(big.Float).SetPrec(prec+64).Mul(a, a)
+	s = bigfloat.Sqrt(s.Add(s, big.NewFloat(2)))
+	d := new(big.Float).Add(s, a)
+	d.Mul(d, s)
+
+	return d.Quo(big.NewFloat(1), d).SetPrec(prec)
+}
+
+func TestStudentTCDF(t *testing.T) {
+	two := big.NewFloat(2)
+	for _, prec := range []uint{53, 100, 300} {
+		for _, ts := range []string{"-1e100", "-12345.5", "-3", "-0.5", "-1e-20", "1e-20", "0.5", "3", "1e5"} {
+			x, _ := new(big.Float).SetPrec(prec).SetString(ts)
+			want := tail2(x, prec)
+			if x.Sign() > 0 {
+				want.Sub(big.NewFloat(1), want)
+			}
+			if got := dist.StudentTCDF(two, x); got.Prec() != prec || bigfloat.CmpUlp(got, want, 2) != 0 {
+				t.Errorf("StudentTCDF(2, %s) at prec %d = %g; want %g", ts, prec, got, want)
+			}
+		}
+	}
+
+	// for 1 degree of freedom, P(T < -t) = atan(1/t)/π = (1/t - 1/(3t³) + ...)/π
+	const prec = 300
+	x := new(big.Float).SetPrec(prec).SetMantExp(big.NewFloat(-1), 1000)
+	want := new(big.Float).SetPrec(prec+64).Quo(big.NewFloat(-1), x)
+	want.Quo(want, bigfloat.Pi.Value(prec+64)).SetPrec(prec)
+	if got := dist.StudentTCDF(big.NewFloat(1), x); bigfloat.CmpUlp(got, want, 2) != 0 {
+		t.Errorf("StudentTCDF(1, -2**1000) = %g; want %g", got, want)
+	}
+
+	for _, test := range []struct {
+		nu, t, want float64
+	}{
+		{3, 0, 0.5},
+		{3, math.Inf(-1), 0},
+		{3, math.Inf(1), 1},
+	} {
+		if got, _ := dist.StudentTCDF(big.NewFloat(test.nu), big.NewFloat(test.t)).Float64(); got != test.want {
+			t.Errorf("StudentTCDF(%g, %g) = %g; want %g", test.nu, test.t, got, test.want)
+		}
+	}
+}
+
+func TestStudentTQuantile(t *testing.T) {
+	// for 2 degrees of freedom, t = (2p-1)/√(2p(1-p))
+	two := big.NewFloat(2)
+	one := big.NewFloat(1)
+	for _, prec := range []uint{53, 100, 300} {
+		for _, p := range []*big.Float{
+			new(big.Float).SetMantExp(one, -1000),
+			big.NewFloat(1e-10),
+			big.NewFloat(0.3),
+			big.NewFloat(0.75),
+			big.NewFloat(0.999),
+			new(big.Float).SetPrec(prec).Sub(one, new(big.Float).SetMantExp(one, -int(prec))),
+		} {
+			p := new(big.Float).SetPrec(prec).Set(p)
+			w := prec + 1100
+			q := new(big.Float).SetPrec(w).Sub(one, p)
+			want := new(big.Float).SetPrec(w).Mul(p, q)
+			want = bigfloat.Sqrt(want.Mul(want, two))
+			want.Quo(new(big.Float).SetPrec(w).Sub(p, q), want).SetPrec(prec)
+			if got := dist.StudentTQuantile(two, p); got.Prec() != prec || bigfloat.CmpUlp(got, want, 2) != 0 {
+				t.Errorf("StudentTQuantile(2, %g) at prec %d = %g; want %g", p, prec, got, want)
+			}
+		}
+	}
+
+	// the two-sided 5% threshold for 10 degrees of freedom, from
+	// 0.975 rounded past the 200 bits of the result
+	p, _ := new(big.Float).SetPrec(300).SetString("0.975")
+	want, _ := new(big.Float).SetPrec(200).SetString("2.2281388519862747483954906632018067178560193664167331547194816473604784")
+	if got := dist.StudentTQuantile(big.NewFloat(10), p).SetPrec(200); bigfloat.CmpUlp(got, want, 2) != 0 {
+		t.Errorf("StudentTQuantile(10, 0.975) = %g; want %g", got, want)
+	}
+
+	// the quantiles of a tail probability of 1e-300 for ν = 1/2
+	nu := big.NewFloat(0.5)
+	p, _ = p.SetPrec(200).SetString("1e-300")
+	q := dist.StudentTQuantile(nu, p)
+	if got := dist.StudentTCDF(nu, q.SetPrec(264)).SetPrec(200); bigfloat.CmpUlp(got, p, 8) != 0 {
+		t.Errorf("StudentTCDF(0.5, StudentTQuantile(0.5, 1e-300)) = %g", got)
+	}
+
+	for _, test := range []struct {
+		p, want float64
+	}{
+		{0, math.Inf(-1)},
+		{0.5, 0},
+		{1, math.Inf(1)},
+	} {
+		if got, _ := dist.StudentTQuantile(two, big.NewFloat(test.p)).Float64(); got != test.want {
+			t.Errorf("StudentTQuantile(2, %g) = %g; want %g", test.p, got, test.want)
+		}
+	}
+
+	for _, f := range []func(){
+		func() { dist.StudentTQuantile(big.NewFloat(0), big.NewFloat(0.5)) },
+		func() { dist.StudentTQuantile(two, big.NewFloat(1.5)) },
+		func() { dist.StudentTCDF(big.NewFloat(-1), big.NewFloat(1)) },
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Error("invalid arguments didn't panic")
+				}
+			}()
+			f()
+		}()
+	}
+}
+
+// ---------- Benchmarks ----------
+
+func BenchmarkStudentTQuantile(b *testing.B) {
+	for _, prec := range []uint{1e2, 1e3} {
+		nu := big.NewFloat(10)
+		p := new(big.Float).SetPrec(prec).SetFloat64(0.975)
+		b.Run(fmt.Sprintf("%v", prec), func(b *testing.B) {
+			b.ReportAllocs()
+			for n := 0; n < b.N; n++ {
+				dist.StudentTQuantile(nu, p)
+			}
+		})
+	}
+}
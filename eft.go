@@ -0,0 +1,70 @@
+package bigfloat
+
+import "math/big"
+
+// TwoSum returns s = a + b, rounded to nearest even to the largest
+// precision of a and b, and the error e of the rounding, so that
+// s + e = a + b exactly. The error is exact, at the same precision as
+// s, and |e| is at most half an ulp of s; it's 0 when s is infinite.
+// TwoSum and TwoProd are the error-free transformations on which the
+// compensated algorithms are built, like the sum of Ogita, Rump and
+// Oishi, as accurate as a sum at twice the precision:
+//
+//	s, c := new(big.Float).SetPrec(prec), new(big.Float).SetPrec(prec)
+//	for _, x := range xs {
+//		var e *big.Float
+//		s, e = bigfloat.TwoSum(s, x)
+//		c.Add(c, e)
+//	}
+//	s.Add(s, c)
+//
+// The function panics with a big.ErrNaN if a and b are infinities of
+// opposite sign.
+func TwoSum(a, b *big.Float) (s, e *big.Float) {
+
+	prec := a.Prec()
+	if b.Prec() > prec {
+		prec = b.Prec()
+	}
+	s = new(big.Float).SetPrec(prec).Add(a, b)
+	e = new(big.Float).SetPrec(prec)
+	if s.IsInf() {
+		return s, e
+	}
+
+	// Knuth's TwoSum: with rounding to nearest, all the operations but
+	// the first are exact
+	bv := new(big.Float).SetPrec(prec).Sub(s, a)
+	av := new(big.Float).SetPrec(prec).Sub(s, bv)
+	av.Sub(a, av)
+	bv.Sub(b, bv)
+	e.Add(av, bv)
+
+	return s, e
+}
+
+// TwoProd returns p = a·b, rounded to nearest even to the largest
+// precision of a and b, and the error e of the rounding, so that
+// p + e = a·b exactly. The error is exact, at the same precision as
+// p, and |e| is at most half an ulp of p; it's 0 when p is infinite.
+// The function panics with a big.ErrNaN if a is 0 and b an infinity,
+// or the reverse.
+func TwoProd(a, b *big.Float) (p, e *big.Float) {
+
+	prec := a.Prec()
+	if b.Prec() > prec {
+		prec = b.Prec()
+	}
+	p = new(big.Float).SetPrec(prec).Mul(a, b)
+	e = new(big.Float).SetPrec(prec)
+	if p.IsInf() || p.Sign() == 0 {
+		return p, e
+	}
+
+	// the exact product has at most a.Prec() + b.Prec() bits, and the
+	// error of its rounding fits in prec bits
+	x := new(big.Float).SetPrec(a.Prec()+b.Prec()).Mul(a, b)
+	e.Sub(x, p)
+
+	return p, e
+}
@@ -0,0 +1,109 @@
+package bigfloat_test
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/ThreeAndTwo/bigfloat"
+)
+
+// checkEFT checks that z + e is the exact result x, whose precision
+// holds it, that e has the precision of z, and that |e| <= ulp(z)/2.
+func checkEFT(t *testing.T, name string, a, b, z, e, x *big.Float) {
+
+	prec := a.Prec()
+	if b.Prec() > prec {
+		prec = b.Prec()
+	}
+	sum := new(big.Float).SetPrec(x.Prec()).Add(z, e)
+	half := new(big.Float).SetMantExp(bigfloat.Ulp(z), -1)
+	if z.Prec() != prec || e.Prec() != prec || sum.Cmp(x) != 0 || new(big.Float).Abs(e).Cmp(half) > 0 {
+		t.Errorf("%s(%g, %g) = %g, %g; want a sum of %g", name, a, b, z, e, x)
+	}
+}
+
+func TestTwoSum(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for _, prec := range []uint{24, 53, 100, 1000} {
+		for i := 0; i < 200; i++ {
+			a := new(big.Float).SetPrec(prec).SetFloat64(r.Float64())
+			b := new(big.Float).SetPrec(prec).SetFloat64(r.NormFloat64())
+			a.SetMantExp(a, r.Intn(200)-100).Quo(a, big.NewFloat(3))
+			b.SetMantExp(b, r.Intn(200)-100).Quo(b, big.NewFloat(7))
+			s, e := bigfloat.TwoSum(a, b)
+			x := new(big.Float).SetPrec(prec+512).Add(a, b)
+			checkEFT(t, "TwoSum", a, b, s, e, x)
+		}
+	}
+
+	// an exact sum has no error, and an infinite one neither
+	if s, e := bigfloat.TwoSum(big.NewFloat(1), big.NewFloat(2)); s.Cmp(big.NewFloat(3)) != 0 || e.Sign() != 0 {
+		t.Errorf("TwoSum(1, 2) = %g, %g; want 3, 0", s, e)
+	}
+	if s, e := bigfloat.TwoSum(big.NewFloat(math.Inf(1)), big.NewFloat(2)); !s.IsInf() || e.Sign() != 0 {
+		t.Errorf("TwoSum(+Inf, 2) = %g, %g; want +Inf, 0", s, e)
+	}
+
+	// the error of 1 + 2**-100 at 53 bits is 2**-100
+	s, e := bigfloat.TwoSum(big.NewFloat(1), pow2(-100).SetPrec(53))
+	if s.Cmp(big.NewFloat(1)) != 0 || e.Cmp(pow2(-100)) != 0 {
+		t.Errorf("TwoSum(1, 2**-100) = %g, %g", s, e)
+	}
+}
+
+func TestTwoProd(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for _, prec := range []uint{24, 53, 100, 1000} {
+		for i := 0; i < 200; i++ {
+			a := new(big.Float).SetPrec(prec).SetFloat64(r.Float64())
+			b := new(big.Float).SetPrec(prec).SetFloat64(r.NormFloat64())
+			a.SetMantExp(a, r.Intn(200)-100).Quo(a, big.NewFloat(3))
+			b.SetMantExp(b, r.Intn(200)-100).Quo(b, big.NewFloat(7))
+			p, e := bigfloat.TwoProd(a, b)
+			x := new(big.Float).SetPrec(2*prec).Mul(a, b)
+			checkEFT(t, "TwoProd", a, b, p, e, x)
+		}
+	}
+
+	if p, e := bigfloat.TwoProd(big.NewFloat(0), big.NewFloat(2)); p.Sign() != 0 || e.Sign() != 0 {
+		t.Errorf("TwoProd(0, 2) = %g, %g; want 0, 0", p, e)
+	}
+}
+
+func TestTwoSumCompensated(t *testing.T) {
+	// the compensated sum of the doc of TwoSum, against the exact Sum
+	const prec = 53
+	r := rand.New(rand.NewSource(2))
+	xs := make([]*big.Float, 1000)
+	for i := range xs {
+		xs[i] = new(big.Float).SetPrec(prec).SetFloat64(math.Ldexp(r.NormFloat64(), r.Intn(40)))
+	}
+	s, c := new(big.Float).SetPrec(prec), new(big.Float).SetPrec(prec)
+	for _, x := range xs {
+		var e *big.Float
+		s, e = bigfloat.TwoSum(s, x)
+		c.Add(c, e)
+	}
+	s.Add(s, c)
+	if want := bigfloat.Sum(xs); bigfloat.CmpUlp(s, want, 1) != 0 {
+		t.Errorf("compensated sum = %g; want %g", s, want)
+	}
+}
+
+// ---------- Benchmarks ----------
+
+func BenchmarkTwoSum(b *testing.B) {
+	for _, prec := range []uint{53, 1e3, 1e4} {
+		x := new(big.Float).SetPrec(prec).Quo(big.NewFloat(1), big.NewFloat(3))
+		y := new(big.Float).SetPrec(prec).Quo(big.NewFloat(1e-10), big.NewFloat(7))
+		b.Run(fmt.Sprintf("%v", prec), func(b *testing.B) {
+			b.ReportAllocs()
+			for n := 0; n < b.N; n++ {
+				bigfloat.TwoSum(x, y)
+			}
+		})
+	}
+}
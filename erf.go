@@ -0,0 +1,88 @@
+package bigfloat
+
+import (
+	"math/big"
+)
+
+// Erf returns the error function
+//
+//	erf(x) = 2/√π ∫ exp(-t²) dt, for t from 0 to x
+//
+// Precision is the same as the one of x (64 if it is 0). It's computed
+// as P(1/2, x²) with the regularized incomplete gamma function, with
+// the sign of x, so the small values for small x keep their relative
+// accuracy. Erf(±Inf) = ±1 and Erf(±0) = ±0.
+func Erf(x *big.Float) *big.Float {
+
+	defer trace("Erf", x.Prec())()
+
+	prec := x.Prec()
+	if prec == 0 {
+		prec = 64
+	}
+	z := new(big.Float).SetPrec(prec)
+	switch {
+	case x.Sign() == 0:
+		return z.Set(x)
+	case erfSaturates(x):
+		return withSign(z.SetInt64(1), x.Sign() < 0)
+	}
+
+	z.Set(GammaInc(big.NewFloat(0.5), erfSquare(x, prec)))
+
+	return withSign(z, x.Sign() < 0)
+}
+
+// Erfc returns the complementary error function erfc(x) = 1 - erf(x).
+// Precision is the same as the one of x (64 if it is 0). It's computed
+// as Q(1/2, x²) for x > 0, with the regularized incomplete gamma
+// function, so the tiny values of the upper tail keep their relative
+// accuracy, far below the range of float64, and as 1 + P(1/2, x²)
+// for x < 0. Erfc(+Inf) = 0 and Erfc(-Inf) = 2.
+func Erfc(x *big.Float) *big.Float {
+
+	defer trace("Erfc", x.Prec())()
+
+	prec := x.Prec()
+	if prec == 0 {
+		prec = 64
+	}
+	z := new(big.Float).SetPrec(prec)
+	switch {
+	case x.Sign() == 0:
+		return z.SetInt64(1)
+	case erfSaturates(x):
+		if x.Sign() < 0 {
+			z.SetInt64(2)
+		}
+		return z
+	}
+
+	half := big.NewFloat(0.5)
+	x2 := erfSquare(x, prec)
+	if x.Sign() > 0 {
+		return z.Set(GammaIncC(half, x2))
+	}
+
+	return z.Add(GammaInc(half, x2), big.NewFloat(1))
+}
+
+// erfSquare returns x² at the precision that keeps the result of the
+// incomplete gamma function accurate to prec bits: its condition
+// number in x² is about x², and so 2·log2|x| more bits are needed.
+func erfSquare(x *big.Float, prec uint) *big.Float {
+
+	wprec := prec + 64
+	if e := x.MantExp(nil); e > 0 {
+		wprec += 2 * uint(e)
+	}
+
+	return new(big.Float).SetPrec(wprec).Mul(x, x)
+}
+
+// erfSaturates reports whether |x| >= 2**32, where exp(-x²) is below
+// the range of big.Float, and so erf(x) is ±1 and erfc(x) is 0 or 2 at
+// any precision.
+func erfSaturates(x *big.Float) bool {
+	return x.IsInf() || x.MantExp(nil) > 32
+}
@@ -0,0 +1,115 @@
+package bigfloat_test
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/ThreeAndTwo/bigfloat"
+)
+
+// erfRef returns erf(x) = 2/√π·Σ (-1)**n·x**(2n+1)/(n!·(2n+1)), with
+// the bits lost to the cancellation of the terms added.
+func erfRef(x *big.Float, prec uint) *big.Float {
+
+	x2 := new(big.Float).Mul(x, x)
+	f, _ := x2.Float64()
+	wprec := prec + 64 + uint(2*f)
+	x2.SetPrec(wprec).Mul(x, x)
+	s := new(big.Float).SetPrec(wprec)
+	t := new(big.Float).SetPrec(wprec).Set(x)
+	for n := int64(0); ; n++ {
+		if n > 0 {
+			t.Mul(t, x2).Quo(t, big.NewFloat(float64(-n)))
+		}
+		u := new(big.Float).Quo(t, big.NewFloat(float64(2*n+1)))
+		s.Add(s, u)
+		if u.Sign() == 0 || u.MantExp(nil) < s.MantExp(nil)-int(wprec) {
+			break
+		}
+	}
+	s.Mul(s, big.NewFloat(2)).Quo(s, bigfloat.Sqrt(bigfloat.Pi.Value(wprec)))
+
+	return s.SetPrec(prec)
+}
+
+// erfcRef returns erfc(x) for a large x, from the asymptotic series
+// exp(-x²)/(x√π)·Σ (-1)**n·(2n-1)!!/(2x²)**n.
+func erfcRef(x *big.Float, prec uint) *big.Float {
+
+	wprec := prec + 64
+	x2 := new(big.Float).SetPrec(wprec).Mul(x, x)
+	y := new(big.Float).SetPrec(wprec).SetMantExp(x2, 1)
+	s := new(big.Float).SetPrec(wprec)
+	t := new(big.Float).SetPrec(wprec).SetInt64(1)
+	for n := int64(0); t.Sign() != 0 && t.MantExp(nil) >= -int(wprec); n++ {
+		s.Add(s, t)
+		t.Mul(t, big.NewFloat(float64(-(2*n+1)))).Quo(t, y)
+	}
+	e := bigfloat.Exp(x2.Neg(x2))
+	s.Mul(s, e).Quo(s, x).Quo(s, bigfloat.Sqrt(bigfloat.Pi.Value(wprec)))
+
+	return s.SetPrec(prec)
+}
+
+func TestErf(t *testing.T) {
+	one := big.NewFloat(1)
+	for _, prec := range []uint{24, 53, 100, 300} {
+		for _, s := range []string{"1e-30", "0.1", "-0.5", "1", "2.5", "-4", "6"} {
+			x, _ := new(big.Float).SetPrec(prec).SetString(s)
+			want := erfRef(x, prec)
+			if got := bigfloat.Erf(x); got.Prec() != prec || bigfloat.CmpUlp(got, want, 1) != 0 {
+				t.Errorf("Erf(%s) at prec %d = %g; want %g", s, prec, got, want)
+			}
+
+			// 1 - erf(x) cancels the 52 leading bits of erf(6)
+			want = erfRef(x, prec+128)
+			want.Sub(one, want).SetPrec(prec)
+			if got := bigfloat.Erfc(x); got.Prec() != prec || bigfloat.CmpUlp(got, want, 1) != 0 {
+				t.Errorf("Erfc(%s) at prec %d = %g; want %g", s, prec, got, want)
+			}
+		}
+
+		// the tails far below the range of float64
+		for _, s := range []string{"30", "1000", "1e5"} {
+			x, _ := new(big.Float).SetPrec(prec).SetString(s)
+			if got, want := bigfloat.Erfc(x), erfcRef(x, prec); bigfloat.CmpUlp(got, want, 1) != 0 {
+				t.Errorf("Erfc(%s) at prec %d = %g; want %g", s, prec, got, want)
+			}
+		}
+	}
+
+	for _, test := range []struct {
+		x, erf, erfc float64
+	}{
+		{0, 0, 1},
+		{math.Inf(+1), 1, 0},
+		{math.Inf(-1), -1, 2},
+		{1e300, 1, 0},
+		{-1e300, -1, 2},
+	} {
+		erf, _ := bigfloat.Erf(big.NewFloat(test.x)).Float64()
+		erfc, _ := bigfloat.Erfc(big.NewFloat(test.x)).Float64()
+		if erf != test.erf || erfc != test.erfc {
+			t.Errorf("Erf, Erfc(%g) = %g, %g; want %g, %g", test.x, erf, erfc, test.erf, test.erfc)
+		}
+	}
+	if got := bigfloat.Erf(new(big.Float).Neg(new(big.Float))); !got.Signbit() {
+		t.Error("Erf(-0) is not -0")
+	}
+}
+
+// ---------- Benchmarks ----------
+
+func BenchmarkErf(b *testing.B) {
+	for _, prec := range []uint{1e2, 1e3} {
+		x := new(big.Float).SetPrec(prec).SetFloat64(0.75)
+		b.Run(fmt.Sprintf("%v", prec), func(b *testing.B) {
+			b.ReportAllocs()
+			for n := 0; n < b.N; n++ {
+				bigfloat.Erf(x)
+			}
+		})
+	}
+}
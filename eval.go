@@ -0,0 +1,444 @@
+package bigfloat
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// An EvalError records a failed call to Eval: a syntax error, an
+// unknown name, or an argument outside the domain of a function.
+type EvalError struct {
+	Expr   string // the expression given to Eval
+	Offset int    // byte offset in Expr of the offending token
+	Msg    string // description of the problem
+}
+
+func (e *EvalError) Error() string {
+	return fmt.Sprintf("bigfloat.Eval: %s at offset %d in %q", e.Msg, e.Offset, e.Expr)
+}
+
+// evalFunc is a function that can be called in the expressions given
+// to Eval.
+type evalFunc struct {
+	args int
+	f    func(x []*big.Float) *big.Float
+}
+
+var evalFuncs = map[string]evalFunc{
+	"sqrt":  {1, func(x []*big.Float) *big.Float { return Sqrt(x[0]) }},
+	"exp":   {1, func(x []*big.Float) *big.Float { return Exp(x[0]) }},
+	"log":   {1, func(x []*big.Float) *big.Float { return Log(x[0]) }},
+	"pow":   {2, func(x []*big.Float) *big.Float { return realPow("pow", x[0], x[1]) }},
+	"abs":   {1, func(x []*big.Float) *big.Float { return x[0].Abs(x[0]) }},
+	"floor": {1, func(x []*big.Float) *big.Float { return Floor(x[0]) }},
+	"ceil":  {1, func(x []*big.Float) *big.Float { return Ceil(x[0]) }},
+	"trunc": {1, func(x []*big.Float) *big.Float { return Trunc(x[0]) }},
+	"round": {1, func(x []*big.Float) *big.Float { return Round(x[0]) }},
+	"min":   {2, func(x []*big.Float) *big.Float { return Min(x[0], x[1]) }},
+	"max":   {2, func(x []*big.Float) *big.Float { return Max(x[0], x[1]) }},
+	"sin":   {1, func(x []*big.Float) *big.Float { s, _ := finiteSincos("sin", x[0]); return s }},
+	"cos":   {1, func(x []*big.Float) *big.Float { _, c := finiteSincos("cos", x[0]); return c }},
+	"tan": {1, func(x []*big.Float) *big.Float {
+		s, c := finiteSincos("tan", x[0])
+		return s.Quo(s, c)
+	}},
+}
+
+// finiteSincos returns sincos(x), and panics if x is infinite.
+func finiteSincos(name string, x *big.Float) (sin, cos *big.Float) {
+
+	if x.IsInf() {
+		panic(name + ": infinite argument")
+	}
+
+	return sincos(x)
+}
+
+// argTooLarge reports whether the argument of the function name is
+// 2**maxExp or more in magnitude, for the functions whose cost grows
+// with the exponent of their argument, if maxExp > 0.
+func argTooLarge(name string, x []*big.Float, maxExp int) bool {
+
+	switch name {
+	case "sin", "cos", "tan":
+		return maxExp > 0 && x[0].MantExp(nil) > maxExp
+	}
+
+	return false
+}
+
+// realPow returns x**y, with Pow for x ≥ 0. For x < 0, the power is
+// real when y is an integer, and it's computed with PowInt, or when y
+// is a fraction p/q with an odd denominator q, as 1/3 rounded to the
+// working precision is, and it's then Root(x, q)**p. The function
+// panics, with name, for the other negative x.
+func realPow(name string, x, y *big.Float) *big.Float {
+
+	if x.Sign() >= 0 {
+		return Pow(x, y)
+	}
+	if y.IsInf() {
+		panic(name + ": negative base and infinite exponent")
+	}
+	if y.IsInt() {
+		n, _ := y.Int(nil)
+		return PowInt(x, n)
+	}
+
+	// the simplest fraction within an ulp of y, with a denominator
+	// small enough not to be found by chance
+	tol := new(big.Float).SetMantExp(big.NewFloat(1), y.MantExp(nil)-int(y.Prec()))
+	r := RatApprox(y, tol)
+	q := r.Denom()
+	if q.Bit(0) == 0 || q.BitLen() > 20 {
+		panic(name + ": negative base and exponent not a fraction with an odd denominator")
+	}
+
+	// the root is raised to the power p, which multiplies its error by
+	// p, so it's computed with the bits of p more
+	p := r.Num()
+	prec := x.Prec()
+	root := Root(new(big.Float).SetPrec(prec+uint(p.BitLen())+16).Set(x), int(q.Int64()))
+
+	return PowInt(root, p).SetPrec(prec)
+}
+
+// Eval evaluates the arithmetic expression expr, and returns the
+// result rounded to prec bits (64 if prec is 0), using the
+// ToNearestEven rounding mode.
+//
+// An expression is made of numbers, written as decimal floating-point
+// literals such as "2", "0.5" or "1e-10", of the operators + and -
+// (binary and unary), * and /, and ^ (exponentiation, which is right
+// associative and binds tighter than unary minus, so -2^2 = -4), of
+// parentheses, of the names of vars and of the constants pi and e,
+// and of calls to the functions
+//
+//	sqrt exp log pow abs floor ceil trunc round min max sin cos tan
+//
+// where pow, min and max take two arguments, separated by a comma. A
+// negative number raised to an integer power, or to a fraction with an
+// odd denominator, as (-8)^(1/3) = -2, is a real number; the other
+// powers of negative numbers are outside the domain.
+// Variables hide the constants with the same name. White space
+// between the tokens is ignored.
+//
+// All the intermediate results are computed with 64 bits more than
+// prec, so the result is accurate unless the expression is badly
+// conditioned, as 1e40 + 1 - 1e40 is; a Tracked value can be used to
+// check that.
+//
+// If expr isn't a valid expression, or if an argument is outside the
+// domain of a function, the error is an *EvalError reporting the
+// position of the offending token. Divisions by zero give infinities.
+// The results out of the range of big.Float give ±Inf or ±0, and, if
+// the RangeMode is ReportRange, the result is returned with a
+// *RangeError for the first of them.
+func Eval(expr string, vars map[string]*big.Float, prec uint) (z *big.Float, err error) {
+	return eval(expr, vars, prec, 0)
+}
+
+// eval is Eval, but the arguments of sin, cos and tan of 2**maxExp or
+// more in magnitude, if maxExp > 0, are outside the domain.
+func eval(expr string, vars map[string]*big.Float, prec uint, maxExp int) (z *big.Float, err error) {
+
+	if prec == 0 {
+		prec = 64
+	}
+
+	e := evaluator{in: expr, vars: vars, prec: prec + 64, maxExp: maxExp}
+	defer func() {
+		if r := recover(); r != nil {
+			// a panic of an operation, like the square root of a
+			// negative number
+			var msg string
+			switch r := r.(type) {
+			case string:
+				msg = r
+			case big.ErrNaN:
+				msg = r.Error()
+			default:
+				panic(r)
+			}
+			z, err = nil, &EvalError{expr, e.opOff, msg}
+		}
+	}()
+
+	e.next()
+	x := e.expr()
+	if e.err == nil && e.tok != "" {
+		e.errorf("unexpected %q", e.tok)
+	}
+	if e.err != nil {
+		return nil, e.err
+	}
+
+	return new(big.Float).SetPrec(prec).Set(x), e.rerr
+}
+
+// evaluator holds the state of Eval, which evaluates the expression
+// while parsing it by recursive descent.
+type evaluator struct {
+	in     string
+	vars   map[string]*big.Float
+	prec   uint // working precision
+	maxExp int  // largest exponent of the arguments of sin, cos and tan, if > 0
+
+	tok    string // current token; "" at the end
+	tokOff int    // offset of tok
+	i      int    // offset after tok
+	opOff  int    // offset of the last operation started
+	err    error
+	rerr   error // the first *RangeError
+}
+
+// checkRange records in e.rerr the first result out of range.
+func (e *evaluator) checkRange(name string, z *big.Float, args ...*big.Float) {
+	if e.rerr == nil {
+		e.rerr = checkRange(name, z, args...)
+	}
+}
+
+func (e *evaluator) errorf(format string, args ...interface{}) {
+	if e.err == nil {
+		e.err = &EvalError{e.in, e.tokOff, fmt.Sprintf(format, args...)}
+	}
+}
+
+// next moves to the next token, a number, a name or a single
+// character.
+func (e *evaluator) next() {
+
+	for e.i < len(e.in) && isSpace(e.in[e.i]) {
+		e.i++
+	}
+	e.tokOff = e.i
+	if e.i == len(e.in) {
+		e.tok = ""
+		return
+	}
+
+	j := e.i
+	switch c := e.in[j]; {
+	case isDigit(c) || c == '.':
+		for j < len(e.in) && (isDigit(e.in[j]) || e.in[j] == '.') {
+			j++
+		}
+		if j < len(e.in) && (e.in[j] == 'e' || e.in[j] == 'E') {
+			j++
+			if j < len(e.in) && (e.in[j] == '+' || e.in[j] == '-') {
+				j++
+			}
+			for j < len(e.in) && isDigit(e.in[j]) {
+				j++
+			}
+		}
+	case isLetter(c):
+		for j < len(e.in) && (isLetter(e.in[j]) || isDigit(e.in[j])) {
+			j++
+		}
+	default:
+		j++
+	}
+	e.tok, e.i = e.in[e.i:j], j
+}
+
+// expr parses and evaluates term {("+" | "-") term}.
+func (e *evaluator) expr() *big.Float {
+
+	x := e.term()
+	for e.err == nil && (e.tok == "+" || e.tok == "-") {
+		op, off := e.tok, e.tokOff
+		e.next()
+		y := e.term()
+		if e.err != nil {
+			break
+		}
+		e.opOff = off
+		z := new(big.Float).SetPrec(x.Prec())
+		if op == "+" {
+			z.Add(x, y)
+		} else {
+			z.Sub(x, y)
+		}
+		e.checkRange(op, z, x, y)
+		x = z
+	}
+
+	return x
+}
+
+// term parses and evaluates unary {("*" | "/") unary}.
+func (e *evaluator) term() *big.Float {
+
+	x := e.unary()
+	for e.err == nil && (e.tok == "*" || e.tok == "/") {
+		op, off := e.tok, e.tokOff
+		e.next()
+		y := e.unary()
+		if e.err != nil {
+			break
+		}
+		e.opOff = off
+		z := new(big.Float).SetPrec(x.Prec())
+		if op == "*" {
+			z.Mul(x, y)
+		} else {
+			z.Quo(x, y)
+		}
+		e.checkRange(op, z, x, y)
+		x = z
+	}
+
+	return x
+}
+
+// unary parses and evaluates {"-" | "+"} power.
+func (e *evaluator) unary() *big.Float {
+
+	if e.tok == "-" || e.tok == "+" {
+		neg := e.tok == "-"
+		e.next()
+		x := e.unary()
+		if neg {
+			x.Neg(x)
+		}
+		return x
+	}
+
+	return e.power()
+}
+
+// power parses and evaluates primary ["^" unary].
+func (e *evaluator) power() *big.Float {
+
+	x := e.primary()
+	if e.err == nil && e.tok == "^" {
+		off := e.tokOff
+		e.next()
+		y := e.unary()
+		if e.err != nil {
+			return x
+		}
+		e.opOff = off
+		z := realPow("^", x, y)
+		e.checkRange("^", z, x, y)
+		x = z
+	}
+
+	return x
+}
+
+// primary parses and evaluates a number, a name, a call or a
+// parenthesized expression.
+func (e *evaluator) primary() *big.Float {
+
+	x := new(big.Float).SetPrec(e.prec)
+	if e.err != nil {
+		return x
+	}
+
+	tok, off := e.tok, e.tokOff
+	switch {
+	case tok == "":
+		e.errorf("unexpected end of expression")
+	case tok == "(":
+		e.next()
+		x = e.expr()
+		e.expect(")")
+	case isDigit(tok[0]) || tok[0] == '.':
+		if _, _, err := x.Parse(tok, 10); err != nil {
+			e.errorf("invalid number %q", tok)
+		}
+		e.next()
+	case isLetter(tok[0]):
+		e.next()
+		if e.tok == "(" {
+			return e.call(tok, off)
+		}
+		if v, ok := e.vars[tok]; ok {
+			x.Set(v)
+		} else if tok == "pi" {
+			x = pi(e.prec)
+		} else if tok == "e" {
+			x = Exp(x.SetInt64(1))
+		} else {
+			e.tokOff = off
+			e.errorf("unknown name %q", tok)
+		}
+	default:
+		e.errorf("unexpected %q", tok)
+	}
+
+	return x
+}
+
+// call parses and evaluates the arguments of a call to the function
+// name at offset off, and calls it; the current token is "(". After
+// an error, the result is 0.
+func (e *evaluator) call(name string, off int) *big.Float {
+
+	zero := new(big.Float).SetPrec(e.prec)
+	f, ok := evalFuncs[name]
+	if !ok {
+		e.tokOff = off
+		e.errorf("unknown function %q", name)
+		return zero
+	}
+
+	var args []*big.Float
+	e.next()
+	for e.err == nil {
+		args = append(args, e.expr())
+		if e.tok != "," {
+			break
+		}
+		e.next()
+	}
+	e.expect(")")
+	if e.err != nil {
+		return zero
+	}
+	if len(args) != f.args {
+		e.tokOff = off
+		e.errorf("%s takes %d arguments, not %d", name, f.args, len(args))
+		return zero
+	}
+	if argTooLarge(name, args, e.maxExp) {
+		e.tokOff = off
+		e.errorf("%s: argument too large", name)
+		return zero
+	}
+
+	e.opOff = off
+	z := f.f(args)
+	e.checkRange(name, z, args...)
+
+	return z
+}
+
+// expect moves past the token tok, which must be the current one.
+func (e *evaluator) expect(tok string) {
+
+	if e.err != nil {
+		return
+	}
+	if e.tok != tok {
+		if e.tok == "" {
+			e.errorf("missing %q", tok)
+		} else {
+			e.errorf("unexpected %q, want %q", e.tok, tok)
+		}
+		return
+	}
+
+	e.next()
+}
+
+func isDigit(c byte) bool {
+	return '0' <= c && c <= '9'
+}
+
+func isLetter(c byte) bool {
+	return 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || c == '_'
+}
@@ -0,0 +1,167 @@
+package bigfloat_test
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/ThreeAndTwo/bigfloat"
+)
+
+func TestEval(t *testing.T) {
+
+	vars := map[string]*big.Float{
+		"x":  big.NewFloat(3),
+		"y2": big.NewFloat(0.25),
+		"e":  big.NewFloat(10), // hides the constant
+	}
+	for _, test := range []struct {
+		expr string
+		want string
+	}{
+		{"1 + 2 * 3", "7"},
+		{"(1 + 2) * 3", "9"},
+		{"2 ^ 3 ^ 2", "512"},
+		{"-2^2", "-4"},
+		{"2^-1", "0.5"},
+		{"- -x", "3"},
+		{"10 - 4 - 3", "3"},
+		{"1 / 4 / 2", "0.125"},
+		{"x * y2", "0.75"},
+		{"e", "10"},
+		{"1.5e3 + .5", "1500.5"},
+		{"sqrt(16) + abs(-1)", "5"},
+		{"pow(2, 10)", "1024"},
+		{"max(x, 7) - min(x, 7)", "4"},
+		{"floor(-1.5) + ceil(1.2) + trunc(-2.7) + round(2.5)", "1"},
+		{"exp(log(5))", "5"},
+		{"1 / 0", "+Inf"},
+	} {
+		z, err := bigfloat.Eval(test.expr, vars, 53)
+		if err != nil {
+			t.Errorf("Eval(%q): %v", test.expr, err)
+			continue
+		}
+		if got := z.Text('g', 17); got != test.want {
+			t.Errorf("Eval(%q) = %s; want %s", test.expr, got, test.want)
+		}
+		if z.Prec() != 53 {
+			t.Errorf("Eval(%q) has precision %d; want 53", test.expr, z.Prec())
+		}
+	}
+}
+
+func TestEvalPrecision(t *testing.T) {
+
+	const prec = 300
+	z, err := bigfloat.Eval("4*(sin(pi/6) + cos(pi/3)) - 3 + tan(pi/4) * sqrt(2)^2 - e^1", nil, prec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := bigfloat.Exp(new(big.Float).SetPrec(prec + 64).SetInt64(1))
+	want.Sub(big.NewFloat(3), want).SetPrec(prec)
+	if bigfloat.CmpUlp(z, want, 1) != 0 {
+		t.Errorf("Eval = %.90g; want %.90g", z, want)
+	}
+
+	// the literals are rounded to the working precision, not to 53 bits
+	z, _ = bigfloat.Eval("0.1 * 3", nil, prec)
+	want, _, _ = bigfloat.Parse("0.3", prec)
+	if z.Cmp(want) != 0 {
+		t.Errorf("Eval(0.1 * 3) = %.90g; want %.90g", z, want)
+	}
+}
+
+func TestEvalErrors(t *testing.T) {
+	for _, test := range []struct {
+		expr   string
+		offset int
+	}{
+		{"", 0},
+		{"1 +", 3},
+		{"(1 + 2", 6},
+		{"1 + 2)", 5},
+		{"1 2", 2},
+		{"2 * foo", 4},
+		{"bar(1)", 0},
+		{"pow(1)", 0},
+		{"sqrt(1, 2)", 0},
+		{"1.2.3", 0},
+		{"1e", 0},
+		{"1 $ 2", 2},
+		{"1 + sqrt(-1)", 4},
+		{"log(-2)", 0},
+		{"0 / 0", 2},
+		{"sin(1/0)", 0},
+		{"1 + (-2)^0.5", 8},
+		{"pow(-2, 0.1)", 0},
+		{"(-2)^(1/0)", 4},
+	} {
+		_, err := bigfloat.Eval(test.expr, nil, 0)
+		var e *bigfloat.EvalError
+		if !errors.As(err, &e) {
+			t.Errorf("Eval(%q) gave error %v; want an *EvalError", test.expr, err)
+			continue
+		}
+		if e.Offset != test.offset {
+			t.Errorf("Eval(%q) gave an error at offset %d; want %d (%v)", test.expr, e.Offset, test.offset, err)
+		}
+	}
+}
+
+func TestEvalNegativePow(t *testing.T) {
+
+	const prec = 200
+	for _, test := range []struct {
+		expr string
+		want func() *big.Float
+	}{
+		{"(-3)^41", func() *big.Float {
+			n := new(big.Int).Exp(big.NewInt(3), big.NewInt(41), nil)
+			return new(big.Float).SetPrec(prec).SetInt(n.Neg(n))
+		}},
+		{"pow(-3, 40)", func() *big.Float {
+			n := new(big.Int).Exp(big.NewInt(3), big.NewInt(40), nil)
+			return new(big.Float).SetPrec(prec).SetInt(n)
+		}},
+		{"(-7)^-3", func() *big.Float {
+			return new(big.Float).SetPrec(prec).Quo(big.NewFloat(-1), big.NewFloat(343))
+		}},
+		{"(-1.5)^201", func() *big.Float {
+			n := new(big.Int).Exp(big.NewInt(3), big.NewInt(201), nil)
+			z := new(big.Float).SetPrec(prec).SetInt(n.Neg(n))
+			return z.SetMantExp(z, -201)
+		}},
+		{"(-8)^(1/3)", func() *big.Float { return new(big.Float).SetPrec(prec).SetInt64(-2) }},
+		{"pow(-32, 3/5)", func() *big.Float { return new(big.Float).SetPrec(prec).SetInt64(-8) }},
+		{"(-2)^(2/3)", func() *big.Float {
+			return bigfloat.Root(new(big.Float).SetPrec(prec).SetInt64(4), 3)
+		}},
+		{"(-2)^(-1/3)", func() *big.Float {
+			z := bigfloat.Root(new(big.Float).SetPrec(prec+64).SetInt64(-2), 3)
+			return z.Quo(big.NewFloat(1), z).SetPrec(prec)
+		}},
+	} {
+		z, err := bigfloat.Eval(test.expr, nil, prec)
+		if err != nil {
+			t.Errorf("Eval(%q): %v", test.expr, err)
+			continue
+		}
+		if want := test.want(); bigfloat.CmpUlp(z, want, 1) != 0 {
+			t.Errorf("Eval(%q) =\n%.70g;\nwant %.70g", test.expr, z, want)
+		}
+	}
+}
+
+// ---------- Benchmarks ----------
+
+func BenchmarkEval(b *testing.B) {
+	for _, prec := range []uint{64, 1000} {
+		b.Run(fmt.Sprintf("%v", prec), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				bigfloat.Eval("sqrt(2) * exp(1/3) + log(10)^2", nil, prec)
+			}
+		})
+	}
+}
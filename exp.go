@@ -10,6 +10,8 @@ import (
 // when z = +Inf, and 0 when z = -Inf.
 func Exp(z *big.Float) *big.Float {
 
+	defer trace("Exp", z.Prec())()
+
 	// exp(0) == 1
 	if z.Sign() == 0 {
 		return big.NewFloat(1).SetPrec(z.Prec())
@@ -0,0 +1,171 @@
+package bigfloat
+
+import (
+	"math/big"
+	"math/bits"
+)
+
+// factExactBits is the size in bits, as estimated by n·log2(n), of the
+// largest integers that Factorial and Binomial compute exactly at
+// small precisions.
+const factExactBits = 1 << 16
+
+// exactProduct reports whether a product of n factors of up to b bits
+// is small enough to be computed exactly at prec bits: the exact
+// product is as fast as Stirling's series while its size is not much
+// larger than the precision.
+func exactProduct(n uint64, b int, prec uint) bool {
+
+	size := n * uint64(b)
+	if n != 0 && size/n != uint64(b) {
+		return false
+	}
+
+	return size <= factExactBits || size <= 8*uint64(prec)
+}
+
+// Factorial returns n! to prec bits (64 if prec is 0). Small factorials
+// are computed exactly, as integers, and rounded once; the others are
+// computed as exp(log Γ(n+1)), from Stirling's series. The result
+// overflows to +Inf when n! is out of the range of big.Float, for n
+// above about 8.6e7.
+func Factorial(n uint64, prec uint) *big.Float {
+
+	if prec == 0 {
+		prec = 64
+	}
+	z := new(big.Float).SetPrec(prec)
+	if n < 2 {
+		return z.SetInt64(1)
+	}
+
+	if exactProduct(n, bits.Len64(n), prec) {
+		return z.SetInt(mulRange(1, n))
+	}
+
+	x := new(big.Float).SetUint64(n)
+	x.Add(x, big.NewFloat(1))
+
+	return z.Set(expLog(lgammaStirling(x, int(prec)+64), prec))
+}
+
+// LogFactorial returns log(n!) = log Γ(n+1) to prec bits (64 if prec
+// is 0). Small factorials are computed exactly, as integers, and their
+// logarithm is taken; the others come from Stirling's series, at an
+// accuracy relative to the size of log(n!), with log(2π)/2 and the
+// Bernoulli numbers taken from the cache. It's Lgamma(n+1), without
+// the big.Float of n+1 and its precision.
+func LogFactorial(n uint64, prec uint) *big.Float {
+
+	if prec == 0 {
+		prec = 64
+	}
+	z := new(big.Float).SetPrec(prec)
+	if n < 2 {
+		return z
+	}
+
+	if exactProduct(n, bits.Len64(n), prec) {
+		f := new(big.Float).SetPrec(prec + 64).SetInt(mulRange(1, n))
+		return z.Set(Log(f))
+	}
+
+	// log(n!) is about n·log(n), with the bits of n and of log(n)
+	x := new(big.Float).SetUint64(n)
+	x.Add(x, big.NewFloat(1))
+	e := bits.Len64(n)
+
+	return z.Set(lgammaStirling(x, int(prec)+64-e-bits.Len(uint(e))))
+}
+
+// Binomial returns the binomial coefficient (n k) = n!/(k!·(n-k)!) to
+// prec bits (64 if prec is 0); it is 0 for k > n. Small coefficients
+// are computed exactly, as integers, and rounded once; the others are
+// computed from the logarithms of the three factorials, with
+// Stirling's series, at a precision high enough for their
+// cancellation. So the coefficients with n up to about 2.1e9, at any k,
+// are in the range of big.Float, as needed by the probability mass
+// functions of the binomial distribution; the larger ones can overflow
+// to +Inf.
+func Binomial(n, k uint64, prec uint) *big.Float {
+
+	if prec == 0 {
+		prec = 64
+	}
+	z := new(big.Float).SetPrec(prec)
+	if k > n {
+		return z
+	}
+	if k > n-k {
+		k = n - k
+	}
+	if k == 0 {
+		return z.SetInt64(1)
+	}
+
+	if exactProduct(k, bits.Len64(n), prec) {
+		b := mulRange(n-k+1, n)
+		return z.SetInt(b.Quo(b, mulRange(1, k)))
+	}
+
+	// the three logarithms have absolute errors, which add up
+	wprec := prec + 66
+	l := new(big.Float).SetPrec(wprec + 2*uint(bits.Len64(n)))
+	one := big.NewFloat(1)
+	lg := func(m uint64) *big.Float {
+		x := new(big.Float).SetUint64(m)
+		return lgammaStirling(x.Add(x, one), int(wprec))
+	}
+	l.Sub(lg(n), lg(k))
+	l.Sub(l, lg(n-k))
+
+	return z.Set(expLog(l, prec))
+}
+
+// mulRange returns the product of the integers from a to b, for
+// 0 < a <= b.
+func mulRange(a, b uint64) *big.Int {
+
+	// big.Int.MulRange takes int64
+	if b <= 1<<63-1 {
+		return new(big.Int).MulRange(int64(a), int64(b))
+	}
+	z := big.NewInt(1)
+	t := new(big.Int)
+	for i := a; ; i++ {
+		z.Mul(z, t.SetUint64(i))
+		if i == b {
+			return z
+		}
+	}
+}
+
+// expLog returns exp(l) to prec bits, for an l with an absolute error
+// below 2**-(prec+64), as 2**k·exp(r), for l = k·log(2) + r, so that
+// large values of l, up to the range of big.Float, take a single
+// evaluation of Exp.
+func expLog(l *big.Float, prec uint) *big.Float {
+
+	z := new(big.Float).SetPrec(prec)
+	ln2 := Ln2.Value(64)
+	kf := new(big.Float).Quo(l, ln2)
+	k, _ := kf.Int(nil)
+	if !k.IsInt64() || k.Int64() > big.MaxExp+1 || k.Int64() < big.MinExp-1 {
+		if l.Sign() > 0 {
+			return z.SetInf(false)
+		}
+		return z
+	}
+
+	// r = l - k·log(2), with no error from log(2) at prec bits
+	ki := k.Int64()
+	wprec := prec + 64 + uint(bits.Len64(uint64(abs64(ki))))
+	r := new(big.Float).SetPrec(wprec).SetInt64(ki)
+	r.Mul(r, Ln2.Value(wprec))
+	r.Sub(new(big.Float).SetPrec(wprec).Set(l), r)
+	r.SetPrec(prec + 64)
+	e := Exp(r)
+
+	// SetMantExp keeps the precision of e
+	return z.SetMantExp(e, int(ki)).SetPrec(prec)
+}
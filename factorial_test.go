@@ -0,0 +1,153 @@
+package bigfloat_test
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/ThreeAndTwo/bigfloat"
+)
+
+func TestFactorial(t *testing.T) {
+	for _, prec := range []uint{0, 24, 53, 100, 300} {
+		wp := prec
+		if wp == 0 {
+			wp = 64
+		}
+		// the small ones are exact, the large ones from Stirling's series
+		for _, n := range []uint64{0, 1, 2, 3, 10, 20, 21, 100, 1000, 5000, 20000, 70000} {
+			exact := big.NewInt(1)
+			if n > 1 {
+				exact.MulRange(2, int64(n))
+			}
+			want := new(big.Float).SetPrec(wp).SetInt(exact)
+			if got := bigfloat.Factorial(n, prec); got.Prec() != wp || bigfloat.CmpUlp(got, want, 1) != 0 {
+				t.Errorf("Factorial(%d, %d) = %g; want %g", n, prec, got, want)
+			}
+		}
+	}
+
+	// the largest n! in the range of big.Float
+	if got := bigfloat.Factorial(86181405, 100); got.IsInf() || got.MantExp(nil) != 2147483626 {
+		t.Errorf("Factorial(86181405) has exponent %d; want 2147483626", got.MantExp(nil))
+	}
+	if got := bigfloat.Factorial(86181406, 100); !got.IsInf() {
+		t.Errorf("Factorial(86181406) has exponent %d; want +Inf", got.MantExp(nil))
+	}
+}
+
+func TestLogFactorial(t *testing.T) {
+	for _, prec := range []uint{0, 24, 53, 100, 300} {
+		wp := prec
+		if wp == 0 {
+			wp = 64
+		}
+		for _, n := range []uint64{0, 1, 2, 3, 10, 100, 1000, 5000, 20000} {
+			exact := big.NewInt(1)
+			if n > 1 {
+				exact.MulRange(2, int64(n))
+			}
+			want := bigfloat.Log(new(big.Float).SetPrec(wp + 64).SetInt(exact)).SetPrec(wp)
+			if got := bigfloat.LogFactorial(n, prec); got.Prec() != wp || bigfloat.CmpUlp(got, want, 1) != 0 {
+				t.Errorf("LogFactorial(%d, %d) = %g; want %g", n, prec, got, want)
+			}
+		}
+	}
+
+	// Lgamma(n+1), at the precision of n+1
+	for _, n := range []uint64{1e6, 1e15, 1<<62 + 1} {
+		x := new(big.Float).SetPrec(200).SetUint64(n)
+		want, _ := bigfloat.Lgamma(x.Add(x, big.NewFloat(1)))
+		want.SetPrec(100)
+		if got := bigfloat.LogFactorial(n, 100); bigfloat.CmpUlp(got, want, 1) != 0 {
+			t.Errorf("LogFactorial(%d) = %g; want %g", n, got, want)
+		}
+	}
+}
+
+func TestBinomial(t *testing.T) {
+	for _, test := range []struct {
+		n, k uint64
+		prec uint
+	}{
+		{0, 0, 53},
+		{1, 0, 53},
+		{1, 1, 53},
+		{5, 2, 53},
+		{10, 3, 0},
+		{62, 31, 53},
+		{1000, 500, 100},
+		{100000, 50000, 100},
+		{1000000, 10000, 200},
+		{1000000, 999000, 200},
+		{3000, 1500, 2000},
+	} {
+		wp := test.prec
+		if wp == 0 {
+			wp = 64
+		}
+		exact := new(big.Int).Binomial(int64(test.n), int64(test.k))
+		want := new(big.Float).SetPrec(wp).SetInt(exact)
+		if got := bigfloat.Binomial(test.n, test.k, test.prec); got.Prec() != wp || bigfloat.CmpUlp(got, want, 1) != 0 {
+			t.Errorf("Binomial(%d, %d, %d) = %g; want %g", test.n, test.k, test.prec, got, want)
+		}
+	}
+
+	if got := bigfloat.Binomial(3, 4, 53); got.Sign() != 0 {
+		t.Errorf("Binomial(3, 4) = %g; want 0", got)
+	}
+
+	// at n = 1e9, (n k+1) = (n k)·(n-k)/(k+1)
+	const n = 1000000000
+	for _, k := range []uint64{100000, 300000000, 500000000} {
+		a := bigfloat.Binomial(n, k, 100)
+		b := bigfloat.Binomial(n, k+1, 100)
+		if a.IsInf() || a.Sign() <= 0 {
+			t.Errorf("Binomial(%d, %d) = %g; want a finite value", n, k, a)
+			continue
+		}
+		want := new(big.Float).SetPrec(100).Mul(a, new(big.Float).SetUint64(n-k))
+		want.Quo(want, new(big.Float).SetUint64(k+1))
+		if bigfloat.CmpUlp(b, want, 4) != 0 {
+			t.Errorf("Binomial(%d, %d) = %g; want %g", n, k+1, b, want)
+		}
+		if c := bigfloat.Binomial(n, n-k, 100); c.Cmp(a) != 0 {
+			t.Errorf("Binomial(%d, %d) = %g; want %g", n, n-k, c, a)
+		}
+	}
+}
+
+// ---------- Benchmarks ----------
+
+func BenchmarkFactorial(b *testing.B) {
+	for _, prec := range []uint{1e2, 1e3, 1e4} {
+		b.Run(fmt.Sprintf("%v", prec), func(b *testing.B) {
+			b.ReportAllocs()
+			for n := 0; n < b.N; n++ {
+				bigfloat.Factorial(1e7, prec)
+			}
+		})
+	}
+}
+
+func BenchmarkLogFactorial(b *testing.B) {
+	for _, prec := range []uint{1e2, 1e3, 1e4} {
+		b.Run(fmt.Sprintf("%v", prec), func(b *testing.B) {
+			b.ReportAllocs()
+			for n := 0; n < b.N; n++ {
+				bigfloat.LogFactorial(1e12, prec)
+			}
+		})
+	}
+}
+
+func BenchmarkBinomial(b *testing.B) {
+	for _, prec := range []uint{1e2, 1e3, 1e4} {
+		b.Run(fmt.Sprintf("%v", prec), func(b *testing.B) {
+			b.ReportAllocs()
+			for n := 0; n < b.N; n++ {
+				bigfloat.Binomial(1e9, 5e8, prec)
+			}
+		})
+	}
+}
@@ -0,0 +1,157 @@
+package fft
+
+import (
+	"math/big"
+	"math/bits"
+
+	"github.com/ThreeAndTwo/bigfloat"
+)
+
+// directMax is the largest length of the shorter input that Convolve
+// and CyclicConvolve compute directly, and not with an FFT.
+const directMax = 32
+
+// Convolve returns the linear convolution of x and y, with the
+// len(x)+len(y)-1 values
+//
+//	z[k] = Σ x[j]·y[k-j]
+//
+// the coefficients of the product of the polynomials of coefficients
+// x and y, rounded to prec bits (64 if prec is 0). It's empty if x or
+// y is. If the shorter of x and y has at most 32 values, the sums are
+// computed directly, with bigfloat.Dot: they are exact, and rounded
+// once when the coefficients have at most prec bits. Otherwise they're
+// computed with an FFT, with 64 guard bits: the error of every value
+// is then far below 2**-prec·‖x‖·‖y‖, for the Euclidean norms of x and
+// y, so the values larger than 2**-48·‖x‖·‖y‖ are within an ulp, and
+// the ones far smaller have fewer correct bits, as from any FFT.
+func Convolve(x, y []*big.Float, prec uint) []*big.Float {
+
+	if prec == 0 {
+		prec = 64
+	}
+	if len(x) == 0 || len(y) == 0 {
+		return []*big.Float{}
+	}
+
+	n := len(x) + len(y) - 1
+	if len(x) <= directMax || len(y) <= directMax {
+		z := make([]*big.Float, n)
+		for k := range z {
+			lo, hi := k-len(y)+1, k
+			if lo < 0 {
+				lo = 0
+			}
+			if hi > len(x)-1 {
+				hi = len(x) - 1
+			}
+			z[k] = dot(x, y, lo, hi, func(j int) int { return k - j }, prec)
+		}
+		return z
+	}
+
+	z := convolveFFT(x, y, prec)
+	for _, v := range z {
+		v.SetPrec(prec)
+	}
+
+	return z
+}
+
+// CyclicConvolve returns the cyclic convolution of x and y, of the
+// same length n, with the n values
+//
+//	z[k] = Σ x[j]·y[(k-j) mod n]
+//
+// rounded to prec bits (64 if prec is 0), and computed as by Convolve.
+// The function panics if x and y have different lengths.
+func CyclicConvolve(x, y []*big.Float, prec uint) []*big.Float {
+
+	if len(x) != len(y) {
+		panic("CyclicConvolve: slices of different lengths")
+	}
+	if prec == 0 {
+		prec = 64
+	}
+
+	n := len(x)
+	if n <= directMax {
+		z := make([]*big.Float, n)
+		for k := range z {
+			z[k] = dot(x, y, 0, n-1, func(j int) int { return (k - j + n) % n }, prec)
+		}
+		return z
+	}
+
+	// the linear convolution folded on n values
+	l := convolveFFT(x, y, prec)
+	z := l[:n]
+	for k := n; k < len(l); k++ {
+		z[k-n].Add(z[k-n], l[k])
+	}
+	for _, v := range z {
+		v.SetPrec(prec)
+	}
+
+	return z
+}
+
+// dot returns the sum of x[j]·y[i(j)] for j from lo to hi, rounded to
+// prec bits, with bigfloat.Dot: a zero of prec bits makes its result
+// at least as precise.
+func dot(x, y []*big.Float, lo, hi int, i func(j int) int, prec uint) *big.Float {
+
+	xs := make([]*big.Float, 0, hi-lo+2)
+	ys := make([]*big.Float, 0, hi-lo+2)
+	for j := lo; j <= hi; j++ {
+		xs = append(xs, x[j])
+		ys = append(ys, y[i(j)])
+	}
+	zero := new(big.Float).SetPrec(prec)
+	xs = append(xs, zero)
+	ys = append(ys, zero)
+
+	return bigfloat.Dot(xs, ys).SetPrec(prec)
+}
+
+// convolveFFT returns the linear convolution of x and y, computed with
+// an FFT of the smallest power of 2 of at least len(x)+len(y)-1 values,
+// at the precision prec plus 64 guard bits, plus the bits of the sums
+// of the products.
+func convolveFFT(x, y []*big.Float, prec uint) []*big.Float {
+
+	n := len(x) + len(y) - 1
+	size := 1 << bits.Len(uint(n-1))
+	wprec := prec + 64 + uint(bits.Len(uint(n)))
+
+	pad := func(v []*big.Float) []bigfloat.Complex {
+		c := make([]bigfloat.Complex, size)
+		for i := range c {
+			c[i] = bigfloat.Complex{Re: new(big.Float).SetPrec(wprec), Im: new(big.Float).SetPrec(wprec)}
+			if i < len(v) {
+				c[i].Re.Set(v[i])
+			}
+		}
+		return c
+	}
+	fx := Forward(pad(x), wprec)
+	fy := Forward(pad(y), wprec)
+
+	// the product of the spectra
+	t := new(big.Float).SetPrec(wprec)
+	for i, a := range fx {
+		b := fy[i]
+		re := new(big.Float).SetPrec(wprec).Mul(a.Re, b.Re)
+		re.Sub(re, t.Mul(a.Im, b.Im))
+		im := new(big.Float).SetPrec(wprec).Mul(a.Re, b.Im)
+		im.Add(im, t.Mul(a.Im, b.Re))
+		fx[i] = bigfloat.Complex{Re: re, Im: im}
+	}
+
+	z := make([]*big.Float, n)
+	for i, v := range Inverse(fx, wprec)[:n] {
+		z[i] = v.Re
+	}
+
+	return z
+}
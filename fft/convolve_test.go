@@ -0,0 +1,139 @@
+package fft_test
+
+import (
+	"fmt"
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/ThreeAndTwo/bigfloat"
+	"github.com/ThreeAndTwo/bigfloat/fft"
+)
+
+// floats returns the values of xs as big.Float values of 53 bits.
+func floats(xs ...float64) []*big.Float {
+
+	z := make([]*big.Float, len(xs))
+	for i, x := range xs {
+		z[i] = big.NewFloat(x)
+	}
+
+	return z
+}
+
+// randomFloats returns n random values of prec bits.
+func randomFloats(r *rand.Rand, n int, prec uint) []*big.Float {
+
+	z := make([]*big.Float, n)
+	for i := range z {
+		z[i] = new(big.Float).SetPrec(prec).SetFloat64(r.NormFloat64())
+		z[i].Quo(z[i], big.NewFloat(3))
+	}
+
+	return z
+}
+
+func TestConvolve(t *testing.T) {
+	for _, test := range []struct {
+		x, y, want []*big.Float
+	}{
+		{floats(), floats(1, 2), floats()},
+		{floats(2), floats(3), floats(6)},
+		// (1 + 2t)·(3 + 4t + 5t²)
+		{floats(1, 2), floats(3, 4, 5), floats(3, 10, 13, 10)},
+		// (1 - t)·(1 + t + t²) = 1 - t³
+		{floats(1, -1), floats(1, 1, 1), floats(1, 0, 0, -1)},
+	} {
+		got := fft.Convolve(test.x, test.y, 53)
+		if len(got) != len(test.want) {
+			t.Errorf("Convolve(%v, %v) = %v; want %v", test.x, test.y, got, test.want)
+			continue
+		}
+		for i := range got {
+			if got[i].Cmp(test.want[i]) != 0 || got[i].Prec() != 53 {
+				t.Errorf("Convolve(%v, %v) = %v; want %v", test.x, test.y, got, test.want)
+				break
+			}
+		}
+	}
+}
+
+func TestConvolveFFT(t *testing.T) {
+	// long inputs go through the FFT, and agree with the exact sums
+	// of the short ones, computed here by pieces of at most 32 values
+	r := rand.New(rand.NewSource(1))
+	for _, prec := range []uint{53, 200} {
+		x := randomFloats(r, 70, prec)
+		y := randomFloats(r, 45, prec)
+		got := fft.Convolve(x, y, prec)
+		want := make([]*big.Float, len(x)+len(y)-1)
+		for i := range want {
+			want[i] = new(big.Float).SetPrec(4 * prec)
+		}
+		for i := 0; i < len(x); i += 32 {
+			end := i + 32
+			if end > len(x) {
+				end = len(x)
+			}
+			for k, v := range fft.Convolve(x[i:end], y, 4*prec) {
+				want[i+k].Add(want[i+k], v)
+			}
+		}
+		for i := range got {
+			w := new(big.Float).SetPrec(prec).Set(want[i])
+			if bigfloat.CmpUlp(got[i], w, 1) != 0 && w.MantExp(nil) > -40 {
+				t.Errorf("prec = %d: Convolve(x, y)[%d] = %g; want %g", prec, i, got[i], w)
+			}
+		}
+	}
+}
+
+func TestCyclicConvolve(t *testing.T) {
+	// (1 + 2t)·(3 + 4t + 5t²) mod t³ - 1
+	got := fft.CyclicConvolve(floats(1, 2, 0), floats(3, 4, 5), 53)
+	for i, w := range []float64{13, 10, 13} {
+		if got[i].Cmp(big.NewFloat(w)) != 0 {
+			t.Errorf("CyclicConvolve = %v; want [13 10 13]", got)
+			break
+		}
+	}
+
+	// the long ones agree with the folded linear convolution
+	r := rand.New(rand.NewSource(2))
+	const n, prec = 50, 100
+	x, y := randomFloats(r, n, prec), randomFloats(r, n, prec)
+	c := fft.CyclicConvolve(x, y, prec)
+	l := fft.Convolve(x, y, 2*prec)
+	for k := range c {
+		w := new(big.Float).SetPrec(2 * prec).Set(l[k])
+		if k+n < len(l) {
+			w.Add(w, l[k+n])
+		}
+		w.SetPrec(prec)
+		if bigfloat.CmpUlp(c[k], w, 1) != 0 && w.MantExp(nil) > -40 {
+			t.Errorf("CyclicConvolve(x, y)[%d] = %g; want %g", k, c[k], w)
+		}
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("CyclicConvolve of different lengths did not panic")
+		}
+	}()
+	fft.CyclicConvolve(floats(1, 2), floats(1), 53)
+}
+
+// ---------- Benchmarks ----------
+
+func BenchmarkConvolve(b *testing.B) {
+	r := rand.New(rand.NewSource(1))
+	for _, n := range []int{16, 64, 256} {
+		x, y := randomFloats(r, n, 200), randomFloats(r, n, 200)
+		b.Run(fmt.Sprintf("%v", n), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				fft.Convolve(x, y, 200)
+			}
+		})
+	}
+}
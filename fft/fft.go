@@ -0,0 +1,184 @@
+// Package fft provides the discrete Fourier transform of vectors of
+// bigfloat.Complex values at any precision, with a radix-2 FFT, as a
+// reference to validate the spectra of float64 FFT implementations
+// against.
+//
+// The twiddle factors are computed by bigfloat.SinCosBinaryAngle from
+// the exact angles 2π·j/n, so they're accurate to the last bit, and
+// the same on every platform, and the transform is computed with guard
+// bits for the roundings of its log₂(n) stages: the error of every
+// component of the result is a few ulps of prec bits of the norm of
+// the input, as for any FFT, so the small components of a spectrum
+// have fewer correct bits than the large ones.
+//
+// Convolve and CyclicConvolve multiply polynomials, and filter signals,
+// with big.Float coefficients: directly for the short inputs, and with
+// an FFT for the long ones.
+package fft
+
+import (
+	"math/big"
+	"math/bits"
+
+	"github.com/ThreeAndTwo/bigfloat"
+)
+
+// Forward returns the discrete Fourier transform of x,
+//
+//	X[k] = Σ x[j]·exp(-2πi·j·k/n)
+//
+// for n = len(x), with the parts rounded to prec bits (64 if prec is
+// 0). x isn't modified. The function panics if n isn't a power of 2.
+func Forward(x []bigfloat.Complex, prec uint) []bigfloat.Complex {
+	return transform("Forward", x, prec, false)
+}
+
+// Inverse returns the inverse discrete Fourier transform of x,
+//
+//	X[k] = (1/n)·Σ x[j]·exp(2πi·j·k/n)
+//
+// for n = len(x), with the parts rounded to prec bits (64 if prec is
+// 0), so Inverse(Forward(x)) is x, within the rounding errors. x isn't
+// modified. The function panics if n isn't a power of 2.
+func Inverse(x []bigfloat.Complex, prec uint) []bigfloat.Complex {
+	return transform("Inverse", x, prec, true)
+}
+
+// Twiddles returns the twiddle factors of an FFT of size n, the n/2
+// values exp(-2πi·j/n) for j < n/2, with the parts rounded to prec
+// bits (64 if prec is 0). The function panics if n isn't a power of 2.
+func Twiddles(n int, prec uint) []bigfloat.Complex {
+
+	if prec == 0 {
+		prec = 64
+	}
+	log := log2("Twiddles", n)
+
+	// exp(-2πi·j/n) = cos(2j·π/2**log) - i·sin(2j·π/2**log)
+	w := make([]bigfloat.Complex, n/2)
+	k := new(big.Int)
+	for j := range w {
+		s, c := bigfloat.SinCosBinaryAngle(k.SetInt64(2*int64(j)), log, prec)
+		if s.Sign() != 0 {
+			s.Neg(s)
+		}
+		w[j] = bigfloat.Complex{Re: c, Im: s}
+	}
+
+	return w
+}
+
+// FromComplex128 returns the values of x as bigfloat.Complex values,
+// with parts of prec bits (53 if prec is 0), which hold them exactly
+// from 53 bits.
+func FromComplex128(x []complex128, prec uint) []bigfloat.Complex {
+
+	if prec == 0 {
+		prec = 53
+	}
+	z := make([]bigfloat.Complex, len(x))
+	for i, v := range x {
+		z[i] = bigfloat.Complex{
+			Re: new(big.Float).SetPrec(prec).SetFloat64(real(v)),
+			Im: new(big.Float).SetPrec(prec).SetFloat64(imag(v)),
+		}
+	}
+
+	return z
+}
+
+// ToComplex128 returns the values of x rounded to complex128 values,
+// with their parts rounded to nearest even.
+func ToComplex128(x []bigfloat.Complex) []complex128 {
+
+	z := make([]complex128, len(x))
+	for i, v := range x {
+		re, _ := v.Re.Float64()
+		im, _ := v.Im.Float64()
+		z[i] = complex(re, im)
+	}
+
+	return z
+}
+
+// log2 returns log₂(n), and panics, with the name of the function, if
+// n isn't a power of 2.
+func log2(name string, n int) uint {
+
+	if n <= 0 || n&(n-1) != 0 {
+		panic(name + ": length is not a power of 2")
+	}
+
+	return uint(bits.TrailingZeros(uint(n)))
+}
+
+// transform returns the Fourier transform of x to prec bits, or the
+// inverse one, with the radix-2 decimation in time of Cooley and
+// Tukey on the values in bit-reversed order.
+func transform(name string, x []bigfloat.Complex, prec uint, inverse bool) []bigfloat.Complex {
+
+	if prec == 0 {
+		prec = 64
+	}
+	n := len(x)
+	log := log2(name, n)
+
+	// every stage rounds a few times, and multiplies the error bound
+	// by at most 2
+	wprec := prec + 2*log + 32
+	w := Twiddles(n, wprec)
+	if inverse {
+		for _, t := range w {
+			t.Im.Neg(t.Im)
+		}
+	}
+
+	re := make([]*big.Float, n)
+	im := make([]*big.Float, n)
+	for i, v := range x {
+		j := i
+		if log > 0 {
+			j = int(bits.Reverse(uint(i)) >> (bits.UintSize - int(log)))
+		}
+		re[j] = new(big.Float).SetPrec(wprec).Set(v.Re)
+		im[j] = new(big.Float).SetPrec(wprec).Set(v.Im)
+	}
+
+	// the butterflies of the blocks of size m, with the twiddles of a
+	// stride of n/m
+	tr := new(big.Float).SetPrec(wprec)
+	ti := new(big.Float).SetPrec(wprec)
+	u := new(big.Float).SetPrec(wprec)
+	for m := 2; m <= n; m *= 2 {
+		h, stride := m/2, n/m
+		for s := 0; s < n; s += m {
+			for j := 0; j < h; j++ {
+				// t = w·x[s+j+h]
+				a, b := s+j, s+j+h
+				t := w[j*stride]
+				tr.Mul(t.Re, re[b])
+				u.Mul(t.Im, im[b])
+				tr.Sub(tr, u)
+				ti.Mul(t.Re, im[b])
+				u.Mul(t.Im, re[b])
+				ti.Add(ti, u)
+
+				re[b].Sub(re[a], tr)
+				im[b].Sub(im[a], ti)
+				re[a].Add(re[a], tr)
+				im[a].Add(im[a], ti)
+			}
+		}
+	}
+
+	z := make([]bigfloat.Complex, n)
+	for i := range z {
+		if inverse {
+			re[i].SetMantExp(re[i], -int(log))
+			im[i].SetMantExp(im[i], -int(log))
+		}
+		z[i] = bigfloat.Complex{Re: re[i].SetPrec(prec), Im: im[i].SetPrec(prec)}
+	}
+
+	return z
+}
@@ -0,0 +1,166 @@
+package fft_test
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"math/cmplx"
+	"math/rand"
+	"testing"
+
+	"github.com/ThreeAndTwo/bigfloat"
+	"github.com/ThreeAndTwo/bigfloat/fft"
+)
+
+// randomVector returns n random complex values of prec bits.
+func randomVector(r *rand.Rand, n int, prec uint) []bigfloat.Complex {
+
+	x := make([]bigfloat.Complex, n)
+	for i := range x {
+		re := new(big.Float).SetPrec(prec).SetFloat64(r.NormFloat64())
+		im := new(big.Float).SetPrec(prec).SetFloat64(r.NormFloat64())
+		x[i] = bigfloat.Complex{Re: re.Quo(re, big.NewFloat(3)), Im: im.Quo(im, big.NewFloat(7))}
+	}
+
+	return x
+}
+
+// dft returns the transform of x by its definition, at prec bits.
+func dft(x []bigfloat.Complex, prec uint) []bigfloat.Complex {
+
+	n := len(x)
+	w := fft.Twiddles(n, prec)
+	z := make([]bigfloat.Complex, n)
+	t, u := new(big.Float).SetPrec(prec), new(big.Float).SetPrec(prec)
+	for k := range z {
+		re, im := new(big.Float).SetPrec(prec), new(big.Float).SetPrec(prec)
+		for j, v := range x {
+			// exp(-2πi·m/n), with exp(-2πi·(m+n/2)/n) = -exp(-2πi·m/n)
+			m := j * k % n
+			c, s := w[m%(n/2)].Re, w[m%(n/2)].Im
+			sign := 1.0
+			if m >= n/2 {
+				sign = -1
+			}
+			t.Mul(c, v.Re)
+			re.Add(re, t.Mul(t, big.NewFloat(sign)))
+			t.Mul(s, v.Im)
+			re.Sub(re, t.Mul(t, big.NewFloat(sign)))
+			t.Mul(c, v.Im)
+			u.Mul(s, v.Re)
+			t.Add(t, u)
+			im.Add(im, t.Mul(t, big.NewFloat(sign)))
+		}
+		z[k] = bigfloat.Complex{Re: re, Im: im}
+	}
+
+	return z
+}
+
+// near reports whether the parts of x and y differ by at most
+// 2**-bits.
+func near(x, y []bigfloat.Complex, bits int) bool {
+
+	for i := range x {
+		for _, d := range []*big.Float{
+			new(big.Float).Sub(x[i].Re, y[i].Re),
+			new(big.Float).Sub(x[i].Im, y[i].Im),
+		} {
+			if d.Sign() != 0 && d.MantExp(nil) > -bits {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+func TestForward(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for _, n := range []int{2, 4, 8, 32} {
+		for _, prec := range []uint{53, 200} {
+			x := randomVector(r, n, prec)
+			got := fft.Forward(x, prec)
+			want := dft(x, prec+64)
+			// the values are about 1, so their ulp is about 2**-prec
+			if !near(got, want, int(prec)-6) || got[0].Re.Prec() != prec {
+				t.Errorf("n = %d, prec = %d: Forward(x) =\n%v;\nwant\n%v", n, prec, got, want)
+			}
+			back := fft.Inverse(got, prec)
+			if !near(back, x, int(prec)-6) {
+				t.Errorf("n = %d, prec = %d: Inverse(Forward(x)) =\n%v;\nwant\n%v", n, prec, back, x)
+			}
+		}
+	}
+}
+
+func TestForwardFloat64(t *testing.T) {
+	// a single value is its own transform
+	if z := fft.ToComplex128(fft.Forward(fft.FromComplex128([]complex128{2 + 3i}, 0), 53)); z[0] != 2+3i {
+		t.Errorf("Forward(2+3i) = %v", z)
+	}
+
+	// an impulse has a flat spectrum, and a wave a single line
+	const n = 16
+	x := make([]complex128, n)
+	x[0] = 1
+	for _, z := range fft.ToComplex128(fft.Forward(fft.FromComplex128(x, 0), 53)) {
+		if z != 1 {
+			t.Errorf("Forward(impulse) has %v; want 1", z)
+		}
+	}
+	for j := range x {
+		x[j] = cmplx.Exp(complex(0, 2*math.Pi*3*float64(j)/n))
+	}
+	for k, z := range fft.ToComplex128(fft.Forward(fft.FromComplex128(x, 0), 53)) {
+		want := 0.0
+		if k == 3 {
+			want = n
+		}
+		if cmplx.Abs(z-complex(want, 0)) > 1e-13 {
+			t.Errorf("Forward(wave)[%d] = %v; want %v", k, z, want)
+		}
+	}
+}
+
+func TestTwiddles(t *testing.T) {
+	// cmplx.Exp is within 1 ulp, for the rounded π
+	w := fft.Twiddles(8, 53)
+	for j, z := range fft.ToComplex128(w) {
+		want := cmplx.Exp(complex(0, -2*math.Pi*float64(j)/8))
+		if cmplx.Abs(z-want) > 3e-16 {
+			t.Errorf("Twiddles(8)[%d] = %v; want %v", j, z, want)
+		}
+	}
+
+	// the exact ones are exact: exp(-iπ/2) = -i, and the others are
+	// correctly rounded: exp(-iπ/4) = √½ - i·√½
+	if w[2].Re.Sign() != 0 || w[2].Im.Cmp(big.NewFloat(-1)) != 0 {
+		t.Errorf("Twiddles(8)[2] = %v; want -i", w[2])
+	}
+	if h := math.Sqrt(0.5); fft.ToComplex128(w)[1] != complex(h, -h) {
+		t.Errorf("Twiddles(8)[1] = %v; want %v", w[1], complex(h, -h))
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Forward of 6 values did not panic")
+		}
+	}()
+	fft.Forward(make([]bigfloat.Complex, 6), 53)
+}
+
+// ---------- Benchmarks ----------
+
+func BenchmarkForward(b *testing.B) {
+	r := rand.New(rand.NewSource(1))
+	for _, prec := range []uint{53, 1e3} {
+		x := randomVector(r, 256, prec)
+		b.Run(fmt.Sprintf("%v", prec), func(b *testing.B) {
+			b.ReportAllocs()
+			for n := 0; n < b.N; n++ {
+				fft.Forward(x, prec)
+			}
+		})
+	}
+}
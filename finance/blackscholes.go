@@ -0,0 +1,156 @@
+package finance
+
+import (
+	"math/big"
+
+	"github.com/ThreeAndTwo/bigfloat"
+)
+
+// An Option is a European option on an asset with a continuous
+// dividend yield, in the Black–Scholes model. The rates and the
+// volatility are annual and continuously compounded, and the expiry
+// is in years.
+type Option struct {
+	Put      bool       // a put option, rather than a call
+	Spot     *big.Float // the price S of the asset
+	Strike   *big.Float // the strike price K
+	Rate     *big.Float // the risk-free rate r
+	Dividend *big.Float // the dividend yield q, or nil for 0
+	Vol      *big.Float // the volatility σ
+	Expiry   *big.Float // the time to expiry T
+}
+
+// The Greeks of an option are the derivatives of its price: Delta and
+// Gamma by the spot price, Vega by the volatility, Theta by the time,
+// as the time to expiry decreases, and Rho by the rate. Theta is per
+// year, and Vega and Rho are per unit, not per percentage point.
+type Greeks struct {
+	Delta, Gamma, Vega, Theta, Rho *big.Float
+}
+
+// Price returns the Black–Scholes price of the option, to prec bits (64
+// if prec is 0):
+//
+//	call = S·exp(-qT)·N(d1) - K·exp(-rT)·N(d2)
+//	put  = K·exp(-rT)·N(-d2) - S·exp(-qT)·N(-d1)
+//
+// with d1 = (log(S/K) + (r - q + σ²/2)·T)/(σ√T) and d2 = d1 - σ√T. The
+// prices of the options far out of the money, where the two terms
+// cancel, are as accurate as the others. The method panics if the spot
+// price, the strike, the volatility or the expiry is not positive and
+// finite, or if the rate is nil.
+func (o *Option) Price(prec uint) *big.Float {
+
+	o.check("Price")
+	z, _ := o.eval(prec)
+
+	return z
+}
+
+// Greeks returns the Greeks of the option, to prec bits (64 if prec is
+// 0). The method panics as Price.
+func (o *Option) Greeks(prec uint) Greeks {
+
+	o.check("Greeks")
+	_, g := o.eval(prec)
+
+	return g
+}
+
+// eval returns the price and the Greeks of the option to prec bits,
+// computed again with the bits lost to cancellation until none are.
+func (o *Option) eval(prec uint) (*big.Float, Greeks) {
+
+	if prec == 0 {
+		prec = 64
+	}
+	wprec := prec + 64
+	for {
+		v, g, lost := o.terms(wprec)
+		if wprec >= prec+64+lost {
+			round := func(x *big.Float) *big.Float {
+				return new(big.Float).SetPrec(prec).Set(x)
+			}
+			return round(v), Greeks{round(g.Delta), round(g.Gamma), round(g.Vega), round(g.Theta), round(g.Rho)}
+		}
+		wprec = prec + 64 + lost
+	}
+}
+
+// terms returns the price and the Greeks of the option at precision
+// prec, and the bits lost to cancellation.
+func (o *Option) terms(prec uint) (price *big.Float, g Greeks, lost uint) {
+
+	w := func(x *big.Float) *big.Float {
+		return new(big.Float).SetPrec(prec).Set(x)
+	}
+	s, k, r, sigma, t := w(o.Spot), w(o.Strike), w(o.Rate), w(o.Vol), w(o.Expiry)
+	q := new(big.Float).SetPrec(prec)
+	if o.Dividend != nil {
+		q.Set(o.Dividend)
+	}
+
+	// the discounted spot and strike S·exp(-qT) and K·exp(-rT)
+	rt := new(big.Float).SetPrec(prec).Mul(r, t)
+	qt := new(big.Float).SetPrec(prec).Mul(q, t)
+	dk := bigfloat.Exp(new(big.Float).Neg(rt))
+	dk.Mul(dk, k)
+	ds := bigfloat.Exp(new(big.Float).Neg(qt))
+	ds.Mul(ds, s)
+
+	// d1 = log(S·exp(-qT)/(K·exp(-rT)))/(σ√T) + σ√T/2, d2 = d1 - σ√T
+	rootT := bigfloat.Sqrt(t)
+	sd := new(big.Float).SetPrec(prec).Mul(sigma, rootT)
+	d1 := bigfloat.Log(new(big.Float).SetPrec(prec).Quo(ds, dk))
+	d1.Quo(d1, sd)
+	d1.Add(d1, new(big.Float).SetMantExp(sd, -1))
+	d2 := new(big.Float).SetPrec(prec).Sub(d1, sd)
+
+	// N(±d1) and N(±d2), with + for a call and - for a put, and φ(d1)
+	sign := big.NewFloat(1)
+	if o.Put {
+		sign.SetInt64(-1)
+	}
+	n1 := normCDF(new(big.Float).Mul(sign, d1), prec)
+	n2 := normCDF(new(big.Float).Mul(sign, d2), prec)
+	p1 := normPDF(d1, prec)
+
+	mul := func(xs ...*big.Float) *big.Float {
+		z := new(big.Float).SetPrec(prec).SetInt64(1)
+		for _, x := range xs {
+			z.Mul(z, x)
+		}
+		return z
+	}
+	a := mul(ds, n1)
+	b := mul(dk, n2)
+	price = sum(prec, &lost, mul(sign, a), mul(big.NewFloat(-1), sign, b))
+
+	// Delta = ±exp(-qT)·N(±d1), Gamma = exp(-qT)·φ(d1)/(S·σ√T),
+	// Vega = S·exp(-qT)·φ(d1)·√T, Rho = ±K·T·exp(-rT)·N(±d2), and
+	// Theta = -S·exp(-qT)·φ(d1)·σ/(2√T) ∓ r·K·exp(-rT)·N(±d2) ± q·S·exp(-qT)·N(±d1)
+	g.Delta = mul(sign, a)
+	g.Delta.Quo(g.Delta, s)
+	g.Gamma = mul(ds, p1)
+	g.Gamma.Quo(g.Gamma, s).Quo(g.Gamma, s).Quo(g.Gamma, sd)
+	g.Vega = mul(ds, p1, rootT)
+	g.Rho = mul(sign, b, t)
+	decay := mul(ds, p1, sigma)
+	decay.Quo(decay, rootT).SetMantExp(decay, -1)
+	g.Theta = sum(prec, &lost, decay.Neg(decay), mul(big.NewFloat(-1), sign, r, b), mul(sign, q, a))
+
+	return price, g, lost
+}
+
+// check panics if the parameters of the option are not valid.
+func (o *Option) check(name string) {
+
+	for _, x := range []*big.Float{o.Spot, o.Strike, o.Vol, o.Expiry} {
+		if x == nil || x.Sign() <= 0 || x.IsInf() {
+			panic(name + ": spot, strike, volatility or expiry is not positive and finite")
+		}
+	}
+	if o.Rate == nil {
+		panic(name + ": nil rate")
+	}
+}
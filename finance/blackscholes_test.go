@@ -0,0 +1,129 @@
+package finance_test
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/ThreeAndTwo/bigfloat"
+	"github.com/ThreeAndTwo/bigfloat/finance"
+)
+
+// option returns the option with the given parameters, at precision
+// prec.
+func option(put bool, s, k, r, q, sigma, t string, prec uint) *finance.Option {
+
+	f := func(s string) *big.Float {
+		x, _ := new(big.Float).SetPrec(prec).SetString(s)
+		return x
+	}
+
+	return &finance.Option{Put: put, Spot: f(s), Strike: f(k), Rate: f(r), Dividend: f(q), Vol: f(sigma), Expiry: f(t)}
+}
+
+func TestBlackScholes(t *testing.T) {
+	// the parameters are exact in binary, and the last two options are
+	// far out of the money, where the terms of the price cancel
+	for _, test := range []struct {
+		put                  bool
+		s, k, r, q, sigma, t string
+		want                 [6]string // price, Delta, Gamma, Vega, Theta, Rho
+	}{
+		{false, "100", "100", "0.0625", "0", "0.25", "1", [6]string{
+			"1.2973849713942427717007395978996154674984646938662529855423541925543001e+1",
+			"6.4616976667272379437316278839325654191169418623511417719078686324473146e-1",
+			"1.4874203754790756450929861401069005403931975902621388478736359150568762e-2",
+			"3.7185509386976891127324653502672513509829939756553471196840897876421905e+1",
+			"-7.8758841079552333734348868666046579084902906998722393910835587594858721e+0",
+			"5.1643126953329951720308882860329499516184771684848887863655144398930145e+1",
+		}},
+		{true, "100", "100", "0.0625", "0", "0.25", "1", [6]string{
+			"6.9151559952900063289784784412266631274527359936067120563728081308970737e+0",
+			"-3.5383023332727620562683721160674345808830581376488582280921313675526854e-1",
+			"1.4874203754790756450929861401069005403931975902621388478736359150568762e-2",
+			"3.7185509386976891127324653502672513509829939756553471196840897876421905e+1",
+			"-2.0045524653710097101866942127152511302110351339382280035242296216512426e+0",
+			"-4.2298179328017626891662199601901008936283317370095294337294121806423927e+1",
+		}},
+		{false, "42.5", "60", "0.03125", "0.015625", "0.375", "0.75", [6]string{
+			"1.2672484608436219648113055936140070679890992322520155226351661975720431e+0",
+			"1.9170747647215110975636903186446558917506575041516533430286650195820740e-1",
+			"1.9679176597135265873999921284473418572201755838139560180772797925335338e-2",
+			"9.9971754549118801832566006368975315832610872919923820371464936335541433e+0",
+			"-2.5869980954213947057363856696464418552521795718752907540147717511710316e+0",
+			"5.1602394669171001498757836954693353539633963702943833889274951017388284e+0",
+		}},
+		{true, "42.5", "60", "0.03125", "0.015625", "0.375", "0.75", [6]string{
+			"1.7872489955508929481287264346090345492808427286011037119619057998982125e+1",
+			"-7.9664217064169393765597920406006232233470270931926735265730015748537515e-1",
+			"1.9679176597135265873999921284473418572201755838139560180772797925335338e-2",
+			"9.9971754549118801832566006368975315832610872919923820371464936335541433e+0",
+			"-1.4117583611765661190492494582129244632396199524029246229245394600902125e+0",
+			"-3.8797336655835691373749785388982245644024969324059924705665736019082926e+1",
+		}},
+		{false, "100", "1000", "0.0625", "0", "0.25", "1", [6]string{
+			"1.3411876936987382599060060394083103089861627843208867356143023941478007e-18",
+			"4.9896969870190526597949899424875717437325930137799837702697345334069711e-19",
+			"1.7854717472312837034106365811477836766798727010616061270011548465367327e-19",
+			"4.4636793680782092585265914528694591916996817526540153175028871163418318e-16",
+			"-5.8830728487008352502710136497459952841767257367767625904228779138018017e-17",
+			"4.8555782176491788338043893385467407128339767353478950967083042939921910e-17",
+		}},
+		{true, "1000", "100", "0.0625", "0.015625", "0.3125", "0.5", [6]string{
+			"2.0762407173708130011491565250420266099952324162771156986657171528472843e-25",
+			"-9.9607516732265495031230412217920802045678023138711516047918413515551738e-27",
+			"4.8364616226877411355735995688552235363755140019501849934358227089328515e-28",
+			"7.5569712854495955243337493263362867755867406280471640522434729827075805e-23",
+			"-2.3135648527863923924326891859247879747806978526582945458669574775401745e-23",
+			"-5.0841878724818154016189784371481414327836627777494315873292065334199511e-24",
+		}},
+	} {
+		for _, prec := range []uint{53, 100, 200} {
+			o := option(test.put, test.s, test.k, test.r, test.q, test.sigma, test.t, prec)
+			g := o.Greeks(prec)
+			for i, got := range []*big.Float{o.Price(prec), g.Delta, g.Gamma, g.Vega, g.Theta, g.Rho} {
+				want, _ := new(big.Float).SetPrec(prec).SetString(test.want[i])
+				if got.Prec() != prec || bigfloat.CmpUlp(got, want, 2) != 0 {
+					t.Errorf("%v: result %d at prec %d = %g; want %g", test, i, prec, got, want)
+				}
+			}
+		}
+	}
+
+	// no dividend yield
+	o := option(false, "100", "100", "0.0625", "0", "0.25", "1", 53)
+	o.Dividend = nil
+	if got, _ := o.Price(53).Float64(); got != 12.973849713942428 {
+		t.Errorf("Price() = %v; want 12.973849713942428", got)
+	}
+
+	for _, o := range []*finance.Option{
+		option(false, "0", "100", "0.05", "0", "0.2", "1", 53),
+		option(false, "100", "100", "0.05", "0", "-0.2", "1", 53),
+		option(false, "100", "100", "0.05", "0", "0.2", "0", 53),
+		{Spot: big.NewFloat(1), Strike: big.NewFloat(1), Vol: big.NewFloat(1), Expiry: big.NewFloat(1)},
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("Price() of %+v didn't panic", o)
+				}
+			}()
+			o.Price(53)
+		}()
+	}
+}
+
+// ---------- Benchmarks ----------
+
+func BenchmarkPrice(b *testing.B) {
+	for _, prec := range []uint{1e2, 1e3} {
+		o := option(false, "100", "110", "0.05", "0.01", "0.2", "1", prec)
+		b.Run(fmt.Sprintf("%v", prec), func(b *testing.B) {
+			b.ReportAllocs()
+			for n := 0; n < b.N; n++ {
+				o.Price(prec)
+			}
+		})
+	}
+}
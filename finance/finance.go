@@ -0,0 +1,62 @@
+// Package finance provides the pricing formulas of quantitative
+// finance at any precision, as oracles to validate the float64
+// implementations used in production against: the results are
+// accurate to the last bit, with the cancellations of the formulas
+// made up for by computing them again with the bits lost.
+//
+// The time-value-of-money computations of Annuity are exact instead,
+// with rational rates and fixed-point amounts of money, which are
+// rounded once, at the end, with an explicit rounding rule, as an
+// audit requires.
+package finance
+
+import (
+	"math/big"
+
+	"github.com/ThreeAndTwo/bigfloat"
+)
+
+// normCDF returns the standard normal distribution function
+// N(x) = erfc(-x/√2)/2 at precision prec, with the relative accuracy
+// of the tiny values of its lower tail.
+func normCDF(x *big.Float, prec uint) *big.Float {
+
+	t := new(big.Float).SetPrec(prec).Quo(x, bigfloat.Sqrt2.Value(prec))
+	z := bigfloat.Erfc(t.Neg(t))
+
+	return z.SetMantExp(z, -1)
+}
+
+// normPDF returns the standard normal density exp(-x²/2)/√(2π) at
+// precision prec.
+func normPDF(x *big.Float, prec uint) *big.Float {
+
+	t := new(big.Float).SetPrec(prec).Mul(x, x)
+	t.SetMantExp(t, -1)
+	z := bigfloat.Exp(t.Neg(t))
+	d := new(big.Float).SetPrec(prec).SetMantExp(bigfloat.Pi.Value(prec), 1)
+
+	return z.Quo(z, bigfloat.Sqrt(d))
+}
+
+// sum returns the sum of the terms at precision prec, and raises lost
+// to the bits lost to cancellation in it, if more: the difference of
+// the exponents of the largest term and of the sum.
+func sum(prec uint, lost *uint, terms ...*big.Float) *big.Float {
+
+	z := new(big.Float).SetPrec(prec)
+	top := 0
+	for i, t := range terms {
+		if e := t.MantExp(nil); t.Sign() != 0 && (i == 0 || e > top) {
+			top = e
+		}
+		z.Add(z, t)
+	}
+	if z.Sign() != 0 {
+		if l := top - z.MantExp(nil); l > 0 && uint(l) > *lost {
+			*lost = uint(l)
+		}
+	}
+
+	return z
+}
@@ -0,0 +1,332 @@
+package finance
+
+import (
+	"errors"
+	"math/big"
+	"time"
+
+	"github.com/ThreeAndTwo/bigfloat"
+)
+
+// A CashFlow is an amount received at a date, or paid if it is
+// negative.
+type CashFlow struct {
+	Date   time.Time
+	Amount *big.Float
+}
+
+// NPV returns the net present value Σ flows[i]/(1+rate)**i of the
+// cash flows, one per period from period 0, at the rate per period,
+// rounded to prec bits (64 if prec is 0). It's computed exactly, with
+// rationals, and so it's correctly rounded, however the flows cancel.
+// The function panics if rate <= -1.
+func NPV(rate *big.Float, flows []*big.Float, prec uint) *big.Float {
+
+	if prec == 0 {
+		prec = 64
+	}
+	checkRate("NPV", rate)
+
+	// Horner's rule in 1/(1+r), from the last flow
+	v := new(big.Rat).Add(bigfloat.ToRat(rate), big.NewRat(1, 1))
+	v.Inv(v)
+	z := new(big.Rat)
+	for i := len(flows) - 1; i >= 0; i-- {
+		z.Mul(z, v).Add(z, bigfloat.ToRat(flows[i]))
+	}
+	x, _ := bigfloat.FromRat(z, prec)
+
+	return x
+}
+
+// XNPV returns the net present value Σ amount/(1+rate)**(d/365) of the
+// cash flows, at the annual rate, where d is the number of days from
+// the first of the flows, as in spreadsheets, to prec bits (64 if prec
+// is 0). The bits lost to the cancellation of the terms are made up
+// for by computing it again with as many more, once: a value still
+// below the rounding errors of the terms then is returned as 0. The
+// function panics if rate <= -1.
+func XNPV(rate *big.Float, flows []CashFlow, prec uint) *big.Float {
+
+	if prec == 0 {
+		prec = 64
+	}
+	checkRate("XNPV", rate)
+
+	f := dated(flows)
+	wprec := prec + 64
+	for retry := false; ; retry = true {
+		r := new(big.Float).SetPrec(wprec).Add(rate, big.NewFloat(1))
+		v, _, _, lost := f.eval(bigfloat.Log(r), wprec)
+		switch {
+		case wprec >= prec+64+lost:
+			return v.SetPrec(prec)
+		case retry:
+			return new(big.Float).SetPrec(prec)
+		}
+		wprec = prec + 64 + lost
+	}
+}
+
+// IRR returns the internal rate of return of the cash flows, one per
+// period from period 0: the rate r > -1 at which their net present
+// value is 0, to prec bits (64 if prec is 0).
+//
+// The root is found by Newton's method on log(1 + r), with a bisection
+// whenever a step leaves the bracket of the root, which is searched
+// for between samples of the net present value from r = 0 outwards,
+// at every 1/64 of log(1 + r) up to ±1, and then at ±2, ±4, ... When
+// the cash flows change sign more than once, and there are several
+// rates, it's the one nearest to 0 in log(1 + r) that's found, unless
+// the rates are closer to each other than the samples. The bits lost
+// to the cancellation of the flows near the root, in the nearly
+// degenerate patterns where float64 solvers go astray, are made up for
+// by solving again with as many more. IRR returns an error if there is no such rate, as when no
+// flow is negative or none is positive.
+func IRR(flows []*big.Float, prec uint) (*big.Float, error) {
+	return irr(periods(flows), prec)
+}
+
+// XIRR returns the internal rate of return of the cash flows at their
+// dates: the annual rate r at which their net present value XNPV is 0,
+// to prec bits (64 if prec is 0). It's found as the one of IRR, and
+// XIRR returns an error if there is no such rate.
+func XIRR(flows []CashFlow, prec uint) (*big.Float, error) {
+	return irr(dated(flows), prec)
+}
+
+// flows are amounts at the times t/unit, in periods or in years, with
+// t exact.
+type flows struct {
+	c, t []*big.Float
+	unit int64
+}
+
+// periods returns the flows at the times 0, 1, 2, ...
+func periods(c []*big.Float) flows {
+
+	f := flows{c: c, t: make([]*big.Float, len(c)), unit: 1}
+	for i := range c {
+		f.t[i] = new(big.Float).SetInt64(int64(i))
+	}
+
+	return f
+}
+
+// dated returns the flows at their times in years of 365 days from the
+// first one, with the days counted between the calendar dates.
+func dated(cf []CashFlow) flows {
+
+	f := flows{unit: 365}
+	if len(cf) == 0 {
+		return f
+	}
+	for _, x := range cf {
+		days := dayNumber(x.Date) - dayNumber(cf[0].Date)
+		f.c = append(f.c, x.Amount)
+		f.t = append(f.t, new(big.Float).SetInt64(days))
+	}
+
+	return f
+}
+
+// dayNumber returns the number of days of the date of t, in its own
+// location, from 1970-01-01.
+func dayNumber(t time.Time) int64 {
+
+	y, m, d := t.Date()
+
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC).Unix() / 86400
+}
+
+// checkRate panics if rate <= -1.
+func checkRate(name string, rate *big.Float) {
+	if rate.Cmp(big.NewFloat(-1)) <= 0 {
+		panic(name + ": rate <= -1")
+	}
+}
+
+// eval returns the net present value v(s) = Σ c·exp(-s·t) of the flows
+// at s = log(1 + r), its derivative, the sum of the absolute values of
+// the terms, and the bits lost to cancellation in v, at precision prec.
+func (f flows) eval(s *big.Float, prec uint) (v, dv, abs *big.Float, lost uint) {
+
+	terms := make([]*big.Float, len(f.c))
+	dv = new(big.Float).SetPrec(prec)
+	abs = new(big.Float).SetPrec(prec)
+	unit := new(big.Float).SetInt64(f.unit)
+	for i, c := range f.c {
+		t := new(big.Float).SetPrec(prec).Quo(f.t[i], unit)
+		e := new(big.Float).SetPrec(prec).Mul(s, t)
+		e = bigfloat.Exp(e.Neg(e))
+		terms[i] = e.Mul(e, c)
+		dv.Sub(dv, new(big.Float).Mul(e, t))
+		abs.Add(abs, new(big.Float).Abs(e))
+	}
+	v = sum(prec, &lost, terms...)
+
+	return v, dv, abs, lost
+}
+
+// maxLogRate bounds the search for the root in log(1 + r).
+const maxLogRate = 1 << 10
+
+// irr returns the root of the net present value of the flows.
+func irr(f flows, prec uint) (*big.Float, error) {
+
+	if prec == 0 {
+		prec = 64
+	}
+	var pos, neg bool
+	for _, c := range f.c {
+		pos = pos || c.Sign() > 0
+		neg = neg || c.Sign() < 0
+	}
+	if !pos || !neg {
+		return nil, errors.New("finance: the cash flows don't change sign")
+	}
+
+	wprec := prec + 64
+	for {
+		s, lost, err := f.root(wprec)
+		if err != nil {
+			return nil, err
+		}
+		if wprec >= prec+64+lost {
+			return expm1(s, prec), nil
+		}
+		wprec = prec + 64 + lost
+	}
+}
+
+// root returns the root s of v(s) at precision prec, and the bits of
+// relative accuracy of exp(s) - 1 lost to its condition number.
+func (f flows) root(prec uint) (*big.Float, uint, error) {
+
+	// a bracket lo < root < hi, between consecutive samples of v on
+	// both sides of s = 0, going outwards, the positive one first
+	s := new(big.Float).SetPrec(prec)
+	v, _, _, _ := f.eval(s, prec)
+	if v.Sign() == 0 {
+		return s, 0, nil
+	}
+	lo, hi := new(big.Float).SetPrec(prec), new(big.Float).SetPrec(prec)
+	var vlo int
+	last := [2]*big.Float{s, s}
+	sign := [2]int{v.Sign(), v.Sign()}
+search:
+	for k := 1; ; k++ {
+		d := gridPoint(k, prec)
+		if d.Cmp(big.NewFloat(maxLogRate)) > 0 {
+			return nil, 0, errors.New("finance: no internal rate of return found")
+		}
+		for i, b := range []*big.Float{d, new(big.Float).Neg(d)} {
+			bv, _, _, _ := f.eval(b, prec)
+			if bv.Sign() == sign[i] {
+				last[i] = b
+				continue
+			}
+
+			// the sign changes between the last sample and b, or v is
+			// 0 at b
+			switch {
+			case bv.Sign() == 0:
+				lo.Set(b)
+				hi.Set(b)
+			case i == 0:
+				lo.Set(last[i])
+				hi.Set(b)
+				vlo = sign[i]
+			default:
+				lo.Set(b)
+				hi.Set(last[i])
+				vlo = bv.Sign()
+			}
+			break search
+		}
+	}
+
+	s.Add(lo, hi)
+	s.SetMantExp(s, -1)
+	half := big.NewFloat(0.5)
+	for {
+		v, dv, abs, _ := f.eval(s, prec)
+		if v.Sign() == 0 {
+			return s, conditionLost(s, dv, abs), nil
+		}
+		if v.Sign() == vlo {
+			lo.Set(s)
+		} else {
+			hi.Set(s)
+		}
+
+		sn := new(big.Float).SetPrec(prec)
+		if dv.Sign() != 0 {
+			step := new(big.Float).SetPrec(prec).Quo(v, dv)
+			sn.Sub(s, step)
+			if step.Sign() == 0 || step.MantExp(nil) < s.MantExp(nil)-int(prec)+8 {
+				return sn, conditionLost(sn, dv, abs), nil
+			}
+		}
+		if dv.Sign() == 0 || sn.Cmp(lo) <= 0 || sn.Cmp(hi) >= 0 {
+			sn.Add(lo, hi).Mul(sn, half)
+		}
+		s = sn
+		if w := new(big.Float).Sub(hi, lo); w.MantExp(nil) < s.MantExp(nil)-int(prec)+8 {
+			return s, conditionLost(s, dv, abs), nil
+		}
+	}
+}
+
+// gridSteps is the number of samples of v per unit of s, up to |s| = 1,
+// in the search for a bracket of its root.
+const gridSteps = 64
+
+// gridPoint returns the k-th sample of s > 0 in the search for the
+// bracket of the root: k/gridSteps up to 1, and then 2, 4, 8, ...
+func gridPoint(k int, prec uint) *big.Float {
+
+	b := new(big.Float).SetPrec(prec)
+	if k <= gridSteps {
+		return b.Quo(big.NewFloat(float64(k)), big.NewFloat(gridSteps))
+	}
+
+	return b.SetMantExp(big.NewFloat(1), k-gridSteps)
+}
+
+// conditionLost returns the bits of relative accuracy of exp(s) - 1
+// lost to the rounding errors of v at s, bounded by abs·2**-prec, with
+// the slope dv: the relative error of s is abs/(|dv|·|s|) times the
+// one of v, and the one of exp(s) - 1 is about max(1, s) times that.
+func conditionLost(s, dv, abs *big.Float) uint {
+
+	if s.Sign() == 0 || dv.Sign() == 0 {
+		return 0
+	}
+	l := abs.MantExp(nil) - dv.MantExp(nil) - s.MantExp(nil)
+	if e := s.MantExp(nil); e > 0 && s.Sign() > 0 {
+		l += e
+	}
+	if l < 0 {
+		return 0
+	}
+
+	return uint(l)
+}
+
+// expm1 returns exp(s) - 1 to prec bits, with the bits that cancel
+// for a small s.
+func expm1(s *big.Float, prec uint) *big.Float {
+
+	z := new(big.Float).SetPrec(prec)
+	if s.Sign() == 0 {
+		return z
+	}
+	wprec := prec + 64
+	if e := s.MantExp(nil); e < 0 {
+		wprec += uint(-e)
+	}
+	x := bigfloat.Exp(new(big.Float).SetPrec(wprec).Set(s))
+
+	return z.Sub(x, big.NewFloat(1))
+}
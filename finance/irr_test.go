@@ -0,0 +1,138 @@
+package finance_test
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ThreeAndTwo/bigfloat"
+	"github.com/ThreeAndTwo/bigfloat/finance"
+)
+
+// amounts returns the amounts at precision prec.
+func amounts(prec uint, xs ...string) []*big.Float {
+
+	var z []*big.Float
+	for _, s := range xs {
+		x, _ := new(big.Float).SetPrec(prec).SetString(s)
+		z = append(z, x)
+	}
+
+	return z
+}
+
+// xirrFlows are the dated cash flows of the XIRR example of the
+// spreadsheets.
+func xirrFlows(prec uint) []finance.CashFlow {
+
+	date := func(y int, m time.Month, d int) time.Time {
+		return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+	}
+	a := amounts(prec, "-10000", "2750", "4250", "3250", "2750")
+
+	return []finance.CashFlow{
+		{date(2008, 1, 1), a[0]},
+		{date(2008, 3, 1), a[1]},
+		{date(2008, 10, 30), a[2]},
+		{date(2009, 2, 15), a[3]},
+		{date(2009, 4, 1), a[4]},
+	}
+}
+
+func TestIRR(t *testing.T) {
+	for _, prec := range []uint{53, 100, 200} {
+		for _, test := range []struct {
+			flows []string
+			want  string
+		}{
+			{[]string{"-100", "110"}, "0.1"},
+			{[]string{"100", "-110"}, "0.1"},
+			{[]string{"-1000", "300", "400", "500"}, "0.08896339469334993531776567968686894276082779102759860526380698278695784"},
+			{[]string{"-1", "0", "0", "0", "0", "0", "0", "0", "0", "0", "1"}, "0"},
+			{[]string{"-1", "0", "4"}, "1"},
+			{[]string{"-1", "0.25"}, "-0.75"},
+
+			// the rates 0.1 and 0.2, both between the first samples
+			// of a search by powers of 2
+			{[]string{"-100", "230", "-132"}, "0.1"},
+			{[]string{"100", "-230", "132"}, "0.1"},
+
+			// -(1 - v)³ + 2**-60·v³, with v = 1/(1+r), has the root
+			// 1/v = 1 + 2**-20, near a triple one
+			{[]string{"-1", "3", "-3", "1.000000000000000000867361737988403547205962240695953369140625"}, "9.5367431640625e-7"},
+		} {
+			want, _ := new(big.Float).SetPrec(prec).SetString(test.want)
+			got, err := finance.IRR(amounts(prec+64, test.flows...), prec)
+			if err != nil || got.Prec() != prec || bigfloat.CmpUlp(got, want, 1) != 0 {
+				t.Errorf("IRR(%v) at prec %d = %g, %v; want %g", test.flows, prec, got, err, want)
+			}
+		}
+
+		want, _ := new(big.Float).SetPrec(prec).SetString("0.37336253351883151030845541191455482417129784528583109760034710488653673")
+		if got, err := finance.XIRR(xirrFlows(prec), prec); err != nil || bigfloat.CmpUlp(got, want, 1) != 0 {
+			t.Errorf("XIRR(...) at prec %d = %g, %v; want %g", prec, got, err, want)
+		}
+	}
+
+	for _, flows := range [][]string{
+		{},
+		{"100", "10"},
+		{"-100", "-10"},
+		{"1", "-1", "1"},
+	} {
+		if got, err := finance.IRR(amounts(53, flows...), 53); err == nil {
+			t.Errorf("IRR(%v) = %g; want an error", flows, got)
+		}
+	}
+}
+
+func TestNPV(t *testing.T) {
+	for _, prec := range []uint{53, 100, 200} {
+		// 110/1.25 - 100 is -12, and 125/1.25 - 100 is 0, exactly
+		rate := new(big.Float).SetPrec(prec).SetFloat64(0.25)
+		for _, test := range []struct {
+			flows []string
+			want  float64
+		}{
+			{[]string{"-100", "110"}, -12},
+			{[]string{"-100", "125"}, 0},
+			{[]string{"1", "-2.5", "1.5625"}, 0},
+			{[]string{"7"}, 7},
+			{nil, 0},
+		} {
+			if got, _ := finance.NPV(rate, amounts(prec, test.flows...), prec).Float64(); got != test.want {
+				t.Errorf("NPV(0.25, %v) at prec %d = %g; want %g", test.flows, prec, got, test.want)
+			}
+		}
+
+		r, _ := new(big.Float).SetPrec(prec).SetString("0.09")
+		want, _ := new(big.Float).SetPrec(prec).SetString("2086.6476020315366216636100943141440868692865968713416608661273605601096")
+		if got := finance.XNPV(r, xirrFlows(prec), prec); bigfloat.CmpUlp(got, want, 1) != 0 {
+			t.Errorf("XNPV(0.09, ...) at prec %d = %g; want %g", prec, got, want)
+		}
+	}
+
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Error("NPV(-1, ...) didn't panic")
+			}
+		}()
+		finance.NPV(big.NewFloat(-1), amounts(53, "1"), 53)
+	}()
+}
+
+// ---------- Benchmarks ----------
+
+func BenchmarkIRR(b *testing.B) {
+	for _, prec := range []uint{1e2, 1e3} {
+		flows := amounts(prec, "-1000", "300", "400", "500", "-50", "200")
+		b.Run(fmt.Sprintf("%v", prec), func(b *testing.B) {
+			b.ReportAllocs()
+			for n := 0; n < b.N; n++ {
+				finance.IRR(flows, prec)
+			}
+		})
+	}
+}
@@ -0,0 +1,162 @@
+package finance
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ThreeAndTwo/bigfloat"
+	"github.com/ThreeAndTwo/bigfloat/fixed"
+)
+
+// An Annuity is a series of equal payments, one per period for
+// Periods periods, at the constant Rate per period, paid at the end of
+// the periods, or at their start if Due is true. The rate is a
+// rational, so that the nominal rates such as 6%/12 are exact.
+//
+// The amounts of money are fixed-point numbers, with the scale of the
+// currency, such as fixed.Decimal(2) for cents, and the signs of the
+// spreadsheets: the amounts received are positive and the amounts paid
+// are negative, so the present value pv, the payment pmt and the future
+// value fv of an annuity satisfy
+//
+//	pv·(1+r)**n + pmt·(1 + r·due)·((1+r)**n - 1)/r + fv = 0
+//
+// and a loan of 1000 has pv = 1000 and a negative payment. The methods
+// solve it exactly, with rationals, and round only their result, to
+// the scale of their arguments and with the given rule. They panic if
+// Rate <= -1, Periods is negative, or their arguments have different
+// scales.
+type Annuity struct {
+	Rate    *big.Rat
+	Periods int
+	Due     bool
+}
+
+// An Installment is a row of an amortization schedule: the payment of
+// a period, the interest it pays, the principal it repays, and the
+// balance left after it.
+type Installment struct {
+	Payment, Interest, Principal, Balance *fixed.Fixed
+}
+
+// PV returns the present value of the payments pmt and of the future
+// value fv.
+func (a Annuity) PV(pmt, fv *fixed.Fixed, rule bigfloat.RoundingRule) *fixed.Fixed {
+
+	g, f := a.factors("PV", pmt, fv)
+
+	// pv = -(pmt·f + fv)/g
+	z := new(big.Rat).Mul(pmt.Rat(), f)
+	z.Add(z, fv.Rat()).Quo(z, g)
+
+	return round(z.Neg(z), pmt.Scale(), rule)
+}
+
+// FV returns the future value of the present value pv and of the
+// payments pmt.
+func (a Annuity) FV(pv, pmt *fixed.Fixed, rule bigfloat.RoundingRule) *fixed.Fixed {
+
+	g, f := a.factors("FV", pv, pmt)
+
+	// fv = -(pv·g + pmt·f)
+	z := new(big.Rat).Mul(pv.Rat(), g)
+	z.Add(z, new(big.Rat).Mul(pmt.Rat(), f))
+
+	return round(z.Neg(z), pv.Scale(), rule)
+}
+
+// Payment returns the payment that brings the present value pv to the
+// future value fv. The method panics if Periods is 0.
+func (a Annuity) Payment(pv, fv *fixed.Fixed, rule bigfloat.RoundingRule) *fixed.Fixed {
+
+	g, f := a.factors("Payment", pv, fv)
+	if a.Periods == 0 {
+		panic("Payment: no periods")
+	}
+
+	// pmt = -(pv·g + fv)/f
+	z := new(big.Rat).Mul(pv.Rat(), g)
+	z.Add(z, fv.Rat()).Quo(z, f)
+
+	return round(z.Neg(z), pv.Scale(), rule)
+}
+
+// Schedule returns the amortization schedule of a loan of the amount
+// pv, one installment per period. The amounts of the installments have
+// the sign of pv. The interest of every period is the balance times
+// the rate, rounded with the given rule, as on a statement, and so is
+// the payment; the last payment repays the whole balance left, so the
+// rounding errors don't accumulate, and the last balance is 0. With
+// Due, the first payment is made at once and pays no interest. The
+// method panics if Periods is 0.
+func (a Annuity) Schedule(pv *fixed.Fixed, rule bigfloat.RoundingRule) []Installment {
+
+	s := pv.Scale()
+	zero := fixed.FromInt64(0, s)
+	a.factors("Schedule", pv, zero)
+	if a.Periods == 0 {
+		panic("Schedule: no periods")
+	}
+
+	pmt := a.Payment(pv, zero, rule).Neg()
+	rows := make([]Installment, a.Periods)
+	balance := pv
+	for i := range rows {
+		interest := zero
+		if i > 0 || !a.Due {
+			interest = round(new(big.Rat).Mul(balance.Rat(), a.Rate), s, rule)
+		}
+		p := pmt
+		if i == len(rows)-1 {
+			p = balance.Add(interest)
+		}
+		principal := p.Sub(interest)
+		balance = balance.Sub(principal)
+		rows[i] = Installment{p, interest, principal, balance}
+	}
+
+	return rows
+}
+
+// factors checks the annuity and the amounts x and y, and returns the
+// growth (1+r)**n and the factor (1 + r·due)·((1+r)**n - 1)/r of the
+// payments, which is n for r = 0.
+func (a Annuity) factors(name string, x, y *fixed.Fixed) (g, f *big.Rat) {
+
+	one := big.NewRat(1, 1)
+	switch {
+	case a.Rate == nil:
+		panic(name + ": nil rate")
+	case a.Rate.Cmp(big.NewRat(-1, 1)) <= 0:
+		panic(name + ": rate <= -1")
+	case a.Periods < 0:
+		panic(name + ": negative periods")
+	case x.Scale() != y.Scale():
+		panic(fmt.Sprintf("%s: different scales %v and %v", name, x.Scale(), y.Scale()))
+	}
+
+	r1 := new(big.Rat).Add(a.Rate, one)
+	g = new(big.Rat).SetFrac(
+		new(big.Int).Exp(r1.Num(), big.NewInt(int64(a.Periods)), nil),
+		new(big.Int).Exp(r1.Denom(), big.NewInt(int64(a.Periods)), nil))
+	if a.Rate.Sign() == 0 {
+		return g, new(big.Rat).SetInt64(int64(a.Periods))
+	}
+	f = new(big.Rat).Sub(g, one)
+	f.Quo(f, a.Rate)
+	if a.Due {
+		f.Mul(f, r1)
+	}
+
+	return g, f
+}
+
+// round returns r rounded to the unit of s with the given rule.
+func round(r *big.Rat, s fixed.Scale, rule bigfloat.RoundingRule) *fixed.Fixed {
+
+	// r·u/u in units of s, divided by the integer denominator of r
+	u := fixed.FromInt64(1, s).Raw()
+	num := fixed.New(new(big.Int).Mul(r.Num(), u), s)
+
+	return num.Quo(fixed.New(r.Denom(), fixed.Scale{}), rule)
+}
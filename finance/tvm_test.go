@@ -0,0 +1,129 @@
+package finance_test
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/ThreeAndTwo/bigfloat"
+	"github.com/ThreeAndTwo/bigfloat/finance"
+	"github.com/ThreeAndTwo/bigfloat/fixed"
+)
+
+// cents returns the amount c/100.
+func cents(c int64) *fixed.Fixed {
+	return fixed.New(big.NewInt(c), fixed.Decimal(2))
+}
+
+func TestAnnuity(t *testing.T) {
+	mortgage := finance.Annuity{Rate: big.NewRat(6, 1200), Periods: 360}
+	due := finance.Annuity{Rate: big.NewRat(6, 1200), Periods: 360, Due: true}
+	savings := finance.Annuity{Rate: big.NewRat(5, 1200), Periods: 120}
+	savingsDue := finance.Annuity{Rate: big.NewRat(5, 1200), Periods: 120, Due: true}
+	flat := finance.Annuity{Rate: new(big.Rat), Periods: 12}
+	balloon := finance.Annuity{Rate: big.NewRat(7, 100), Periods: 10}
+	for _, test := range []struct {
+		name string
+		got  *fixed.Fixed
+		want string
+	}{
+		{"Payment(mortgage)", mortgage.Payment(cents(200000_00), cents(0), bigfloat.HalfEven), "-1199.10"},
+		{"Payment(due)", due.Payment(cents(200000_00), cents(0), bigfloat.HalfEven), "-1193.14"},
+		{"Payment(flat)", flat.Payment(cents(1200_00), cents(0), bigfloat.HalfEven), "-100.00"},
+		{"Payment(balloon)", balloon.Payment(cents(10000_00), cents(-5000_00), bigfloat.HalfEven), "-1061.89"},
+		{"Payment(balloon, TowardZero)", balloon.Payment(cents(10000_00), cents(-5000_00), bigfloat.TowardZero), "-1061.88"},
+		{"PV(savings)", savings.PV(cents(-100_00), cents(0), bigfloat.HalfEven), "9428.14"},
+		{"FV(savings)", savings.FV(cents(0), cents(-100_00), bigfloat.HalfEven), "15528.23"},
+		{"FV(savingsDue)", savingsDue.FV(cents(-1000_00), cents(-100_00), bigfloat.HalfEven), "17239.94"},
+		{"FV(flat)", flat.FV(cents(-1_00), cents(-1_00), bigfloat.HalfEven), "13.00"},
+	} {
+		if got := test.got.String(); got != test.want {
+			t.Errorf("%s = %s; want %s", test.name, got, test.want)
+		}
+	}
+
+	for _, f := range []func(){
+		func() { finance.Annuity{Rate: big.NewRat(-1, 1), Periods: 1}.PV(cents(1), cents(1), bigfloat.HalfEven) },
+		func() {
+			finance.Annuity{Rate: big.NewRat(1, 10), Periods: -1}.FV(cents(1), cents(1), bigfloat.HalfEven)
+		},
+		func() { finance.Annuity{Rate: big.NewRat(1, 10)}.Payment(cents(1), cents(1), bigfloat.HalfEven) },
+		func() { finance.Annuity{Periods: 1}.PV(cents(1), cents(1), bigfloat.HalfEven) },
+		func() {
+			finance.Annuity{Rate: big.NewRat(1, 10), Periods: 1}.PV(cents(1), fixed.FromInt64(1, fixed.Decimal(3)), bigfloat.HalfEven)
+		},
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Error("invalid annuity didn't panic")
+				}
+			}()
+			f()
+		}()
+	}
+}
+
+func TestSchedule(t *testing.T) {
+	for _, test := range []struct {
+		a     finance.Annuity
+		pv    *fixed.Fixed
+		first [2][4]string
+		last  [4]string
+	}{
+		{
+			finance.Annuity{Rate: big.NewRat(6, 1200), Periods: 360}, cents(200000_00),
+			[2][4]string{{"1199.10", "1000.00", "199.10", "199800.90"}, {"1199.10", "999.00", "200.10", "199600.80"}},
+			[4]string{"1200.14", "5.97", "1194.17", "0.00"},
+		},
+		{
+			finance.Annuity{Rate: big.NewRat(6, 1200), Periods: 360, Due: true}, cents(200000_00),
+			[2][4]string{{"1193.14", "0.00", "1193.14", "198806.86"}, {"1193.14", "994.03", "199.11", "198607.75"}},
+			[4]string{"1188.23", "5.91", "1182.32", "0.00"},
+		},
+		{
+			finance.Annuity{Rate: big.NewRat(1, 100), Periods: 3}, cents(100_00),
+			[2][4]string{{"34.00", "1.00", "33.00", "67.00"}, {"34.00", "0.67", "33.33", "33.67"}},
+			[4]string{"34.01", "0.34", "33.67", "0.00"},
+		},
+	} {
+		rows := test.a.Schedule(test.pv, bigfloat.HalfEven)
+		if len(rows) != test.a.Periods {
+			t.Fatalf("Schedule(%v) has %d rows; want %d", test.a, len(rows), test.a.Periods)
+		}
+		str := func(r finance.Installment) [4]string {
+			return [4]string{r.Payment.String(), r.Interest.String(), r.Principal.String(), r.Balance.String()}
+		}
+		for i := 0; i < 2; i++ {
+			if got := str(rows[i]); got != test.first[i] {
+				t.Errorf("Schedule(%v)[%d] = %v; want %v", test.a, i, got, test.first[i])
+			}
+		}
+		if got := str(rows[len(rows)-1]); got != test.last {
+			t.Errorf("Schedule(%v) ends with %v; want %v", test.a, got, test.last)
+		}
+
+		// the principal repaid is the loan
+		sum := cents(0)
+		for _, r := range rows {
+			sum = sum.Add(r.Principal)
+		}
+		if sum.Cmp(test.pv) != 0 {
+			t.Errorf("Schedule(%v) repays %s; want %s", test.a, sum, test.pv)
+		}
+	}
+}
+
+// ---------- Benchmarks ----------
+
+func BenchmarkSchedule(b *testing.B) {
+	for _, n := range []int{12, 360} {
+		a := finance.Annuity{Rate: big.NewRat(6, 1200), Periods: n}
+		b.Run(fmt.Sprintf("%v", n), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				a.Schedule(cents(200000_00), bigfloat.HalfEven)
+			}
+		})
+	}
+}
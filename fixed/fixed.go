@@ -0,0 +1,296 @@
+// Package fixed provides an arbitrary-precision fixed-point type: an
+// integer of any size times a constant unit, 2**-k or 10**-k, for the
+// computations that must give the same results everywhere, as in smart
+// contracts and embedded systems, and still need the functions of
+// bigfloat.
+//
+// Additions and subtractions are exact. Products, quotients and the
+// elementary functions are correctly rounded to the unit of their
+// result with one of the bigfloat rounding rules, so they don't depend
+// on the precisions used to compute them:
+//
+//	s := fixed.Decimal(18) // the unit is 10**-18
+//	x := fixed.FromInt64(2, s)
+//	r := x.Sqrt(bigfloat.HalfEven) // 1.414213562373095049
+package fixed
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ThreeAndTwo/bigfloat"
+)
+
+// A Scale is the unit of a fixed-point number, a negative power of 2
+// or 10. The zero value is the scale of the integers.
+type Scale struct {
+	base int
+	frac int
+}
+
+// Binary returns the scale with the unit 2**-frac. The function panics
+// if frac is negative.
+func Binary(frac int) Scale {
+	return newScale("Binary", 2, frac)
+}
+
+// Decimal returns the scale with the unit 10**-frac; Decimal(2) is the
+// scale of cents. The function panics if frac is negative.
+func Decimal(frac int) Scale {
+	return newScale("Decimal", 10, frac)
+}
+
+func newScale(name string, base, frac int) Scale {
+
+	if frac < 0 {
+		panic(name + ": negative frac")
+	}
+	if frac == 0 {
+		return Scale{}
+	}
+
+	return Scale{base, frac}
+}
+
+// Base returns 2 or 10, the base of the unit of s, or 0 for the scale
+// of the integers.
+func (s Scale) Base() int {
+	return s.base
+}
+
+// Frac returns the number of fractional digits of s, in its base.
+func (s Scale) Frac() int {
+	return s.frac
+}
+
+func (s Scale) String() string {
+
+	if s.frac == 0 {
+		return "1"
+	}
+
+	return fmt.Sprintf("%d**-%d", s.base, s.frac)
+}
+
+// invUnit returns base**frac, the inverse of the unit of s.
+func (s Scale) invUnit() *big.Int {
+
+	if s.frac == 0 {
+		return big.NewInt(1)
+	}
+
+	return new(big.Int).Exp(big.NewInt(int64(s.base)), big.NewInt(int64(s.frac)), nil)
+}
+
+// bits returns the number of bits of the fractional part of s,
+// rounded up.
+func (s Scale) bits() uint {
+
+	if s.base == 10 {
+		return uint(s.frac*3322/1000 + 1)
+	}
+
+	return uint(s.frac)
+}
+
+// round returns num/den in units of s, rounded with the given rule.
+// den must be positive.
+func (s Scale) round(num, den *big.Int, rule bigfloat.RoundingRule) *big.Int {
+	return bigfloat.RoundQuo(new(big.Int).Mul(num, s.invUnit()), den, rule)
+}
+
+// A Fixed is the number v·u, where v is an integer, its raw value, and
+// u the unit of its scale. The zero value is the integer 0. A Fixed is
+// never modified after its creation, and its methods return new values.
+type Fixed struct {
+	v big.Int
+	s Scale
+}
+
+// New returns the number with the raw value v, v·u where u is the unit
+// of s.
+func New(v *big.Int, s Scale) *Fixed {
+
+	z := &Fixed{s: s}
+	z.v.Set(v)
+
+	return z
+}
+
+// FromInt64 returns the integer x with the scale s.
+func FromInt64(x int64, s Scale) *Fixed {
+
+	z := &Fixed{s: s}
+	z.v.Mul(big.NewInt(x), s.invUnit())
+
+	return z
+}
+
+// FromFloat returns x rounded to the unit of s with the given rule. The
+// function panics if x is infinite.
+func FromFloat(x *big.Float, s Scale, rule bigfloat.RoundingRule) *Fixed {
+
+	if x.IsInf() {
+		panic("FromFloat: infinite argument")
+	}
+	r, _ := x.Rat(nil)
+
+	return New(s.round(r.Num(), r.Denom(), rule), s)
+}
+
+// Raw returns the raw value of x, the integer v with x = v·u.
+func (x *Fixed) Raw() *big.Int {
+	return new(big.Int).Set(&x.v)
+}
+
+// Scale returns the scale of x.
+func (x *Fixed) Scale() Scale {
+	return x.s
+}
+
+// Sign returns -1, 0 or +1 depending on the sign of x.
+func (x *Fixed) Sign() int {
+	return x.v.Sign()
+}
+
+// Cmp returns -1, 0 or +1 depending on whether x < y, x == y or
+// x > y. The scales of x and y can differ.
+func (x *Fixed) Cmp(y *Fixed) int {
+
+	if x.s == y.s {
+		return x.v.Cmp(&y.v)
+	}
+
+	return x.Rat().Cmp(y.Rat())
+}
+
+// Neg returns -x.
+func (x *Fixed) Neg() *Fixed {
+
+	z := &Fixed{s: x.s}
+	z.v.Neg(&x.v)
+
+	return z
+}
+
+// Abs returns |x|.
+func (x *Fixed) Abs() *Fixed {
+
+	z := &Fixed{s: x.s}
+	z.v.Abs(&x.v)
+
+	return z
+}
+
+// Add returns x + y, exactly. The method panics if the scales of x and
+// y differ.
+func (x *Fixed) Add(y *Fixed) *Fixed {
+
+	checkScales("Add", x, y)
+	z := &Fixed{s: x.s}
+	z.v.Add(&x.v, &y.v)
+
+	return z
+}
+
+// Sub returns x - y, exactly. The method panics if the scales of x and
+// y differ.
+func (x *Fixed) Sub(y *Fixed) *Fixed {
+
+	checkScales("Sub", x, y)
+	z := &Fixed{s: x.s}
+	z.v.Sub(&x.v, &y.v)
+
+	return z
+}
+
+// Mul returns x·y rounded to the unit of x with the given rule. The
+// scale of y can differ from the one of x.
+func (x *Fixed) Mul(y *Fixed, rule bigfloat.RoundingRule) *Fixed {
+
+	num := new(big.Int).Mul(&x.v, &y.v)
+
+	return New(bigfloat.RoundQuo(num, y.s.invUnit(), rule), x.s)
+}
+
+// Quo returns x/y rounded to the unit of x with the given rule. The
+// scale of y can differ from the one of x. The method panics if y is
+// 0.
+func (x *Fixed) Quo(y *Fixed, rule bigfloat.RoundingRule) *Fixed {
+
+	if y.Sign() == 0 {
+		panic("Quo: division by zero")
+	}
+
+	num := new(big.Int).Mul(&x.v, y.s.invUnit())
+	den := &y.v
+	if den.Sign() < 0 {
+		num.Neg(num)
+		den = new(big.Int).Neg(den)
+	}
+
+	return New(bigfloat.RoundQuo(num, den, rule), x.s)
+}
+
+// Rescale returns x rounded to the unit of s with the given rule.
+func (x *Fixed) Rescale(s Scale, rule bigfloat.RoundingRule) *Fixed {
+
+	if s == x.s {
+		return x
+	}
+
+	return New(s.round(&x.v, x.s.invUnit(), rule), s)
+}
+
+// Float returns x rounded to nearest even to prec bits (64 if prec is
+// 0).
+func (x *Fixed) Float(prec uint) *big.Float {
+
+	if prec == 0 {
+		prec = 64
+	}
+
+	if x.s.base != 10 {
+		z := new(big.Float).SetPrec(prec).SetInt(&x.v)
+		return z.SetMantExp(z, -x.s.frac)
+	}
+	f, _ := bigfloat.FromRat(x.Rat(), prec)
+
+	return f
+}
+
+// Rat returns x as a big.Rat.
+func (x *Fixed) Rat() *big.Rat {
+	return new(big.Rat).SetFrac(&x.v, x.s.invUnit())
+}
+
+// String returns the exact decimal representation of x, with as many
+// fractional digits as the scale of x: 2 for Decimal(2) and 3 for
+// Binary(3), as in "-1.50" and "0.125".
+func (x *Fixed) String() string {
+
+	// a unit 2**-k is 5**k·10**-k
+	d := new(big.Int).Abs(&x.v)
+	if x.s.base == 2 {
+		d.Mul(d, new(big.Int).Exp(big.NewInt(5), big.NewInt(int64(x.s.frac)), nil))
+	}
+	s := d.String()
+	if k := x.s.frac; k > 0 {
+		if len(s) <= k {
+			s = strings.Repeat("0", k-len(s)+1) + s
+		}
+		s = s[:len(s)-k] + "." + s[len(s)-k:]
+	}
+	if x.v.Sign() < 0 {
+		s = "-" + s
+	}
+
+	return s
+}
+
+func checkScales(name string, x, y *Fixed) {
+	if x.s != y.s {
+		panic(fmt.Sprintf("%s: different scales %v and %v", name, x.s, y.s))
+	}
+}
@@ -0,0 +1,169 @@
+package fixed_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ThreeAndTwo/bigfloat"
+	"github.com/ThreeAndTwo/bigfloat/fixed"
+)
+
+func parse(s string, sc fixed.Scale) *fixed.Fixed {
+	r, ok := new(big.Rat).SetString(s)
+	if !ok {
+		panic("invalid number " + s)
+	}
+	return fixed.FromFloat(new(big.Float).SetPrec(1000).SetRat(r), sc, bigfloat.HalfEven)
+}
+
+func parseInt(s string) *big.Int {
+	n, ok := new(big.Int).SetString(s, 10)
+	if !ok {
+		panic("invalid integer " + s)
+	}
+	return n
+}
+
+func TestScale(t *testing.T) {
+	for _, test := range []struct {
+		s          fixed.Scale
+		base, frac int
+		str        string
+	}{
+		{fixed.Binary(64), 2, 64, "2**-64"},
+		{fixed.Decimal(2), 10, 2, "10**-2"},
+		{fixed.Decimal(0), 0, 0, "1"},
+		{fixed.Binary(0), 0, 0, "1"},
+		{fixed.Scale{}, 0, 0, "1"},
+	} {
+		if test.s.Base() != test.base || test.s.Frac() != test.frac || test.s.String() != test.str {
+			t.Errorf("scale %v: base %d, frac %d; want %d, %d, %s", test.s, test.s.Base(), test.s.Frac(), test.base, test.frac, test.str)
+		}
+	}
+	if fixed.Binary(0) != fixed.Decimal(0) {
+		t.Error("Binary(0) != Decimal(0)")
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Decimal(-1) didn't panic")
+		}
+	}()
+	fixed.Decimal(-1)
+}
+
+func TestString(t *testing.T) {
+	for _, test := range []struct {
+		v    int64
+		s    fixed.Scale
+		want string
+	}{
+		{-150, fixed.Decimal(2), "-1.50"},
+		{5, fixed.Decimal(3), "0.005"},
+		{1, fixed.Binary(3), "0.125"},
+		{-3, fixed.Binary(1), "-1.5"},
+		{0, fixed.Binary(2), "0.00"},
+		{42, fixed.Scale{}, "42"},
+	} {
+		if got := fixed.New(big.NewInt(test.v), test.s).String(); got != test.want {
+			t.Errorf("New(%d, %v) = %s; want %s", test.v, test.s, got, test.want)
+		}
+	}
+	if got := fixed.FromInt64(-7, fixed.Decimal(2)).String(); got != "-7.00" {
+		t.Errorf("FromInt64(-7) = %s; want -7.00", got)
+	}
+}
+
+func TestFromFloat(t *testing.T) {
+	for _, test := range []struct {
+		x    float64
+		s    fixed.Scale
+		rule bigfloat.RoundingRule
+		want string
+	}{
+		{2.675, fixed.Decimal(2), bigfloat.HalfUp, "2.67"}, // the float64 is below 2.675
+		{0.125, fixed.Decimal(2), bigfloat.HalfEven, "0.12"},
+		{0.125, fixed.Decimal(2), bigfloat.HalfUp, "0.13"},
+		{-0.125, fixed.Decimal(2), bigfloat.HalfUp, "-0.12"},
+		{-0.125, fixed.Decimal(2), bigfloat.HalfAwayFromZero, "-0.13"},
+		{-0.129, fixed.Decimal(2), bigfloat.TowardZero, "-0.12"},
+		{0.1, fixed.Binary(4), bigfloat.HalfEven, "0.1250"},
+		{1e20, fixed.Decimal(1), bigfloat.HalfEven, "100000000000000000000.0"},
+	} {
+		got := fixed.FromFloat(big.NewFloat(test.x), test.s, test.rule)
+		if got.String() != test.want || got.Scale() != test.s {
+			t.Errorf("FromFloat(%g, %v, %v) = %s; want %s", test.x, test.s, test.rule, got, test.want)
+		}
+	}
+
+	// binary scales convert exactly
+	x := fixed.New(big.NewInt(-12345), fixed.Binary(10))
+	if f := x.Float(20); f.Cmp(big.NewFloat(-12345.0/1024)) != 0 {
+		t.Errorf("Float(%v) = %g", x, f)
+	}
+	if y := fixed.FromFloat(x.Float(0), fixed.Binary(10), bigfloat.TowardZero); y.Cmp(x) != 0 {
+		t.Errorf("FromFloat(Float(%v)) = %v", x, y)
+	}
+	if f := parse("0.1", fixed.Decimal(1)).Float(53); f.Cmp(big.NewFloat(0.1)) != 0 {
+		t.Errorf("Float(0.1) = %g", f)
+	}
+}
+
+func TestArithmetic(t *testing.T) {
+	d2, d4, b8 := fixed.Decimal(2), fixed.Decimal(4), fixed.Binary(8)
+	for _, test := range []struct {
+		name string
+		got  *fixed.Fixed
+		want string
+	}{
+		{"1.25 + 2.50", parse("1.25", d2).Add(parse("2.5", d2)), "3.75"},
+		{"1.25 - 2.50", parse("1.25", d2).Sub(parse("2.5", d2)), "-1.25"},
+		{"1.25·1.25", parse("1.25", d2).Mul(parse("1.25", d2), bigfloat.HalfEven), "1.56"},
+		{"0.5·0.25", parse("0.5", d2).Mul(parse("0.25", d2), bigfloat.HalfEven), "0.12"},
+		{"0.5·0.25 up", parse("0.5", d2).Mul(parse("0.25", d2), bigfloat.HalfUp), "0.13"},
+		{"-0.5·0.25 up", parse("-0.5", d2).Mul(parse("0.25", d2), bigfloat.HalfUp), "-0.12"},
+		{"19.99·0.0825", parse("19.99", d2).Mul(parse("0.0825", d4), bigfloat.HalfEven), "1.65"},
+		{"1.5·0.5 binary", parse("1.5", b8).Mul(parse("0.5", b8), bigfloat.HalfEven), "0.75000000"},
+		{"1/3", parse("1", d4).Quo(parse("3", d2), bigfloat.HalfEven), "0.3333"},
+		{"2/3", parse("2", d4).Quo(parse("3", d2), bigfloat.HalfEven), "0.6667"},
+		{"2/-3", parse("2", d4).Quo(parse("-3", d2), bigfloat.HalfEven), "-0.6667"},
+		{"2/-3 truncated", parse("2", d4).Quo(parse("-3", d2), bigfloat.TowardZero), "-0.6666"},
+		{"1/8", parse("1", d2).Quo(parse("8", d2), bigfloat.HalfEven), "0.12"},
+		{"Rescale", parse("1.2345", d4).Rescale(d2, bigfloat.HalfEven), "1.23"},
+		{"Rescale up", parse("1.235", d4).Rescale(d2, bigfloat.HalfEven), "1.24"},
+		{"Rescale binary", parse("1.2345", d4).Rescale(b8, bigfloat.HalfEven), "1.23437500"},
+		{"Rescale finer", parse("1.25", d2).Rescale(d4, bigfloat.HalfEven), "1.2500"},
+	} {
+		if test.got.String() != test.want {
+			t.Errorf("%s = %s; want %s", test.name, test.got, test.want)
+		}
+	}
+
+	if parse("1.5", d2).Cmp(parse("1.5", b8)) != 0 || parse("1.5", d2).Cmp(parse("1.51", d2)) != -1 {
+		t.Error("Cmp is wrong")
+	}
+	if r := parse("-1.25", d2).Rat(); r.Cmp(big.NewRat(-5, 4)) != 0 {
+		t.Errorf("Rat(-1.25) = %v", r)
+	}
+}
+
+func TestPanics(t *testing.T) {
+	for _, test := range []struct {
+		name string
+		f    func()
+	}{
+		{"Add", func() { fixed.FromInt64(1, fixed.Decimal(2)).Add(fixed.FromInt64(1, fixed.Binary(2))) }},
+		{"Sub", func() { fixed.FromInt64(1, fixed.Decimal(2)).Sub(fixed.FromInt64(1, fixed.Decimal(3))) }},
+		{"Quo", func() { fixed.FromInt64(1, fixed.Decimal(2)).Quo(new(fixed.Fixed), bigfloat.HalfEven) }},
+		{"FromFloat", func() { fixed.FromFloat(new(big.Float).SetInf(false), fixed.Decimal(2), bigfloat.HalfEven) }},
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%s didn't panic", test.name)
+				}
+			}()
+			test.f()
+		}()
+	}
+}
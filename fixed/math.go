@@ -0,0 +1,204 @@
+package fixed
+
+import (
+	"math"
+	"math/big"
+
+	"github.com/ThreeAndTwo/bigfloat"
+)
+
+// Sqrt returns the square root of x rounded to the unit of x with the
+// given rule. The method panics if x is negative.
+func (x *Fixed) Sqrt(rule bigfloat.RoundingRule) *Fixed {
+
+	if x.Sign() < 0 {
+		panic("Sqrt: argument is negative")
+	}
+
+	// the raw value of the root is √(v·u)/u = √n, with n = v/u
+	n := new(big.Int).Mul(&x.v, x.s.invUnit())
+	q := new(big.Int).Sqrt(n)
+	if rule == bigfloat.TowardZero {
+		return New(q, x.s)
+	}
+
+	// √n is above q + 1/2 if 4n > (2q + 1)², and can't be equal to it
+	h := new(big.Int).Lsh(q, 1)
+	h.Add(h, big.NewInt(1))
+	if new(big.Int).Lsh(n, 2).Cmp(h.Mul(h, h)) > 0 {
+		q.Add(q, big.NewInt(1))
+	}
+
+	return New(q, x.s)
+}
+
+// Exp returns exp(x) rounded to the unit of x with the given rule.
+func (x *Fixed) Exp(rule bigfloat.RoundingRule) *Fixed {
+
+	if x.Sign() == 0 {
+		return FromInt64(1, x.s)
+	}
+
+	// the relative error of the result is the absolute one of x, and
+	// the result has about x·log2(e) integer bits
+	extra := uint(8)
+	if f, _ := x.Float(0).Float64(); f > 0 {
+		extra += uint(math.Ceil(f * math.Log2E))
+	}
+	xb := intBits(x)
+
+	return ziv(x.s, rule, func(prec uint) *big.Float {
+		p := prec + extra
+		return bigfloat.Exp(x.Float(p + xb))
+	})
+}
+
+// Log returns the natural logarithm of x rounded to the unit of x with
+// the given rule. The method panics if x isn't positive.
+func (x *Fixed) Log(rule bigfloat.RoundingRule) *Fixed {
+
+	if x.Sign() <= 0 {
+		panic("Log: argument is not positive")
+	}
+
+	// the absolute error of the result is the relative one of x
+	lb := logBits(x)
+
+	return ziv(x.s, rule, func(prec uint) *big.Float {
+		return bigfloat.Log(x.Float(prec + lb + 8))
+	})
+}
+
+// Pow returns x**y rounded to the unit of x with the given rule. The
+// scale of y can differ from the one of x. The powers with an integer
+// y that aren't too large are computed exactly before the rounding.
+// The method panics if x is negative and y isn't an integer, or if x
+// is 0 and y is negative.
+func (x *Fixed) Pow(y *Fixed, rule bigfloat.RoundingRule) *Fixed {
+
+	yInt := new(big.Int).Rem(&y.v, y.s.invUnit()).Sign() == 0
+	switch {
+	case y.Sign() == 0:
+		return FromInt64(1, x.s)
+	case x.Sign() == 0 && y.Sign() < 0:
+		panic("Pow: zero base with negative exponent")
+	case x.Sign() == 0:
+		return &Fixed{s: x.s}
+	case x.Sign() < 0 && !yInt:
+		panic("Pow: negative base with non-integer exponent")
+	}
+
+	n := new(big.Int).Quo(&y.v, y.s.invUnit())
+	if yInt && n.IsInt64() && uint64(x.v.BitLen())*absUint64(n.Int64()) <= maxPowBits {
+		return x.powInt(n.Int64(), rule)
+	}
+
+	// the relative error of the result is the absolute one of y·log|x|
+	ax := x.Abs()
+	yf, _ := y.Float(0).Float64()
+	lf, _ := bigfloat.Log(ax.Float(0)).Float64()
+	extra := uint(8)
+	if t := yf * lf * math.Log2E; t > 0 {
+		extra += uint(math.Ceil(math.Min(t, math.MaxInt32)))
+	}
+	yb, lb := intBits(y), logBits(ax)
+
+	z := ziv(x.s, rule, func(prec uint) *big.Float {
+		p := prec + extra
+		t := bigfloat.Log(ax.Float(p + yb + lb + 8))
+		t.Mul(t, y.Float(p+yb+lb+8))
+		return bigfloat.Exp(t.SetPrec(p + yb + lb))
+	})
+	if x.Sign() < 0 && n.Bit(0) == 1 {
+		z = z.Neg()
+	}
+
+	return z
+}
+
+// maxPowBits is the largest number of bits of the raw value of x
+// times the exponent for which Pow computes x**n exactly.
+const maxPowBits = 1 << 20
+
+// powInt returns x**n rounded to the unit of x.
+func (x *Fixed) powInt(n int64, rule bigfloat.RoundingRule) *Fixed {
+
+	// (v·u)**n = v**n·u**n
+	k := big.NewInt(absInt64(n))
+	num := new(big.Int).Exp(&x.v, k, nil)
+	den := new(big.Int).Exp(x.s.invUnit(), k, nil)
+	if n < 0 {
+		num, den = den, num
+		if den.Sign() < 0 {
+			num.Neg(num)
+			den.Neg(den)
+		}
+	}
+
+	return New(x.s.round(num, den, rule), x.s)
+}
+
+// ziv returns the value z rounded to the unit of s, where f returns z
+// with an absolute error of a few units of 2**-prec. f is called with
+// increasing precisions until the rounding of z is known; if it isn't
+// after zivRounds calls, the result is assumed to be exact or a tie,
+// and z is rounded.
+func ziv(s Scale, rule bigfloat.RoundingRule, f func(prec uint) *big.Float) *Fixed {
+
+	prec := s.bits() + 32
+	for i := 0; ; i++ {
+		z := f(prec)
+		r, _ := z.Rat(nil)
+		if i == zivRounds || z.Sign() == 0 {
+			return New(s.round(r.Num(), r.Denom(), rule), s)
+		}
+
+		// z is within 2**(8 - prec) of the result
+		err := new(big.Rat).SetFrac(big.NewInt(1), new(big.Int).Lsh(big.NewInt(1), prec-8))
+		lo := new(big.Rat).Sub(r, err)
+		hi := new(big.Rat).Add(r, err)
+		qlo := s.round(lo.Num(), lo.Denom(), rule)
+		qhi := s.round(hi.Num(), hi.Denom(), rule)
+		if qlo.Cmp(qhi) == 0 {
+			return New(qlo, s)
+		}
+		prec *= 2
+	}
+}
+
+// zivRounds is the number of times ziv doubles the precision before
+// assuming that the result is exact or a tie.
+const zivRounds = 4
+
+// intBits returns the number of bits of the integer part of |x|.
+func intBits(x *Fixed) uint {
+
+	if e := x.Float(0).MantExp(nil); e > 0 {
+		return uint(e)
+	}
+
+	return 0
+}
+
+// logBits returns the number of bits of the integer part of |log x|,
+// plus one, for x > 0.
+func logBits(x *Fixed) uint {
+
+	e := x.Float(0).MantExp(nil)
+	if e < 0 {
+		e = -e
+	}
+
+	return uint(big.NewInt(int64(e)).BitLen()) + 1
+}
+
+func absInt64(x int64) int64 {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
+
+func absUint64(x int64) uint64 {
+	return uint64(absInt64(x))
+}
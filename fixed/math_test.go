@@ -0,0 +1,79 @@
+package fixed_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/ThreeAndTwo/bigfloat"
+	"github.com/ThreeAndTwo/bigfloat/fixed"
+)
+
+func TestFunctions(t *testing.T) {
+	d30, d4, b20, b32 := fixed.Decimal(30), fixed.Decimal(4), fixed.Binary(20), fixed.Binary(32)
+	for _, test := range []struct {
+		name string
+		got  func() *fixed.Fixed
+		want string
+	}{
+		{"Sqrt(2)", func() *fixed.Fixed { return parse("2", d30).Sqrt(bigfloat.HalfEven) }, "1.414213562373095048801688724210"},
+		{"Sqrt(2) truncated", func() *fixed.Fixed { return parse("2", d30).Sqrt(bigfloat.TowardZero) }, "1.414213562373095048801688724209"},
+		{"Sqrt(4)", func() *fixed.Fixed { return parse("4", d4).Sqrt(bigfloat.HalfEven) }, "2.0000"},
+		{"Sqrt(2) binary", func() *fixed.Fixed { return parse("2", b20).Sqrt(bigfloat.HalfEven) }, fixed.FromFloat(bigfloat.Sqrt(parse("2", b20).Float(200)), b20, bigfloat.HalfEven).String()},
+		{"Exp(1)", func() *fixed.Fixed { return parse("1", d30).Exp(bigfloat.HalfEven) }, "2.718281828459045235360287471353"},
+		{"Exp(-1)", func() *fixed.Fixed { return parse("-1", d30).Exp(bigfloat.HalfEven) }, "0.367879441171442321595523770161"},
+		{"Exp(20)", func() *fixed.Fixed { return parse("20", d4).Exp(bigfloat.HalfEven) }, "485165195.4098"},
+		{"Exp(-40)", func() *fixed.Fixed { return parse("-40", d30).Exp(bigfloat.HalfEven) }, "0.000000000000000004248354255292"},
+		{"Exp(-40) coarse", func() *fixed.Fixed { return parse("-40", d4).Exp(bigfloat.HalfUp) }, "0.0000"},
+		{"Exp(0)", func() *fixed.Fixed { return parse("0", d4).Exp(bigfloat.TowardZero) }, "1.0000"},
+		{"Log(10)", func() *fixed.Fixed { return parse("10", d30).Log(bigfloat.HalfEven) }, "2.302585092994045684017991454684"},
+		{"Log(0.5)", func() *fixed.Fixed { return parse("0.5", d30).Log(bigfloat.HalfEven) }, "-0.693147180559945309417232121458"},
+		{"Log(1)", func() *fixed.Fixed { return parse("1", d4).Log(bigfloat.TowardZero) }, "0.0000"},
+		{"Log(3) binary", func() *fixed.Fixed { return parse("3", b32).Log(bigfloat.TowardZero) }, fixed.New(parseInt("4718503850"), b32).String()},
+		{"Pow(2, 0.5)", func() *fixed.Fixed { return parse("2", d30).Pow(parse("0.5", d4), bigfloat.HalfEven) }, "1.414213562373095048801688724210"},
+		{"Pow(2.5, 1.5)", func() *fixed.Fixed { return parse("2.5", d30).Pow(parse("1.5", d4), bigfloat.HalfEven) }, "3.952847075210474164998616930541"},
+		{"Pow(1.05, -10)", func() *fixed.Fixed { return parse("1.05", d30).Pow(parse("-10", d4), bigfloat.HalfEven) }, "0.613913253540759374358546898604"},
+		{"Pow(-1.5, 3)", func() *fixed.Fixed { return parse("-1.5", d4).Pow(parse("3", d4), bigfloat.HalfEven) }, "-3.3750"},
+		{"Pow(-1.5, 3) coarse", func() *fixed.Fixed { return parse("-1.5", fixed.Decimal(2)).Pow(parse("3", d4), bigfloat.HalfEven) }, "-3.38"},
+		{"Pow(0, 2)", func() *fixed.Fixed { return parse("0", d4).Pow(parse("2", d4), bigfloat.HalfEven) }, "0.0000"},
+		{"Pow(7, 0)", func() *fixed.Fixed { return parse("7", d4).Pow(parse("0", d4), bigfloat.HalfEven) }, "1.0000"},
+	} {
+		if got := test.got().String(); got != test.want {
+			t.Errorf("%s = %s; want %s", test.name, got, test.want)
+		}
+	}
+}
+
+func TestFunctionsPanics(t *testing.T) {
+	d := fixed.Decimal(4)
+	for _, test := range []struct {
+		name string
+		f    func()
+	}{
+		{"Sqrt(-1)", func() { parse("-1", d).Sqrt(bigfloat.HalfEven) }},
+		{"Log(0)", func() { parse("0", d).Log(bigfloat.HalfEven) }},
+		{"Pow(-2, 0.5)", func() { parse("-2", d).Pow(parse("0.5", d), bigfloat.HalfEven) }},
+		{"Pow(0, -1)", func() { parse("0", d).Pow(parse("-1", d), bigfloat.HalfEven) }},
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%s didn't panic", test.name)
+				}
+			}()
+			test.f()
+		}()
+	}
+}
+
+// ---------- Benchmarks ----------
+
+func BenchmarkExp(b *testing.B) {
+	for _, frac := range []int{18, 100, 1000} {
+		x := parse("1.2345", fixed.Decimal(frac))
+		b.Run(fmt.Sprintf("%v", frac), func(b *testing.B) {
+			for n := 0; n < b.N; n++ {
+				_ = x.Exp(bigfloat.HalfEven)
+			}
+		})
+	}
+}
@@ -0,0 +1,39 @@
+package bigfloat
+
+import "math/big"
+
+// A Float is a big.Float that can be serialized without losing its
+// value, its precision or its rounding mode. It embeds a big.Float,
+// so all the big.Float methods can be called on it, and &f.Float can
+// be passed to the functions of this package. The zero value is 0,
+// with precision 0, like the one of big.Float.
+//
+// Like a big.Float, a Float must not be copied, and its methods have
+// pointer receivers: a Float field is marshaled by them when the value
+// holding it is addressable, as when a pointer to it is marshaled.
+type Float struct {
+	big.Float
+}
+
+// NewFloat returns a new Float with the value, the precision and the
+// rounding mode of x.
+func NewFloat(x *big.Float) *Float {
+
+	f := new(Float)
+	f.Copy(x)
+
+	return f
+}
+
+// parseRoundingMode returns the big.RoundingMode with the name s, as
+// given by its String method.
+func parseRoundingMode(s string) (big.RoundingMode, bool) {
+
+	for m := big.ToNearestEven; m <= big.ToPositiveInf; m++ {
+		if m.String() == s {
+			return m, true
+		}
+	}
+
+	return 0, false
+}
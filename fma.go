@@ -0,0 +1,31 @@
+package bigfloat
+
+import "math/big"
+
+// FMA returns a·b + c, computed with a single rounding: the product
+// is formed exactly, and only the sum is rounded, to the largest
+// precision of the arguments with the ToNearestEven rounding mode.
+// Like big.Float operations, FMA panics with a big.ErrNaN if the
+// result would be NaN, that is, if a·b is 0·Inf, or if a·b and c are
+// infinities of opposite sign.
+func FMA(a, b, c *big.Float) *big.Float {
+
+	prec := a.Prec()
+	if b.Prec() > prec {
+		prec = b.Prec()
+	}
+	if c.Prec() > prec {
+		prec = c.Prec()
+	}
+
+	// the product of two values of pa and pb bits has at most pa+pb
+	// bits
+	p := new(big.Float).SetPrec(a.Prec() + b.Prec())
+	if p.Prec() == 0 {
+		// a and b are zeros or infinities
+		p.SetPrec(1)
+	}
+	p.Mul(a, b)
+
+	return new(big.Float).SetPrec(prec).Add(p, c)
+}
@@ -0,0 +1,75 @@
+package bigfloat_test
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/ThreeAndTwo/bigfloat"
+)
+
+func TestFMAFloat64(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	check := func(a, b, c float64) {
+		z := bigfloat.FMA(big.NewFloat(a), big.NewFloat(b), big.NewFloat(c))
+		got, _ := z.Float64()
+		if want := math.FMA(a, b, c); got != want || math.Signbit(got) != math.Signbit(want) {
+			t.Errorf("FMA(%v, %v, %v) = %v; want %v", a, b, c, got, want)
+		}
+	}
+
+	for _, abc := range [][3]float64{
+		{2, 3, 4},
+		{0.1, 10, -1}, // 2**-54 + ..., lost by a rounded product
+		{1 + 0x1p-52, 1 - 0x1p-52, -1},
+		{0, -1, 0},
+		{math.Copysign(0, -1), 1, math.Copysign(0, -1)},
+		{1, 1, math.Inf(-1)},
+		{1e308, 10, -math.Inf(1)},
+	} {
+		check(abc[0], abc[1], abc[2])
+	}
+	for i := 0; i < 2000; i++ {
+		a, b := r.NormFloat64(), r.NormFloat64()
+		// c close to -a·b, so that the cancellation is large
+		c := -a * b * (1 + r.NormFloat64()*0x1p-40)
+		check(a, b, c)
+	}
+}
+
+func TestFMAPrecision(t *testing.T) {
+	a := new(big.Float).SetPrec(200).SetInt64(3)
+	b := big.NewFloat(1.0 / 3)
+	c := new(big.Float).SetPrec(10).SetInt64(-1)
+
+	// 3·fl(1/3) - 1 = -2**-54, exactly
+	z := bigfloat.FMA(a, b, c)
+	if want := big.NewFloat(-0x1p-54); z.Cmp(want) != 0 || z.Prec() != 200 {
+		t.Errorf("FMA(3, 1/3, -1) = %g (prec %d); want %g", z, z.Prec(), want)
+	}
+}
+
+func TestFMANaN(t *testing.T) {
+	defer func() {
+		if _, ok := recover().(big.ErrNaN); !ok {
+			t.Errorf("FMA(0, Inf, 1) did not panic with ErrNaN")
+		}
+	}()
+	bigfloat.FMA(new(big.Float), new(big.Float).SetInf(false), big.NewFloat(1))
+}
+
+// ---------- Benchmarks ----------
+
+func BenchmarkFMA(b *testing.B) {
+	for _, prec := range []uint{53, 1000, 10000} {
+		x := bigfloat.Sqrt(new(big.Float).SetPrec(prec).SetInt64(2))
+		y := bigfloat.Sqrt(new(big.Float).SetPrec(prec).SetInt64(3))
+		b.Run(fmt.Sprintf("%v", prec), func(b *testing.B) {
+			for n := 0; n < b.N; n++ {
+				bigfloat.FMA(x, y, x)
+			}
+		})
+	}
+}
@@ -0,0 +1,126 @@
+package bigfloat
+
+import (
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// A RoundingRule selects how FormatFixed rounds the decimal digits
+// that don't fit in the output, and how RoundQuo rounds a quotient.
+type RoundingRule int
+
+const (
+	HalfEven         RoundingRule = iota // to nearest, ties to even (banker's rounding)
+	HalfUp                               // to nearest, ties toward +Inf
+	HalfAwayFromZero                     // to nearest, ties away from zero
+	TowardZero                           // truncation
+)
+
+func (r RoundingRule) String() string {
+
+	switch r {
+	case HalfEven:
+		return "HalfEven"
+	case HalfUp:
+		return "HalfUp"
+	case HalfAwayFromZero:
+		return "HalfAwayFromZero"
+	case TowardZero:
+		return "TowardZero"
+	}
+
+	return "RoundingRule(" + strconv.Itoa(int(r)) + ")"
+}
+
+// FormatFixed returns the decimal representation of x with exactly
+// digits digits after the decimal point, rounded using the given
+// rule. The rounding is done on the exact value of x, so, for
+// example, the float64 value closest to 2.675 (which is slightly
+// below it) is formatted with two digits as "2.67" with any rule.
+// Values that round to zero are formatted without a sign, and ±Inf
+// as "+Inf" and "-Inf". The function panics if digits < 0.
+func FormatFixed(x *big.Float, digits int, rule RoundingRule) string {
+
+	if digits < 0 {
+		panic("FormatFixed: negative digits")
+	}
+
+	return formatFixed(x, 10, digits, rule)
+}
+
+// formatFixed returns x in base, with digits digits after the radix
+// point, rounded with rule, for FormatFixed and TextBase.
+func formatFixed(x *big.Float, base, digits int, rule RoundingRule) string {
+
+	if x.IsInf() {
+		return x.Text('g', 0)
+	}
+
+	num, den := scaleInt(x, base, digits)
+	s := roundQuo(num, den, rule, x.Signbit()).Text(base)
+	if len(s) <= digits {
+		s = strings.Repeat("0", digits-len(s)+1) + s
+	}
+
+	var b strings.Builder
+	if x.Signbit() && strings.Trim(s, "0") != "" {
+		b.WriteByte('-')
+	}
+	b.WriteString(s[:len(s)-digits])
+	if digits > 0 {
+		b.WriteByte('.')
+		b.WriteString(s[len(s)-digits:])
+	}
+
+	return b.String()
+}
+
+// RoundQuo returns num/den rounded to an integer using the given
+// rule, for any signs of num and den. The function panics if den is
+// 0.
+func RoundQuo(num, den *big.Int, rule RoundingRule) *big.Int {
+
+	q, r := new(big.Int).QuoRem(num, den, new(big.Int))
+	if r.Sign() == 0 || rule == TowardZero {
+		return q
+	}
+
+	// q is truncated toward zero; away moves it one further from zero
+	neg := num.Sign() != den.Sign()
+	away := false
+	switch r.Abs(r).Lsh(r, 1).CmpAbs(den) {
+	case 1:
+		away = true
+	case 0:
+		switch rule {
+		case HalfEven:
+			away = q.Bit(0) == 1
+		case HalfUp:
+			away = !neg
+		case HalfAwayFromZero:
+			away = true
+		}
+	}
+	if away && neg {
+		q.Sub(q, big.NewInt(1))
+	} else if away {
+		q.Add(q, big.NewInt(1))
+	}
+
+	return q
+}
+
+// roundQuo returns num/den rounded to an integer using the given
+// rule, where neg tells if the value being rounded is -num/den. num
+// must be non-negative and den positive, and the result is the
+// absolute value of the rounded value.
+func roundQuo(num, den *big.Int, rule RoundingRule, neg bool) *big.Int {
+
+	if neg {
+		num = new(big.Int).Neg(num)
+	}
+	q := RoundQuo(num, den, rule)
+
+	return q.Abs(q)
+}
@@ -0,0 +1,98 @@
+package bigfloat_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ThreeAndTwo/bigfloat"
+)
+
+func TestFormatFixed(t *testing.T) {
+	for _, test := range []struct {
+		x      string
+		digits int
+		want   [4]string // HalfEven, HalfUp, HalfAwayFromZero, TowardZero
+	}{
+		{"2.5", 0, [4]string{"2", "3", "3", "2"}},
+		{"-2.5", 0, [4]string{"-2", "-2", "-3", "-2"}},
+		{"3.5", 0, [4]string{"4", "4", "4", "3"}},
+		{"1.0625", 3, [4]string{"1.062", "1.063", "1.063", "1.062"}},
+		{"-0.375", 2, [4]string{"-0.38", "-0.37", "-0.38", "-0.37"}},
+		{"1.0051", 2, [4]string{"1.01", "1.01", "1.01", "1.00"}},
+		{"0.001", 2, [4]string{"0.00", "0.00", "0.00", "0.00"}},
+		{"-0.001", 2, [4]string{"0.00", "0.00", "0.00", "0.00"}},
+		{"-0.5", 0, [4]string{"0", "0", "-1", "0"}},
+		{"123456789.987654321", 3, [4]string{"123456789.988", "123456789.988", "123456789.988", "123456789.987"}},
+		{"0", 3, [4]string{"0.000", "0.000", "0.000", "0.000"}},
+		{"1e-30", 0, [4]string{"0", "0", "0", "0"}},
+		{"1e25", 1, [4]string{"10000000000000000000000000.0", "10000000000000000000000000.0", "10000000000000000000000000.0", "10000000000000000000000000.0"}},
+	} {
+		// all the ties are dyadic, so they're exact
+		x, _, _ := new(big.Float).SetPrec(1000).Parse(test.x, 10)
+		for i, rule := range []bigfloat.RoundingRule{bigfloat.HalfEven, bigfloat.HalfUp, bigfloat.HalfAwayFromZero, bigfloat.TowardZero} {
+			want := test.want[i]
+			if s := bigfloat.FormatFixed(x, test.digits, rule); s != want {
+				t.Errorf("FormatFixed(%s, %d, %v) = %s; want %s", test.x, test.digits, rule, s, want)
+			}
+		}
+	}
+}
+
+func TestFormatFixedExactValue(t *testing.T) {
+	// 2.675 is stored as 2.67499999999999982236431605997495353221893310546875
+	x := big.NewFloat(2.675)
+	for _, rule := range []bigfloat.RoundingRule{bigfloat.HalfEven, bigfloat.HalfUp, bigfloat.HalfAwayFromZero, bigfloat.TowardZero} {
+		if s := bigfloat.FormatFixed(x, 2, rule); s != "2.67" {
+			t.Errorf("FormatFixed(2.675, 2, %v) = %s; want 2.67", rule, s)
+		}
+	}
+
+	// 0.125 is exact, so it's a real tie
+	x = big.NewFloat(0.125)
+	for rule, want := range map[bigfloat.RoundingRule]string{
+		bigfloat.HalfEven:         "0.12",
+		bigfloat.HalfUp:           "0.13",
+		bigfloat.HalfAwayFromZero: "0.13",
+		bigfloat.TowardZero:       "0.12",
+	} {
+		if s := bigfloat.FormatFixed(x, 2, rule); s != want {
+			t.Errorf("FormatFixed(0.125, 2, %v) = %s; want %s", rule, s, want)
+		}
+	}
+}
+
+// ---------- Benchmarks ----------
+
+func BenchmarkFormatFixed(b *testing.B) {
+	x, _, _ := new(big.Float).SetPrec(1000).Parse(piStr, 10)
+	for n := 0; n < b.N; n++ {
+		bigfloat.FormatFixed(x, 100, bigfloat.HalfEven)
+	}
+}
+
+func TestRoundQuo(t *testing.T) {
+	for _, test := range []struct {
+		num, den int64
+		want     [4]int64 // HalfEven, HalfUp, HalfAwayFromZero, TowardZero
+	}{
+		{5, 2, [4]int64{2, 3, 3, 2}},
+		{-5, 2, [4]int64{-2, -2, -3, -2}},
+		{5, -2, [4]int64{-2, -2, -3, -2}},
+		{-5, -2, [4]int64{2, 3, 3, 2}},
+		{7, 2, [4]int64{4, 4, 4, 3}},
+		{-7, 2, [4]int64{-4, -3, -4, -3}},
+		{8, 3, [4]int64{3, 3, 3, 2}},
+		{-8, 3, [4]int64{-3, -3, -3, -2}},
+		{7, 3, [4]int64{2, 2, 2, 2}},
+		{-1, 3, [4]int64{0, 0, 0, 0}},
+		{6, 3, [4]int64{2, 2, 2, 2}},
+		{0, -3, [4]int64{0, 0, 0, 0}},
+	} {
+		for i, rule := range []bigfloat.RoundingRule{bigfloat.HalfEven, bigfloat.HalfUp, bigfloat.HalfAwayFromZero, bigfloat.TowardZero} {
+			q := bigfloat.RoundQuo(big.NewInt(test.num), big.NewInt(test.den), rule)
+			if q.Cmp(big.NewInt(test.want[i])) != 0 {
+				t.Errorf("RoundQuo(%d, %d, %v) = %d; want %d", test.num, test.den, rule, q, test.want[i])
+			}
+		}
+	}
+}
@@ -0,0 +1,243 @@
+package bigfloat
+
+import (
+	"errors"
+	"io"
+	"math/big"
+)
+
+// Constants of the MPFR portable binary format.
+const (
+	fpifMaxPrecSize    = 7   // largest byte introducing an external precision
+	fpifMaxEmbeddedExp = 47  // largest absolute exponent stored in the first byte
+	fpifExternalExp    = 94  // first byte for external exponents, minus their size
+	fpifKindZero       = 119 // first byte for ±0
+	fpifKindInf        = 120 // first byte for ±Inf
+	fpifKindNaN        = 121 // first byte for NaN
+)
+
+// ExportFpif writes x to w in the portable binary format of MPFR's
+// mpfr_fpif_export, so that it can be read by mpfr_fpif_import, and
+// returns any write error. Values with zero precision, which can only
+// be ±0 or ±Inf, are written with precision 1.
+//
+// The format stores the precision, the sign and the exponent in a few
+// bytes, followed by the mantissa, in a layout that matches the one
+// of MPFR builds with 64-bit limbs: the bytes of the least
+// significant, partial limb come first, most significant first, and
+// each full limb follows in little-endian order.
+func ExportFpif(w io.Writer, x *big.Float) error {
+
+	prec := x.Prec()
+	if prec == 0 {
+		prec = 1
+	}
+
+	// precision
+	var buf []byte
+	if prec <= 255-fpifMaxPrecSize {
+		buf = append(buf, byte(prec+fpifMaxPrecSize))
+	} else {
+		p := uint64(prec - (255 - fpifMaxPrecSize + 1))
+		b := littleEndian(p, 0)
+		buf = append(buf, byte(len(b)-1))
+		buf = append(buf, b...)
+	}
+
+	// sign and exponent
+	var sign byte
+	if x.Signbit() {
+		sign = 0x80
+	}
+	switch {
+	case x.Sign() == 0:
+		buf = append(buf, fpifKindZero|sign)
+	case x.IsInf():
+		buf = append(buf, fpifKindInf|sign)
+	default:
+		e := x.MantExp(nil)
+		u := e
+		if u < 0 {
+			u = -u
+		}
+		if u <= fpifMaxEmbeddedExp {
+			buf = append(buf, byte(e+fpifMaxEmbeddedExp)|sign)
+		} else {
+			// the top bit of the last byte holds the exponent sign
+			b := littleEndian(uint64(u-fpifMaxEmbeddedExp), 1)
+			if e < 0 {
+				b[len(b)-1] |= 0x80
+			}
+			buf = append(buf, byte(fpifExternalExp+len(b))|sign)
+			buf = append(buf, b...)
+		}
+		buf = append(buf, fpifMantissa(x, prec)...)
+	}
+
+	_, err := w.Write(buf)
+	return err
+}
+
+// ImportFpif reads from r a value written in the portable binary
+// format of MPFR's mpfr_fpif_export (or by ExportFpif), with its
+// precision. It returns ErrNaN if the value is a NaN, and an error
+// if the data is invalid, or can't be represented by a big.Float.
+func ImportFpif(r io.Reader) (*big.Float, error) {
+
+	var b [1]byte
+	if _, err := io.ReadFull(r, b[:]); err != nil {
+		return nil, err
+	}
+
+	// precision
+	var prec uint64
+	if b[0] > fpifMaxPrecSize {
+		prec = uint64(b[0] - fpifMaxPrecSize)
+	} else {
+		p, err := readLittleEndian(r, int(b[0])+1)
+		if err != nil {
+			return nil, err
+		}
+		prec = p + 255 - fpifMaxPrecSize + 1
+		if prec > big.MaxPrec || prec < p {
+			return nil, errors.New("ImportFpif: precision too large")
+		}
+	}
+	z := new(big.Float).SetPrec(uint(prec))
+
+	// sign and exponent
+	if _, err := io.ReadFull(r, b[:]); err != nil {
+		return nil, err
+	}
+	neg := b[0]&0x80 != 0
+	var e int64
+	switch k := b[0] &^ 0x80; {
+	case k == fpifKindZero:
+		if neg {
+			z.Neg(z)
+		}
+		return z, nil
+	case k == fpifKindInf:
+		return z.SetInf(neg), nil
+	case k == fpifKindNaN:
+		return nil, ErrNaN
+	case k <= 2*fpifMaxEmbeddedExp:
+		e = int64(k) - fpifMaxEmbeddedExp
+	case k <= fpifExternalExp+8:
+		n := int(k) - fpifExternalExp
+		u, err := readLittleEndian(r, n)
+		if err != nil {
+			return nil, err
+		}
+		s := uint64(1) << (8*uint(n) - 1)
+		e = int64(u&^s) + fpifMaxEmbeddedExp
+		if u&s != 0 {
+			e = -e
+		}
+		if e < big.MinExp || e > big.MaxExp {
+			return nil, errors.New("ImportFpif: exponent out of range")
+		}
+	default:
+		return nil, errors.New("ImportFpif: invalid exponent")
+	}
+
+	// mantissa
+	n := int((prec + 7) / 8)
+	limbs := make([]byte, n)
+	if _, err := io.ReadFull(r, limbs); err != nil {
+		return nil, err
+	}
+	m := new(big.Int)
+	for _, c := range fpifBigEndian(limbs) {
+		m.Lsh(m, 8).Or(m, big.NewInt(int64(c)))
+	}
+	if m.Sign() == 0 {
+		return nil, errors.New("ImportFpif: zero mantissa")
+	}
+	if neg {
+		m.Neg(m)
+	}
+	z.SetInt(m)
+
+	return z.SetMantExp(z, int(e)-8*n), nil
+}
+
+// fpifMantissa returns the mantissa of the finite, non-zero x, in
+// the byte order of the MPFR format.
+func fpifMantissa(x *big.Float, prec uint) []byte {
+
+	// the mantissa as an integer of n bytes, with the leading bit set
+	n := int(prec+7) / 8
+	mant := new(big.Float)
+	x.MantExp(mant)
+	mant.SetMantExp(mant.Abs(mant), 8*n)
+	m, _ := mant.Int(nil)
+	be := m.FillBytes(make([]byte, n))
+
+	// the partial limb keeps its order; the full limbs are reversed
+	b := make([]byte, 0, n)
+	p := n % 8
+	b = append(b, be[n-p:]...)
+	for i := n - p; i > 0; i -= 8 {
+		for j := i - 1; j >= i-8; j-- {
+			b = append(b, be[j])
+		}
+	}
+
+	return b
+}
+
+// fpifBigEndian returns the big-endian mantissa given by the bytes b,
+// in the byte order of the MPFR format. It's the inverse of the
+// reordering done by fpifMantissa.
+func fpifBigEndian(b []byte) []byte {
+
+	n := len(b)
+	p := n % 8
+	be := make([]byte, n)
+	copy(be[n-p:], b[:p])
+	k := p
+	for i := n - p; i > 0; i -= 8 {
+		for j := i - 1; j >= i-8; j-- {
+			be[j] = b[k]
+			k++
+		}
+	}
+
+	return be
+}
+
+// littleEndian returns the shortest little-endian encoding of u, with
+// at least spare free bits in the last byte.
+func littleEndian(u uint64, spare uint) []byte {
+
+	var b []byte
+	for v := u << spare; ; v >>= 8 {
+		b = append(b, byte(u))
+		u >>= 8
+		if v>>8 == 0 {
+			break
+		}
+	}
+
+	return b
+}
+
+// readLittleEndian reads an n-byte little-endian integer from r.
+func readLittleEndian(r io.Reader, n int) (uint64, error) {
+
+	b := make([]byte, n)
+	if _, err := io.ReadFull(r, b); err != nil {
+		return 0, err
+	}
+	if n > 8 {
+		return 0, errors.New("ImportFpif: integer too large")
+	}
+
+	var u uint64
+	for i := n - 1; i >= 0; i-- {
+		u = u<<8 | uint64(b[i])
+	}
+
+	return u, nil
+}
@@ -0,0 +1,114 @@
+package bigfloat_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/ThreeAndTwo/bigfloat"
+)
+
+// fpifTests were written by mpfr_fpif_export of MPFR 4.2.0, on x86-64.
+var fpifTests = []struct {
+	prec uint
+	x    string
+	data string
+}{
+	{53, "1", "3c3080000000000000"},
+	{53, "-0", "3cf7"},
+	{53, "+Inf", "3c78"},
+	{53, "-Inf", "3cf8"},
+	{53, "1e-30", "3c5fb4a2425ff75e1500"},
+	{17, "-3.75", "18b1f00000"},
+	{1, "1", "083080"},
+	{2, "2", "093180"},
+	{16, "1.5", "1730c000"},
+	{24, "0.1", "1f2ccccccd"},
+	{64, "1e-30", "475fb432fc145ef75f42a2"},
+	{65, "1e-30", "485fb48031fc145ef75f42a2"},
+	{72, "1e-30", "4f5fb4a131fc145ef75f42a2"},
+	{80, "1e-30", "575fb4a12631fc145ef75f42a2"},
+	{120, "1e-30", "7f5fb4a1258379a94d0331fc145ef75f42a2"},
+	{128, "0.333333333333333333333333333333333333333333333", "872eabaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"},
+	{130, "0.333333333333333333333333333333333333333333333", "892ec0aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"},
+	{248, "1", "ff3000000000000000000000000000000000000000000000000000000000000080"},
+	{249, "1", "0000300000000000000000000000000000000000000000000000000000000000000080"},
+	{53, "1e100000", "3c61721105e054e092d5b1f0"},
+	{53, "-1e-100000", "3ce17111859211cb7471ee20"},
+}
+
+func TestExportFpif(t *testing.T) {
+	for _, test := range fpifTests {
+		x, _, _ := new(big.Float).SetPrec(test.prec).Parse(test.x, 10)
+		var buf bytes.Buffer
+		if err := bigfloat.ExportFpif(&buf, x); err != nil {
+			t.Fatal(err)
+		}
+		if s := hex.EncodeToString(buf.Bytes()); s != test.data {
+			t.Errorf("ExportFpif(%s, prec %d) =\n%s; want\n%s", test.x, test.prec, s, test.data)
+		}
+	}
+}
+
+func TestImportFpif(t *testing.T) {
+	for _, test := range fpifTests {
+		want, _, _ := new(big.Float).SetPrec(test.prec).Parse(test.x, 10)
+		data, _ := hex.DecodeString(test.data)
+		z, err := bigfloat.ImportFpif(bytes.NewReader(data))
+		if err != nil {
+			t.Errorf("ImportFpif(%s) error: %v", test.data, err)
+			continue
+		}
+		if z.Cmp(want) != 0 || z.Signbit() != want.Signbit() || z.Prec() != test.prec {
+			t.Errorf("ImportFpif(%s) = %g (prec %d); want %g (prec %d)", test.data, z, z.Prec(), want, test.prec)
+		}
+	}
+}
+
+func TestFpifRoundTrip(t *testing.T) {
+	// several values in one stream, with large precisions
+	var buf bytes.Buffer
+	var xs []*big.Float
+	for _, prec := range []uint{70000, 1000, 3, 255, 256, 4096} {
+		x := bigfloat.Sqrt(new(big.Float).SetPrec(prec).SetInt64(3))
+		x.SetMantExp(x, -12345)
+		xs = append(xs, x)
+		if err := bigfloat.ExportFpif(&buf, x); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, x := range xs {
+		z, err := bigfloat.ImportFpif(&buf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if z.Cmp(x) != 0 || z.Prec() != x.Prec() {
+			t.Errorf("prec %d: ImportFpif(ExportFpif(x)) = %g; want %g", x.Prec(), z, x)
+		}
+	}
+}
+
+func TestImportFpifErrors(t *testing.T) {
+	for _, data := range []string{"", "3c", "3c79", "3c30800000", "3c7f", "3c6f"} {
+		b, _ := hex.DecodeString(data)
+		if _, err := bigfloat.ImportFpif(bytes.NewReader(b)); err == nil {
+			t.Errorf("ImportFpif(%q) did not fail", data)
+		}
+	}
+	b, _ := hex.DecodeString("3c79")
+	if _, err := bigfloat.ImportFpif(bytes.NewReader(b)); err != bigfloat.ErrNaN {
+		t.Errorf("ImportFpif(NaN) error = %v; want ErrNaN", err)
+	}
+}
+
+// ---------- Benchmarks ----------
+
+func BenchmarkExportFpif(b *testing.B) {
+	x := bigfloat.Sqrt(new(big.Float).SetPrec(10000).SetInt64(2))
+	var buf bytes.Buffer
+	for n := 0; n < b.N; n++ {
+		buf.Reset()
+		bigfloat.ExportFpif(&buf, x)
+	}
+}
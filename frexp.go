@@ -0,0 +1,48 @@
+package bigfloat
+
+import "math/big"
+
+// Modf returns the integer and the fractional parts of x, both with
+// the sign of x, like math.Modf. Precision is the same as the one of
+// the argument, and int + frac = x exactly. Modf(±0) = ±0, ±0. The
+// function panics if x is an infinity, since the fractional part
+// would be NaN.
+func Modf(x *big.Float) (int, frac *big.Float) {
+
+	if x.IsInf() {
+		panic("Modf: infinite argument")
+	}
+
+	int = Trunc(x)
+
+	// x - int only drops the leading bits of x, and it's exact
+	frac = new(big.Float).SetPrec(x.Prec()).SetMode(x.Mode())
+	frac.Sub(x, int)
+	if frac.Sign() == 0 && x.Signbit() {
+		frac.Neg(frac)
+	}
+
+	return int, frac
+}
+
+// Frexp breaks x into a fraction and a power of two, like
+// math.Frexp. It returns frac and exp such that x = frac·2**exp, with
+// 0.5 <= |frac| < 1. Precision is the same as the one of the
+// argument. Frexp(±0) = ±0, 0 and Frexp(±Inf) = ±Inf, 0.
+func Frexp(x *big.Float) (frac *big.Float, exp int) {
+
+	frac = new(big.Float).SetPrec(x.Prec()).SetMode(x.Mode())
+	exp = x.MantExp(frac)
+
+	return frac, exp
+}
+
+// Ldexp returns frac·2**exp, like math.Ldexp, overflowing to ±Inf
+// and underflowing to ±0 outside of the exponent range of big.Float.
+// Precision is the same as the one of the argument.
+func Ldexp(frac *big.Float, exp int) *big.Float {
+
+	z := new(big.Float).SetPrec(frac.Prec()).SetMode(frac.Mode())
+
+	return z.SetMantExp(frac, exp)
+}
@@ -0,0 +1,67 @@
+package bigfloat_test
+
+import (
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/ThreeAndTwo/bigfloat"
+)
+
+func TestModf(t *testing.T) {
+	for _, x := range []float64{
+		3.5, -3.5, 0.25, -0.25, 3, -3, 0, math.Copysign(0, -1), 1 << 60, 1e15 + 0.5,
+	} {
+		i, f := bigfloat.Modf(big.NewFloat(x))
+		gi, _ := i.Float64()
+		gf, _ := f.Float64()
+		wi, wf := math.Modf(x)
+		if gi != wi || gf != wf || math.Signbit(gi) != math.Signbit(wi) || math.Signbit(gf) != math.Signbit(wf) {
+			t.Errorf("Modf(%v) = %v, %v; want %v, %v", x, gi, gf, wi, wf)
+		}
+	}
+
+	// exact at high precision
+	x := bigfloat.Sqrt(new(big.Float).SetPrec(1000).SetInt64(1 << 40))
+	x.Add(x, bigfloat.Sqrt(new(big.Float).SetPrec(1000).SetInt64(2)))
+	i, f := bigfloat.Modf(x)
+	if s := new(big.Float).SetPrec(2000).Add(i, f); s.Cmp(x) != 0 || i.Prec() != 1000 || f.Prec() != 1000 {
+		t.Errorf("Modf(2**20 + √2) = %g, %g", i, f)
+	}
+	if !i.IsInt() || i.Cmp(big.NewFloat(1<<20+1)) != 0 {
+		t.Errorf("Modf(2**20 + √2) has integer part %g", i)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Modf(+Inf) did not panic")
+		}
+	}()
+	bigfloat.Modf(new(big.Float).SetInf(false))
+}
+
+func TestFrexpLdexp(t *testing.T) {
+	for _, x := range []float64{
+		1, -1, 0.75, 1e300, -1e-300, 0, math.Copysign(0, -1), math.Inf(1), math.Inf(-1),
+	} {
+		f, e := bigfloat.Frexp(big.NewFloat(x))
+		gf, _ := f.Float64()
+		wf, we := math.Frexp(x)
+		if gf != wf || e != we || math.Signbit(gf) != math.Signbit(wf) || f.Prec() != 53 {
+			t.Errorf("Frexp(%v) = %v, %d; want %v, %d", x, gf, e, wf, we)
+		}
+
+		z := bigfloat.Ldexp(f, e)
+		if g, _ := z.Float64(); g != x || math.Signbit(g) != math.Signbit(x) || z.Prec() != 53 {
+			t.Errorf("Ldexp(Frexp(%v)) = %v", x, g)
+		}
+	}
+
+	// outside of the exponent range
+	if z := bigfloat.Ldexp(big.NewFloat(-1), big.MaxExp); !z.IsInf() || !z.Signbit() {
+		t.Errorf("Ldexp(-1, MaxExp) = %s; want -Inf", z.Text('p', 0))
+	}
+	if z := bigfloat.Ldexp(big.NewFloat(0.5), big.MinExp-1); z.Sign() != 0 || z.Signbit() {
+		t.Errorf("Ldexp(0.5, MinExp-1) = %s; want +0", z.Text('p', 0))
+	}
+}
@@ -0,0 +1,147 @@
+package bigfloat
+
+import (
+	"math/big"
+	"math/bits"
+)
+
+// Gamma returns Γ(x). Precision is the same as the one of x (64 if it
+// is 0). Positive integers give factorials, as Factorial; the other
+// arguments are computed as exp(log Γ(x)), from Stirling's series,
+// with the reflection formula Γ(x)·Γ(1-x) = π/sin(πx) for x < 0. The
+// function returns ±Inf for x = ±0 and for x = +Inf, and it panics
+// if x is a negative integer or -Inf. The result overflows to +Inf
+// for x above about 8.6e7, and it underflows to ±0 for the x below
+// about -8.6e7.
+func Gamma(x *big.Float) *big.Float {
+
+	defer trace("Gamma", x.Prec())()
+
+	prec := x.Prec()
+	if prec == 0 {
+		prec = 64
+	}
+	z := new(big.Float).SetPrec(prec)
+
+	switch {
+	case x.Sign() == 0:
+		return z.SetInf(x.Signbit())
+	case x.IsInf() && x.Sign() > 0:
+		return z.SetInf(false)
+	case x.IsInf() || x.Sign() < 0 && x.IsInt():
+		panic("Gamma: argument is a negative integer or -Inf")
+	}
+
+	if x.IsInt() && x.Sign() > 0 && x.MantExp(nil) <= 64 {
+		n, _ := x.Uint64()
+		return Factorial(n-1, prec)
+	}
+
+	// out of range, far from the exponent of x
+	if x.MantExp(nil) > 32 {
+		if x.Sign() > 0 {
+			return z.SetInf(false)
+		}
+		// the sign of Γ is (-1)**(n+1) in (-n-1, -n)
+		n, _ := x.Int(nil)
+		return withSign(z, n.Bit(0) == 0)
+	}
+
+	l, sign := lgammaAcc(x, int(prec)+64)
+
+	return withSign(z.Set(expLog(l, prec)), sign < 0)
+}
+
+// Lgamma returns the natural logarithm and the sign, -1 or +1, of
+// Γ(x). Precision is the same as the one of x (64 if it is 0). The
+// logarithm comes from Stirling's series, with the reflection formula
+// for x < 0, at an accuracy relative to its size: the huge arguments
+// take few terms of it at the precision of x, and the arguments near
+// the zeros of log |Γ|, as 1, 2 and about -2.457, take more bits. As
+// in the math package, Lgamma(+Inf) = +Inf, Lgamma(-Inf) = -Inf, and
+// Lgamma(x) = +Inf for x = 0 and the negative integers.
+func Lgamma(x *big.Float) (lgamma *big.Float, sign int) {
+
+	defer trace("Lgamma", x.Prec())()
+
+	prec := x.Prec()
+	if prec == 0 {
+		prec = 64
+	}
+	z := new(big.Float).SetPrec(prec)
+
+	switch {
+	case x.IsInf():
+		return z.SetInf(x.Sign() < 0), 1
+	case x.Sign() == 0:
+		if x.Signbit() {
+			return z.SetInf(false), -1
+		}
+		return z.SetInf(false), 1
+	case x.Sign() < 0 && x.IsInt():
+		return z.SetInf(false), 1
+	case x.Cmp(big.NewFloat(1)) == 0 || x.Cmp(big.NewFloat(2)) == 0:
+		return z, 1
+	}
+
+	// log Γ(x) is about x·log(x) for the large x, and -log(x) for the
+	// small ones
+	e := x.MantExp(nil)
+	est := bits.Len(uint(abs64(int64(e))))
+	if e > 0 {
+		est += e
+	}
+	acc := int(prec) + 64 - est
+	for {
+		l, sign := lgammaAcc(x, acc)
+		need := int(prec) + 64
+		if l.Sign() != 0 {
+			need -= l.MantExp(nil)
+			if acc >= need {
+				return z.Set(l), sign
+			}
+		} else {
+			need = acc + int(prec)
+		}
+		acc = need
+	}
+}
+
+// lgammaAcc returns log |Γ(x)| and the sign of Γ(x), with an absolute
+// error below 2**-acc, for a finite x which is not 0 or a negative
+// integer.
+func lgammaAcc(x *big.Float, acc int) (*big.Float, int) {
+
+	if x.Sign() > 0 {
+		return lgammaStirling(x, acc), 1
+	}
+
+	// log |Γ(x)| = log(π) - log |sin(πx)| - log Γ(1-x), where
+	// sin(πx) = (-1)**n·sin(πf), for x = n + f, |f| <= 1/2, and the
+	// relative error of sin(πf) is the absolute error of its log
+	wp := uint(64)
+	if acc > 32 {
+		wp = uint(acc) + 32
+	}
+	n, _ := x.Int(nil)
+	f := new(big.Float).SetPrec(x.Prec()).Sub(x, new(big.Float).SetInt(n))
+	if f.Cmp(big.NewFloat(-0.5)) < 0 {
+		f.Add(f, big.NewFloat(1))
+		n.Sub(n, big.NewInt(1))
+	}
+	p := pi(wp)
+	s, _ := sincos(new(big.Float).SetPrec(wp).Mul(p, f))
+	sign := s.Sign()
+	if n.Bit(0) == 1 {
+		sign = -sign
+	}
+
+	y := new(big.Float).SetPrec(x.Prec()+64).Sub(big.NewFloat(1), x)
+	lg := lgammaStirling(y, acc)
+	if lg.Prec() < wp {
+		lg.SetPrec(wp)
+	}
+	z := new(big.Float).SetPrec(lg.Prec()).Sub(Log(p), Log(s.Abs(s)))
+
+	return z.Sub(z, lg), sign
+}
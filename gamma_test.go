@@ -0,0 +1,163 @@
+package bigfloat_test
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/ThreeAndTwo/bigfloat"
+)
+
+func TestGamma(t *testing.T) {
+	for _, prec := range []uint{24, 53, 200, 1000} {
+		sqrtPi := bigfloat.Sqrt(bigfloat.Pi.Value(prec + 64))
+		for _, test := range []struct {
+			x        float64
+			num, den int64 // the ratio to √π
+		}{
+			{0.5, 1, 1},
+			{1.5, 1, 2},
+			{-0.5, -2, 1},
+			{-1.5, 4, 3},
+			{-2.5, -8, 15},
+		} {
+			x := new(big.Float).SetPrec(prec).SetFloat64(test.x)
+			want := new(big.Float).SetPrec(prec + 64).SetInt64(test.num)
+			want.Mul(want, sqrtPi).Quo(want, big.NewFloat(float64(test.den)))
+			want.SetPrec(prec)
+			if got := bigfloat.Gamma(x); got.Prec() != prec || bigfloat.CmpUlp(got, want, 1) != 0 {
+				t.Errorf("Gamma(%g) at prec %d = %g; want %g", test.x, prec, got, want)
+			}
+		}
+
+		for _, n := range []int64{1, 2, 3, 10, 30, 1000} {
+			want := new(big.Float).SetPrec(prec).SetInt(new(big.Int).MulRange(1, n-1))
+			if got := bigfloat.Gamma(new(big.Float).SetPrec(prec).SetInt64(n)); got.Cmp(want) != 0 {
+				t.Errorf("Gamma(%d) at prec %d = %g; want %g", n, prec, got, want)
+			}
+		}
+	}
+
+	// Γ(x+1) = x·Γ(x)
+	rnd := rand.New(rand.NewSource(1))
+	for i := 0; i < 100; i++ {
+		x := new(big.Float).SetPrec(200).SetFloat64(50 * rnd.NormFloat64())
+		x1 := new(big.Float).SetPrec(200).Add(x, big.NewFloat(1))
+		want := new(big.Float).SetPrec(200).Mul(x, bigfloat.Gamma(x))
+		if got := bigfloat.Gamma(x1); bigfloat.CmpUlp(got, want, 2) != 0 {
+			t.Errorf("Gamma(%g) = %g; want %g", x1, got, want)
+		}
+	}
+
+	for _, test := range []struct {
+		x, want float64
+	}{
+		{0, math.Inf(+1)},
+		{math.Copysign(0, -1), math.Inf(-1)},
+		{math.Inf(+1), math.Inf(+1)},
+		{1e9, math.Inf(+1)},
+		{1e20, math.Inf(+1)},
+		{-1e9 - 0.5, math.Copysign(0, -1)},
+		{-3000000001.5, 0},
+	} {
+		got, _ := bigfloat.Gamma(big.NewFloat(test.x)).Float64()
+		if got != test.want || math.Signbit(got) != math.Signbit(test.want) {
+			t.Errorf("Gamma(%g) = %g; want %g", test.x, got, test.want)
+		}
+	}
+
+	for _, x := range []float64{-1, -1e6, math.Inf(-1)} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("Gamma(%g) didn't panic", x)
+				}
+			}()
+			bigfloat.Gamma(big.NewFloat(x))
+		}()
+	}
+}
+
+func TestLgamma(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for i := 0; i < 100; i++ {
+		a := rnd.NormFloat64()
+		x := new(big.Float).SetPrec(200).SetFloat64(a * math.Pow(10, float64(rnd.Intn(6))))
+		g := bigfloat.Gamma(new(big.Float).SetPrec(300).Set(x))
+		want := bigfloat.Log(g.Abs(g)).SetPrec(200)
+		if got, _ := bigfloat.Lgamma(x); bigfloat.CmpUlp(got, want, 1) != 0 {
+			t.Errorf("Lgamma(%g) = %g; want %g", x, got, want)
+		}
+	}
+
+	// near the zeros of log |Γ|, the accuracy is relative
+	for _, x := range []float64{1 + 0x1p-40, 2 - 0x1p-40, -2.4570247382208006, -2.7476826467274127} {
+		for _, prec := range []uint{53, 100, 300} {
+			bx := new(big.Float).SetPrec(prec).SetFloat64(x)
+			g := bigfloat.Gamma(new(big.Float).SetPrec(prec + 200).Set(bx))
+			want := bigfloat.Log(new(big.Float).Abs(g)).SetPrec(prec)
+			got, sign := bigfloat.Lgamma(bx)
+			if bigfloat.CmpUlp(got, want, 1) != 0 || sign != g.Sign() {
+				t.Errorf("Lgamma(%g) at prec %d = %g, %d; want %g", x, prec, got, sign, want)
+			}
+		}
+	}
+
+	// the huge arguments take the first terms of Stirling's series:
+	// log Γ(x) = (x - 1/2)·log(x) - x + log(2π)/2 + 1/(12x) + ...
+	x, _ := new(big.Float).SetPrec(100).SetString("1e100000")
+	want := new(big.Float).SetPrec(400).Sub(x, big.NewFloat(0.5))
+	want.Mul(want, bigfloat.Log(new(big.Float).SetPrec(400).Set(x)))
+	want.Sub(want, x).SetPrec(100)
+	if got, sign := bigfloat.Lgamma(x); bigfloat.CmpUlp(got, want, 1) != 0 || sign != 1 {
+		t.Errorf("Lgamma(1e100000) = %g, %d; want %g", got, sign, want)
+	}
+
+	for _, test := range []struct {
+		x, want float64
+		sign    int
+	}{
+		{1, 0, 1},
+		{2, 0, 1},
+		{0, math.Inf(+1), 1},
+		{math.Copysign(0, -1), math.Inf(+1), -1},
+		{-3, math.Inf(+1), 1},
+		{math.Inf(+1), math.Inf(+1), 1},
+		{math.Inf(-1), math.Inf(-1), 1},
+		{-0.5, 1.2655121234846454, -1},
+		{-2.5, -0.056243716497674054, -1},
+	} {
+		l, sign := bigfloat.Lgamma(big.NewFloat(test.x))
+		if got, _ := l.Float64(); got != test.want || sign != test.sign {
+			t.Errorf("Lgamma(%g) = %g, %d; want %g, %d", test.x, got, sign, test.want, test.sign)
+		}
+	}
+}
+
+// ---------- Benchmarks ----------
+
+func BenchmarkGamma(b *testing.B) {
+	for _, prec := range []uint{1e2, 1e3, 1e4} {
+		x := new(big.Float).SetPrec(prec).SetFloat64(12.34)
+		b.Run(fmt.Sprintf("%v", prec), func(b *testing.B) {
+			b.ReportAllocs()
+			for n := 0; n < b.N; n++ {
+				bigfloat.Gamma(x)
+			}
+		})
+	}
+}
+
+func BenchmarkLgammaHuge(b *testing.B) {
+	for _, prec := range []uint{1e2, 1e3, 1e4} {
+		x, _ := new(big.Float).SetPrec(prec).SetString("1e100000")
+		b.Run(fmt.Sprintf("%v", prec), func(b *testing.B) {
+			b.ReportAllocs()
+			for n := 0; n < b.N; n++ {
+				bigfloat.Lgamma(x)
+			}
+		})
+	}
+}
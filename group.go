@@ -0,0 +1,76 @@
+package bigfloat
+
+import (
+	"math/big"
+	"strings"
+)
+
+// A NumberFormat describes how to write the digits of a number for a
+// locale: the separator between groups of digits in the integer part,
+// and the decimal mark. For example, the French format, as in
+// "1 234 567,89", is
+//
+//	NumberFormat{Group: " ", Decimal: ","}
+//
+// The zero value writes numbers unchanged.
+type NumberFormat struct {
+	Group     string // group separator; "" for no grouping
+	GroupSize int    // digits in a group; 0 for 3
+	Decimal   string // decimal mark; "" for "."
+}
+
+// Text returns x formatted by x.Text(format, prec), with the digits
+// grouped and the decimal mark replaced as described by f.
+func (f NumberFormat) Text(x *big.Float, format byte, prec int) string {
+	return f.Format(x.Text(format, prec))
+}
+
+// Format returns s, a number formatted by big.Float.Text or by one of
+// the formatting functions of this package, with the digits grouped
+// and the decimal mark replaced as described by f. Only the digits
+// of the integer part of the mantissa are grouped; the sign, the
+// "0x" prefix and the exponent are left unchanged, as are the
+// infinities.
+func (f NumberFormat) Format(s string) string {
+
+	size := f.GroupSize
+	if size <= 0 {
+		size = 3
+	}
+
+	var b strings.Builder
+	i := 0
+	if i < len(s) && (s[i] == '+' || s[i] == '-') {
+		i++
+	}
+	base := 10
+	if strings.HasPrefix(s[i:], "0x") || strings.HasPrefix(s[i:], "0X") {
+		base = 16
+		i += 2
+	}
+	b.WriteString(s[:i])
+
+	start := i
+	for i < len(s) && digitVal(s[i]) < base {
+		i++
+	}
+	intPart := s[start:i]
+	for j := 0; j < len(intPart); j++ {
+		if j > 0 && f.Group != "" && (len(intPart)-j)%size == 0 {
+			b.WriteString(f.Group)
+		}
+		b.WriteByte(intPart[j])
+	}
+
+	if i < len(s) && s[i] == '.' {
+		if f.Decimal != "" {
+			b.WriteString(f.Decimal)
+		} else {
+			b.WriteByte('.')
+		}
+		i++
+	}
+	b.WriteString(s[i:])
+
+	return b.String()
+}
@@ -0,0 +1,47 @@
+package bigfloat_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ThreeAndTwo/bigfloat"
+)
+
+func TestNumberFormat(t *testing.T) {
+	fr := bigfloat.NumberFormat{Group: " ", Decimal: ","}
+	en := bigfloat.NumberFormat{Group: ","}
+	ch := bigfloat.NumberFormat{Group: "'", GroupSize: 4}
+
+	for _, test := range []struct {
+		f    bigfloat.NumberFormat
+		s    string
+		want string
+	}{
+		{fr, "1234567.89", "1 234 567,89"},
+		{fr, "-1234567.89", "-1 234 567,89"},
+		{fr, "+123", "+123"},
+		{fr, "1234", "1 234"},
+		{fr, "0.000123", "0,000123"},
+		{fr, "1.234567e+06", "1,234567e+06"},
+		{fr, "-12345.6e-300", "-12 345,6e-300"},
+		{fr, "+Inf", "+Inf"},
+		{fr, "-Inf", "-Inf"},
+		{en, "1234567890", "1,234,567,890"},
+		{en, "123456", "123,456"},
+		{en, "0x1234.8p+3", "0x1,234.8p+3"},
+		{ch, "123456789.5", "1'2345'6789.5"},
+		{bigfloat.NumberFormat{}, "1234567.89", "1234567.89"},
+	} {
+		if got := test.f.Format(test.s); got != test.want {
+			t.Errorf("%+v.Format(%q) = %q; want %q", test.f, test.s, got, test.want)
+		}
+	}
+
+	x := new(big.Float).SetPrec(100).SetFloat64(-1234567.25)
+	if got, want := fr.Text(x, 'f', 2), "-1 234 567,25"; got != want {
+		t.Errorf("Text = %q; want %q", got, want)
+	}
+	if got, want := en.Format(bigfloat.FormatFixed(x, 1, bigfloat.HalfEven)), "-1,234,567.2"; got != want {
+		t.Errorf("Format(FormatFixed) = %q; want %q", got, want)
+	}
+}
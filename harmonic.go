@@ -0,0 +1,157 @@
+package bigfloat
+
+import (
+	"math/big"
+	"math/bits"
+	"strconv"
+)
+
+// Harmonic returns the harmonic number H(n) = 1 + 1/2 + ... + 1/n to
+// prec bits (64 if prec is 0), as GeneralizedHarmonic(n, 1, prec).
+func Harmonic(n uint64, prec uint) *big.Float {
+	return GeneralizedHarmonic(n, 1, prec)
+}
+
+// GeneralizedHarmonic returns the generalized harmonic number
+//
+//	H(n, m) = 1 + 1/2**m + ... + 1/n**m
+//
+// to prec bits (64 if prec is 0). For small n the sum is computed
+// exactly, as a fraction, by binary splitting, and rounded once. For
+// large n it's computed from the asymptotic expansion of the digamma
+// function, for m = 1, and of the Hurwitz zeta function otherwise:
+//
+//	H(n, 1) = γ + ψ(n+1)
+//	H(n, m) = ζ(m) - ζ(m, n+1)
+//
+// whose error is bounded by the first term left out, so that H(n) for
+// n up to 2**64 takes a few terms of it. H(0, m) = 0 and H(n, 0) = n.
+func GeneralizedHarmonic(n uint64, m uint, prec uint) *big.Float {
+
+	if prec == 0 {
+		prec = 64
+	}
+	z := new(big.Float).SetPrec(prec)
+	switch {
+	case n == 0:
+		return z
+	case m == 0:
+		return z.SetUint64(n)
+	}
+
+	if !exactProduct(n, int(m)*bits.Len64(n), prec) {
+		// H(n, m) is about 1 or more, the absolute errors are relative
+		acc := int(prec) + 64
+		if a, ok := harmonicAsym(n, m, acc); ok {
+			if m == 1 {
+				a.Add(a, EulerGamma.Value(a.Prec()))
+			} else {
+				a.Add(a, zeta(m, a.Prec()))
+			}
+			return z.Set(a)
+		}
+	}
+
+	p, q := harmonicSplit(1, n+1, m)
+
+	return z.SetRat(new(big.Rat).SetFrac(p, q))
+}
+
+// harmonicSplit returns p and q with p/q = Σ 1/k**m for k from a to
+// b-1, by binary splitting.
+func harmonicSplit(a, b uint64, m uint) (p, q *big.Int) {
+
+	if b-a == 1 {
+		q = new(big.Int).SetUint64(a)
+		return big.NewInt(1), q.Exp(q, big.NewInt(int64(m)), nil)
+	}
+
+	c := a + (b-a)/2
+	p1, q1 := harmonicSplit(a, c, m)
+	p2, q2 := harmonicSplit(c, b, m)
+
+	// p1/q1 + p2/q2
+	p1.Mul(p1, q2)
+	p1.Add(p1, p2.Mul(p2, q1))
+
+	return p1, q1.Mul(q1, q2)
+}
+
+// harmonicAsym returns the part of H(n, m) that depends on n, with an
+// absolute error below 2**-acc, from the expansions
+//
+//	H(n, 1) - γ    = log(n) + 1/(2n) - Σ B(2k)/(2k·n**(2k))
+//	H(n, m) - ζ(m) = -1/((m-1)·n**(m-1)) + 1/(2n**m) - Σ B(2k)·(m)_(2k-1)/((2k)!·n**(m+2k-1))
+//
+// for k from 1; ok is false when the terms start growing before they
+// are small enough, for the n too small.
+func harmonicAsym(n uint64, m uint, acc int) (a *big.Float, ok bool) {
+
+	w := uint(64)
+	if acc > 32 {
+		w = uint(acc) + 32
+	}
+	x := new(big.Float).SetPrec(w).SetUint64(n)
+
+	// x**-m, and then x**-(m+2k-1) by 1/x²
+	xm := PowInt(new(big.Float).SetPrec(w).Quo(big.NewFloat(1), x), big.NewInt(int64(m)))
+	ix2 := new(big.Float).SetPrec(w).Quo(big.NewFloat(1), x)
+	ix2.Mul(ix2, ix2)
+
+	a = new(big.Float).SetPrec(w)
+	if m == 1 {
+		a.Add(Log(x), a.SetMantExp(xm, -1))
+	} else {
+		// -x**(1-m)/(m-1) + x**-m/2
+		a.Mul(xm, x).Quo(a, new(big.Float).SetInt64(int64(m-1)))
+		a.Neg(a)
+		a.Add(a, new(big.Float).SetMantExp(xm, -1))
+	}
+
+	// c = (m)_(2k-1)/(2k)!, from m/2
+	c := new(big.Rat).SetFrac64(int64(m), 2)
+	xp := new(big.Float).SetPrec(w).Quo(xm, x)
+	t := new(big.Float).SetPrec(w)
+	f := new(big.Float).SetPrec(w)
+	var last *big.Float
+	for k := 1; ; k++ {
+		r := new(big.Rat).Mul(bernoulli2k(k), c)
+		t.SetInt(r.Num())
+		f.SetInt(r.Denom())
+		t.Quo(t, f).Mul(t, xp)
+		if t.Sign() == 0 || t.MantExp(nil) < -acc-1 {
+			return a, true
+		}
+		if last != nil && new(big.Float).Abs(t).Cmp(last) >= 0 {
+			return nil, false
+		}
+		a.Sub(a, t)
+		last = new(big.Float).Abs(t)
+
+		// (m+2k-1)(m+2k)/((2k+1)(2k+2))
+		j := int64(m) + int64(2*k)
+		c.Mul(c, new(big.Rat).SetFrac64((j-1)*j, int64(2*k+1)*int64(2*k+2)))
+		xp.Mul(xp, ix2)
+	}
+}
+
+// zeta returns ζ(m) for an integer m >= 2 to prec bits, from the
+// cache, as H(N, m) - (H(N, m) - ζ(m)): an exact sum of N terms, with
+// N large enough for the expansion of harmonicAsym.
+func zeta(m uint, prec uint) *big.Float {
+	return cached("zeta("+strconv.FormatUint(uint64(m), 10)+")", prec, func() *big.Float {
+
+		// the least term, of the expansion, at 2k ≈ 2πN, is about
+		// exp(-2πN)·N**m
+		acc := int(prec) + 64
+		n := uint64(acc)/9 + uint64(m) + 2
+		for {
+			if a, ok := harmonicAsym(n, m, acc); ok {
+				p, q := harmonicSplit(1, n+1, m)
+				z := new(big.Float).SetPrec(a.Prec()).SetRat(new(big.Rat).SetFrac(p, q))
+				return z.Sub(z, a).SetPrec(prec)
+			}
+			n *= 2
+		}
+	})
+}
@@ -0,0 +1,91 @@
+package bigfloat_test
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/ThreeAndTwo/bigfloat"
+)
+
+// harmonicRat returns Σ 1/k**m for k from 1 to n, exactly.
+func harmonicRat(n uint64, m int64) *big.Rat {
+
+	s := new(big.Rat)
+	for k := uint64(1); k <= n; k++ {
+		d := new(big.Int).SetUint64(k)
+		s.Add(s, new(big.Rat).SetFrac(big.NewInt(1), d.Exp(d, big.NewInt(m), nil)))
+	}
+
+	return s
+}
+
+func TestGeneralizedHarmonic(t *testing.T) {
+	// the small ones are exact, the large ones from the expansions
+	for _, m := range []uint{1, 2, 3, 7} {
+		for _, n := range []uint64{1, 2, 10, 100, 1000, 3000, 6000} {
+			if n == 6000 && m > 1 {
+				continue
+			}
+			r := harmonicRat(n, int64(m))
+			for _, prec := range []uint{24, 53, 100, 300} {
+				want, _ := bigfloat.FromRat(r, prec)
+				if got := bigfloat.GeneralizedHarmonic(n, m, prec); got.Prec() != prec || bigfloat.CmpUlp(got, want, 1) != 0 {
+					t.Errorf("GeneralizedHarmonic(%d, %d, %d) = %g; want %g", n, m, prec, got, want)
+				}
+			}
+		}
+	}
+
+	const prec = 200
+	one := big.NewFloat(1)
+
+	// H(n) = log(n) + γ + 1/(2n) - 1/(12n²) + 1/(120n⁴) - ...
+	x := new(big.Float).SetPrec(prec + 64).SetUint64(1e18)
+	want := bigfloat.Log(x)
+	want.Add(want, bigfloat.EulerGamma.Value(prec+64))
+	t1 := new(big.Float).SetPrec(prec+64).Quo(one, x)
+	want.Add(want, new(big.Float).SetMantExp(t1, -1))
+	t1.Mul(t1, t1).Quo(t1, big.NewFloat(12))
+	want.Sub(want, t1).SetPrec(prec)
+	if got := bigfloat.Harmonic(1e18, prec); bigfloat.CmpUlp(got, want, 1) != 0 {
+		t.Errorf("Harmonic(1e18) = %g; want %g", got, want)
+	}
+
+	// H(n, 3) = ζ(3) - 1/(2n²) + 1/(2n³) - ..., with n = 2**60
+	z3, _ := new(big.Float).SetPrec(prec + 64).SetString("1.2020569031595942853997381615114499907649862923404988817922715553418382057863131")
+	want = new(big.Float).SetPrec(prec+64).Sub(z3, new(big.Float).SetMantExp(one, -121))
+	want.Add(want, new(big.Float).SetMantExp(one, -181)).SetPrec(prec)
+	if got := bigfloat.GeneralizedHarmonic(1<<60, 3, prec); bigfloat.CmpUlp(got, want, 1) != 0 {
+		t.Errorf("GeneralizedHarmonic(2**60, 3) = %g; want %g", got, want)
+	}
+
+	for _, test := range []struct {
+		n    uint64
+		m    uint
+		want float64
+	}{
+		{0, 1, 0},
+		{0, 0, 0},
+		{5, 0, 5},
+		{1, 5, 1},
+		{4, 1, 25.0 / 12},
+	} {
+		if got, _ := bigfloat.GeneralizedHarmonic(test.n, test.m, 53).Float64(); got != test.want {
+			t.Errorf("GeneralizedHarmonic(%d, %d) = %g; want %g", test.n, test.m, got, test.want)
+		}
+	}
+}
+
+// ---------- Benchmarks ----------
+
+func BenchmarkHarmonic(b *testing.B) {
+	for _, prec := range []uint{1e2, 1e3, 1e4} {
+		b.Run(fmt.Sprintf("%v", prec), func(b *testing.B) {
+			b.ReportAllocs()
+			for n := 0; n < b.N; n++ {
+				bigfloat.Harmonic(1e12, prec)
+			}
+		})
+	}
+}
@@ -0,0 +1,59 @@
+package bigfloat
+
+import (
+	"encoding/binary"
+	"hash/fnv"
+	"math/big"
+)
+
+// Hash returns a hash of the value of x that doesn't depend on its
+// precision, its rounding mode or its accuracy: Equal values have the
+// same hash. It's computed with FNV-1a over the sign, the exponent and
+// the mantissa without its trailing zeros, so it's the same in every
+// run and on every platform. Hash and Equal make buckets of values for
+// maps and deduplication:
+//
+//	m := map[uint64][]*big.Float{}
+//	h := bigfloat.Hash(x)
+//	m[h] = append(m[h], x) // unless an Equal value is there already
+//
+// A nil x, standing for a NaN as in Compare, has a hash too.
+func Hash(x *big.Float) uint64 {
+
+	h := fnv.New64a()
+	var b [8]byte
+	switch {
+	case x == nil:
+		b[0] = 'n'
+	case x.IsInf():
+		b[0] = 'i'
+	case x.Sign() == 0:
+		b[0] = 'z'
+	default:
+		b[0] = 'f'
+	}
+	if x != nil && x.Signbit() {
+		b[1] = '-'
+	}
+	h.Write(b[:2])
+	if x == nil || x.IsInf() || x.Sign() == 0 {
+		return h.Sum64()
+	}
+
+	// x = m·2**(e-p) for the odd integer m of p = MinPrec bits
+	e := x.MantExp(nil)
+	p := x.MinPrec()
+	binary.BigEndian.PutUint64(b[:8], uint64(int64(e)))
+	h.Write(b[:8])
+	m, _ := new(big.Float).SetMantExp(x, int(p)-e).Int(nil)
+	h.Write(m.Abs(m).Bytes())
+
+	return h.Sum64()
+}
+
+// Equal reports whether x and y have the same value, whatever their
+// precisions: it's Compare(x, y) == 0, so a nil is equal only to a
+// nil, and -0 and +0 aren't equal, as they aren't the same value.
+func Equal(x, y *big.Float) bool {
+	return Compare(x, y) == 0
+}
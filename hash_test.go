@@ -0,0 +1,73 @@
+package bigfloat_test
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/ThreeAndTwo/bigfloat"
+)
+
+func TestHash(t *testing.T) {
+	// the same values at different precisions, modes and accuracies
+	third := new(big.Float).SetPrec(100).Quo(big.NewFloat(1), big.NewFloat(3))
+	for _, group := range [][]*big.Float{
+		{big.NewFloat(1), new(big.Float).SetPrec(1).SetInt64(1), new(big.Float).SetPrec(1000).SetMode(big.ToZero).SetInt64(1)},
+		{big.NewFloat(-0.375), new(big.Float).SetPrec(3).SetFloat64(-0.375)},
+		{big.NewFloat(6), new(big.Float).SetPrec(200).SetFloat64(6)},
+		{third, new(big.Float).SetPrec(500).Set(third), new(big.Float).Copy(third)},
+		{big.NewFloat(0), new(big.Float).SetPrec(10)},
+		{big.NewFloat(math.Copysign(0, -1)), new(big.Float).SetPrec(300).Neg(new(big.Float))},
+		{new(big.Float).SetInf(false), new(big.Float).SetPrec(10).SetInf(false)},
+		{new(big.Float).SetInf(true), new(big.Float).SetPrec(10).SetInf(true)},
+		{nil, nil},
+	} {
+		for _, x := range group[1:] {
+			if !bigfloat.Equal(group[0], x) {
+				t.Errorf("Equal(%s, %s) = false", nilOr(group[0]), nilOr(x))
+			}
+			if bigfloat.Hash(group[0]) != bigfloat.Hash(x) {
+				t.Errorf("Hash(%s) at prec %d and %d differ", nilOr(x), group[0].Prec(), x.Prec())
+			}
+		}
+	}
+
+	// different values have different hashes, here the integers from
+	// -1000 to 1000 scaled by powers of 2
+	values := []*big.Float{nil, new(big.Float).SetInf(false), new(big.Float).SetInf(true), big.NewFloat(math.Copysign(0, -1))}
+	for i := -1000; i <= 1000; i++ {
+		for _, e := range []int{-100, 0, 3, 1 << 20} {
+			x := new(big.Float).SetPrec(64).SetInt64(int64(i))
+			values = append(values, x.SetMantExp(x, e))
+		}
+	}
+	seen := map[uint64][]*big.Float{}
+	for _, x := range values {
+		h := bigfloat.Hash(x)
+		for _, y := range seen[h] {
+			if bigfloat.Equal(x, y) {
+				continue
+			}
+			t.Errorf("Hash(%s) = Hash(%s)", nilOr(x), nilOr(y))
+		}
+		seen[h] = append(seen[h], x)
+	}
+	if bigfloat.Equal(big.NewFloat(0), big.NewFloat(math.Copysign(0, -1))) || bigfloat.Equal(nil, big.NewFloat(0)) {
+		t.Error("Equal(0, -0) or Equal(nil, 0) = true")
+	}
+}
+
+// ---------- Benchmarks ----------
+
+func BenchmarkHash(b *testing.B) {
+	for _, prec := range []uint{1e2, 1e3, 1e4} {
+		x := new(big.Float).SetPrec(prec).Quo(big.NewFloat(1), big.NewFloat(3))
+		b.Run(fmt.Sprintf("%v", prec), func(b *testing.B) {
+			b.ReportAllocs()
+			for n := 0; n < b.N; n++ {
+				bigfloat.Hash(x)
+			}
+		})
+	}
+}
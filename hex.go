@@ -0,0 +1,65 @@
+package bigfloat
+
+import (
+	"math/big"
+	"strings"
+)
+
+// FormatHex returns x in the hexadecimal floating-point format used
+// by the %a verb of C's printf and by MPFR, for example "0x1.8p+1"
+// or "-0x1.5555555555555555555555p-2". All the significant bits of
+// the mantissa are written, so that ParseHex gives back exactly x.
+// ±0 are formatted as "0x0p+0" and "-0x0p+0", ±Inf as "+Inf" and
+// "-Inf".
+func FormatHex(x *big.Float) string {
+
+	s := x.Text('x', -1)
+	if x.IsInf() {
+		return s
+	}
+
+	// big.Float writes at least two exponent digits; C doesn't
+	i := strings.LastIndexByte(s, 'p') + 2
+	j := i
+	for j < len(s)-1 && s[j] == '0' {
+		j++
+	}
+
+	return s[:i] + s[j:]
+}
+
+// ParseHex parses s, which must contain a hexadecimal floating-point
+// number with the "0x" prefix, like the ones printed by FormatHex and
+// by the %a verb of C's printf, or "Inf" or "Infinity" (in any case)
+// with an optional sign. If prec is 0, the result has the smallest
+// precision that represents the number exactly, whatever the length
+// of its mantissa; otherwise the number is rounded to prec bits,
+// using the ToNearestEven rounding mode. The syntax is otherwise the
+// one of Parse, and so are the errors.
+func ParseHex(s string, prec uint) (*big.Float, error) {
+
+	p := parser{in: s, base: 10}
+	if err := p.scan(); err != nil {
+		return nil, err
+	}
+	if p.inf {
+		return new(big.Float).SetPrec(prec).SetInf(p.neg), nil
+	}
+	if p.base != 16 {
+		return nil, &ParseError{s, 0, p.base, "missing 0x prefix"}
+	}
+
+	if prec == 0 {
+		m, _ := new(big.Int).SetString(p.digits.String(), 16)
+		if prec = oddBitLen(m); prec == 0 {
+			prec = 1
+		}
+	}
+
+	z := new(big.Float).SetPrec(prec)
+	if _, _, err := z.Parse(p.clean.String(), 0); err != nil {
+		return nil, &ParseError{s, p.expOff, p.base, "exponent out of range"}
+	}
+
+	return z, nil
+}
@@ -0,0 +1,98 @@
+package bigfloat_test
+
+import (
+	"errors"
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/ThreeAndTwo/bigfloat"
+)
+
+func TestFormatHex(t *testing.T) {
+	third := new(big.Float).SetPrec(100).Quo(big.NewFloat(1), big.NewFloat(3))
+	for _, test := range []struct {
+		x    *big.Float
+		want string
+	}{
+		{big.NewFloat(1.5), "0x1.8p+0"},
+		{big.NewFloat(-0.03125), "-0x1p-5"},
+		{big.NewFloat(1024), "0x1p+10"},
+		{big.NewFloat(0), "0x0p+0"},
+		{new(big.Float).Neg(big.NewFloat(0)), "-0x0p+0"},
+		{big.NewFloat(math.SmallestNonzeroFloat64), "0x1p-1074"},
+		{third, "0x1.5555555555555555555555556p-2"},
+		{new(big.Float).SetInf(false), "+Inf"},
+	} {
+		if s := bigfloat.FormatHex(test.x); s != test.want {
+			t.Errorf("FormatHex(%g) = %s; want %s", test.x, s, test.want)
+		}
+	}
+}
+
+func TestHexRoundTrip(t *testing.T) {
+	for _, prec := range []uint{1, 24, 53, 113, 1000, 100000} {
+		x := new(big.Float).SetPrec(prec).SetInt64(-7)
+		x = bigfloat.Sqrt(x.Neg(x))
+		x.Neg(x)
+
+		s := bigfloat.FormatHex(x)
+		z, err := bigfloat.ParseHex(s, prec)
+		if err != nil {
+			t.Fatalf("ParseHex(FormatHex(x)) error: %v", err)
+		}
+		if z.Cmp(x) != 0 || z.Prec() != prec {
+			t.Errorf("prec = %d: ParseHex(FormatHex(x)) = %g; want %g", prec, z, x)
+		}
+
+		// exact precision
+		z, _ = bigfloat.ParseHex(s, 0)
+		if z.Cmp(x) != 0 || z.Prec() != x.MinPrec() {
+			t.Errorf("prec = %d: ParseHex(FormatHex(x), 0) has prec %d; want %d", prec, z.Prec(), x.MinPrec())
+		}
+	}
+}
+
+func TestParseHex(t *testing.T) {
+	for _, test := range []struct {
+		s     string
+		prec  uint
+		want  string
+		wprec uint
+	}{
+		{"0x1.8p+1", 0, "3", 2},
+		{"-0X1P-2", 0, "-0.25", 1},
+		{"0x1.fffffffffffff8p+0", 53, "2", 53},
+		{"0x1.fffffffffffff8p+0", 0, "1.99999999999999988897769753748434595763683319091796875", 54},
+		{"0x0p+0", 0, "0", 1},
+		{"  0x_10  ", 0, "16", 1},
+		{"-inf", 0, "-Inf", 0},
+	} {
+		z, err := bigfloat.ParseHex(test.s, test.prec)
+		if err != nil {
+			t.Errorf("ParseHex(%q) error: %v", test.s, err)
+			continue
+		}
+		want, _, _ := new(big.Float).SetPrec(200).Parse(test.want, 10)
+		if z.Cmp(want) != 0 || z.Prec() != test.wprec {
+			t.Errorf("ParseHex(%q, %d) = %g (prec %d); want %s (prec %d)", test.s, test.prec, z, z.Prec(), test.want, test.wprec)
+		}
+	}
+
+	for _, s := range []string{"1.5p1", "0x1.8q1", "", "0b1p1"} {
+		_, err := bigfloat.ParseHex(s, 0)
+		var perr *bigfloat.ParseError
+		if !errors.As(err, &perr) {
+			t.Errorf("ParseHex(%q) error = %v; want a *ParseError", s, err)
+		}
+	}
+}
+
+// ---------- Benchmarks ----------
+
+func BenchmarkFormatHex(b *testing.B) {
+	x := bigfloat.Sqrt(new(big.Float).SetPrec(10000).SetInt64(2))
+	for n := 0; n < b.N; n++ {
+		bigfloat.FormatHex(x)
+	}
+}
@@ -0,0 +1,279 @@
+package bigfloat
+
+import (
+	"encoding/binary"
+	"errors"
+	"math"
+	"math/big"
+)
+
+// ErrNaN is returned when decoding an encoding of NaN, which can't
+// be represented by a big.Float.
+var ErrNaN = errors.New("bigfloat: NaN")
+
+// An ieeeFormat is an IEEE 754 binary interchange format.
+type ieeeFormat struct {
+	name    string
+	expBits uint // width of the exponent field
+	prec    uint // precision, including the implicit bit
+}
+
+var (
+	binary16  = ieeeFormat{"binary16", 5, 11}
+	bfloat16  = ieeeFormat{"bfloat16", 8, 8}
+	binary32  = ieeeFormat{"binary32", 8, 24}
+	binary64  = ieeeFormat{"binary64", 11, 53}
+	binary128 = ieeeFormat{"binary128", 15, 113}
+	binary256 = ieeeFormat{"binary256", 19, 237}
+)
+
+// Float64 returns x rounded to a float64 with the given rounding
+// mode, and the accuracy of the result. Unlike big.Float.Float64,
+// which always rounds to nearest even, Float64 follows the rounding
+// mode also for subnormals and overflows, which give ±Inf or
+// ±math.MaxFloat64 as required by the mode.
+func Float64(x *big.Float, mode big.RoundingMode) (float64, big.Accuracy) {
+
+	b, acc := binary64.encode(x, mode)
+
+	return math.Float64frombits(binary.BigEndian.Uint64(b)), acc
+}
+
+// EncodeBinary128 returns the IEEE 754 binary128 (quadruple
+// precision) encoding of x, as 16 bytes in big-endian order, rounding
+// x with the given rounding mode. Values too small in magnitude for a
+// normal number are encoded as subnormals, and values too large are
+// encoded as infinities or as the largest finite value, as required
+// by the rounding mode. The accuracy reports whether the encoded value
+// is exactly x, or if it's smaller or larger.
+func EncodeBinary128(x *big.Float, mode big.RoundingMode) ([]byte, big.Accuracy) {
+	return binary128.encode(x, mode)
+}
+
+// DecodeBinary128 returns the value of the IEEE 754 binary128
+// encoding b, given as 16 bytes in big-endian order. The result has
+// 113 bits of precision, so it's exact also for subnormals. The
+// function returns ErrNaN if b encodes a NaN.
+func DecodeBinary128(b []byte) (*big.Float, error) {
+	return binary128.decode(b)
+}
+
+// EncodeBinary256 returns the IEEE 754 binary256 (octuple precision)
+// encoding of x, as 32 bytes in big-endian order, rounding x with the
+// given rounding mode. The handling of subnormals and overflows is
+// the same as in EncodeBinary128.
+func EncodeBinary256(x *big.Float, mode big.RoundingMode) ([]byte, big.Accuracy) {
+	return binary256.encode(x, mode)
+}
+
+// DecodeBinary256 returns the value of the IEEE 754 binary256
+// encoding b, given as 32 bytes in big-endian order. The result has
+// 237 bits of precision. The function returns ErrNaN if b encodes a
+// NaN.
+func DecodeBinary256(b []byte) (*big.Float, error) {
+	return binary256.decode(b)
+}
+
+// EncodeFloat16 returns the IEEE 754 binary16 (half precision)
+// encoding of x, rounding x with the given rounding mode, and its
+// accuracy. The handling of subnormals and overflows is the same as in
+// EncodeBinary128.
+func EncodeFloat16(x *big.Float, mode big.RoundingMode) (uint16, big.Accuracy) {
+
+	b, acc := binary16.encode(x, mode)
+
+	return binary.BigEndian.Uint16(b), acc
+}
+
+// DecodeFloat16 returns the value of the IEEE 754 binary16 encoding
+// b, with 11 bits of precision. The function returns ErrNaN if b
+// encodes a NaN.
+func DecodeFloat16(b uint16) (*big.Float, error) {
+
+	var e [2]byte
+	binary.BigEndian.PutUint16(e[:], b)
+
+	return binary16.decode(e[:])
+}
+
+// EncodeBFloat16 returns the bfloat16 encoding of x, the one of the
+// top 16 bits of a binary32, with its 8 bits of exponent and 8 bits of
+// precision, rounding x with the given rounding mode, and its
+// accuracy. Unlike the truncation of a float32 often used for it, the
+// rounding is the one of the mode, also for subnormals and overflows,
+// as in EncodeBinary128.
+func EncodeBFloat16(x *big.Float, mode big.RoundingMode) (uint16, big.Accuracy) {
+
+	b, acc := bfloat16.encode(x, mode)
+
+	return binary.BigEndian.Uint16(b), acc
+}
+
+// DecodeBFloat16 returns the value of the bfloat16 encoding b, with 8
+// bits of precision. The function returns ErrNaN if b encodes a NaN.
+func DecodeBFloat16(b uint16) (*big.Float, error) {
+
+	var e [2]byte
+	binary.BigEndian.PutUint16(e[:], b)
+
+	return bfloat16.decode(e[:])
+}
+
+// size returns the size of an encoding in bytes.
+func (f ieeeFormat) size() int {
+	return int(1+f.expBits+f.prec-1) / 8
+}
+
+// emax returns the largest exponent of a finite value; the smallest
+// exponent of a normal value is 1 - emax.
+func (f ieeeFormat) emax() int {
+	return 1<<(f.expBits-1) - 1
+}
+
+// context returns the Context of the values of f, with the rounding
+// mode mode.
+func (f ieeeFormat) context(mode big.RoundingMode) Context {
+
+	// MantExp counts one more than the exponents of IEEE 754
+	return Context{
+		Prec:      f.prec,
+		Mode:      mode,
+		Subnormal: true,
+		MinExp:    2 - f.emax(),
+		Overflow:  true,
+		MaxExp:    f.emax() + 1,
+	}
+}
+
+func (f ieeeFormat) encode(x *big.Float, mode big.RoundingMode) ([]byte, big.Accuracy) {
+
+	emax := f.emax()
+	p := int(f.prec)
+	neg := x.Signbit()
+	expMax := new(big.Int).Lsh(big.NewInt(1), f.expBits)
+	expMax.Sub(expMax, big.NewInt(1))
+
+	var biased, field *big.Int
+	switch {
+	case x.Sign() == 0:
+		biased, field = new(big.Int), new(big.Int)
+	case x.IsInf():
+		biased, field = expMax, new(big.Int)
+	default:
+		// x = q·2**qe, with q an integer of at most p bits, and qe the
+		// exponent of the last bit of the mantissa; subnormals have
+		// the same qe as the smallest normal numbers
+		e := x.MantExp(nil) - 1
+		if e < 1-emax {
+			e = 1 - emax
+		}
+		qe := e - (p - 1)
+		t := new(big.Float).SetMantExp(x, -qe)
+		q := RoundInt(t, mode)
+		q.Abs(q)
+
+		// rounding up can carry into a new bit
+		if q.BitLen() > p {
+			q.Rsh(q, 1)
+			qe++
+		}
+
+		switch {
+		case qe+p-1 > emax:
+			// overflow: the result is either ±Inf or the largest
+			// finite value, toward zero
+			if roundsToInf(mode, neg) {
+				biased, field = expMax, new(big.Int)
+			} else {
+				biased = new(big.Int).Sub(expMax, big.NewInt(1))
+				field = new(big.Int).Lsh(big.NewInt(1), f.prec-1)
+				field.Sub(field, big.NewInt(1))
+			}
+		case q.BitLen() < p:
+			// subnormal, or zero
+			biased, field = new(big.Int), q
+		default:
+			biased = big.NewInt(int64(qe + p - 1 + emax))
+			field = q.SetBit(q, p-1, 0)
+		}
+	}
+
+	bits := new(big.Int)
+	if neg {
+		bits.SetBit(bits, int(f.expBits+f.prec-1), 1)
+	}
+	bits.Or(bits, biased.Lsh(biased, f.prec-1))
+	bits.Or(bits, field)
+	b := bits.FillBytes(make([]byte, f.size()))
+
+	// the accuracy is found by comparing the encoded value with x
+	z, _ := f.decode(b)
+	var acc big.Accuracy
+	switch c := z.Cmp(x); {
+	case c < 0:
+		acc = big.Below
+	case c > 0:
+		acc = big.Above
+	}
+
+	return b, acc
+}
+
+func (f ieeeFormat) decode(b []byte) (*big.Float, error) {
+
+	if len(b) != f.size() {
+		return nil, errors.New("bigfloat: invalid " + f.name + " length")
+	}
+
+	bits := new(big.Int).SetBytes(b)
+	neg := bits.Bit(int(f.expBits+f.prec-1)) == 1
+
+	field := new(big.Int).Lsh(big.NewInt(1), f.prec-1)
+	field.Sub(field, big.NewInt(1))
+	field.And(field, bits)
+
+	biased := new(big.Int).Rsh(bits, f.prec-1)
+	biased.SetBit(biased, int(f.expBits), 0)
+	e := int(biased.Int64())
+
+	z := new(big.Float).SetPrec(f.prec)
+	switch {
+	case e == 1<<f.expBits-1:
+		if field.Sign() != 0 {
+			return nil, ErrNaN
+		}
+		z.SetInf(neg)
+		return z, nil
+	case e == 0:
+		// subnormal, or zero: the exponent is the one of the smallest
+		// normal numbers, without the implicit bit
+		e = 1
+	default:
+		field.SetBit(field, int(f.prec-1), 1)
+	}
+
+	z.SetInt(field)
+	z.SetMantExp(z, e-f.emax()-int(f.prec-1))
+	if neg {
+		z.Neg(z)
+	}
+
+	return z, nil
+}
+
+// roundsToInf reports whether an overflowing value of the given sign
+// is rounded to infinity, rather than to the largest finite value,
+// by the rounding mode.
+func roundsToInf(mode big.RoundingMode, neg bool) bool {
+
+	switch mode {
+	case big.ToZero:
+		return false
+	case big.ToNegativeInf:
+		return neg
+	case big.ToPositiveInf:
+		return !neg
+	}
+
+	return true
+}
@@ -0,0 +1,240 @@
+package bigfloat_test
+
+import (
+	"encoding/hex"
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/ThreeAndTwo/bigfloat"
+)
+
+func pow2(e int) *big.Float {
+	return new(big.Float).SetMantExp(big.NewFloat(1), e)
+}
+
+func TestEncodeBinary128(t *testing.T) {
+	pi, _, _ := new(big.Float).SetPrec(200).Parse(piStr, 10)
+	third := new(big.Float).SetPrec(200).Quo(big.NewFloat(1), big.NewFloat(3))
+	for _, test := range []struct {
+		x    *big.Float
+		mode big.RoundingMode
+		want string
+		acc  big.Accuracy
+	}{
+		{big.NewFloat(1), big.ToNearestEven, "3fff0000000000000000000000000000", big.Exact},
+		{big.NewFloat(-2), big.ToNearestEven, "c0000000000000000000000000000000", big.Exact},
+		{big.NewFloat(0), big.ToNearestEven, "00000000000000000000000000000000", big.Exact},
+		{new(big.Float).Neg(big.NewFloat(0)), big.ToNearestEven, "80000000000000000000000000000000", big.Exact},
+		{new(big.Float).SetInf(false), big.ToNearestEven, "7fff0000000000000000000000000000", big.Exact},
+		{pi, big.ToNearestEven, "4000921fb54442d18469898cc51701b8", big.Below},
+		{pi, big.ToZero, "4000921fb54442d18469898cc51701b8", big.Below},
+		{pi, big.AwayFromZero, "4000921fb54442d18469898cc51701b9", big.Above},
+		{third, big.ToNearestEven, "3ffd5555555555555555555555555555", big.Below},
+		{third, big.ToPositiveInf, "3ffd5555555555555555555555555556", big.Above},
+
+		// smallest subnormal, and ties around it
+		{pow2(-16494), big.ToNearestEven, "00000000000000000000000000000001", big.Exact},
+		{pow2(-16495), big.ToNearestEven, "00000000000000000000000000000000", big.Below},
+		{pow2(-16495), big.ToNearestAway, "00000000000000000000000000000001", big.Above},
+		{new(big.Float).Mul(big.NewFloat(1.5), pow2(-16495)), big.ToNearestEven, "00000000000000000000000000000001", big.Above},
+		{pow2(-20000), big.AwayFromZero, "00000000000000000000000000000001", big.Above},
+
+		// smallest normal
+		{pow2(-16382), big.ToNearestEven, "00010000000000000000000000000000", big.Exact},
+
+		// overflow
+		{pow2(16384), big.ToNearestEven, "7fff0000000000000000000000000000", big.Above},
+		{pow2(16384), big.ToZero, "7ffeffffffffffffffffffffffffffff", big.Below},
+		{new(big.Float).Neg(pow2(16384)), big.ToPositiveInf, "fffeffffffffffffffffffffffffffff", big.Above},
+		{new(big.Float).Neg(pow2(16384)), big.ToNegativeInf, "ffff0000000000000000000000000000", big.Below},
+	} {
+		b, acc := bigfloat.EncodeBinary128(test.x, test.mode)
+		if s := hex.EncodeToString(b); s != test.want || acc != test.acc {
+			t.Errorf("EncodeBinary128(%g, %v) = %s (%v); want %s (%v)", test.x, test.mode, s, acc, test.want, test.acc)
+		}
+	}
+}
+
+func TestFloat64(t *testing.T) {
+	third := new(big.Float).SetPrec(200).Quo(big.NewFloat(1), big.NewFloat(3))
+	for _, test := range []struct {
+		x    *big.Float
+		mode big.RoundingMode
+		want float64
+		acc  big.Accuracy
+	}{
+		{big.NewFloat(1.5), big.ToZero, 1.5, big.Exact},
+		{third, big.ToNearestEven, 1.0 / 3, big.Below},
+		{third, big.ToPositiveInf, math.Nextafter(1.0/3, 1), big.Above},
+		{new(big.Float).Neg(third), big.ToPositiveInf, -1.0 / 3, big.Above},
+		{new(big.Float).Neg(third), big.AwayFromZero, -math.Nextafter(1.0/3, 1), big.Below},
+
+		// subnormals are rounded once, with the mode
+		{new(big.Float).Mul(big.NewFloat(1.5), pow2(-1074)), big.ToNearestEven, 2 * math.SmallestNonzeroFloat64, big.Above},
+		{new(big.Float).Mul(big.NewFloat(1.5), pow2(-1074)), big.ToZero, math.SmallestNonzeroFloat64, big.Below},
+		{pow2(-2000), big.ToZero, 0, big.Below},
+		{pow2(-2000), big.ToPositiveInf, math.SmallestNonzeroFloat64, big.Above},
+
+		// overflow
+		{pow2(1024), big.ToNearestEven, math.Inf(+1), big.Above},
+		{pow2(1024), big.ToZero, math.MaxFloat64, big.Below},
+		{new(big.Float).Neg(pow2(1024)), big.ToPositiveInf, -math.MaxFloat64, big.Above},
+	} {
+		f, acc := bigfloat.Float64(test.x, test.mode)
+		if f != test.want || acc != test.acc {
+			t.Errorf("Float64(%g, %v) = %g (%v); want %g (%v)", test.x, test.mode, f, acc, test.want, test.acc)
+		}
+	}
+
+	// with ToNearestEven, the same as big.Float.Float64
+	for _, f := range []float64{0, 1, -0.1, math.Pi, math.MaxFloat64, -1e-310} {
+		x := new(big.Float).SetPrec(100).SetFloat64(f)
+		x.Mul(x, new(big.Float).SetPrec(100).SetFloat64(1+1.0/3))
+		got, acc := bigfloat.Float64(x, big.ToNearestEven)
+		if want, wacc := x.Float64(); got != want || acc != wacc {
+			t.Errorf("Float64(%g) = %g (%v); want %g (%v)", x, got, acc, want, wacc)
+		}
+	}
+	if f, _ := bigfloat.Float64(new(big.Float).Neg(big.NewFloat(0)), big.ToZero); !math.Signbit(f) {
+		t.Errorf("Float64(-0) = %g", f)
+	}
+}
+
+func TestBinary128RoundTrip(t *testing.T) {
+	for _, f := range []float64{1, -1, 0.1, math.Pi, math.MaxFloat64, math.SmallestNonzeroFloat64, -1e-310, 123456789} {
+		x := big.NewFloat(f)
+		b, acc := bigfloat.EncodeBinary128(x, big.ToNearestEven)
+		if acc != big.Exact {
+			t.Errorf("EncodeBinary128(%g) is not exact", f)
+		}
+		z, err := bigfloat.DecodeBinary128(b)
+		if err != nil || z.Cmp(x) != 0 || z.Prec() != 113 {
+			t.Errorf("DecodeBinary128(EncodeBinary128(%g)) = %g, %v", f, z, err)
+		}
+	}
+
+	// every 113-bit normal number round trips
+	x := bigfloat.Sqrt(new(big.Float).SetPrec(113).SetInt64(5))
+	for _, e := range []int{-16000, -1, 0, 1, 16000} {
+		y := new(big.Float).SetMantExp(x, e)
+		b, _ := bigfloat.EncodeBinary128(y, big.ToNearestEven)
+		if z, _ := bigfloat.DecodeBinary128(b); z.Cmp(y) != 0 {
+			t.Errorf("DecodeBinary128(EncodeBinary128(%g)) = %g", y, z)
+		}
+	}
+}
+
+func TestDecodeBinary128Errors(t *testing.T) {
+	nan, _ := hex.DecodeString("7fff8000000000000000000000000000")
+	if _, err := bigfloat.DecodeBinary128(nan); err != bigfloat.ErrNaN {
+		t.Errorf("DecodeBinary128(NaN) error = %v; want ErrNaN", err)
+	}
+	if _, err := bigfloat.DecodeBinary128(make([]byte, 15)); err == nil {
+		t.Errorf("DecodeBinary128 of 15 bytes did not fail")
+	}
+}
+
+func TestBinary256(t *testing.T) {
+	for _, test := range []struct {
+		x    *big.Float
+		want string
+	}{
+		{big.NewFloat(1), "3ffff00000000000000000000000000000000000000000000000000000000000"},
+		{big.NewFloat(-0.5), "bfffe00000000000000000000000000000000000000000000000000000000000"},
+		{pow2(-262378), "0000000000000000000000000000000000000000000000000000000000000001"},
+		{pow2(262144), "7ffff00000000000000000000000000000000000000000000000000000000000"},
+	} {
+		b, _ := bigfloat.EncodeBinary256(test.x, big.ToNearestEven)
+		if s := hex.EncodeToString(b); s != test.want {
+			t.Errorf("EncodeBinary256(%g) = %s; want %s", test.x, s, test.want)
+		}
+	}
+
+	x := bigfloat.Sqrt(new(big.Float).SetPrec(237).SetInt64(2))
+	b, acc := bigfloat.EncodeBinary256(x, big.ToNearestEven)
+	z, err := bigfloat.DecodeBinary256(b)
+	if acc != big.Exact || err != nil || z.Cmp(x) != 0 {
+		t.Errorf("DecodeBinary256(EncodeBinary256(√2)) = %g, %v; want %g", z, err, x)
+	}
+}
+
+func TestEncodeFloat16(t *testing.T) {
+	pi, _, _ := new(big.Float).SetPrec(200).Parse(piStr, 10)
+	third := new(big.Float).SetPrec(200).Quo(big.NewFloat(1), big.NewFloat(3))
+	for _, test := range []struct {
+		x     *big.Float
+		mode  big.RoundingMode
+		f16   uint16
+		acc16 big.Accuracy
+		bf16  uint16
+		accBF big.Accuracy
+	}{
+		{big.NewFloat(1), big.ToNearestEven, 0x3c00, big.Exact, 0x3f80, big.Exact},
+		{big.NewFloat(-2), big.ToNearestEven, 0xc000, big.Exact, 0xc000, big.Exact},
+		{new(big.Float).Neg(big.NewFloat(0)), big.ToNearestEven, 0x8000, big.Exact, 0x8000, big.Exact},
+		{new(big.Float).SetInf(false), big.ToNearestEven, 0x7c00, big.Exact, 0x7f80, big.Exact},
+		{pi, big.ToNearestEven, 0x4248, big.Below, 0x4049, big.Below},
+		{pi, big.AwayFromZero, 0x4249, big.Above, 0x404a, big.Above},
+		{third, big.ToNearestEven, 0x3555, big.Below, 0x3eab, big.Above},
+		{third, big.ToZero, 0x3555, big.Below, 0x3eaa, big.Below},
+		{big.NewFloat(65504), big.ToNearestEven, 0x7bff, big.Exact, 0x4780, big.Above},
+		{big.NewFloat(65520), big.ToNearestEven, 0x7c00, big.Above, 0x4780, big.Above},
+		{big.NewFloat(65520), big.ToZero, 0x7bff, big.Below, 0x477f, big.Below},
+		{pow2(-14), big.ToNearestEven, 0x0400, big.Exact, 0x3880, big.Exact},
+		{pow2(-24), big.ToNearestEven, 0x0001, big.Exact, 0x3380, big.Exact},
+		{pow2(-25), big.ToNearestEven, 0x0000, big.Below, 0x3300, big.Exact},
+		{pow2(-25), big.ToNearestAway, 0x0001, big.Above, 0x3300, big.Exact},
+		{pow2(-133), big.ToNearestEven, 0x0000, big.Below, 0x0001, big.Exact},
+		{pow2(128), big.ToZero, 0x7bff, big.Below, 0x7f7f, big.Below},
+		{pow2(128), big.ToNearestEven, 0x7c00, big.Above, 0x7f80, big.Above},
+	} {
+		if b, acc := bigfloat.EncodeFloat16(test.x, test.mode); b != test.f16 || acc != test.acc16 {
+			t.Errorf("EncodeFloat16(%g, %v) = %#04x (%v); want %#04x (%v)", test.x, test.mode, b, acc, test.f16, test.acc16)
+		}
+		if b, acc := bigfloat.EncodeBFloat16(test.x, test.mode); b != test.bf16 || acc != test.accBF {
+			t.Errorf("EncodeBFloat16(%g, %v) = %#04x (%v); want %#04x (%v)", test.x, test.mode, b, acc, test.bf16, test.accBF)
+		}
+	}
+}
+
+func TestFloat16RoundTrip(t *testing.T) {
+	for i := 0; i < 1<<16; i++ {
+		b := uint16(i)
+
+		// a bfloat16 is the top half of a float32
+		z, err := bigfloat.DecodeBFloat16(b)
+		f := math.Float32frombits(uint32(b) << 16)
+		if f != f {
+			if err != bigfloat.ErrNaN {
+				t.Errorf("DecodeBFloat16(%#04x) = %g, %v; want ErrNaN", b, z, err)
+			}
+		} else if g, _ := z.Float32(); err != nil || g != f || math.Signbit(float64(g)) != math.Signbit(float64(f)) {
+			t.Errorf("DecodeBFloat16(%#04x) = %g, %v; want %g", b, z, err, f)
+		} else if e, acc := bigfloat.EncodeBFloat16(z, big.ToNearestEven); e != b || acc != big.Exact {
+			t.Errorf("EncodeBFloat16(DecodeBFloat16(%#04x)) = %#04x (%v)", b, e, acc)
+		}
+
+		z, err = bigfloat.DecodeFloat16(b)
+		if b&0x7c00 == 0x7c00 && b&0x3ff != 0 {
+			if err != bigfloat.ErrNaN {
+				t.Errorf("DecodeFloat16(%#04x) = %g, %v; want ErrNaN", b, z, err)
+			}
+			continue
+		}
+		if err != nil || z.Prec() != 11 {
+			t.Errorf("DecodeFloat16(%#04x) = %g, %v", b, z, err)
+		} else if e, acc := bigfloat.EncodeFloat16(z, big.ToZero); e != b || acc != big.Exact {
+			t.Errorf("EncodeFloat16(DecodeFloat16(%#04x)) = %#04x (%v)", b, e, acc)
+		}
+	}
+}
+
+// ---------- Benchmarks ----------
+
+func BenchmarkEncodeBinary128(b *testing.B) {
+	x := bigfloat.Sqrt(new(big.Float).SetPrec(200).SetInt64(2))
+	for n := 0; n < b.N; n++ {
+		bigfloat.EncodeBinary128(x, big.ToNearestEven)
+	}
+}
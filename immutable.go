@@ -0,0 +1,88 @@
+package bigfloat
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// A Const is a read-only big.Float, for the precomputed values shared
+// by goroutines: it holds its own copy of its value, and its methods
+// only read it, so no SetPrec or Set call downstream can change it.
+// Float returns a copy to compute with. The zero value is 0, with
+// precision 0, like the one of big.Float. The methods of a Const can
+// be called concurrently.
+type Const struct {
+	x big.Float
+}
+
+// NewConst returns a Const with the value, the precision and the
+// rounding mode of x.
+func NewConst(x *big.Float) *Const {
+
+	c := new(Const)
+	c.x.Copy(x)
+
+	return c
+}
+
+// Float returns a new big.Float with the value, the precision and the
+// rounding mode of c, to modify freely.
+func (c *Const) Float() *big.Float {
+	return new(big.Float).Copy(&c.x)
+}
+
+// Prec returns the precision of c in bits.
+func (c *Const) Prec() uint {
+	return c.x.Prec()
+}
+
+// Mode returns the rounding mode of c.
+func (c *Const) Mode() big.RoundingMode {
+	return c.x.Mode()
+}
+
+// Sign returns -1, 0 or +1 as c is negative, zero or positive.
+func (c *Const) Sign() int {
+	return c.x.Sign()
+}
+
+// Signbit reports whether c is negative or -0.
+func (c *Const) Signbit() bool {
+	return c.x.Signbit()
+}
+
+// IsInf reports whether c is +Inf or -Inf.
+func (c *Const) IsInf() bool {
+	return c.x.IsInf()
+}
+
+// IsInt reports whether c is an integer.
+func (c *Const) IsInt() bool {
+	return c.x.IsInt()
+}
+
+// Cmp compares c and y as x.Cmp(y) does.
+func (c *Const) Cmp(y *big.Float) int {
+	return c.x.Cmp(y)
+}
+
+// Float64 returns the float64 value nearest to c, and its accuracy,
+// as big.Float.Float64 does.
+func (c *Const) Float64() (float64, big.Accuracy) {
+	return c.x.Float64()
+}
+
+// Text formats c as big.Float.Text does.
+func (c *Const) Text(format byte, prec int) string {
+	return c.x.Text(format, prec)
+}
+
+// String formats c like x.Text('g', 10).
+func (c *Const) String() string {
+	return c.x.String()
+}
+
+// Format implements fmt.Formatter, with the verbs of big.Float.
+func (c *Const) Format(s fmt.State, format rune) {
+	c.x.Format(s, format)
+}
@@ -0,0 +1,58 @@
+package bigfloat_test
+
+import (
+	"fmt"
+	"math/big"
+	"sync"
+	"testing"
+
+	"github.com/ThreeAndTwo/bigfloat"
+)
+
+func TestConst(t *testing.T) {
+	x := new(big.Float).SetPrec(200).SetMode(big.ToZero).SetFloat64(-2.5)
+	c := bigfloat.NewConst(x)
+
+	// changing x, or the copies of c, doesn't change c
+	x.SetPrec(10).SetInt64(7)
+	f := c.Float()
+	f.SetPrec(1).SetInt64(1)
+	if got := c.Float(); got.Prec() != 200 || got.Mode() != big.ToZero || got.Cmp(big.NewFloat(-2.5)) != 0 {
+		t.Errorf("Const changed to %g at prec %d, mode %v", got, got.Prec(), got.Mode())
+	}
+
+	if c.Prec() != 200 || c.Mode() != big.ToZero || c.Sign() != -1 || !c.Signbit() || c.IsInf() || c.IsInt() {
+		t.Errorf("Const(-2.5) has the wrong properties")
+	}
+	if c.Cmp(big.NewFloat(-3)) != 1 || c.Cmp(big.NewFloat(-2.5)) != 0 {
+		t.Errorf("Const(-2.5).Cmp is wrong")
+	}
+	if v, acc := c.Float64(); v != -2.5 || acc != big.Exact {
+		t.Errorf("Const(-2.5).Float64() = %g, %v; want -2.5, Exact", v, acc)
+	}
+	if got := fmt.Sprintf("%s %v %.3f %s", c.String(), c, c, c.Text('e', 2)); got != "-2.5 -2.5 -2.500 -2.50e+00" {
+		t.Errorf("Const(-2.5) formats as %q", got)
+	}
+
+	var zero bigfloat.Const
+	if zero.Sign() != 0 || zero.Prec() != 0 || zero.String() != "0" {
+		t.Errorf("the zero Const is %s at prec %d", &zero, zero.Prec())
+	}
+
+	// shared by goroutines, as a constant computed once
+	pi := bigfloat.NewConst(bigfloat.Pi.Value(1000))
+	want := pi.Text('g', 300)
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			z := pi.Float()
+			z.Mul(z, z).SetPrec(53)
+			if pi.Text('g', 300) != want {
+				t.Error("the shared Const changed")
+			}
+		}()
+	}
+	wg.Wait()
+}
@@ -0,0 +1,246 @@
+package bigfloat
+
+import (
+	"math/big"
+)
+
+// GammaInc returns the regularized lower incomplete gamma function
+//
+//	P(a, x) = γ(a, x)/Γ(a) = 1/Γ(a) ∫ t**(a-1)·exp(-t) dt, for t from 0 to x
+//
+// for a > 0 and x >= 0. Precision is the same as the one of x (64 if
+// it is 0). It's computed from its power series for x < a+1, and as
+// 1 - GammaIncC(a, x) otherwise, where GammaIncC has no cancellation.
+// So the small values of P keep their relative accuracy, as far down
+// as the range of big.Float goes. The function panics if a is not
+// positive or x is negative.
+func GammaInc(a, x *big.Float) *big.Float {
+	return gammaInc("GammaInc", a, x, true)
+}
+
+// GammaIncC returns the regularized upper incomplete gamma function
+// Q(a, x) = 1 - P(a, x), for a > 0 and x >= 0. Precision is the same as
+// the one of x (64 if it is 0). It's computed from its continued
+// fraction for x > a+1, and as 1 - GammaInc(a, x) otherwise, so the
+// small values of Q, in the upper tail, keep their relative accuracy.
+// The function panics if a is not positive or x is negative.
+func GammaIncC(a, x *big.Float) *big.Float {
+	return gammaInc("GammaIncC", a, x, false)
+}
+
+// BetaInc returns the regularized incomplete beta function
+//
+//	I(x; a, b) = 1/B(a, b) ∫ t**(a-1)·(1-t)**(b-1) dt, for t from 0 to x
+//
+// for a, b > 0 and x in [0, 1]. Precision is the same as the one of x
+// (64 if it is 0). It's computed from its continued fraction, for x
+// below the mean (a+1)/(a+b+2), and as 1 - BetaIncC(a, b, x) above,
+// so the small values of I keep their relative accuracy. The function
+// panics if a or b is not positive, or if x is not in [0, 1].
+func BetaInc(a, b, x *big.Float) *big.Float {
+	return betaInc("BetaInc", a, b, x, true)
+}
+
+// BetaIncC returns 1 - I(x; a, b) = I(1-x; b, a), for a, b > 0 and x
+// in [0, 1], computed without cancellation when it is small: 1 - x is
+// computed exactly, and the continued fraction of I(1-x; b, a) is
+// used for x above the mean. Precision is the same as the one of x
+// (64 if it is 0). The function panics as BetaInc.
+func BetaIncC(a, b, x *big.Float) *big.Float {
+	return betaInc("BetaIncC", a, b, x, false)
+}
+
+// gammaInc returns P(a, x) if lower is true, Q(a, x) otherwise.
+func gammaInc(name string, a, x *big.Float, lower bool) *big.Float {
+
+	defer trace(name, x.Prec())()
+
+	prec := x.Prec()
+	if prec == 0 {
+		prec = 64
+	}
+	switch {
+	case a.Sign() <= 0 || a.IsInf():
+		panic(name + ": a is not positive and finite")
+	case x.Sign() < 0:
+		panic(name + ": argument is negative")
+	}
+	z := new(big.Float).SetPrec(prec)
+	if x.Sign() == 0 || x.IsInf() {
+		if (x.Sign() == 0) != lower {
+			z.SetInt64(1)
+		}
+		return z
+	}
+
+	// the prefactor x**a·exp(-x)/Γ(a) = exp(a·log(x) - x - log Γ(a))
+	wprec := prec + 64
+	acc := int(wprec) + 64
+	w := uint(acc) + uint(abs64(int64(a.MantExp(nil)))) + uint(abs64(int64(x.MantExp(nil)))) + 16
+	xw := new(big.Float).SetPrec(w).Set(x)
+	l := new(big.Float).SetPrec(w).Mul(a, Log(xw))
+	l.Sub(l, xw)
+	l.Sub(l, lgammaStirling(a, acc))
+	f := expLog(l, wprec)
+
+	one := big.NewFloat(1)
+	s := new(big.Float).SetPrec(wprec)
+	a1 := new(big.Float).SetPrec(wprec).Add(a, one)
+	direct := x.Cmp(a1) < 0
+	if direct {
+		// P = f/a·Σ x**n/((a+1)···(a+n))
+		t := new(big.Float).SetPrec(wprec).SetInt64(1)
+		s.SetInt64(1)
+		an := new(big.Float).SetPrec(wprec).Set(a)
+		for {
+			an.Add(an, one)
+			t.Mul(t, x).Quo(t, an)
+			s.Add(s, t)
+			if t.Sign() == 0 || t.MantExp(nil) < s.MantExp(nil)-int(wprec) {
+				break
+			}
+		}
+		s.Mul(s, f).Quo(s, a)
+	} else {
+		// Q = f·1/(x+1-a- 1·(1-a)/(x+3-a- 2·(2-a)/(x+5-a- ...)))
+		b := new(big.Float).SetPrec(wprec).Sub(x, a)
+		b.Add(b, one)
+		h := lentz(wprec, b, func(i int, an, bn *big.Float) {
+			// an = -i·(i-a), bn = x + 2i + 1 - a
+			an.SetInt64(int64(i))
+			an.Sub(an, a).Mul(an, new(big.Float).SetInt64(int64(-i)))
+			bn.Add(bn, big.NewFloat(2))
+		})
+		s.Mul(f, h)
+	}
+
+	if direct != lower {
+		s.Sub(one, s)
+	}
+
+	return z.Set(s)
+}
+
+// betaInc returns I(x; a, b) if lower is true, 1 - I(x; a, b)
+// otherwise.
+func betaInc(name string, a, b, x *big.Float, lower bool) *big.Float {
+
+	defer trace(name, x.Prec())()
+
+	prec := x.Prec()
+	if prec == 0 {
+		prec = 64
+	}
+	one := big.NewFloat(1)
+	switch {
+	case a.Sign() <= 0 || a.IsInf() || b.Sign() <= 0 || b.IsInf():
+		panic(name + ": a or b is not positive and finite")
+	case x.Sign() < 0 || x.Cmp(one) > 0:
+		panic(name + ": argument is not in [0, 1]")
+	}
+	z := new(big.Float).SetPrec(prec)
+	if x.Sign() == 0 || x.Cmp(one) == 0 {
+		if (x.Sign() == 0) != lower {
+			z.SetInt64(1)
+		}
+		return z
+	}
+
+	// 1 - x is exact, with the bits of x down from 2**0
+	y := new(big.Float).SetPrec(x.Prec()+uint(-x.MantExp(nil))+2).Sub(one, x)
+
+	// I(x; a, b) for x below the mean, or 1 - I(1-x; b, a)
+	wprec := prec + 64
+	m := new(big.Float).SetPrec(wprec).Add(a, one)
+	d := new(big.Float).SetPrec(wprec).Add(a, b)
+	m.Quo(m, d.Add(d, big.NewFloat(2)))
+	direct := x.Cmp(m) < 0
+	if !direct {
+		a, b, x, y = b, a, y, x
+	}
+
+	// the prefactor x**a·y**b/(a·B(a, b)), with log B(a, b) =
+	// log Γ(a) + log Γ(b) - log Γ(a+b)
+	acc := int(wprec) + 64
+	w := uint(acc) + uint(abs64(int64(a.MantExp(nil)))) + uint(abs64(int64(b.MantExp(nil)))) + 16
+	ab := new(big.Float).SetPrec(w).Add(a, b)
+	l := new(big.Float).SetPrec(w).Mul(a, Log(new(big.Float).SetPrec(w).Set(x)))
+	l.Add(l, new(big.Float).SetPrec(w).Mul(b, Log(new(big.Float).SetPrec(w).Set(y))))
+	l.Sub(l, lgammaStirling(a, acc))
+	l.Sub(l, lgammaStirling(b, acc))
+	l.Add(l, lgammaStirling(ab, acc))
+	f := expLog(l, wprec)
+	f.Quo(f, a)
+
+	// the continued fraction 1/(1+ d1/(1+ d2/(1+ ...))), with
+	//	d(2m+1) = -(a+m)(a+b+m)·x/((a+2m)(a+2m+1))
+	//	d(2m)   = m(b-m)·x/((a+2m-1)(a+2m))
+	xw := new(big.Float).SetPrec(wprec).Set(x)
+	h := lentz(wprec, new(big.Float).SetPrec(wprec).SetInt64(1), func(i int, an, bn *big.Float) {
+		k := int64(i / 2)
+		t := new(big.Float).SetPrec(wprec)
+		u := new(big.Float).SetPrec(wprec)
+		if i%2 == 1 {
+			an.Add(a, t.SetInt64(k))
+			an.Mul(an, u.Add(ab, t)).Neg(an)
+			t.SetInt64(2*k).Add(t, a)
+			an.Quo(an, t).Quo(an, u.Add(t, one))
+		} else {
+			an.Sub(b, t.SetInt64(k))
+			an.Mul(an, t)
+			t.SetInt64(2*k-1).Add(t, a)
+			an.Quo(an, t).Quo(an, u.Add(t, one))
+		}
+		an.Mul(an, xw)
+	})
+	s := new(big.Float).SetPrec(wprec).Mul(f, h)
+
+	if direct != lower {
+		s.Sub(one, s)
+	}
+
+	return z.Set(s)
+}
+
+// lentz returns the continued fraction
+//
+//	1/(b0 + a1/(b1 + a2/(b2 + ...)))
+//
+// at precision prec, with the modified algorithm of Lentz, where
+// next(i, an, bn) sets an = a(i) and bn = b(i), for i from 1, with bn
+// holding b(i-1) when it's called. It stops when a step changes the
+// value by less than 2**-prec, relatively.
+func lentz(prec uint, b0 *big.Float, next func(i int, an, bn *big.Float)) *big.Float {
+
+	tiny := new(big.Float).SetMantExp(big.NewFloat(1), -4*int(prec))
+	one := big.NewFloat(1)
+	fix := func(x *big.Float) {
+		if x.Sign() == 0 {
+			x.Set(tiny)
+		}
+	}
+
+	// h = 1/b0 as the convergent of 0 + 1/(b0 + ...), with c and d
+	// the ratios of its successive numerators and denominators
+	bn := new(big.Float).SetPrec(prec).Set(b0)
+	an := new(big.Float).SetPrec(prec)
+	d := new(big.Float).SetPrec(prec).Set(bn)
+	fix(d)
+	d.Quo(one, d)
+	c := new(big.Float).SetPrec(prec).Quo(one, tiny)
+	h := new(big.Float).SetPrec(prec).Set(d)
+	del := new(big.Float).SetPrec(prec)
+	for i := 1; ; i++ {
+		next(i, an, bn)
+		d.Mul(d, an).Add(d, bn)
+		fix(d)
+		d.Quo(one, d)
+		c.Quo(an, c).Add(c, bn)
+		fix(c)
+		del.Mul(c, d)
+		h.Mul(h, del)
+		if del.Sub(del, one); del.Sign() == 0 || del.MantExp(nil) < -int(prec) {
+			return h
+		}
+	}
+}
@@ -0,0 +1,167 @@
+package bigfloat_test
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/ThreeAndTwo/bigfloat"
+)
+
+// gammaIncCRef returns Q(n, x) = exp(-x)·Σ x**k/k!, for k from 0 to
+// n-1, for an integer n.
+func gammaIncCRef(n int, x *big.Float, prec uint) *big.Float {
+
+	wprec := prec + 64
+	s := new(big.Float).SetPrec(wprec)
+	t := new(big.Float).SetPrec(wprec).SetInt64(1)
+	for k := 0; k < n; k++ {
+		if k > 0 {
+			t.Mul(t, x).Quo(t, big.NewFloat(float64(k)))
+		}
+		s.Add(s, t)
+	}
+	e := bigfloat.Exp(new(big.Float).SetPrec(wprec).Neg(x))
+
+	return s.Mul(s, e).SetPrec(prec)
+}
+
+// gammaIncRef returns P(n, x) = exp(-x)·Σ x**k/k!, for k from n, for
+// an integer n.
+func gammaIncRef(n int, x *big.Float, prec uint) *big.Float {
+
+	wprec := prec + 64
+	s := new(big.Float).SetPrec(wprec)
+	t := new(big.Float).SetPrec(wprec).SetInt64(1)
+	for k := 1; ; k++ {
+		t.Mul(t, x).Quo(t, big.NewFloat(float64(k)))
+		if k < n {
+			continue
+		}
+		s.Add(s, t)
+		if t.MantExp(nil) < s.MantExp(nil)-int(wprec) {
+			break
+		}
+	}
+	e := bigfloat.Exp(new(big.Float).SetPrec(wprec).Neg(x))
+
+	return s.Mul(s, e).SetPrec(prec)
+}
+
+func TestGammaInc(t *testing.T) {
+	for _, prec := range []uint{53, 100, 300} {
+		for _, n := range []int{1, 2, 5, 30} {
+			a := big.NewFloat(float64(n))
+			for _, xs := range []string{"1e-20", "0.1", "3", "6", "29.5", "31", "50", "1000"} {
+				x, _ := new(big.Float).SetPrec(prec).SetString(xs)
+				want := gammaIncCRef(n, x, prec)
+				if got := bigfloat.GammaIncC(a, x); got.Prec() != prec || bigfloat.CmpUlp(got, want, 2) != 0 {
+					t.Errorf("GammaIncC(%d, %s) at prec %d = %g; want %g", n, xs, prec, got, want)
+				}
+
+				want = gammaIncRef(n, x, prec)
+				if got := bigfloat.GammaInc(a, x); got.Prec() != prec || bigfloat.CmpUlp(got, want, 2) != 0 {
+					t.Errorf("GammaInc(%d, %s) at prec %d = %g; want %g", n, xs, prec, got, want)
+				}
+			}
+		}
+	}
+
+	for _, test := range []struct {
+		a, x float64
+		p, q float64
+	}{
+		{2, 0, 0, 1},
+	} {
+		p, _ := bigfloat.GammaInc(big.NewFloat(test.a), big.NewFloat(test.x)).Float64()
+		q, _ := bigfloat.GammaIncC(big.NewFloat(test.a), big.NewFloat(test.x)).Float64()
+		if p != test.p || q != test.q {
+			t.Errorf("GammaInc(%g, %g) = %g, %g; want %g, %g", test.a, test.x, p, q, test.p, test.q)
+		}
+	}
+}
+
+// betaIncRat returns I(x; a, b) for integers a and b, exactly, as
+// Σ C(a+b-1, j)·x**j·(1-x)**(a+b-1-j) for j from a to a+b-1.
+func betaIncRat(a, b int64, x *big.Rat) *big.Rat {
+
+	n := a + b - 1
+	y := new(big.Rat).Sub(big.NewRat(1, 1), x)
+	s := new(big.Rat)
+	for j := a; j <= n; j++ {
+		t := new(big.Rat).SetInt(new(big.Int).Binomial(n, j))
+		for i := int64(0); i < j; i++ {
+			t.Mul(t, x)
+		}
+		for i := j; i < n; i++ {
+			t.Mul(t, y)
+		}
+		s.Add(s, t)
+	}
+
+	return s
+}
+
+func TestBetaInc(t *testing.T) {
+	for _, prec := range []uint{53, 100, 300} {
+		for _, ab := range [][2]int64{{1, 1}, {2, 3}, {5, 2}, {10, 10}, {1, 40}} {
+			a, b := big.NewFloat(float64(ab[0])), big.NewFloat(float64(ab[1]))
+			for _, xs := range []string{"1e-30", "0.01", "0.3", "0.5", "0.7", "0.99", "0.9999999999"} {
+				x, _ := new(big.Float).SetPrec(prec).SetString(xs)
+				r := betaIncRat(ab[0], ab[1], bigfloat.ToRat(x))
+				want, _ := bigfloat.FromRat(r, prec)
+				if got := bigfloat.BetaInc(a, b, x); got.Prec() != prec || bigfloat.CmpUlp(got, want, 2) != 0 {
+					t.Errorf("BetaInc(%v, %s) at prec %d = %g; want %g", ab, xs, prec, got, want)
+				}
+				want, _ = bigfloat.FromRat(r.Sub(big.NewRat(1, 1), r), prec)
+				if got := bigfloat.BetaIncC(a, b, x); got.Prec() != prec || bigfloat.CmpUlp(got, want, 2) != 0 {
+					t.Errorf("BetaIncC(%v, %s) at prec %d = %g; want %g", ab, xs, prec, got, want)
+				}
+			}
+		}
+	}
+
+	for _, f := range []func(){
+		func() { bigfloat.BetaInc(big.NewFloat(0), big.NewFloat(1), big.NewFloat(0.5)) },
+		func() { bigfloat.BetaInc(big.NewFloat(1), big.NewFloat(1), big.NewFloat(1.5)) },
+		func() { bigfloat.GammaInc(big.NewFloat(-1), big.NewFloat(1)) },
+		func() { bigfloat.GammaIncC(big.NewFloat(1), big.NewFloat(-1)) },
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Error("invalid arguments didn't panic")
+				}
+			}()
+			f()
+		}()
+	}
+}
+
+// ---------- Benchmarks ----------
+
+func BenchmarkGammaInc(b *testing.B) {
+	for _, prec := range []uint{1e2, 1e3} {
+		a := big.NewFloat(10.5)
+		x := new(big.Float).SetPrec(prec).SetFloat64(8)
+		b.Run(fmt.Sprintf("%v", prec), func(b *testing.B) {
+			b.ReportAllocs()
+			for n := 0; n < b.N; n++ {
+				bigfloat.GammaInc(a, x)
+			}
+		})
+	}
+}
+
+func BenchmarkBetaInc(b *testing.B) {
+	for _, prec := range []uint{1e2, 1e3} {
+		a, c := big.NewFloat(10.5), big.NewFloat(3.25)
+		x := new(big.Float).SetPrec(prec).SetFloat64(0.6)
+		b.Run(fmt.Sprintf("%v", prec), func(b *testing.B) {
+			b.ReportAllocs()
+			for n := 0; n < b.N; n++ {
+				bigfloat.BetaInc(a, c, x)
+			}
+		})
+	}
+}
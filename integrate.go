@@ -0,0 +1,160 @@
+package bigfloat
+
+import "math/big"
+
+// rombergMaxLevel is the maximum number of times the trapezoidal
+// step is halved by Romberg before giving up on convergence.
+const rombergMaxLevel = 24
+
+// Romberg returns an approximation of the integral of f over [a, b]
+// computed using Romberg's method. Precision is the same as the one
+// of a. f is called with arguments having a few guard digits more
+// than the result precision; it should return values at least as
+// precise as its argument.
+//
+// Romberg is a good choice for smooth integrands, and it's extremely
+// fast for smooth periodic integrands over a full period, for which
+// the trapezoidal estimates already converge geometrically. If the
+// estimates have not converged after 2**24 function evaluations,
+// the best estimate found is returned.
+func Romberg(f func(*big.Float) *big.Float, a, b *big.Float) *big.Float {
+	z, _ := RombergDiagnostics(f, a, b)
+	return z
+}
+
+// RombergDiagnostics returns Romberg(f, a, b), and a Diagnostics with
+// the number of times the step was halved, the difference between the
+// last two extrapolated estimates, and whether they agreed to the
+// precision of the result.
+func RombergDiagnostics(f func(*big.Float) *big.Float, a, b *big.Float) (*big.Float, Diagnostics) {
+
+	defer trace("Romberg", a.Prec())()
+
+	var d Diagnostics
+	prec := a.Prec()
+	wprec := prec + 32 // guard digits
+
+	// ∫ f over [a, a] = 0
+	if a.Cmp(b) == 0 {
+		d.set(0, new(big.Float), wprec, 1, true)
+		return big.NewFloat(0).SetPrec(prec), d
+	}
+
+	h := new(big.Float).SetPrec(wprec).Sub(b, a)
+	half := big.NewFloat(0.5)
+
+	// first trapezoidal estimate: h·(f(a) + f(b))/2
+	t := new(big.Float).SetPrec(wprec)
+	t.Add(f(new(big.Float).SetPrec(wprec).Set(a)), f(new(big.Float).SetPrec(wprec).Set(b)))
+	t.Mul(t, h).Mul(t, half)
+
+	row := []*big.Float{t}
+	x := new(big.Float).SetPrec(wprec)
+	sum := new(big.Float).SetPrec(wprec)
+	n := 1 // number of new evaluation points at the current level
+	for k := 1; ; k++ {
+		// Halve the step. The new trapezoidal estimate reuses the
+		// previous one:
+		//     T(h/2) = T(h)/2 + h/2·Σ f(a + (2i-1)·h/2)
+		h.Mul(h, half)
+		sum.SetFloat64(0)
+		for i := 0; i < n; i++ {
+			x.SetInt64(int64(2*i + 1))
+			x.Mul(x, h).Add(x, a)
+			sum.Add(sum, f(x))
+		}
+		n *= 2
+
+		t := new(big.Float).SetPrec(wprec).Mul(row[0], half)
+		t.Add(t, sum.Mul(sum, h))
+
+		next := richardsonRow(row, t, 2, 2)
+		ok := k >= 4 && converged(next[k], row[k-1], prec)
+		if ok || k == rombergMaxLevel {
+			c := new(big.Float).Sub(next[k], row[k-1])
+			d.set(k, c.Abs(c), wprec, 1, ok)
+			return next[k].SetPrec(prec), d
+		}
+		row = next
+	}
+}
+
+// Richardson returns the value obtained by Richardson extrapolation
+// of the sequence seq, where seq[k] is an approximation computed
+// with step h/ratio**k of a quantity whose error has an expansion
+// in powers of h**exponent:
+//
+//	A(h) = A + c₁h**exponent + c₂h**(2·exponent) + ...
+//
+// Precision is the largest precision of the elements of seq. The
+// function panics if seq is empty or ratio < 2.
+//
+// For example, the trapezoidal estimates of an integral with
+// successive halving of the step have ratio = 2 and exponent = 2,
+// and their Richardson extrapolation is Romberg's method.
+func Richardson(seq []*big.Float, ratio, exponent uint) *big.Float {
+
+	prec := seqPrec("Richardson", seq)
+	if ratio < 2 {
+		panic("Richardson: ratio must be at least 2")
+	}
+
+	var row []*big.Float
+	for _, s := range seq {
+		row = richardsonRow(row, new(big.Float).SetPrec(prec+32).Set(s), ratio, exponent)
+	}
+
+	return row[len(row)-1].SetPrec(prec)
+}
+
+// richardsonRow computes a new row of the Richardson extrapolation
+// table, given the previous row and the new sequence element s:
+//
+//	R[k][0] = s
+//	R[k][j] = R[k][j-1] + (R[k][j-1] - R[k-1][j-1])/(q**j - 1)
+//
+// where q = ratio**exponent. s becomes the first element of the new
+// row, and it must not be modified by the caller afterwards.
+func richardsonRow(prev []*big.Float, s *big.Float, ratio, exponent uint) []*big.Float {
+
+	prec := s.Prec()
+
+	q := big.NewFloat(1).SetPrec(prec)
+	for i := uint(0); i < exponent; i++ {
+		q.Mul(q, new(big.Float).SetUint64(uint64(ratio)))
+	}
+
+	row := make([]*big.Float, len(prev)+1)
+	row[0] = s
+
+	qj := new(big.Float).SetPrec(prec).Set(q) // q**j
+	d := new(big.Float).SetPrec(prec)
+	for j := 1; j <= len(prev); j++ {
+		r := new(big.Float).SetPrec(prec).Sub(row[j-1], prev[j-1])
+		r.Quo(r, d.Sub(qj, big.NewFloat(1)))
+		row[j] = r.Add(row[j-1], r)
+		qj.Mul(qj, q)
+	}
+
+	return row
+}
+
+// converged reports whether x and y agree to prec bits, relative to
+// the larger of the two.
+func converged(x, y *big.Float, prec uint) bool {
+
+	d := new(big.Float).Sub(x, y)
+	if d.Sign() == 0 {
+		return true
+	}
+
+	m := new(big.Float).Abs(x)
+	if ay := new(big.Float).Abs(y); ay.Cmp(m) > 0 {
+		m = ay
+	}
+	if m.Sign() == 0 {
+		return false
+	}
+
+	return d.MantExp(nil) <= m.MantExp(nil)-int(prec)
+}
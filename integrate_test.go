@@ -0,0 +1,152 @@
+package bigfloat_test
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/ThreeAndTwo/bigfloat"
+)
+
+const piStr = "3.1415926535897932384626433832795028841971693993751058209749445923078164062862089986280348253421170679821480865132823066470938446095505822317253594081284811174502841027019385211055596446229489549303819644288109756659334461284756482337867831652712019091456485669234603486104543266482133936072602491412737245870066063155881748815209209628292540917153644"
+
+// relErr returns |x - want|/|want|.
+func relErr(x, want *big.Float) *big.Float {
+	d := new(big.Float).Sub(x, want)
+	return d.Quo(d.Abs(d), new(big.Float).Abs(want))
+}
+
+// closeTo reports whether x and want agree to bits binary digits.
+func closeTo(x, want *big.Float, bits int) bool {
+	if want.Sign() == 0 {
+		return x.Sign() == 0
+	}
+	e := relErr(x, want)
+	return e.Sign() == 0 || e.MantExp(nil) <= -bits
+}
+
+func TestRomberg(t *testing.T) {
+	// ∫ 4/(1+x²) over [0, 1] = π
+	f := func(x *big.Float) *big.Float {
+		y := new(big.Float).Mul(x, x)
+		y.Add(y, big.NewFloat(1))
+		return y.Quo(big.NewFloat(4), y)
+	}
+
+	for _, prec := range []uint{24, 53, 64, 100} {
+		want, _, _ := new(big.Float).SetPrec(prec).Parse(piStr, 10)
+		a := new(big.Float).SetPrec(prec)
+		b := big.NewFloat(1).SetPrec(prec)
+
+		x := bigfloat.Romberg(f, a, b)
+		if x.Prec() != prec {
+			t.Errorf("prec = %d, Romberg returned precision %d", prec, x.Prec())
+		}
+		if !closeTo(x, want, int(prec)-4) {
+			t.Errorf("prec = %d, Romberg(4/(1+x²), 0, 1) =\ngot  %g;\nwant %g", prec, x, want)
+		}
+	}
+}
+
+func TestRombergPolynomial(t *testing.T) {
+	// ∫ x³ over [-1, 3] = 20; extrapolation cancels the errors exactly
+	f := func(x *big.Float) *big.Float {
+		y := new(big.Float).Mul(x, x)
+		return y.Mul(y, x)
+	}
+
+	for _, prec := range []uint{53, 200, 1000} {
+		a := big.NewFloat(-1).SetPrec(prec)
+		b := big.NewFloat(3).SetPrec(prec)
+		x := bigfloat.Romberg(f, a, b)
+		if x.Cmp(big.NewFloat(20)) != 0 {
+			t.Errorf("prec = %d, Romberg(x³, -1, 3) = %g; want 20", prec, x)
+		}
+	}
+
+	a := big.NewFloat(2)
+	if x := bigfloat.Romberg(f, a, a); x.Sign() != 0 {
+		t.Errorf("Romberg(x³, 2, 2) = %g; want 0", x)
+	}
+
+	// the estimates agree at once
+	x, d := bigfloat.RombergDiagnostics(f, big.NewFloat(-1).SetPrec(200), big.NewFloat(3))
+	if x.Cmp(big.NewFloat(20)) != 0 || d.Iterations != 4 || d.Correction.Sign() != 0 || d.Prec != 232 || d.Passes != 1 || !d.Converged {
+		t.Errorf("RombergDiagnostics(x³, -1, 3) = %g, %+v", x, d)
+	}
+}
+
+func TestRombergDiagnostics(t *testing.T) {
+	// ∫ 1/(1+x) over [0, 1] = log(2), which takes more halvings at
+	// higher precisions
+	f := func(x *big.Float) *big.Float {
+		y := new(big.Float).Add(x, big.NewFloat(1))
+		return y.Quo(big.NewFloat(1), y)
+	}
+	last := 0
+	for _, prec := range []uint{24, 53, 100} {
+		a := new(big.Float).SetPrec(prec)
+		x, d := bigfloat.RombergDiagnostics(f, a, big.NewFloat(1))
+		if x.Cmp(bigfloat.Romberg(f, a, big.NewFloat(1))) != 0 {
+			t.Errorf("RombergDiagnostics(1/(1+x), 0, 1) at prec %d = %g; want the result of Romberg", prec, x)
+		}
+		if !d.Converged || d.Iterations <= last || d.Prec != prec+32 || d.Passes != 1 {
+			t.Errorf("RombergDiagnostics(1/(1+x), 0, 1) at prec %d: %+v", prec, d)
+		}
+		if d.Correction.Sign() != 0 && d.Correction.MantExp(nil) > x.MantExp(nil)-int(prec) {
+			t.Errorf("RombergDiagnostics(1/(1+x), 0, 1) at prec %d: the last correction %g is too large", prec, d.Correction)
+		}
+		last = d.Iterations
+	}
+}
+
+func TestRichardson(t *testing.T) {
+	// trapezoidal estimates of ∫ x⁴ over [0, 1] with 1, 2, 4 intervals
+	// have error terms in h², h⁴ only, so three of them are enough
+	// for Richardson to recover 1/5 exactly.
+	seq := make([]*big.Float, 0, 3)
+	for _, n := range []int64{1, 2, 4} {
+		s := new(big.Float).SetPrec(200)
+		for i := int64(0); i <= n; i++ {
+			x := new(big.Float).SetPrec(200).Quo(big.NewFloat(float64(i)), big.NewFloat(float64(n)))
+			y := new(big.Float).Mul(x, x)
+			y.Mul(y, y)
+			if i == 0 || i == n {
+				y.Mul(y, big.NewFloat(0.5))
+			}
+			s.Add(s, y)
+		}
+		seq = append(seq, s.Quo(s, big.NewFloat(float64(n))))
+	}
+
+	x := bigfloat.Richardson(seq, 2, 2)
+	want := new(big.Float).SetPrec(200).Quo(big.NewFloat(1), big.NewFloat(5))
+	if !closeTo(x, want, 190) {
+		t.Errorf("Richardson(trapezoid(x⁴)) = %g; want %g", x, want)
+	}
+
+	if x := bigfloat.Richardson(seq[:1], 2, 2); x.Cmp(seq[0]) != 0 {
+		t.Errorf("Richardson of a single element = %g; want %g", x, seq[0])
+	}
+}
+
+// ---------- Benchmarks ----------
+
+func BenchmarkRomberg(b *testing.B) {
+	f := func(x *big.Float) *big.Float {
+		y := new(big.Float).Mul(x, x)
+		y.Add(y, big.NewFloat(1))
+		return y.Quo(big.NewFloat(4), y)
+	}
+
+	for _, prec := range []uint{53, 100} {
+		x := new(big.Float).SetPrec(prec)
+		y := big.NewFloat(1).SetPrec(prec)
+		b.Run(fmt.Sprintf("%v", prec), func(b *testing.B) {
+			b.ReportAllocs()
+			for n := 0; n < b.N; n++ {
+				bigfloat.Romberg(f, x, y)
+			}
+		})
+	}
+}
@@ -0,0 +1,212 @@
+package bigfloat
+
+import (
+	"math"
+	"math/big"
+	"math/bits"
+)
+
+// An Iter gives the successive approximations of the result of a
+// Newton iteration: first the initial estimate, and then the iterate
+// of each step, which has about twice as many correct bits as the
+// previous one. It lets the callers choose when to stop, for example
+// to get a quick estimate or to watch the convergence:
+//
+//	it := bigfloat.SqrtIter(x)
+//	for it.Next() {
+//		fmt.Println(it.Step(), it.Prec(), it.Value())
+//	}
+//
+// The last iterate, after which Next returns false, has the
+// precision of the result.
+type Iter struct {
+	fOverDf func(t *big.Float) *big.Float
+	t       *big.Float // current iterate, without the scale
+	delta   *big.Float // correction that gave t, without the scale
+	scale   int        // the result is t·2**scale
+	prec    uint       // bits expected to be correct in t
+	dPrec   uint       // precision of the result
+	step    int        // steps done, or -1 before the first call to Next
+	done    bool
+}
+
+// iterGuard is the number of bits beyond the expected correct ones
+// used in the steps of an Iter.
+const iterGuard = 64
+
+// NewtonIter returns an Iter that solves f(t) = 0 with Newton's
+// method, starting from guess, for a result of dPrec bits (the
+// precision of guess if dPrec is 0). fOverDf must return
+// f(t)/f'(t), without changing t; the precision of guess is the
+// number of bits it's expected to have right. guess isn't changed.
+func NewtonIter(fOverDf func(t *big.Float) *big.Float, guess *big.Float, dPrec uint) *Iter {
+
+	if dPrec == 0 {
+		dPrec = guess.Prec()
+	}
+
+	return newIter(fOverDf, new(big.Float).Copy(guess), 0, dPrec)
+}
+
+func newIter(fOverDf func(t *big.Float) *big.Float, guess *big.Float, scale int, dPrec uint) *Iter {
+	return &Iter{
+		fOverDf: fOverDf,
+		t:       guess,
+		delta:   new(big.Float).SetInf(false),
+		scale:   scale,
+		prec:    guess.Prec(),
+		dPrec:   dPrec,
+		step:    -1,
+	}
+}
+
+// exactIter returns an Iter whose only value is x.
+func exactIter(x *big.Float) *Iter {
+	return &Iter{t: x, delta: new(big.Float), prec: x.Prec(), dPrec: x.Prec(), step: -1, done: true}
+}
+
+// Next moves to the next iterate, and reports whether there's one:
+// the first call moves to the initial estimate, and Next returns
+// false after the iterate with the precision of the result.
+func (it *Iter) Next() bool {
+
+	if it.step < 0 {
+		it.step = 0
+		return true
+	}
+	if it.done || it.prec >= it.dPrec {
+		it.done = true
+		return false
+	}
+
+	// a step doubles the correct bits, if it's computed with them
+	it.prec *= 2
+	if it.prec > it.dPrec {
+		it.prec = it.dPrec
+	}
+	it.t.SetPrec(it.prec + iterGuard)
+	it.delta = it.fOverDf(it.t)
+	it.t.Sub(it.t, it.delta)
+	it.step++
+
+	return true
+}
+
+// Value returns the current iterate, rounded to Prec bits.
+func (it *Iter) Value() *big.Float {
+
+	z := new(big.Float).SetPrec(it.Prec()).Set(it.t)
+
+	return z.SetMantExp(z, it.scale)
+}
+
+// Prec returns the number of bits expected to be correct in the
+// current iterate, which is at most the precision of the result.
+func (it *Iter) Prec() uint {
+
+	if it.prec > it.dPrec {
+		// an initial estimate with more bits than the result
+		return it.dPrec
+	}
+
+	return it.prec
+}
+
+// Delta returns the absolute value of the correction made by the last
+// step, which is about the error of the previous iterate. It's +Inf
+// for an initial estimate, and 0 for the single iterate of a result
+// known from the start, such as √0.
+func (it *Iter) Delta() *big.Float {
+
+	d := new(big.Float).Abs(it.delta)
+	if d.IsInf() {
+		return d
+	}
+
+	return d.SetMantExp(d, it.scale)
+}
+
+// Step returns the number of steps done to get the current iterate,
+// 0 for the initial estimate.
+func (it *Iter) Step() int {
+	return it.step
+}
+
+// SqrtIter returns an Iter that converges to the square root of z,
+// with the precision of z. The function panics if z is negative. The
+// square roots of ±0 and +Inf have a single iterate.
+func SqrtIter(z *big.Float) *Iter {
+
+	if z.Sign() == -1 {
+		panic("SqrtIter: argument is negative")
+	}
+	if z.Sign() == 0 || z.IsInf() {
+		return exactIter(new(big.Float).SetPrec(z.Prec()).Set(z))
+	}
+
+	// iterate on the mantissa, as Sqrt does
+	mant := new(big.Float)
+	exp := z.MantExp(mant)
+	switch exp % 2 {
+	case 1:
+		mant.Mul(big.NewFloat(2), mant)
+	case -1:
+		mant.Mul(big.NewFloat(0.5), mant)
+	}
+
+	// f(t)/f'(t) = 0.5(t² - z)/t
+	half := big.NewFloat(0.5)
+	f := func(t *big.Float) *big.Float {
+		x := new(big.Float).Mul(t, t)
+		x.Sub(x, mant)
+		x.Mul(half, x)
+		return x.Quo(x, t)
+	}
+	mf, _ := mant.Float64()
+
+	return newIter(f, big.NewFloat(math.Sqrt(mf)), exp/2, z.Prec())
+}
+
+// ExpIter returns an Iter that converges to exp(z), with the precision
+// of z. The exponentials of 0 and ±Inf, and of the arguments whose
+// exponential overflows or underflows, have a single iterate.
+func ExpIter(z *big.Float) *Iter {
+
+	prec := z.Prec()
+	switch {
+	case z.Sign() == 0:
+		return exactIter(new(big.Float).SetPrec(prec).SetInt64(1))
+	case z.IsInf() && z.Sign() > 0:
+		return exactIter(new(big.Float).SetPrec(prec).SetInf(false))
+	case z.IsInf():
+		return exactIter(new(big.Float).SetPrec(prec))
+	}
+
+	// exp(z) = exp(r)·2**k, with k = round(z/log(2)) and |r| <= log(2)/2
+	zf, _ := z.Float64()
+	kf := math.Round(zf / math.Ln2)
+	if math.Abs(kf) > math.MaxInt32 {
+		x := new(big.Float).SetPrec(prec)
+		if kf > 0 {
+			x.SetInf(false)
+		}
+		return exactIter(x)
+	}
+	k := int64(kf)
+
+	// the bits of k·log(2) are the ones of r, and the ones of k that
+	// cancel in z - k·log(2)
+	wprec := prec + iterGuard + uint(bits.Len64(uint64(abs64(k))))
+	r := new(big.Float).SetPrec(wprec).SetInt64(k)
+	r.Mul(r, Ln2.Value(wprec))
+	r.Sub(z, r)
+
+	// f(t)/f'(t) = t(log(t) - r)
+	f := func(t *big.Float) *big.Float {
+		x := new(big.Float).Sub(Log(t), r)
+		return x.Mul(x, t)
+	}
+	rf, _ := r.Float64()
+
+	return newIter(f, big.NewFloat(math.Exp(rf)), int(k), prec)
+}
@@ -0,0 +1,134 @@
+package bigfloat_test
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/ThreeAndTwo/bigfloat"
+)
+
+// checkIter runs it to the end, checking that every iterate is
+// within a few ulps, at its own precision, of want, and that each
+// step doubles the precision.
+func checkIter(t *testing.T, name string, it *bigfloat.Iter, want *big.Float) {
+
+	var lastPrec uint
+	steps := 0
+	for it.Next() {
+		z := it.Value()
+		if it.Step() != steps {
+			t.Fatalf("%s: Step() = %d, want %d", name, it.Step(), steps)
+		}
+		if z.Prec() != it.Prec() {
+			t.Errorf("%s: step %d: precision %d, want %d", name, steps, z.Prec(), it.Prec())
+		}
+		if steps > 0 && it.Prec() < want.Prec() && it.Prec() != 2*lastPrec {
+			t.Errorf("%s: step %d: Prec() = %d after %d", name, steps, it.Prec(), lastPrec)
+		}
+		if steps == 0 && !it.Delta().IsInf() && it.Delta().Sign() != 0 {
+			t.Errorf("%s: Delta() = %g for the initial estimate", name, it.Delta())
+		}
+		if e, _ := relErr(z, want).Float64(); e > math.Ldexp(4, -int(z.Prec())) {
+			t.Errorf("%s: step %d: got %g, want %g (relative error %g)", name, steps, z, want, e)
+		}
+		lastPrec = it.Prec()
+		steps++
+	}
+	if lastPrec != want.Prec() {
+		t.Errorf("%s: last iterate has %d bits, want %d", name, lastPrec, want.Prec())
+	}
+	if it.Next() {
+		t.Errorf("%s: Next() = true after the end", name)
+	}
+}
+
+func TestSqrtIter(t *testing.T) {
+	for _, prec := range []uint{24, 53, 100, 1000, 5000} {
+		for _, s := range []string{"2", "0.5", "3e-40", "1e1000", "4"} {
+			x, _ := new(big.Float).SetPrec(prec).SetString(s)
+			checkIter(t, fmt.Sprintf("SqrtIter(%s) at %d bits", s, prec), bigfloat.SqrtIter(x), bigfloat.Sqrt(x))
+		}
+	}
+
+	// the special cases are a single iterate
+	for _, x := range []*big.Float{big.NewFloat(0), big.NewFloat(math.Inf(+1))} {
+		it := bigfloat.SqrtIter(x)
+		if !it.Next() || it.Value().Cmp(x) != 0 || it.Delta().Sign() != 0 || it.Next() {
+			t.Errorf("SqrtIter(%g) isn't a single exact iterate", x)
+		}
+	}
+}
+
+func TestExpIter(t *testing.T) {
+	for _, prec := range []uint{24, 53, 100, 1000} {
+		for _, s := range []string{"1", "-1", "0.001", "100", "-745.5", "1e5"} {
+			x, _ := new(big.Float).SetPrec(prec).SetString(s)
+			checkIter(t, fmt.Sprintf("ExpIter(%s) at %d bits", s, prec), bigfloat.ExpIter(x), bigfloat.Exp(x))
+		}
+	}
+
+	for _, test := range []struct {
+		x    float64
+		want float64
+	}{
+		{0, 1},
+		{math.Inf(+1), math.Inf(+1)},
+		{math.Inf(-1), 0},
+		{1e300, math.Inf(+1)},
+		{-1e300, 0},
+	} {
+		it := bigfloat.ExpIter(big.NewFloat(test.x))
+		if !it.Next() || it.Value().Cmp(big.NewFloat(test.want)) != 0 || it.Next() {
+			t.Errorf("ExpIter(%g) isn't a single iterate %g", test.x, test.want)
+		}
+	}
+}
+
+func TestNewtonIter(t *testing.T) {
+
+	// the cube root of 10, with f(t) = t³ - 10
+	ten := big.NewFloat(10)
+	f := func(x *big.Float) *big.Float {
+		x2 := new(big.Float).Mul(x, x)
+		num := new(big.Float).Mul(x2, x)
+		num.Sub(num, ten)
+		return num.Quo(num, x2.Mul(x2, big.NewFloat(3)))
+	}
+	guess := big.NewFloat(math.Cbrt(10))
+	it := bigfloat.NewtonIter(f, guess, 500)
+
+	// stop when the correction is small enough
+	tol := new(big.Float).SetMantExp(big.NewFloat(1), -200)
+	for it.Next() && it.Delta().Cmp(tol) > 0 {
+	}
+	z := it.Value()
+	cube := new(big.Float).SetPrec(1000).Mul(z, z)
+	cube.Mul(cube, z)
+	if e, _ := relErr(cube, ten).Float64(); e > 0x1p-190 {
+		t.Errorf("NewtonIter: cube of the result is %g", cube)
+	}
+	if it.Prec() >= 500 || it.Prec() < 200 {
+		t.Errorf("NewtonIter: stopped with Prec() = %d", it.Prec())
+	}
+	if guess.Prec() != 53 || guess.Cmp(big.NewFloat(math.Cbrt(10))) != 0 {
+		t.Errorf("NewtonIter changed the guess")
+	}
+}
+
+// ---------- Benchmarks ----------
+
+func BenchmarkSqrtIter(b *testing.B) {
+	for _, prec := range []uint{1e2, 1e3, 1e4} {
+		x := new(big.Float).SetPrec(prec).SetInt64(2)
+		b.Run(fmt.Sprintf("%v", prec), func(b *testing.B) {
+			b.ReportAllocs()
+			for n := 0; n < b.N; n++ {
+				it := bigfloat.SqrtIter(x)
+				for it.Next() {
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package bigfloat
+
+import (
+	"encoding/json"
+	"errors"
+	"math/big"
+	"strings"
+)
+
+// jsonFloat is the JSON representation of a Float.
+type jsonFloat struct {
+	Value string `json:"value"`
+	Prec  uint   `json:"prec"`
+	Mode  string `json:"mode"`
+}
+
+// MarshalJSON implements the json.Marshaler interface. The value is
+// written as an object with the value as a hexadecimal string, in the
+// format of FormatHex, and the precision and the rounding mode, for
+// example
+//
+//	{"value":"0x1.8p+1","prec":53,"mode":"ToNearestEven"}
+//
+// The value is exact, unlike the one written for a big.Float by its
+// MarshalText method, which also loses the precision and the mode.
+func (f *Float) MarshalJSON() ([]byte, error) {
+	return json.Marshal(jsonFloat{
+		Value: FormatHex(&f.Float),
+		Prec:  f.Prec(),
+		Mode:  f.Mode().String(),
+	})
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface, reading
+// the object written by MarshalJSON. The value can also be a decimal
+// string, in the syntax accepted by Parse, in which case it's rounded
+// to the precision with the ToNearestEven rounding mode. A hexadecimal
+// value with more bits than the precision is an error. The JSON null
+// value leaves f unchanged.
+func (f *Float) UnmarshalJSON(data []byte) error {
+
+	if string(data) == "null" {
+		return nil
+	}
+
+	var j jsonFloat
+	if err := json.Unmarshal(data, &j); err != nil {
+		return err
+	}
+
+	mode, ok := parseRoundingMode(j.Mode)
+	if !ok {
+		return errors.New("bigfloat: invalid rounding mode " + j.Mode)
+	}
+	if j.Prec > big.MaxPrec {
+		return errors.New("bigfloat: precision too large")
+	}
+
+	// Parse uses 64 bits for a zero precision; only zeros and
+	// infinities can have one
+	prec := j.Prec
+	if prec == 0 {
+		prec = 1
+	}
+	x, exact, err := Parse(j.Value, prec)
+	if err != nil {
+		return err
+	}
+	if !exact && isHex(j.Value) {
+		return errors.New("bigfloat: value " + j.Value + " doesn't fit the precision")
+	}
+	if j.Prec == 0 && x.Sign() != 0 && !x.IsInf() {
+		return errors.New("bigfloat: non-zero value with zero precision")
+	}
+
+	f.Copy(x)
+	f.SetPrec(j.Prec).SetMode(mode)
+
+	return nil
+}
+
+// isHex reports whether s is a number with the "0x" prefix.
+func isHex(s string) bool {
+	s = strings.TrimLeft(strings.TrimSpace(s), "+-")
+	return strings.HasPrefix(s, "0x") || strings.HasPrefix(s, "0X")
+}
@@ -0,0 +1,96 @@
+package bigfloat_test
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/ThreeAndTwo/bigfloat"
+)
+
+func TestFloatJSON(t *testing.T) {
+	third := new(big.Float).SetPrec(200).SetMode(big.ToZero)
+	third.Quo(big.NewFloat(1), big.NewFloat(3))
+
+	for _, x := range []*big.Float{
+		big.NewFloat(1.5),
+		third,
+		new(big.Float).SetPrec(10000).SetMode(big.AwayFromZero).SetInt64(-7),
+		new(big.Float),
+		new(big.Float).Neg(new(big.Float).SetPrec(24)),
+		new(big.Float).SetInf(true),
+		bigfloat.Sqrt(new(big.Float).SetPrec(5000).SetInt64(2)),
+	} {
+		data, err := json.Marshal(bigfloat.NewFloat(x))
+		if err != nil {
+			t.Fatal(err)
+		}
+		var f bigfloat.Float
+		if err := json.Unmarshal(data, &f); err != nil {
+			t.Fatalf("Unmarshal(%.80s) error: %v", data, err)
+		}
+		if f.Cmp(x) != 0 || f.Signbit() != x.Signbit() || f.Prec() != x.Prec() || f.Mode() != x.Mode() {
+			t.Errorf("round trip of %.80s gave %g (prec %d, %v)", data, &f.Float, f.Prec(), f.Mode())
+		}
+	}
+}
+
+func TestFloatMarshalJSON(t *testing.T) {
+	v := struct {
+		X bigfloat.Float
+		Y *bigfloat.Float
+	}{Y: bigfloat.NewFloat(big.NewFloat(-0.25))}
+	v.X.SetFloat64(3)
+	data, _ := json.Marshal(&v)
+	want := `{"X":{"value":"0x1.8p+1","prec":53,"mode":"ToNearestEven"},"Y":{"value":"-0x1p-2","prec":53,"mode":"ToNearestEven"}}`
+	if string(data) != want {
+		t.Errorf("Marshal =\n%s; want\n%s", data, want)
+	}
+}
+
+func TestFloatUnmarshalJSON(t *testing.T) {
+	for _, test := range []struct {
+		data string
+		want string
+		prec uint
+		mode big.RoundingMode
+		ok   bool
+	}{
+		{`{"value":"0.1","prec":100,"mode":"ToZero"}`, "0.1", 100, big.ToZero, true},
+		{`{"value":"-Inf","prec":8,"mode":"ToPositiveInf"}`, "-Inf", 8, big.ToPositiveInf, true},
+		{`{"value":"0x1.8p+1","prec":1,"mode":"ToNearestEven"}`, "", 0, 0, false},
+		{`{"value":"1","prec":53,"mode":"Sideways"}`, "", 0, 0, false},
+		{`{"value":"one","prec":53,"mode":"ToZero"}`, "", 0, 0, false},
+		{`{"value":"1","prec":0,"mode":"ToZero"}`, "", 0, 0, false},
+		{`[1]`, "", 0, 0, false},
+	} {
+		var f bigfloat.Float
+		err := json.Unmarshal([]byte(test.data), &f)
+		if (err == nil) != test.ok {
+			t.Errorf("Unmarshal(%s) error = %v", test.data, err)
+			continue
+		}
+		if !test.ok {
+			continue
+		}
+		want, _, _ := new(big.Float).SetPrec(test.prec).Parse(test.want, 10)
+		if f.Cmp(want) != 0 || f.Prec() != test.prec || f.Mode() != test.mode {
+			t.Errorf("Unmarshal(%s) = %g (prec %d, %v)", test.data, &f.Float, f.Prec(), f.Mode())
+		}
+	}
+
+	// null leaves the value unchanged
+	f := bigfloat.NewFloat(big.NewFloat(2))
+	if err := json.Unmarshal([]byte("null"), f); err != nil || f.Cmp(big.NewFloat(2)) != 0 {
+		t.Errorf("Unmarshal(null) = %g, %v", &f.Float, err)
+	}
+}
+
+// ---------- Benchmarks ----------
+
+func BenchmarkFloatMarshalJSON(b *testing.B) {
+	f := bigfloat.NewFloat(bigfloat.Sqrt(new(big.Float).SetPrec(1000).SetInt64(2)))
+	for n := 0; n < b.N; n++ {
+		json.Marshal(f)
+	}
+}
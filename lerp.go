@@ -0,0 +1,52 @@
+package bigfloat
+
+import "math/big"
+
+// Clamp returns x limited to the interval [lo, hi]: lo if x < lo, hi
+// if x > hi, and x otherwise. Precision is the same as the one of x,
+// so that a bound with a larger precision is rounded. The function
+// panics if lo > hi.
+func Clamp(x, lo, hi *big.Float) *big.Float {
+
+	if lo.Cmp(hi) > 0 {
+		panic("Clamp: lo > hi")
+	}
+
+	z := new(big.Float).SetPrec(x.Prec()).SetMode(x.Mode())
+	switch {
+	case x.Cmp(lo) < 0:
+		return z.Set(lo)
+	case x.Cmp(hi) > 0:
+		return z.Set(hi)
+	}
+
+	return z.Set(x)
+}
+
+// Lerp returns the linear interpolation a + t·(b - a) between a and
+// b, computed exactly and rounded a single time to the largest
+// precision of a and b, with the ToNearestEven rounding mode. Since
+// no intermediate result is rounded, Lerp(a, b, 0) = a and
+// Lerp(a, b, 1) = b exactly, and the result is monotonic in t. The
+// function panics if an argument is infinite.
+func Lerp(a, b, t *big.Float) *big.Float {
+
+	if a.IsInf() || b.IsInf() || t.IsInf() {
+		panic("Lerp: infinite argument")
+	}
+
+	prec := a.Prec()
+	if b.Prec() > prec {
+		prec = b.Prec()
+	}
+
+	// a - t·a + t·b, with exact products
+	ta := new(big.Float).SetPrec(t.Prec()+a.Prec()).Mul(t, a)
+	tb := new(big.Float).SetPrec(t.Prec()+b.Prec()).Mul(t, b)
+	var s exactSum
+	s.add(a)
+	s.add(ta.Neg(ta))
+	s.add(tb)
+
+	return s.float(prec, big.ToNearestEven)
+}
@@ -0,0 +1,73 @@
+package bigfloat_test
+
+import (
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/ThreeAndTwo/bigfloat"
+)
+
+func TestClamp(t *testing.T) {
+	lo, hi := big.NewFloat(-1), new(big.Float).SetPrec(200).SetFloat64(2.5)
+	for _, test := range []struct {
+		x, want float64
+	}{
+		{0, 0}, {-1, -1}, {-3, -1}, {2.5, 2.5}, {7, 2.5}, {1.25, 1.25},
+	} {
+		z := bigfloat.Clamp(new(big.Float).SetPrec(10).SetFloat64(test.x), lo, hi)
+		if f, _ := z.Float64(); f != test.want || z.Prec() != 10 {
+			t.Errorf("Clamp(%v, -1, 2.5) = %v (prec %d); want %v", test.x, f, z.Prec(), test.want)
+		}
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Clamp with lo > hi did not panic")
+		}
+	}()
+	bigfloat.Clamp(big.NewFloat(0), hi, lo)
+}
+
+func TestLerp(t *testing.T) {
+	f := big.NewFloat
+	for _, test := range []struct {
+		a, b, t, want *big.Float
+	}{
+		{f(1), f(3), f(0.5), f(2)},
+		{f(1), f(3), f(2), f(5)},
+		{f(1), f(3), f(-1), f(-1)},
+		{f(-2), f(2), f(0.25), f(-1)},
+		{f(0.1), f(0.7), f(0), f(0.1)},
+		{f(0.1), f(0.7), f(1), f(0.7)},
+		{f(1e300), f(-1e300), f(0.5), f(0)},
+	} {
+		if z := bigfloat.Lerp(test.a, test.b, test.t); z.Cmp(test.want) != 0 {
+			t.Errorf("Lerp(%g, %g, %g) = %g; want %g", test.a, test.b, test.t, z, test.want)
+		}
+	}
+}
+
+func TestLerpSingleRounding(t *testing.T) {
+	// against the exact rational value
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 500; i++ {
+		a := bigfloat.Rand(53, r)
+		b := bigfloat.Rand(53, r)
+		if r.Intn(2) == 0 {
+			b.SetMantExp(b, r.Intn(100)-50)
+		}
+		tt := bigfloat.Rand(53, r)
+
+		ar, _ := a.Rat(nil)
+		br, _ := b.Rat(nil)
+		tr, _ := tt.Rat(nil)
+		want := new(big.Rat).Sub(br, ar)
+		want.Mul(want, tr).Add(want, ar)
+		w := new(big.Float).SetPrec(53).SetRat(want)
+
+		if z := bigfloat.Lerp(a, b, tt); z.Cmp(w) != 0 {
+			t.Fatalf("Lerp(%g, %g, %g) = %g; want %g", a, b, tt, z, w)
+		}
+	}
+}
@@ -11,6 +11,8 @@ import (
 // +Inf
 func Log(z *big.Float) *big.Float {
 
+	defer trace("Log", z.Prec())()
+
 	// panic on negative z
 	if z.Sign() == -1 {
 		panic("Log: argument is negative")
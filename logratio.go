@@ -0,0 +1,141 @@
+package bigfloat
+
+import (
+	"math"
+	"math/big"
+)
+
+// LogRatio returns the natural logarithm of a/b, rounded to prec bits
+// (64 if prec is 0). a and b are never converted to big.Float, so the
+// result is accurate even when they have many more bits than prec, or
+// when a/b is very close to 1. The function panics if a or b isn't
+// positive.
+//
+// With a/b = 2**k·r, for |r - 1| <= √2 - 1, LogRatio computes
+//
+//	log(a/b) = k·log(2) + 2·atanh(t),  t = (r - 1)/(r + 1)
+//
+// summing the series of atanh(t) with binary splitting on a rational
+// t, which is exact if a and b aren't much larger than prec bits.
+func LogRatio(a, b *big.Int, prec uint) *big.Float {
+
+	if a.Sign() <= 0 || b.Sign() <= 0 {
+		panic("LogRatio: argument is not positive")
+	}
+	if prec == 0 {
+		prec = 64
+	}
+
+	z := new(big.Float).SetPrec(prec)
+	if a.Cmp(b) == 0 {
+		return z
+	}
+
+	// r = A/B, with A = a·2**-k and B = b if k < 0, or b·2**k
+	// otherwise
+	wprec := prec + 64
+	k := a.BitLen() - b.BitLen()
+	A, B := ratioShift(a, b, k)
+	r := new(big.Float).SetPrec(64).SetInt(A)
+	r.Quo(r, new(big.Float).SetPrec(64).SetInt(B))
+	switch f, _ := r.Float64(); {
+	case f > math.Sqrt2:
+		k++
+		A, B = ratioShift(a, b, k)
+	case f < 1/math.Sqrt2:
+		k--
+		A, B = ratioShift(a, b, k)
+	}
+
+	// t = p/q, exactly, or with a fixed-point p over q = 2**w if that
+	// makes smaller integers; w keeps wprec bits of t
+	p := new(big.Int).Sub(A, B)
+	q := new(big.Int).Add(A, B)
+	if p.Sign() != 0 {
+		if w := int(wprec) + q.BitLen() - p.BitLen() + 1; q.BitLen() > w {
+			p.Lsh(p, uint(w))
+			p.Quo(p, q)
+			q.SetInt64(1).Lsh(q, uint(w))
+		}
+	}
+
+	s := new(big.Float).SetPrec(wprec)
+	if p.Sign() != 0 {
+		// |t| < 2**(bits(p) - bits(q) + 1), and the terms shrink by t²
+		lt := q.BitLen() - p.BitLen() - 1
+		if lt < 1 {
+			lt = 1
+		}
+		n := int(wprec)/(2*lt) + 1
+		as := newArcSeries(p, q, false)
+		_, Q, Bn, T := as.split(0, n)
+		s.SetInt(T)
+		s.Quo(s, new(big.Float).SetPrec(wprec).SetInt(Bn.Mul(Bn, Q)))
+		s.SetMantExp(s, 1)
+	}
+
+	if k != 0 {
+		kb := uint(big.NewInt(int64(k)).BitLen())
+		l := Ln2.Value(wprec + kb)
+		l.Mul(l, new(big.Float).SetInt64(int64(k)))
+		s.Add(s, l)
+	}
+
+	return z.Set(s)
+}
+
+// ratioShift returns a·2**-k and b for k < 0, and a and b·2**k
+// otherwise, whose ratio is a/(b·2**k).
+func ratioShift(a, b *big.Int, k int) (*big.Int, *big.Int) {
+
+	if k < 0 {
+		return new(big.Int).Lsh(a, uint(-k)), b
+	}
+
+	return a, new(big.Int).Lsh(b, uint(k))
+}
+
+// An arcSeries is the series of atanh(p/q) = Σ (p/q)**(2j+1)/(2j+1),
+// or of atan(p/q) = Σ (-1)**j·(p/q)**(2j+1)/(2j+1), summed by binary
+// splitting; p2/q2 is the ratio of the powers of consecutive terms.
+type arcSeries struct {
+	p, q, p2, q2 *big.Int
+}
+
+// newArcSeries returns the series of atan(p/q) if alt is true, and
+// of atanh(p/q) otherwise.
+func newArcSeries(p, q *big.Int, alt bool) *arcSeries {
+
+	s := &arcSeries{p: p, q: q, p2: new(big.Int).Mul(p, p), q2: new(big.Int).Mul(q, q)}
+	if alt {
+		s.p2.Neg(s.p2)
+	}
+
+	return s
+}
+
+// split returns P, Q, B and T for the terms from n1 to n2-1, whose sum
+// is T/(B·Q), and whose ratio of the last term to the one before n1
+// is P/Q without the denominators 2j+1 in B.
+func (s *arcSeries) split(n1, n2 int) (P, Q, B, T *big.Int) {
+
+	if n2-n1 == 1 {
+		if n1 == 0 {
+			P, Q = new(big.Int).Set(s.p), new(big.Int).Set(s.q)
+		} else {
+			P, Q = new(big.Int).Set(s.p2), new(big.Int).Set(s.q2)
+		}
+		return P, Q, big.NewInt(int64(2*n1 + 1)), new(big.Int).Set(P)
+	}
+
+	m := (n1 + n2) / 2
+	P1, Q1, B1, T1 := s.split(n1, m)
+	P2, Q2, B2, T2 := s.split(m, n2)
+
+	// T = B2·Q2·T1 + B1·P1·T2
+	T = T1.Mul(T1, B2).Mul(T1, Q2)
+	T2.Mul(T2, B1).Mul(T2, P1)
+	T.Add(T, T2)
+
+	return P1.Mul(P1, P2), Q1.Mul(Q1, Q2), B1.Mul(B1, B2), T
+}
@@ -0,0 +1,113 @@
+package bigfloat_test
+
+import (
+	"fmt"
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/ThreeAndTwo/bigfloat"
+)
+
+// logRatioRef returns log(a) - log(b), with enough bits to make the
+// cancellation harmless.
+func logRatioRef(a, b *big.Int, prec uint) *big.Float {
+	wprec := prec + uint(a.BitLen()+b.BitLen()) + 128
+	la := bigfloat.Log(new(big.Float).SetPrec(wprec).SetInt(a))
+	lb := bigfloat.Log(new(big.Float).SetPrec(wprec).SetInt(b))
+	return la.Sub(la, lb)
+}
+
+func TestLogRatio(t *testing.T) {
+	huge := new(big.Int).Exp(big.NewInt(10), big.NewInt(1000), nil)
+	for _, test := range []struct {
+		a, b *big.Int
+	}{
+		{big.NewInt(3), big.NewInt(2)},
+		{big.NewInt(2), big.NewInt(3)},
+		{big.NewInt(1), big.NewInt(10)},
+		{big.NewInt(1000001), big.NewInt(1000000)},
+		{big.NewInt(999999), big.NewInt(1000000)},
+		{big.NewInt(1), big.NewInt(1 << 62)},
+		{big.NewInt(1<<62 + 1), big.NewInt(7)},
+		// a/b = 1 + 10**-1000
+		{new(big.Int).Add(huge, big.NewInt(1)), huge},
+		{huge, new(big.Int).Add(huge, big.NewInt(1))},
+		{new(big.Int).Mul(huge, big.NewInt(3)), big.NewInt(7)},
+		{big.NewInt(7), new(big.Int).Mul(huge, huge)},
+	} {
+		for _, prec := range []uint{24, 53, 100, 500, 1000} {
+			got := bigfloat.LogRatio(test.a, test.b, prec)
+			want := logRatioRef(test.a, test.b, prec)
+			if got.Prec() != prec || !closeTo(got, want, int(prec)-1) {
+				t.Errorf("LogRatio(%v, %v, %d) = %g; want %g", test.a, test.b, prec, got, want)
+			}
+		}
+	}
+}
+
+func TestLogRatioRandom(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for i := 0; i < 20; i++ {
+		a := new(big.Int).Rand(rnd, new(big.Int).Lsh(big.NewInt(1), uint(1+rnd.Intn(5000))))
+		b := new(big.Int).Rand(rnd, new(big.Int).Lsh(big.NewInt(1), uint(1+rnd.Intn(5000))))
+		a.Add(a, big.NewInt(1))
+		b.Add(b, big.NewInt(1))
+		if i%2 == 0 {
+			// a and b with a long common prefix
+			b.Add(a, big.NewInt(rnd.Int63n(1000)-500))
+			if b.Sign() <= 0 {
+				b.SetInt64(1)
+			}
+		}
+		prec := uint(24 + rnd.Intn(1000))
+		got := bigfloat.LogRatio(a, b, prec)
+		if want := logRatioRef(a, b, prec); !closeTo(got, want, int(prec)-1) {
+			t.Errorf("LogRatio(a, b, %d) = %g; want %g", prec, got, want)
+		}
+	}
+}
+
+func TestLogRatioSpecial(t *testing.T) {
+	if z := bigfloat.LogRatio(big.NewInt(5), big.NewInt(5), 0); z.Sign() != 0 || z.Prec() != 64 {
+		t.Errorf("LogRatio(5, 5, 0) = %g (prec %d); want 0 (prec 64)", z, z.Prec())
+	}
+
+	// a power of 2 is k·log(2), rounded once
+	z := bigfloat.LogRatio(big.NewInt(1), big.NewInt(1<<40), 200)
+	want := bigfloat.Ln2.Value(300)
+	want.Mul(want, big.NewFloat(-40)).SetPrec(200)
+	if z.Cmp(want) != 0 {
+		t.Errorf("LogRatio(1, 2**40, 200) = %g; want %g", z, want)
+	}
+
+	for _, test := range []struct {
+		a, b int64
+	}{
+		{0, 1}, {1, 0}, {-1, 2}, {2, -1},
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("LogRatio(%d, %d) didn't panic", test.a, test.b)
+				}
+			}()
+			bigfloat.LogRatio(big.NewInt(test.a), big.NewInt(test.b), 53)
+		}()
+	}
+}
+
+// ---------- Benchmarks ----------
+
+func BenchmarkLogRatio(b *testing.B) {
+	x := new(big.Int).Exp(big.NewInt(3), big.NewInt(5000), nil)
+	y := new(big.Int).Add(x, big.NewInt(1))
+	for _, prec := range []uint{64, 1000, 10000} {
+		b.Run(fmt.Sprintf("%v", prec), func(b *testing.B) {
+			b.ReportAllocs()
+			for n := 0; n < b.N; n++ {
+				bigfloat.LogRatio(y, x, prec)
+			}
+		})
+	}
+}
@@ -0,0 +1,82 @@
+package bigfloat
+
+import (
+	"math/big"
+)
+
+// ArctanInv returns arctan(1/k) to prec bits (64 if prec is 0),
+// summing its series with binary splitting, in integer arithmetic:
+// the terms shrink by a factor k², and they are computed exactly
+// until the final division. The function panics if |k| < 2, where the
+// series converges too slowly to be useful.
+func ArctanInv(k int64, prec uint) *big.Float {
+
+	if k > -2 && k < 2 {
+		panic("ArctanInv: |k| < 2")
+	}
+	if prec == 0 {
+		prec = 64
+	}
+
+	return new(big.Float).SetPrec(prec).Set(arctanInv(big.NewInt(k), prec+64))
+}
+
+// arctanInv returns arctan(1/k) with an error below 2**-prec, at
+// precision prec, for |k| >= 2.
+func arctanInv(k *big.Int, prec uint) *big.Float {
+
+	// the terms shrink by k² >= 2**(2(bits(k)-1))
+	n := int(prec)/(2*(k.BitLen()-1)) + 1
+	_, Q, B, T := newArcSeries(big.NewInt(1), k, true).split(0, n)
+
+	z := new(big.Float).SetPrec(prec).SetInt(T)
+	return z.Quo(z, new(big.Float).SetPrec(prec).SetInt(B.Mul(B, Q)))
+}
+
+// A MachinTerm is the term Coef·arctan(1/K) of a Machin-like formula.
+type MachinTerm struct {
+	Coef int64
+	K    int64
+}
+
+// MachinPi is the formula of Machin, π = 16·arctan(1/5) -
+// 4·arctan(1/239), as the argument of Machin.
+var MachinPi = []MachinTerm{{16, 5}, {-4, 239}}
+
+// TakanoPi is the formula of Takano (1982), one of the fastest known
+// for π: π = 48·arctan(1/49) + 128·arctan(1/57) - 20·arctan(1/239) +
+// 48·arctan(1/110443).
+var TakanoPi = []MachinTerm{{48, 49}, {128, 57}, {-20, 239}, {48, 110443}}
+
+// Machin returns Σ t.Coef·arctan(1/t.K) for the terms t of a
+// Machin-like formula, as MachinPi for π, or {{2, 3}, {1, 7}} for
+// π/4, to prec bits (64 if prec is 0). The result is rounded once,
+// after the sum of the terms, computed with ArctanInv with enough
+// guard bits for the coefficients. The function panics if the K of a
+// term is less than 2 in absolute value.
+func Machin(terms []MachinTerm, prec uint) *big.Float {
+
+	if prec == 0 {
+		prec = 64
+	}
+	for _, t := range terms {
+		if t.K > -2 && t.K < 2 {
+			panic("Machin: |K| < 2")
+		}
+	}
+
+	// the errors of the terms add up, weighted by the coefficients
+	var c int64
+	for _, t := range terms {
+		c += abs64(t.Coef)
+	}
+	wprec := prec + 64 + uint(big.NewInt(c).BitLen())
+
+	s := new(big.Float).SetPrec(wprec)
+	for _, t := range terms {
+		a := arctanInv(big.NewInt(t.K), wprec)
+		s.Add(s, a.Mul(a, new(big.Float).SetInt64(t.Coef)))
+	}
+
+	return new(big.Float).SetPrec(prec).Set(s)
+}
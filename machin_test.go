@@ -0,0 +1,97 @@
+package bigfloat_test
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/ThreeAndTwo/bigfloat"
+)
+
+func TestArctanInv(t *testing.T) {
+	for _, test := range []struct {
+		k    int64
+		want string
+	}{
+		{2, "0.4636476090008061162142562314612144020285370542861202638109330887201978641657417053006002839848878925565298522511908375135058181816250111554715305699441056207193362661648801015325"},
+		{3, "0.3217505543966421934014046143586613190207552955576561914328030593567562374058105443564084223506413744390071693771297391482676429707626344024598092820880146586432347595106045287438"},
+		{-3, "-0.3217505543966421934014046143586613190207552955576561914328030593567562374058105443564084223506413744390071693771297391482676429707626344024598092820880146586432347595106045287438"},
+		{239, "0.0041840760020747238645382149592854527410480653076319508270196128871817783414228932737826058136229094549754506664448637560524583947893118650589221288330928008462719623307733759476"},
+	} {
+		for _, prec := range []uint{24, 53, 100, 500} {
+			want, _, _ := new(big.Float).SetPrec(prec).Parse(test.want, 10)
+			if got := bigfloat.ArctanInv(test.k, prec); got.Cmp(want) != 0 || got.Prec() != prec {
+				t.Errorf("ArctanInv(%d, %d) = %g; want %g", test.k, prec, got, want)
+			}
+		}
+	}
+
+	// huge k, where arctan(1/k) = 1/k - 1/(3k³) + ...
+	k := int64(1) << 62
+	got := bigfloat.ArctanInv(k, 200)
+	want := new(big.Float).SetPrec(400).SetInt64(1)
+	want.Quo(want, new(big.Float).SetInt64(k))
+	c := new(big.Float).SetPrec(400).Mul(want, want)
+	c.Mul(c, want).Quo(c, big.NewFloat(3))
+	want.Sub(want, c).SetPrec(200)
+	if got.Cmp(want) != 0 {
+		t.Errorf("ArctanInv(2**62, 200) = %g; want %g", got, want)
+	}
+
+	for _, k := range []int64{-1, 0, 1} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("ArctanInv(%d) didn't panic", k)
+				}
+			}()
+			bigfloat.ArctanInv(k, 53)
+		}()
+	}
+}
+
+func TestMachin(t *testing.T) {
+	for _, prec := range []uint{24, 53, 100, 500, 1000} {
+		want, _, _ := new(big.Float).SetPrec(prec).Parse(piStr, 10)
+		for _, f := range [][]bigfloat.MachinTerm{bigfloat.MachinPi, bigfloat.TakanoPi} {
+			if got := bigfloat.Machin(f, prec); got.Cmp(want) != 0 || got.Prec() != prec {
+				t.Errorf("Machin(%v, %d) = %g; want %g", f, prec, got, want)
+			}
+		}
+
+		// π/4, by the formula of Hutton
+		want.SetMantExp(want, -2)
+		if got := bigfloat.Machin([]bigfloat.MachinTerm{{2, 3}, {1, 7}}, prec); got.Cmp(want) != 0 {
+			t.Errorf("Machin(Hutton, %d) = %g; want %g", prec, got, want)
+		}
+	}
+
+	// the two formulas agree far beyond piStr
+	const prec = 40000
+	x := bigfloat.Machin(bigfloat.MachinPi, prec)
+	if y := bigfloat.Machin(bigfloat.TakanoPi, prec); x.Cmp(y) != 0 {
+		t.Errorf("Machin(MachinPi, %d) != Machin(TakanoPi, %d)", prec, prec)
+	}
+
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Error("Machin with K = 1 didn't panic")
+			}
+		}()
+		bigfloat.Machin([]bigfloat.MachinTerm{{4, 1}}, 53)
+	}()
+}
+
+// ---------- Benchmarks ----------
+
+func BenchmarkMachin(b *testing.B) {
+	for _, prec := range []uint{1e3, 1e4, 1e5} {
+		b.Run(fmt.Sprintf("%v", prec), func(b *testing.B) {
+			b.ReportAllocs()
+			for n := 0; n < b.N; n++ {
+				bigfloat.Machin(bigfloat.TakanoPi, prec)
+			}
+		})
+	}
+}
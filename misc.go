@@ -1,6 +1,9 @@
 package bigfloat
 
-import "math/big"
+import (
+	"math/big"
+	"sync"
+)
 
 // agm returns the arithmetic-geometric mean of a and b.
 // a and b must have the same precision.
@@ -41,12 +44,26 @@ var piCache *big.Float
 var piCachePrec uint
 var enablePiCache bool = true
 
+// piMu guards piCache and piCachePrec, for the concurrent calls of
+// the functions that use pi.
+var piMu sync.Mutex
+
 func init() {
 	if !enablePiCache {
 		return
 	}
 
-	piCache, _, _ = new(big.Float).SetPrec(1024).Parse("3."+
+	piCache, piCachePrec = builtinPi()
+}
+
+// piBuiltinPrec is the precision of the value of π built in the
+// package.
+const piBuiltinPrec = 1024
+
+// builtinPi returns π to piBuiltinPrec bits, and its precision.
+func builtinPi() (*big.Float, uint) {
+
+	x, _, _ := new(big.Float).SetPrec(piBuiltinPrec).Parse("3."+
 		"14159265358979323846264338327950288419716939937510"+
 		"58209749445923078164062862089986280348253421170679"+
 		"82148086513282306647093844609550582231725359408128"+
@@ -55,16 +72,58 @@ func init() {
 		"45648566923460348610454326648213393607260249141273"+
 		"72458700660631558817488152092096282925409171536444", 10)
 
-	piCachePrec = 1024
+	return x, piBuiltinPrec
 }
 
 // pi returns pi to prec bits of precision
 func pi(prec uint) *big.Float {
 
-	if prec <= piCachePrec && enablePiCache {
-		return new(big.Float).Copy(piCache).SetPrec(prec)
+	if enablePiCache {
+		if t := Pi.table(); t != nil && prec <= t.prec() {
+			return t.value(prec)
+		}
+		piMu.Lock()
+		if prec <= piCachePrec {
+			defer piMu.Unlock()
+			return new(big.Float).Copy(piCache).SetPrec(prec)
+		}
+		piMu.Unlock()
+	}
+
+	// binary splitting is faster than the AGM at large precisions
+	var a *big.Float
+	if prec >= piMachinPrec {
+		a = Machin(TakanoPi, prec)
+	} else {
+		a = piAGM(prec)
+	}
+
+	// the larger values of π count in the limit of the cache
+	if enablePiCache && uint64(prec) <= cacheLimit() {
+		piMu.Lock()
+		grown := prec > piCachePrec
+		if grown {
+			piCache.Copy(a)
+			piCachePrec = prec
+		}
+		piMu.Unlock()
+		if grown {
+			cache.mu.Lock()
+			cache.evict()
+			cache.mu.Unlock()
+		}
 	}
 
+	return a
+}
+
+// piMachinPrec is the precision from which pi uses the formula of
+// Takano instead of the AGM.
+const piMachinPrec = 1 << 15
+
+// piAGM returns pi to prec bits of precision, with the AGM.
+func piAGM(prec uint) *big.Float {
+
 	// Following R. P. Brent, Multiple-precision zero-finding
 	// methods and the complexity of elementary function evaluation,
 	// in Analytic Computational Complexity, Academic Press,
@@ -97,14 +156,7 @@ func pi(prec uint) *big.Float {
 	}
 
 	a.Mul(a, a).Quo(a, t) // π = a² / t
-	a.SetPrec(prec)
-
-	if enablePiCache {
-		piCache.Copy(a)
-		piCachePrec = prec
-	}
-
-	return a
+	return a.SetPrec(prec)
 }
 
 // returns an approximate (to precision dPrec) solution to
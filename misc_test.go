@@ -56,6 +56,17 @@ func TestPi(t *testing.T) {
 	enablePiCache = true
 }
 
+func TestPiMachin(t *testing.T) {
+	enablePiCache = false
+	// the two algorithms of pi agree above piMachinPrec
+	for _, prec := range []uint{piMachinPrec, piMachinPrec + 1000} {
+		if x, y := pi(prec), piAGM(prec); x.Cmp(y) != 0 {
+			t.Errorf("pi(%d) differs from piAGM(%d)", prec, prec)
+		}
+	}
+	enablePiCache = true
+}
+
 // ---------- Benchmarks ----------
 
 func BenchmarkAgm(b *testing.B) {
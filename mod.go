@@ -0,0 +1,96 @@
+package bigfloat
+
+import "math/big"
+
+// Mod returns the remainder of x/y, x - n·y with n the quotient x/y
+// truncated to an integer, like math.Mod. The result has the sign of
+// x and a magnitude smaller than |y|. Precision is the same as the
+// one of x, and acc reports the accuracy of the result.
+//
+// The remainder is computed from the exact values of x and y, and
+// then rounded a single time; it's always exact if the precision of
+// y isn't larger than the one of x. Mod(±0, y) = ±0 and
+// Mod(x, ±Inf) = x. The function panics if y is zero or x is an
+// infinity.
+func Mod(x, y *big.Float) (*big.Float, big.Accuracy) {
+	return remainder("Mod", x, y, false)
+}
+
+// Remainder returns the IEEE 754 remainder of x/y, x - n·y with n the
+// quotient x/y rounded to the nearest integer, with ties to even,
+// like math.Remainder. The result has a magnitude of at most |y|/2.
+// Precision is the same as the one of x, and acc reports the accuracy
+// of the result.
+//
+// The remainder is computed from the exact values of x and y, and
+// then rounded a single time; it's always exact if the precision of
+// y isn't larger than the one of x. Remainder(±0, y) = ±0 and
+// Remainder(x, ±Inf) = x. The function panics if y is zero or x is an
+// infinity.
+func Remainder(x, y *big.Float) (*big.Float, big.Accuracy) {
+	return remainder("Remainder", x, y, true)
+}
+
+// remainder returns Remainder(x, y) if nearest is set, and Mod(x, y)
+// otherwise.
+func remainder(name string, x, y *big.Float, nearest bool) (*big.Float, big.Accuracy) {
+
+	if y.Sign() == 0 {
+		panic(name + ": division by zero")
+	}
+	if x.IsInf() {
+		panic(name + ": infinite dividend")
+	}
+
+	z := new(big.Float).SetPrec(x.Prec())
+
+	// |x| < |y| for Mod, or |x| <= |y|/2 for Remainder: x itself
+	ax := new(big.Float).Abs(x)
+	ay := new(big.Float).Abs(y)
+	if nearest {
+		ay.SetMantExp(ay, -1)
+	}
+	if c := ax.Cmp(ay); c < 0 || (nearest && c == 0) || x.Sign() == 0 {
+		return z.Set(x), big.Exact
+	}
+
+	// |x| = X·2**e and |y| = Y·2**e, with X and Y integers and e the
+	// smaller of the two exponents. To avoid building X when it's
+	// much larger than Y, X mod 2Y is computed with a modular
+	// exponentiation of the power of two.
+	mx, ex := intMantExp(x)
+	my, ey := intMantExp(y)
+	mx.Abs(mx)
+	my.Abs(my)
+	e := ex
+	if ey < e {
+		e = ey
+	}
+	Y := new(big.Int).Lsh(my, uint(ey-e))
+	Y2 := new(big.Int).Lsh(Y, 1)
+	r := new(big.Int).Exp(big.NewInt(2), big.NewInt(int64(ex-e)), Y2)
+	r.Mul(r, mx).Mod(r, Y2)
+
+	// r = X mod 2Y, so the truncated quotient is odd if r >= Y
+	odd := r.Cmp(Y) >= 0
+	if odd {
+		r.Sub(r, Y)
+	}
+	if nearest {
+		// round the quotient up if r > Y/2, or ties to even
+		if c := new(big.Int).Lsh(r, 1).Cmp(Y); c > 0 || (c == 0 && odd) {
+			r.Sub(r, Y)
+		}
+	}
+
+	// the result, even when it's zero, has the sign of x
+	z.SetInt(r)
+	acc := z.Acc()
+	z.SetMantExp(z, e)
+	if x.Signbit() {
+		z.Neg(z)
+		acc = -acc
+	}
+
+	return z, acc
+}
@@ -0,0 +1,121 @@
+package bigfloat_test
+
+import (
+	"math"
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/ThreeAndTwo/bigfloat"
+)
+
+func TestModFloat64(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	check := func(x, y float64) {
+		z, acc := bigfloat.Mod(big.NewFloat(x), big.NewFloat(y))
+		got, _ := z.Float64()
+		if want := math.Mod(x, y); got != want || math.Signbit(got) != math.Signbit(want) || acc != big.Exact {
+			t.Errorf("Mod(%v, %v) = %v (%v); want %v", x, y, got, acc, want)
+		}
+		z, acc = bigfloat.Remainder(big.NewFloat(x), big.NewFloat(y))
+		got, _ = z.Float64()
+		if want := math.Remainder(x, y); got != want || math.Signbit(got) != math.Signbit(want) || acc != big.Exact {
+			t.Errorf("Remainder(%v, %v) = %v (%v); want %v", x, y, got, acc, want)
+		}
+	}
+
+	for _, xy := range [][2]float64{
+		{5, 3}, {-5, 3}, {5, -3}, {-5, -3}, {6, 3}, {-6, 3},
+		{2.5, 1}, {3.5, 1}, {-2.5, 1}, {0.5, 1}, {1.5, 1},
+		{0, 3}, {math.Copysign(0, -1), 3}, {1, math.Inf(1)}, {-1, math.Inf(-1)},
+		{1e300, 3}, {1e300, 1e-300}, {3, 1e300}, {0x1p-1000, 0x1p-1074},
+	} {
+		check(xy[0], xy[1])
+	}
+	for i := 0; i < 1000; i++ {
+		x := math.Ldexp(r.Float64(), r.Intn(400)-200)
+		y := math.Ldexp(r.Float64(), r.Intn(400)-200)
+		if r.Intn(2) == 0 {
+			x = -x
+		}
+		if r.Intn(2) == 0 {
+			y = -y
+		}
+		check(x, y)
+	}
+}
+
+func TestModExact(t *testing.T) {
+	// against exact rational arithmetic, at mixed precisions
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 500; i++ {
+		x := bigfloat.Rand(uint(1+r.Intn(300)), r)
+		x.SetMantExp(x, r.Intn(300))
+		y := bigfloat.Rand(uint(1+r.Intn(300)), r)
+		y.SetMantExp(y, r.Intn(300)-150)
+		if r.Intn(2) == 0 {
+			y.Neg(y)
+		}
+		if r.Intn(2) == 0 {
+			x.Neg(x)
+		}
+
+		xr, _ := x.Rat(nil)
+		yr, _ := y.Rat(nil)
+		q := new(big.Rat).Quo(xr, yr)
+		n := new(big.Int).Quo(q.Num(), q.Denom()) // truncated
+		want := new(big.Rat).Sub(xr, new(big.Rat).Mul(new(big.Rat).SetInt(n), yr))
+
+		prec := x.Prec()
+		w := new(big.Float).SetPrec(prec).SetRat(want)
+		z, acc := bigfloat.Mod(x, y)
+		if y.Prec() <= x.Prec() && acc != big.Exact {
+			t.Errorf("Mod(%s, %s) is not exact", x.Text('p', 0), y.Text('p', 0))
+		}
+		if z.Cmp(w) != 0 || z.Prec() != prec {
+			t.Fatalf("Mod(%s, %s) = %s; want %s", x.Text('p', 0), y.Text('p', 0), z.Text('p', 0), w.Text('p', 0))
+		}
+		zr, _ := z.Rat(nil)
+		if c := zr.Cmp(want); (c == 0) != (acc == big.Exact) || (c < 0) != (acc == big.Below) {
+			t.Errorf("Mod(%s, %s) has accuracy %v", x.Text('p', 0), y.Text('p', 0), acc)
+		}
+
+		// the nearest quotient, ties to even
+		frac := new(big.Rat).Sub(q, new(big.Rat).SetInt(n))
+		frac.Abs(frac)
+		if c := frac.Cmp(big.NewRat(1, 2)); c > 0 || (c == 0 && n.Bit(0) == 1) {
+			n.Add(n, big.NewInt(int64(q.Sign())))
+		}
+		want.Sub(xr, new(big.Rat).Mul(new(big.Rat).SetInt(n), yr))
+		w.SetRat(want)
+		if z, _ := bigfloat.Remainder(x, y); z.Cmp(w) != 0 {
+			t.Fatalf("Remainder(%s, %s) = %s; want %s", x.Text('p', 0), y.Text('p', 0), z.Text('p', 0), w.Text('p', 0))
+		}
+	}
+
+	// a huge exponent difference: 2**1000000 = 1 mod 3
+	x := new(big.Float).SetPrec(10).SetInt64(1)
+	x.SetMantExp(x, 1000000)
+	if z, _ := bigfloat.Remainder(x, big.NewFloat(3)); z.Cmp(big.NewFloat(1)) != 0 || z.Prec() != 10 {
+		t.Errorf("Remainder(2**1000000, 3) = %g (prec %d)", z, z.Prec())
+	}
+}
+
+func TestModPanics(t *testing.T) {
+	for _, test := range []struct {
+		name string
+		f    func()
+	}{
+		{"Mod(1, 0)", func() { bigfloat.Mod(big.NewFloat(1), new(big.Float)) }},
+		{"Remainder(Inf, 1)", func() { bigfloat.Remainder(new(big.Float).SetInf(false), big.NewFloat(1)) }},
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%s did not panic", test.name)
+				}
+			}()
+			test.f()
+		}()
+	}
+}
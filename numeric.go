@@ -0,0 +1,134 @@
+package bigfloat
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strings"
+)
+
+// A Numeric is a big.Float that can be stored in and read from a SQL
+// NUMERIC or DECIMAL column, implementing the driver.Valuer and the
+// sql.Scanner interfaces. Values are exchanged with the database as
+// decimal strings, so that no digits are lost in the conversion to
+// float64 done by many drivers.
+//
+// Precision and Scale describe the column type NUMERIC(Precision,
+// Scale): the total number of decimal digits, and the number of them
+// after the decimal point. If Precision is 0, the column is assumed
+// to be unconstrained and Scale is ignored.
+type Numeric struct {
+	big.Float
+	Precision int
+	Scale     int
+}
+
+// Value implements the driver.Valuer interface. If n.Precision is 0,
+// the value is written with the fewest decimal digits that Scan, into
+// a Numeric of the same precision, rounds back to it: the digits it
+// was scanned from, or, for a binary fraction as 0.1 at 53 bits, the
+// shortest decimal that identifies it, "0.1". Otherwise it's rounded
+// to n.Scale digits after the decimal point, with ties rounded away
+// from zero like PostgreSQL does, and an error is returned if the
+// result has more than n.Precision digits, or if it's an infinity.
+func (n *Numeric) Value() (driver.Value, error) {
+
+	if n.Precision < 0 || n.Scale < 0 {
+		return nil, errors.New("bigfloat: negative NUMERIC precision or scale")
+	}
+
+	if n.Precision == 0 {
+		switch {
+		case n.IsInf() && n.Signbit():
+			return "-Infinity", nil
+		case n.IsInf():
+			return "Infinity", nil
+		case n.Sign() == 0:
+			return "0", nil
+		}
+		return n.Text('f', -1), nil
+	}
+
+	if n.IsInf() {
+		return nil, fmt.Errorf("bigfloat: %g doesn't fit NUMERIC(%d, %d)", &n.Float, n.Precision, n.Scale)
+	}
+	s := FormatFixed(&n.Float, n.Scale, HalfAwayFromZero)
+	if d := strings.TrimLeft(strings.Replace(strings.TrimPrefix(s, "-"), ".", "", 1), "0"); len(d) > n.Precision {
+		return nil, fmt.Errorf("bigfloat: %s doesn't fit NUMERIC(%d, %d)", s, n.Precision, n.Scale)
+	}
+
+	return s, nil
+}
+
+// Scan implements the sql.Scanner interface. src can be a string or
+// a []byte holding a decimal number, as returned for NUMERIC columns
+// by most drivers, an int64 or a float64. Strings are rounded to the
+// precision of n, if it's non-zero; otherwise the precision is chosen
+// so that all the digits in the string are kept, and Value writes them
+// back, but for the trailing zeros after the decimal point, when
+// n.Precision is 0. NULL values are an error.
+func (n *Numeric) Scan(src interface{}) error {
+
+	var s string
+	switch v := src.(type) {
+	case string:
+		s = v
+	case []byte:
+		s = string(v)
+	case int64:
+		if n.Prec() == 0 {
+			n.SetPrec(64)
+		}
+		n.SetInt64(v)
+		return nil
+	case float64:
+		if math.IsNaN(v) {
+			return ErrNaN
+		}
+		if n.Prec() == 0 {
+			n.SetPrec(53)
+		}
+		n.SetFloat64(v)
+		return nil
+	case nil:
+		return errors.New("bigfloat: can't scan NULL into Numeric")
+	default:
+		return fmt.Errorf("bigfloat: can't scan %T into Numeric", src)
+	}
+
+	if strings.EqualFold(strings.TrimSpace(s), "NaN") {
+		return ErrNaN
+	}
+	prec := n.Prec()
+	if prec == 0 {
+		prec = decimalPrec(s)
+	}
+	x, _, err := Parse(s, prec)
+	if err != nil {
+		return err
+	}
+	n.Set(x)
+
+	return nil
+}
+
+// decimalPrec returns a precision large enough to keep all the
+// digits of the decimal number s: PrecForDigits of the number of
+// digits in the mantissa.
+func decimalPrec(s string) uint {
+
+	d := 0
+	for i := 0; i < len(s) && s[i] != 'e' && s[i] != 'E'; i++ {
+		if '0' <= s[i] && s[i] <= '9' {
+			d++
+		}
+	}
+
+	if d == 0 {
+		return 1
+	}
+
+	return PrecForDigits(d)
+}
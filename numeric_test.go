@@ -0,0 +1,150 @@
+package bigfloat_test
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"math/big"
+	"testing"
+
+	"github.com/ThreeAndTwo/bigfloat"
+)
+
+var (
+	_ driver.Valuer = &bigfloat.Numeric{}
+	_ sql.Scanner   = &bigfloat.Numeric{}
+)
+
+func TestNumericValue(t *testing.T) {
+	for _, test := range []struct {
+		x          *big.Float
+		prec, scal int
+		want       string
+		ok         bool
+	}{
+		{big.NewFloat(0.1), 0, 0, "0.1", true},
+		{big.NewFloat(1e30), 0, 0, "1000000000000000000000000000000", true},
+		{big.NewFloat(-0.0), 0, 0, "0", true},
+		{big.NewFloat(-1024), 0, 0, "-1024", true},
+		{new(big.Float), 0, 0, "0", true},
+		{new(big.Float).SetInf(false), 0, 0, "Infinity", true},
+		{new(big.Float).SetInf(true), 0, 0, "-Infinity", true},
+		{big.NewFloat(2.5), 5, 2, "2.50", true},
+		{big.NewFloat(-0.125), 5, 2, "-0.13", true}, // ties away from zero
+		{big.NewFloat(999.994), 5, 2, "999.99", true},
+		{big.NewFloat(999.996), 5, 2, "", false}, // rounds to 1000.00
+		{big.NewFloat(12345), 5, 0, "12345", true},
+		{big.NewFloat(123456), 5, 0, "", false},
+		{big.NewFloat(0.0015), 2, 4, "0.0015", true},
+		{big.NewFloat(0.015), 2, 4, "", false},
+		{new(big.Float).SetInf(false), 5, 2, "", false},
+		{big.NewFloat(1), -1, 0, "", false},
+	} {
+		n := bigfloat.Numeric{Precision: test.prec, Scale: test.scal}
+		n.Set(test.x)
+		v, err := n.Value()
+		if !test.ok {
+			if err == nil {
+				t.Errorf("Value(%g, %d, %d) = %v; want error", test.x, test.prec, test.scal, v)
+			}
+			continue
+		}
+		if err != nil || v != test.want {
+			t.Errorf("Value(%g, %d, %d) = %v, %v; want %s", test.x, test.prec, test.scal, v, err, test.want)
+		}
+	}
+}
+
+func TestNumericScan(t *testing.T) {
+	for _, test := range []struct {
+		src  interface{}
+		prec uint
+		want string
+		ok   bool
+	}{
+		{"3.14159", 0, "3.14159", true},
+		{[]byte("-0.000000000000000000000000000001"), 0, "-1e-30", true},
+		{"123456789012345678901234567890.123", 0, "1.23456789012345678901234567890123e+29", true},
+		{"3.14159", 8, "3.14", true},
+		{"-Infinity", 0, "-Inf", true},
+		{int64(-42), 0, "-42", true},
+		{0.5, 0, "0.5", true},
+		{"NaN", 0, "", false},
+		{"12x", 0, "", false},
+		{nil, 0, "", false},
+		{true, 0, "", false},
+	} {
+		var n bigfloat.Numeric
+		n.SetPrec(test.prec)
+		err := n.Scan(test.src)
+		if !test.ok {
+			if err == nil {
+				t.Errorf("Scan(%#v) = %g; want error", test.src, &n.Float)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Scan(%#v) error: %v", test.src, err)
+			continue
+		}
+		if s := n.Text('g', -1); s != test.want {
+			t.Errorf("Scan(%#v) = %s; want %s", test.src, s, test.want)
+		}
+	}
+}
+
+func TestNumericRoundTrip(t *testing.T) {
+	// decimal values are written back unchanged at the scale of the
+	// column they were read from
+	for _, test := range []struct {
+		s          string
+		prec, scal int
+	}{
+		{"0.10", 10, 2},
+		{"-98765432109876543210.0123456789", 30, 10},
+		{"0.00000000000000000000000000000000000000000000000001", 50, 50},
+		{"99999999999999999999999999999999999999.99", 40, 2},
+	} {
+		n := bigfloat.Numeric{Precision: test.prec, Scale: test.scal}
+		if err := n.Scan(test.s); err != nil {
+			t.Fatal(err)
+		}
+		if v, err := n.Value(); err != nil || v != test.s {
+			t.Errorf("round trip of %s gave %v, %v", test.s, v, err)
+		}
+	}
+
+	// and so are the ones of unconstrained columns, without their
+	// trailing zeros
+	for _, test := range []struct {
+		s, want string
+	}{
+		{"0.1", "0.1"},
+		{"3.14159", "3.14159"},
+		{"-2.50", "-2.5"},
+		{"123456789012345678901234567890.123", "123456789012345678901234567890.123"},
+		{"0.000000000000000000000000000001", "0.000000000000000000000000000001"},
+		{"1e30", "1000000000000000000000000000000"},
+	} {
+		var n bigfloat.Numeric
+		if err := n.Scan(test.s); err != nil {
+			t.Fatal(err)
+		}
+		if v, err := n.Value(); err != nil || v != test.want {
+			t.Errorf("round trip of %s gave %v, %v; want %s", test.s, v, err, test.want)
+		}
+	}
+
+	// binary values are read back exactly at their precision
+	x := bigfloat.Sqrt(new(big.Float).SetPrec(500).SetInt64(2))
+	var n bigfloat.Numeric
+	n.Set(x)
+	v, _ := n.Value()
+	n = bigfloat.Numeric{}
+	n.SetPrec(500)
+	if err := n.Scan(v); err != nil {
+		t.Fatal(err)
+	}
+	if n.Cmp(x) != 0 {
+		t.Errorf("round trip of √2 gave %g", &n.Float)
+	}
+}
@@ -0,0 +1,196 @@
+package bigfloat
+
+import (
+	"math"
+	"math/big"
+)
+
+// A TaylorSystem describes the system of ODEs y' = f(t, y) to be
+// solved by TaylorODE. Given the expansion point t and the Taylor
+// coefficients y[i][0], ..., y[i][k] of each component of the
+// solution around t, it must return the k-th Taylor coefficient of
+// each component of f(t, y(t)).
+//
+// The coefficients are usually computed with the rules of automatic
+// differentiation: linear combinations act coefficient-wise, and
+// products have the Cauchy product (computed by TaylorProduct) as
+// coefficients. The Taylor series of the independent variable is
+// t + 1·h, so an explicit dependency on time only needs the first
+// two coefficients.
+type TaylorSystem func(t *big.Float, y [][]*big.Float, k int) []*big.Float
+
+// TaylorProduct returns the k-th Taylor coefficient of the product
+// of two functions with Taylor coefficients a and b, that is
+//
+//	Σ a[j]·b[k-j] for j = 0, ..., k
+//
+// at the largest precision of the elements involved.
+func TaylorProduct(a, b []*big.Float, k int) *big.Float {
+
+	var prec uint
+	for j := 0; j <= k; j++ {
+		if p := a[j].Prec(); p > prec {
+			prec = p
+		}
+		if p := b[k-j].Prec(); p > prec {
+			prec = p
+		}
+	}
+
+	sum := new(big.Float).SetPrec(prec)
+	t := new(big.Float).SetPrec(prec)
+	for j := 0; j <= k; j++ {
+		sum.Add(sum, t.Mul(a[j], b[k-j]))
+	}
+
+	return sum
+}
+
+// TaylorODE integrates the system of ODEs described by f from t0 to
+// t1, starting from the initial state y0, and returns the state at
+// t1. Precision is the largest precision of the elements of y0.
+// order is the degree of the Taylor polynomials used at each step;
+// if order <= 0, an order suitable for the result precision is used.
+//
+// The step size is chosen at every step from the last two Taylor
+// coefficients (following Jorba and Zou, A software package for the
+// numerical integration of ODEs by means of high-order Taylor
+// methods, Experimental Mathematics 14, 2005), so that the local
+// truncation error stays below the working precision. The local
+// errors still accumulate, and on chaotic systems they are amplified
+// exponentially; the result precision must be chosen accordingly.
+//
+// The function panics if a Taylor coefficient is infinite, or if a
+// step is too small to move t at the working precision, as happens
+// when the solution has a singularity between t0 and t1.
+func TaylorODE(f TaylorSystem, t0 *big.Float, y0 []*big.Float, t1 *big.Float, order int) []*big.Float {
+
+	prec := seqPrec("TaylorODE", y0)
+	wprec := prec + 32 // guard digits
+
+	if order <= 0 {
+		// the optimal order for a tolerance ε is about -log(ε)/2
+		order = int(float64(wprec)*math.Ln2/2) + 2
+	}
+	if order < 2 {
+		order = 2
+	}
+
+	dir := new(big.Float).Sub(t1, t0).Sign()
+	t := new(big.Float).SetPrec(wprec).Set(t0)
+	y := make([]*big.Float, len(y0))
+	for i := range y0 {
+		y[i] = new(big.Float).SetPrec(wprec).Set(y0[i])
+	}
+
+	// coefficients of the Taylor series of the solution around t
+	c := make([][]*big.Float, len(y))
+	h := new(big.Float).SetPrec(wprec)
+	rem := new(big.Float).SetPrec(wprec)
+	next := new(big.Float).SetPrec(wprec)
+	for dir != 0 && t.Cmp(t1) != 0 {
+		for i := range c {
+			c[i] = append(c[i][:0], y[i])
+		}
+		for k := 0; k < order; k++ {
+			fk := f(t, c, k)
+			for i := range c {
+				ck := new(big.Float).SetPrec(wprec).Quo(fk[i], big.NewFloat(float64(k+1)))
+				if ck.IsInf() {
+					panic("TaylorODE: infinite Taylor coefficient")
+				}
+				c[i] = append(c[i], ck)
+			}
+		}
+
+		taylorStep(h, c, wprec)
+		if dir < 0 {
+			h.Neg(h)
+		}
+
+		// don't step over t1
+		rem.Sub(t1, t)
+		last := h.Sign() == 0 || new(big.Float).Abs(h).Cmp(new(big.Float).Abs(rem)) >= 0
+		if last {
+			h.Set(rem)
+		} else if next.Add(t, h).Cmp(t) == 0 {
+			panic("TaylorODE: step size underflow, the solution may have a singularity")
+		}
+
+		// evaluate the Taylor polynomials at h using Horner
+		for i := range c {
+			s := new(big.Float).SetPrec(wprec).Set(c[i][order])
+			for k := order - 1; k >= 0; k-- {
+				s.Mul(s, h).Add(s, c[i][k])
+			}
+			y[i] = s
+		}
+
+		if last {
+			t.Set(t1)
+		} else {
+			t.Set(next)
+		}
+	}
+
+	for i := range y {
+		y[i].SetPrec(prec)
+	}
+
+	return y
+}
+
+// taylorStep sets h to the step size for Taylor polynomials with
+// coefficients c. Following Jorba and Zou, with N the order and m[k]
+// the largest k-th coefficient in absolute value, the step is
+//
+//	min((ε/m[N-1])**(1/(N-1)), (ε/m[N])**(1/N)) · exp(-0.7/(N-1))
+//
+// where ε = 2**(-prec)·max(1, m[0]). The computation is done in the
+// log₂ domain, so that tiny and huge steps don't overflow float64.
+// If the last two coefficients are both zero, h is set to 0, and
+// the caller should take a single step to the end of the interval.
+func taylorStep(h *big.Float, c [][]*big.Float, prec uint) {
+
+	n := len(c[0]) - 1
+
+	// log₂ of the largest coefficient of degree k, or -Inf if
+	// they're all zero
+	logMax := func(k int) float64 {
+		l := math.Inf(-1)
+		for i := range c {
+			if c[i][k].Sign() == 0 || c[i][k].IsInf() {
+				continue
+			}
+			mant := new(big.Float)
+			e := c[i][k].MantExp(mant)
+			m, _ := mant.Float64()
+			if v := float64(e) + math.Log2(math.Abs(m)); v > l {
+				l = v
+			}
+		}
+		return l
+	}
+
+	logTol := -float64(prec)
+	if l0 := logMax(0); l0 > 0 {
+		logTol += l0
+	}
+
+	logH := math.Inf(+1)
+	for _, k := range []int{n - 1, n} {
+		if lk := logMax(k); !math.IsInf(lk, -1) {
+			logH = math.Min(logH, (logTol-lk)/float64(k))
+		}
+	}
+	if math.IsInf(logH, +1) {
+		h.SetFloat64(0)
+		return
+	}
+	logH -= 0.7 / float64(n-1) / math.Ln2
+
+	// h = 2**logH
+	i := math.Floor(logH)
+	h.SetFloat64(math.Exp2(logH - i))
+	h.SetMantExp(h, int(i))
+}
@@ -0,0 +1,95 @@
+package bigfloat_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ThreeAndTwo/bigfloat"
+)
+
+func TestTaylorODEExp(t *testing.T) {
+	// y' = y, y(0) = 1 ⇒ y(t) = exp(t)
+	f := func(t *big.Float, y [][]*big.Float, k int) []*big.Float {
+		return []*big.Float{y[0][k]}
+	}
+
+	for _, prec := range []uint{53, 100, 200} {
+		t0 := new(big.Float).SetPrec(prec)
+		t1 := big.NewFloat(3).SetPrec(prec)
+		y0 := []*big.Float{big.NewFloat(1).SetPrec(prec)}
+
+		y := bigfloat.TaylorODE(f, t0, y0, t1, 0)
+		want := bigfloat.Exp(t1)
+		if y[0].Prec() != prec {
+			t.Errorf("prec = %d, TaylorODE returned precision %d", prec, y[0].Prec())
+		}
+		if !closeTo(y[0], want, int(prec)-8) {
+			t.Errorf("prec = %d, y' = y, y(3) =\ngot  %g;\nwant %g", prec, y[0], want)
+		}
+
+		// integrating backwards gets us back to the start
+		z := bigfloat.TaylorODE(f, t1, y, t0, 0)
+		if !closeTo(z[0], y0[0], int(prec)-8) {
+			t.Errorf("prec = %d, y' = y backwards, y(0) = %g; want 1", prec, z[0])
+		}
+	}
+}
+
+func TestTaylorODENonlinear(t *testing.T) {
+	// y' = y², y(0) = 1 ⇒ y(t) = 1/(1-t)
+	f := func(t *big.Float, y [][]*big.Float, k int) []*big.Float {
+		return []*big.Float{bigfloat.TaylorProduct(y[0], y[0], k)}
+	}
+
+	const prec = 150
+	t0 := new(big.Float).SetPrec(prec)
+	t1 := big.NewFloat(0.75).SetPrec(prec)
+	y0 := []*big.Float{big.NewFloat(1).SetPrec(prec)}
+
+	y := bigfloat.TaylorODE(f, t0, y0, t1, 30)
+	if want := big.NewFloat(4); !closeTo(y[0], want, prec-8) {
+		t.Errorf("y' = y², y(0.75) = %g; want %g", y[0], want)
+	}
+}
+
+func TestTaylorODEOscillator(t *testing.T) {
+	// x' = v, v' = -x, with x(0) = 0, v(0) = 1 ⇒ x(t) = sin(t)
+	f := func(t *big.Float, y [][]*big.Float, k int) []*big.Float {
+		return []*big.Float{y[1][k], new(big.Float).Neg(y[0][k])}
+	}
+
+	const prec = 120
+	pi, _, _ := new(big.Float).SetPrec(prec).Parse(piStr, 10)
+	halfPi := new(big.Float).Mul(pi, big.NewFloat(0.5))
+
+	y0 := []*big.Float{big.NewFloat(0).SetPrec(prec), big.NewFloat(1).SetPrec(prec)}
+	y := bigfloat.TaylorODE(f, new(big.Float).SetPrec(prec), y0, halfPi, 0)
+
+	if !closeTo(y[0], big.NewFloat(1), prec-8) {
+		t.Errorf("sin(π/2) = %g; want 1", y[0])
+	}
+	if y[1].MantExp(nil) > -(prec - 8) {
+		t.Errorf("cos(π/2) = %g; want 0", y[1])
+	}
+}
+
+func TestTaylorODESingularity(t *testing.T) {
+	// y' = y², y(0) = 1 ⇒ y(t) = 1/(1-t), which blows up at t = 1
+	f := func(t *big.Float, y [][]*big.Float, k int) []*big.Float {
+		return []*big.Float{bigfloat.TaylorProduct(y[0], y[0], k)}
+	}
+
+	for _, prec := range []uint{53, 100} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("prec = %d, integrating y' = y² past t = 1 did not panic", prec)
+				}
+			}()
+			t0 := new(big.Float).SetPrec(prec)
+			t1 := big.NewFloat(2).SetPrec(prec)
+			y0 := []*big.Float{big.NewFloat(1).SetPrec(prec)}
+			bigfloat.TaylorODE(f, t0, y0, t1, 0)
+		}()
+	}
+}
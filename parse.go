@@ -0,0 +1,289 @@
+package bigfloat
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"strings"
+)
+
+// A ParseError records a failed call to Parse.
+type ParseError struct {
+	Input  string // the string given to Parse
+	Offset int    // byte offset in Input of the offending character
+	Base   int    // base of the mantissa, as given by its prefix
+	Msg    string // description of the problem
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("bigfloat.Parse: %s at offset %d in %q (base %d)", e.Msg, e.Offset, e.Input, e.Base)
+}
+
+// Parse parses s, which must contain a floating-point number, and
+// returns the number rounded to prec bits (64 if prec is 0), using
+// the ToNearestEven rounding mode. exact reports whether the returned
+// value is exactly the number written in s; for example, it's false
+// for "0.1" at any precision, and true for "0.5".
+//
+// The accepted syntax is the one of big.Float.Parse with base 0: an
+// optional sign, an optional "0x", "0o" or "0b" prefix, a mantissa
+// and an optional exponent, "e" (decimal only) or "p", or otherwise
+// "Inf" or "Infinity" (in any case) after the optional sign. Unlike
+// big.Float.Parse, leading and trailing white space is ignored, and
+// underscores can be used to separate digits in the mantissa and in
+// the exponent, either after the prefix or between two digits.
+//
+// If s isn't a valid number, the error is a *ParseError reporting
+// the position of the first invalid character.
+func Parse(s string, prec uint) (f *big.Float, exact bool, err error) {
+
+	if prec == 0 {
+		prec = 64
+	}
+
+	p := parser{in: s, base: 10}
+	if err := p.scan(); err != nil {
+		return nil, false, err
+	}
+
+	z := new(big.Float).SetPrec(prec)
+	if p.inf {
+		return z.SetInf(p.neg), true, nil
+	}
+	if _, _, err := z.Parse(p.clean.String(), 0); err != nil {
+		return nil, false, &ParseError{s, p.expOff, p.base, "exponent out of range"}
+	}
+
+	return z, p.exact(prec), nil
+}
+
+// parser holds the state of Parse.
+type parser struct {
+	in   string
+	i    int // position in in
+	base int
+	neg  bool
+	inf  bool
+
+	clean  strings.Builder // in, without the white space and underscores
+	digits strings.Builder // all the digits of the mantissa
+	frac   int             // digits after the point
+	exp10  int64           // decimal exponent
+	exp2   int64           // binary exponent
+	expOff int             // offset of the exponent
+}
+
+func (p *parser) errorf(format string, args ...interface{}) error {
+	return &ParseError{p.in, p.i, p.base, fmt.Sprintf(format, args...)}
+}
+
+// scan checks the syntax of p.in, and fills the fields of p.
+func (p *parser) scan() error {
+
+	end := len(p.in)
+	for end > 0 && isSpace(p.in[end-1]) {
+		end--
+	}
+	for p.i < end && isSpace(p.in[p.i]) {
+		p.i++
+	}
+	if p.i == end {
+		return p.errorf("empty number")
+	}
+	s := p.in[:end]
+
+	if c := s[p.i]; c == '+' || c == '-' {
+		p.neg = c == '-'
+		p.clean.WriteByte(c)
+		p.i++
+	}
+
+	if rest := strings.ToLower(s[p.i:]); rest == "inf" || rest == "infinity" {
+		p.inf = true
+		return nil
+	}
+
+	// prefix
+	prefix := false
+	if p.i+1 < len(s) && s[p.i] == '0' {
+		switch s[p.i+1] {
+		case 'x', 'X':
+			p.base = 16
+		case 'o', 'O':
+			p.base = 8
+		case 'b', 'B':
+			p.base = 2
+		}
+		if p.base != 10 {
+			p.clean.WriteString(s[p.i : p.i+2])
+			p.i += 2
+			prefix = true
+		}
+	}
+
+	// mantissa
+	n, point := 0, false
+	prev := prefix // whether an underscore is allowed here
+	for ; p.i < len(s); p.i++ {
+		c := s[p.i]
+		switch {
+		case c == '_':
+			if !prev || p.i+1 == len(s) || digitVal(s[p.i+1]) >= p.base {
+				return p.errorf("misplaced underscore")
+			}
+			continue
+		case c == '.':
+			if point {
+				return p.errorf("second decimal point")
+			}
+			point = true
+			p.clean.WriteByte(c)
+			prev = false
+			continue
+		case digitVal(c) < p.base:
+			p.clean.WriteByte(c)
+			p.digits.WriteByte(c)
+			n++
+			if point {
+				p.frac++
+			}
+			prev = true
+			continue
+		}
+		break
+	}
+	if n == 0 {
+		if p.i < len(s) {
+			return p.errorf("invalid character %q", s[p.i])
+		}
+		return p.errorf("missing digits")
+	}
+
+	if p.i == len(s) {
+		return nil
+	}
+
+	// exponent
+	c := s[p.i]
+	switch {
+	case c == 'p' || c == 'P':
+	case (c == 'e' || c == 'E') && p.base == 10:
+	default:
+		return p.errorf("invalid character %q", c)
+	}
+	p.expOff = p.i
+	p.clean.WriteByte(c)
+	p.i++
+
+	neg := false
+	if p.i < len(s) && (s[p.i] == '+' || s[p.i] == '-') {
+		neg = s[p.i] == '-'
+		p.clean.WriteByte(s[p.i])
+		p.i++
+	}
+	var e int64
+	n, prev = 0, false
+	for ; p.i < len(s); p.i++ {
+		c := s[p.i]
+		if c == '_' {
+			if !prev || p.i+1 == len(s) || digitVal(s[p.i+1]) >= 10 {
+				return p.errorf("misplaced underscore")
+			}
+			continue
+		}
+		if digitVal(c) >= 10 {
+			return p.errorf("invalid character %q", c)
+		}
+		p.clean.WriteByte(c)
+		if e < math.MaxInt32 {
+			e = e*10 + int64(c-'0')
+		}
+		n++
+		prev = true
+	}
+	if n == 0 {
+		return p.errorf("missing exponent digits")
+	}
+	if neg {
+		e = -e
+	}
+
+	if c == 'p' || c == 'P' {
+		p.exp2 = e
+	} else {
+		p.exp10 = e
+	}
+
+	return nil
+}
+
+// exact reports whether the number scanned by p can be represented
+// exactly with prec bits.
+func (p *parser) exact(prec uint) bool {
+
+	m, _ := new(big.Int).SetString(p.digits.String(), p.base)
+	if m.Sign() == 0 {
+		return true
+	}
+
+	// for a power of two base, the value is m times a power of two
+	if p.base != 10 {
+		return oddBitLen(m) <= prec
+	}
+
+	// m·10**e, with all the factors of 10 of m moved to e
+	e := p.exp10 - int64(p.frac)
+	ten := big.NewInt(10)
+	q, r := new(big.Int), new(big.Int)
+	for {
+		q.QuoRem(m, ten, r)
+		if r.Sign() != 0 {
+			break
+		}
+		m, q = q, m
+		e++
+	}
+
+	// The factor 5**|e| has about 2.32·|e| bits. Check the size
+	// before computing it, so that huge exponents can't make us
+	// compute huge powers.
+	const log2of5 = 2.321928094887362
+	if e >= 0 {
+		if float64(e)*log2of5 > float64(prec)+1 {
+			return false
+		}
+		m.Mul(m, new(big.Int).Exp(big.NewInt(5), big.NewInt(e), nil))
+		return oddBitLen(m) <= prec
+	}
+
+	// a negative power of 10 is exact only if 5**|e| divides m
+	if float64(-e)*log2of5 > float64(m.BitLen())+1 {
+		return false
+	}
+	q.QuoRem(m, new(big.Int).Exp(big.NewInt(5), big.NewInt(-e), nil), r)
+	return r.Sign() == 0 && oddBitLen(q) <= prec
+}
+
+// oddBitLen returns the number of bits of the odd part of m.
+func oddBitLen(m *big.Int) uint {
+	return uint(m.BitLen()) - m.TrailingZeroBits()
+}
+
+// digitVal returns the value of the digit c, or 36 if c isn't a digit.
+func digitVal(c byte) int {
+
+	switch {
+	case '0' <= c && c <= '9':
+		return int(c - '0')
+	case 'a' <= c && c <= 'z':
+		return int(c-'a') + 10
+	case 'A' <= c && c <= 'Z':
+		return int(c-'A') + 10
+	}
+
+	return 36
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\v' || c == '\f'
+}
@@ -0,0 +1,124 @@
+package bigfloat_test
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ThreeAndTwo/bigfloat"
+)
+
+func TestParse(t *testing.T) {
+	for _, test := range []struct {
+		s     string
+		prec  uint
+		want  string // in the %g format of big.Float.Text
+		exact bool
+	}{
+		{"1", 53, "1", true},
+		{"  -2.5\n", 53, "-2.5", true},
+		{"0.1", 53, "0.1", false},
+		{"0.1", 1000, "0.1", false},
+		{"0.5", 1, "0.5", true},
+		{"1_000_000", 24, "1e+06", true},
+		{"1e-3", 64, "0.001", false},
+		{"1.25e2", 53, "125", true},
+		{"3e100", 53, "3e+100", false},
+		{"3e100", 300, "3e+100", true},
+		{"1e23", 53, "1e+23", false},
+		{"1e23", 60, "1e+23", true},
+		{"0.000", 53, "0", true},
+		{"-0", 53, "-0", true},
+		{"1.5p3", 53, "12", true},
+		{"0x1.8p1", 53, "3", true},
+		{"0x_ff", 8, "255", true},
+		{"0x1ff", 8, "512", false},
+		{"0b1.01", 53, "1.25", true},
+		{"0o17", 53, "15", true},
+		{"1e1_0", 53, "1e+10", true},
+		{"inf", 53, "+Inf", true},
+		{"-Infinity", 53, "-Inf", true},
+		{"0.30000000000000004", 53, "0.30000000000000004", false},
+		{"0.3000000000000000444089209850062616169452667236328125", 53, "0.30000000000000004", true},
+		{"1e-10000", 64, "1e-10000", false},
+	} {
+		x, exact, err := bigfloat.Parse(test.s, test.prec)
+		if err != nil {
+			t.Errorf("Parse(%q) error: %v", test.s, err)
+			continue
+		}
+		if x.Prec() != test.prec {
+			t.Errorf("Parse(%q, %d).Prec() = %d", test.s, test.prec, x.Prec())
+		}
+		if got := x.Text('g', 17); got != test.want {
+			want, _, _ := new(big.Float).SetPrec(test.prec).Parse(test.want, 0)
+			if x.Cmp(want) != 0 || x.Signbit() != want.Signbit() {
+				t.Errorf("Parse(%q, %d) = %s; want %s", test.s, test.prec, got, test.want)
+			}
+		}
+		if exact != test.exact {
+			t.Errorf("Parse(%q, %d) exact = %v; want %v", test.s, test.prec, exact, test.exact)
+		}
+	}
+}
+
+func TestParseMatchesBigFloat(t *testing.T) {
+	for _, s := range []string{"0.1", "-1234.5678e-9", "0x1.fffffp-3", "6.02214076e23", "1e-400"} {
+		for _, prec := range []uint{10, 53, 200} {
+			x, _, err := bigfloat.Parse(s, prec)
+			if err != nil {
+				t.Fatal(err)
+			}
+			want, _, _ := new(big.Float).SetPrec(prec).Parse(s, 0)
+			if x.Cmp(want) != 0 {
+				t.Errorf("Parse(%q, %d) = %g; want %g", s, prec, x, want)
+			}
+		}
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, test := range []struct {
+		s      string
+		offset int
+		base   int
+	}{
+		{"", 0, 10},
+		{"   ", 0, 10},
+		{"1.2x", 3, 10},
+		{"1.2.3", 3, 10},
+		{"  12a", 4, 10},
+		{"0x1.8p", 6, 16},
+		{"0x1g", 3, 16},
+		{"0b102", 4, 2},
+		{"1__0", 1, 10},
+		{"_1", 0, 10},
+		{"1_", 1, 10},
+		{"1._5", 2, 10},
+		{"1e", 2, 10},
+		{"1e+", 3, 10},
+		{"1e5x", 3, 10},
+		{"1 000", 1, 10},
+		{"nan", 0, 10},
+		{"+", 1, 10},
+		{"0x", 2, 16},
+	} {
+		_, _, err := bigfloat.Parse(test.s, 53)
+		var perr *bigfloat.ParseError
+		if !errors.As(err, &perr) {
+			t.Errorf("Parse(%q) error = %v; want a *ParseError", test.s, err)
+			continue
+		}
+		if perr.Offset != test.offset || perr.Base != test.base || perr.Input != test.s {
+			t.Errorf("Parse(%q): %v; want offset %d, base %d", test.s, err, test.offset, test.base)
+		}
+	}
+}
+
+// ---------- Benchmarks ----------
+
+func BenchmarkParse(b *testing.B) {
+	for n := 0; n < b.N; n++ {
+		bigfloat.Parse("3.141_592_653_589_793_238_462_643_383_279_502_884e0", 200)
+	}
+}
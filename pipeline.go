@@ -0,0 +1,201 @@
+package bigfloat
+
+import "math/big"
+
+const (
+	// pipelineGuard is the number of guard bits added by every stage
+	// of a Pipeline to the precision of its inputs.
+	pipelineGuard = 8
+
+	// pipelineMaxPasses is the number of times Run evaluates a
+	// Pipeline at most.
+	pipelineMaxPasses = 8
+)
+
+// A Stage is a step of a Pipeline, which computes a value from values
+// computed before it.
+type Stage struct {
+	// Inputs are the indices of the values the stage takes: the
+	// arguments of Run, from 0, then the results of the stages, in
+	// the order they were added.
+	Inputs []int
+
+	// Func returns the result of the stage from its inputs x, to
+	// about prec bits.
+	Func func(x []*big.Float, prec uint) *big.Float
+
+	// Lost returns the bits of relative accuracy the stage loses,
+	// given its inputs x and its result z, as the logarithm of its
+	// condition number, or SumLost(x, z) for a sum. A nil Lost means
+	// that the stage loses none.
+	Lost func(x []*big.Float, z *big.Float) uint
+}
+
+// A Pipeline is a computation in stages, where each stage declares the
+// bits it loses, so Run can find the working precisions of the stages
+// from the precision of the result: the inputs of a stage need the
+// precision of its result, plus the bits it loses, plus 8 guard bits,
+// and a value used by several stages is computed at the largest
+// precision they need. The losses are measured on the values, so a
+// cancellation is seen only once it happened, and Run evaluates the
+// pipeline again with the precisions it so finds until they're enough;
+// the losses of a stage are the largest it had in these evaluations.
+// For exp(x) - 1, which loses the bits of x above 1 when x is small:
+//
+//	p := bigfloat.NewPipeline(1) // x is the value 0
+//	e := p.Add(bigfloat.Stage{Inputs: []int{0}, Func: func(x []*big.Float, prec uint) *big.Float {
+//		return bigfloat.Exp(new(big.Float).SetPrec(prec).Set(x[0]))
+//	}})
+//	p.Add(bigfloat.Stage{Inputs: []int{e}, Func: func(x []*big.Float, prec uint) *big.Float {
+//		return new(big.Float).SetPrec(prec).Sub(x[0], big.NewFloat(1))
+//	}, Lost: bigfloat.SumLost})
+//	z, precs := p.Run([]*big.Float{x}, 53)
+type Pipeline struct {
+	args   int
+	stages []Stage
+}
+
+// NewPipeline returns an empty Pipeline taking the given number of
+// arguments. The function panics if args is negative.
+func NewPipeline(args int) *Pipeline {
+
+	if args < 0 {
+		panic("NewPipeline: negative number of arguments")
+	}
+
+	return &Pipeline{args: args}
+}
+
+// Add adds the stage s to p, and returns the index of its result. The
+// method panics if an input of s isn't the index of an argument or of
+// the result of an earlier stage.
+func (p *Pipeline) Add(s Stage) int {
+
+	n := p.args + len(p.stages)
+	for _, i := range s.Inputs {
+		if i < 0 || i >= n {
+			panic("Add: input is not an earlier value")
+		}
+	}
+	p.stages = append(p.stages, s)
+
+	return n
+}
+
+// Run evaluates p with the arguments args, and returns the result of
+// its last stage rounded to prec bits (64 if prec is 0), and the
+// precisions needed by all the values: the arguments first, and then
+// the results of the stages. The arguments are used as they are, so
+// an argument rounded to a smaller precision than it needs limits the
+// accuracy of the result. If the losses keep growing with the
+// precision, Run stops after 8 evaluations. The method panics if p has
+// no stage or len(args) isn't the number of arguments of p.
+func (p *Pipeline) Run(args []*big.Float, prec uint) (z *big.Float, precs []uint) {
+
+	if prec == 0 {
+		prec = 64
+	}
+	switch {
+	case len(p.stages) == 0:
+		panic("Run: no stage")
+	case len(args) != p.args:
+		panic("Run: wrong number of arguments")
+	}
+
+	lost := make([]uint, len(p.stages))
+	values := make([]*big.Float, p.args+len(p.stages))
+	copy(values, args)
+	var used []uint
+	for pass := 1; ; pass++ {
+		precs = p.precs(prec, lost)
+		if used != nil && !exceeds(precs, used) || pass > pipelineMaxPasses {
+			break
+		}
+		x := []*big.Float{}
+		for j, s := range p.stages {
+			x = x[:0]
+			for _, i := range s.Inputs {
+				x = append(x, values[i])
+			}
+			v := s.Func(x, precs[p.args+j])
+			values[p.args+j] = v
+			if s.Lost != nil {
+				if l := s.Lost(x, v); l > lost[j] {
+					lost[j] = l
+				}
+			}
+		}
+		used = precs
+	}
+
+	return new(big.Float).SetPrec(prec).Set(values[len(values)-1]), used
+}
+
+// precs returns the precisions of the values of p for a result of
+// prec bits, with the stages losing lost bits, propagated back from
+// the last stage. The results of the stages have at least prec bits,
+// and the arguments that no stage takes 0.
+func (p *Pipeline) precs(prec uint, lost []uint) []uint {
+
+	n := p.args + len(p.stages)
+	precs := make([]uint, n)
+	for i := p.args; i < n; i++ {
+		precs[i] = prec
+	}
+	for j := len(p.stages) - 1; j >= 0; j-- {
+		need := precs[p.args+j] + lost[j] + pipelineGuard
+		for _, i := range p.stages[j].Inputs {
+			if need > precs[i] {
+				precs[i] = need
+			}
+		}
+	}
+
+	return precs
+}
+
+// exceeds reports whether a precision of a is larger than the one of
+// b.
+func exceeds(a, b []uint) bool {
+
+	for i := range a {
+		if a[i] > b[i] {
+			return true
+		}
+	}
+
+	return false
+}
+
+// SumLost returns the bits of relative accuracy lost to cancellation
+// by a sum z of the terms x, with any signs: the exponent of the
+// largest finite term minus the one of z, or 0 if it's not positive
+// or z is infinite. A zero z from terms that aren't all zeros lost all
+// the bits of the terms, the largest of their precisions. SumLost
+// serves as the Lost of the stages of a Pipeline that add or subtract
+// their inputs.
+func SumLost(x []*big.Float, z *big.Float) uint {
+
+	if z.IsInf() {
+		return 0
+	}
+	if z.Sign() == 0 {
+		var lost uint
+		for _, t := range x {
+			if t.Sign() != 0 && t.Prec() > lost {
+				lost = t.Prec()
+			}
+		}
+		return lost
+	}
+
+	e := z.MantExp(nil)
+	m := e
+	for _, t := range x {
+		if t.Sign() != 0 && !t.IsInf() && t.MantExp(nil) > m {
+			m = t.MantExp(nil)
+		}
+	}
+
+	return uint(m - e)
+}
@@ -0,0 +1,91 @@
+package bigfloat_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ThreeAndTwo/bigfloat"
+)
+
+func TestPipeline(t *testing.T) {
+	exp := func(x []*big.Float, prec uint) *big.Float {
+		return bigfloat.Exp(new(big.Float).SetPrec(prec).Set(x[0]))
+	}
+	sub := func(x []*big.Float, prec uint) *big.Float {
+		// exp(x) - 1 is exact at the precision of exp(x)
+		t := new(big.Float).Sub(x[0], big.NewFloat(1))
+		return new(big.Float).SetPrec(prec).Sub(t, x[1])
+	}
+	quo := func(x []*big.Float, prec uint) *big.Float {
+		z := new(big.Float).SetPrec(prec).Quo(x[0], x[1])
+		return z.Quo(z, x[1])
+	}
+
+	// (exp(x) - 1 - x)/x² for x = 2**-40, about 1/2 + x/6, loses 80
+	// bits to the subtraction, all of them at low precisions
+	p := bigfloat.NewPipeline(1)
+	e := p.Add(bigfloat.Stage{Inputs: []int{0}, Func: exp})
+	d := p.Add(bigfloat.Stage{Inputs: []int{e, 0}, Func: sub, Lost: bigfloat.SumLost})
+	p.Add(bigfloat.Stage{Inputs: []int{d, 0}, Func: quo})
+	x := new(big.Float).SetMantExp(big.NewFloat(1), -40)
+	for _, prec := range []uint{24, 53, 200} {
+		want := bigfloat.Exp(new(big.Float).SetPrec(prec + 200).Set(x))
+		want.Sub(want, big.NewFloat(1)).Sub(want, x)
+		want.Quo(want, x).Quo(want, x).SetPrec(prec)
+
+		z, precs := p.Run([]*big.Float{x}, prec)
+		if z.Prec() != prec || bigfloat.CmpUlp(z, want, 1) != 0 {
+			t.Errorf("Run at prec %d = %g; want %g", prec, z, want)
+		}
+		// exp(x) needs the 80 bits lost by the subtraction, and 16
+		// guard bits
+		if len(precs) != 4 || precs[3] != prec || precs[2] != prec+8 || precs[e] < prec+80+16 || precs[0] < precs[e]+8 {
+			t.Errorf("Run at prec %d: precisions %v", prec, precs)
+		}
+	}
+
+	// a value that no stage takes, and an argument that no stage takes
+	q := bigfloat.NewPipeline(2)
+	q.Add(bigfloat.Stage{Inputs: []int{0}, Func: exp})
+	q.Add(bigfloat.Stage{Inputs: []int{0}, Func: exp})
+	z, precs := q.Run([]*big.Float{big.NewFloat(1), nil}, 53)
+	if want := bigfloat.E.Value(53); z.Cmp(want) != 0 || precs[1] != 0 || precs[2] != 53 || precs[3] != 53 || precs[0] != 61 {
+		t.Errorf("Run = %g, with the precisions %v", z, precs)
+	}
+
+	for _, f := range []func(){
+		func() { bigfloat.NewPipeline(-1) },
+		func() { bigfloat.NewPipeline(1).Add(bigfloat.Stage{Inputs: []int{1}, Func: exp}) },
+		func() { bigfloat.NewPipeline(1).Run([]*big.Float{x}, 53) },
+		func() { p.Run(nil, 53) },
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Error("invalid pipeline didn't panic")
+				}
+			}()
+			f()
+		}()
+	}
+}
+
+func TestSumLost(t *testing.T) {
+	f := big.NewFloat
+	for _, test := range []struct {
+		x    []*big.Float
+		z    *big.Float
+		want uint
+	}{
+		{[]*big.Float{f(1), f(1)}, f(2), 0},
+		{[]*big.Float{f(1), f(-0.75)}, f(0.25), 2},
+		{[]*big.Float{f(1 << 30), f(-(1<<30 - 1))}, f(1), 30},
+		{[]*big.Float{new(big.Float).SetInf(false), f(1)}, f(0.5), 1},
+		{[]*big.Float{f(1), new(big.Float).SetPrec(100).SetInt64(-1)}, f(0), 100},
+		{[]*big.Float{f(0), f(0)}, f(0), 0},
+	} {
+		if got := bigfloat.SumLost(test.x, test.z); got != test.want {
+			t.Errorf("SumLost(%v, %g) = %d; want %d", test.x, test.z, got, test.want)
+		}
+	}
+}
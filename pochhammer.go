@@ -0,0 +1,137 @@
+package bigfloat
+
+import (
+	"math/big"
+	"math/bits"
+)
+
+// pochDirect is the largest number of factors of Pochhammer which are
+// multiplied one by one; the remaining factors, all at least 1, come
+// from Stirling's series.
+const pochDirect = 1024
+
+// Pochhammer returns the rising factorial
+//
+//	(x)_n = x·(x+1)···(x+n-1) = Γ(x+n)/Γ(x)
+//
+// Precision is the same as the one of x (64 if it is 0). The factors
+// below 1, and a few more, are multiplied one by one, with guard bits
+// and their exponents added apart, so the result is accurate to about
+// the last bit; for large n, the product of the rest is computed
+// from the logarithms of Γ, with Stirling's series. The negative x of
+// large size are reflected first, from the negative factors to
+// positive ones, so that there are few factors below 1. (x)_0 = 1, and
+// (x)_n = 0 when x is an integer in [1-n, 0]. The result overflows to
+// ±Inf, or underflows to ±0, when it is out of the range of
+// big.Float.
+func Pochhammer(x *big.Float, n uint64) *big.Float {
+
+	prec := x.Prec()
+	if prec == 0 {
+		prec = 64
+	}
+	z := new(big.Float).SetPrec(prec)
+	if n == 0 {
+		return z.SetInt64(1)
+	}
+	if x.IsInf() {
+		// -Inf·-Inf···
+		return z.SetInf(x.Signbit() && n%2 == 1)
+	}
+
+	wprec := prec + 64 + uint(bits.Len64(n))
+	if x.Sign() < 0 && x.Cmp(big.NewFloat(-pochDirect)) < 0 {
+		return pochNegative(x, n, prec, wprec)
+	}
+
+	m := new(big.Float).SetPrec(wprec).SetInt64(1)
+	var e int64
+	y := new(big.Float).SetPrec(wprec)
+	one := big.NewFloat(1)
+	i := uint64(0)
+	for ; i < n; i++ {
+		y.Add(x, y.SetUint64(i))
+		if y.Cmp(one) >= 0 && n-i > pochDirect {
+			break
+		}
+		if y.Sign() == 0 {
+			return z
+		}
+		m.Mul(m, y)
+		e += int64(m.MantExp(m))
+	}
+
+	// (y)_(n-i) = exp(log Γ(y+n-i) - log Γ(y)), for y >= 1
+	if i < n {
+		// y+n-i is exact, even for a huge y
+		tprec := wprec + 64
+		if e := y.MantExp(nil); e > 0 {
+			tprec += uint(e)
+		}
+		t := new(big.Float).SetPrec(tprec).SetUint64(n - i)
+		t.Add(t, y)
+		l := lgammaStirling(t, int(wprec))
+		l.Sub(l, lgammaStirling(y, int(wprec)))
+		m.Mul(m, expLog(l, wprec))
+	}
+
+	if e < big.MinExp-1 || e > big.MaxExp+1 {
+		if e > 0 {
+			return z.SetInf(m.Signbit())
+		}
+		return withSign(z, m.Signbit())
+	}
+
+	return z.SetMantExp(m, int(e)).SetPrec(prec)
+}
+
+// pochNegative returns (x)_n for x < -pochDirect, with the reflection
+//
+//	(x)_n = (-1)**k·(1-x-k)_k·(x+k)_(n-k)
+//
+// for the number k of negative factors, at most n, so that both
+// Pochhammer symbols have positive arguments, and all but one of their
+// factors are at least 1. x+k and 1-x-k are exact at wprec bits,
+// unless x is far larger than n, and its rounding errors then far below
+// the ones of the products.
+func pochNegative(x *big.Float, n uint64, prec, wprec uint) *big.Float {
+
+	// k = min(n, ⌈-x⌉)
+	k := n
+	c := Ceil(new(big.Float).Neg(x))
+	if ck, acc := c.Uint64(); acc == big.Exact && ck < n {
+		k = ck
+	}
+
+	u := new(big.Float).SetPrec(wprec).SetUint64(k)
+	u.Add(u, x)
+	v := new(big.Float).SetPrec(wprec).Sub(big.NewFloat(1), u)
+	w := Pochhammer(u, n-k)
+	if w.Sign() == 0 {
+		// x is an integer, and a factor is 0
+		return new(big.Float).SetPrec(prec)
+	}
+	z := Pochhammer(v, k)
+	z.Mul(z, w)
+	if k%2 == 1 {
+		z.Neg(z)
+	}
+
+	return z.SetPrec(prec)
+}
+
+// FallingFactorial returns the falling factorial
+//
+//	x·(x-1)···(x-n+1) = (-1)**n·(-x)_n
+//
+// with the accuracy, the precision and the special cases of
+// Pochhammer.
+func FallingFactorial(x *big.Float, n uint64) *big.Float {
+
+	z := Pochhammer(new(big.Float).Neg(x), n)
+	if n%2 == 1 && z.Sign() != 0 {
+		z.Neg(z)
+	}
+
+	return z
+}
@@ -0,0 +1,137 @@
+package bigfloat_test
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/ThreeAndTwo/bigfloat"
+)
+
+func TestPochhammer(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for i := 0; i < 200; i++ {
+		prec := uint(24 + rnd.Intn(300))
+		x := new(big.Float).SetPrec(prec).SetFloat64(20 * rnd.NormFloat64())
+		n := uint64(rnd.Intn(50))
+		r, _ := x.Rat(nil)
+		rising, falling := big.NewRat(1, 1), big.NewRat(1, 1)
+		for j := uint64(0); j < n; j++ {
+			d := new(big.Rat).SetInt64(int64(j))
+			rising.Mul(rising, new(big.Rat).Add(r, d))
+			falling.Mul(falling, new(big.Rat).Sub(r, d))
+		}
+		want, _ := bigfloat.FromRat(rising, prec)
+		if got := bigfloat.Pochhammer(x, n); got.Prec() != prec || bigfloat.CmpUlp(got, want, 1) != 0 {
+			t.Errorf("Pochhammer(%g, %d) = %g; want %g", x, n, got, want)
+		}
+		want, _ = bigfloat.FromRat(falling, prec)
+		if got := bigfloat.FallingFactorial(x, n); got.Prec() != prec || bigfloat.CmpUlp(got, want, 1) != 0 {
+			t.Errorf("FallingFactorial(%g, %d) = %g; want %g", x, n, got, want)
+		}
+	}
+
+	// many factors, from Stirling's series: (1)_n = n!, and
+	// (n)_k = (n+k-1)!/(n-1)!
+	for _, prec := range []uint{53, 100, 500} {
+		for _, n := range []uint64{1000, 5000, 40000} {
+			want := bigfloat.Factorial(n, prec)
+			if got := bigfloat.Pochhammer(new(big.Float).SetPrec(prec).SetInt64(1), n); bigfloat.CmpUlp(got, want, 1) != 0 {
+				t.Errorf("Pochhammer(1, %d) at prec %d = %g; want %g", n, prec, got, want)
+			}
+			x := new(big.Float).SetPrec(prec).SetUint64(n)
+			want = new(big.Float).SetPrec(prec).SetInt(new(big.Int).MulRange(int64(n), int64(3*n-1)))
+			if got := bigfloat.Pochhammer(x, 2*n); bigfloat.CmpUlp(got, want, 1) != 0 {
+				t.Errorf("Pochhammer(%d, %d) at prec %d = %g; want %g", n, 2*n, prec, got, want)
+			}
+			want = new(big.Float).SetPrec(prec).SetInt(new(big.Int).MulRange(int64(n+1), int64(3*n)))
+			if got := bigfloat.FallingFactorial(x.SetUint64(3*n), 2*n); bigfloat.CmpUlp(got, want, 1) != 0 {
+				t.Errorf("FallingFactorial(%d, %d) at prec %d = %g; want %g", 3*n, 2*n, prec, got, want)
+			}
+		}
+	}
+
+	for _, test := range []struct {
+		x    float64
+		n    uint64
+		want float64
+	}{
+		{0, 0, 1},
+		{0, 3, 0},
+		{-3, 3, -6},
+		{-3, 4, 0},
+		{-3, 100000, 0},
+		{0.5, 2, 0.75},
+		{math.Inf(+1), 2, math.Inf(+1)},
+		{math.Inf(-1), 3, math.Inf(-1)},
+		{math.Inf(-1), 2, math.Inf(+1)},
+		{math.Inf(-1), 0, 1},
+	} {
+		got, _ := bigfloat.Pochhammer(big.NewFloat(test.x), test.n).Float64()
+		if got != test.want {
+			t.Errorf("Pochhammer(%g, %d) = %g; want %g", test.x, test.n, got, test.want)
+		}
+	}
+
+	// large negative x, reflected: the exact products of the factors
+	// x+i, with the ones below 1 taking about 1e15 steps directly
+	for _, test := range []struct {
+		x string
+		n uint64
+	}{
+		{"-1e15", 3},
+		{"-1e15", 2000},
+		{"-1000000000000000.5", 2000},
+		{"-2000.25", 5000},
+		{"-2000.25", 1500},
+		{"-1e300", 1100},
+	} {
+		for _, prec := range []uint{53, 200} {
+			x, _, _ := new(big.Float).SetPrec(prec).Parse(test.x, 10)
+			// x = m·2**-s, and (x)_n = Π (m + j·2**s)·2**(-s·n)
+			s := int(prec) - x.MantExp(nil)
+			if s < 0 {
+				s = 0
+			}
+			m, _ := new(big.Float).SetMantExp(x, s).Int(nil)
+			p := big.NewInt(1)
+			for j := uint64(0); j < test.n; j++ {
+				f := new(big.Int).Lsh(new(big.Int).SetUint64(j), uint(s))
+				p.Mul(p, f.Add(f, m))
+			}
+			want := new(big.Float).SetPrec(prec).SetInt(p)
+			want.SetMantExp(want, -s*int(test.n))
+			if got := bigfloat.Pochhammer(x, test.n); got.Prec() != prec || bigfloat.CmpUlp(got, want, 1) != 0 {
+				t.Errorf("Pochhammer(%s, %d) at prec %d = %g; want %g", test.x, test.n, prec, got, want)
+			}
+		}
+	}
+	if got := bigfloat.Pochhammer(big.NewFloat(-1e15), 2e15); got.Sign() != 0 {
+		t.Errorf("Pochhammer(-1e15, 2e15) = %g; want 0", got)
+	}
+
+	// out of range: (2**-1e9)_3 and (2**1e9)_3
+	x := new(big.Float).SetMantExp(big.NewFloat(1), -1e9)
+	if got := bigfloat.FallingFactorial(x, 3); got.Sign() == 0 || got.MantExp(nil) != -999999998 {
+		t.Errorf("FallingFactorial(2**-1e9, 3) has exponent %d; want -999999998", got.MantExp(nil))
+	}
+	if got := bigfloat.Pochhammer(x.SetMantExp(big.NewFloat(1), 1e9), 3); !got.IsInf() {
+		t.Errorf("Pochhammer(2**1e9, 3) has exponent %d; want +Inf", got.MantExp(nil))
+	}
+}
+
+// ---------- Benchmarks ----------
+
+func BenchmarkPochhammer(b *testing.B) {
+	for _, prec := range []uint{1e2, 1e3, 1e4} {
+		x := new(big.Float).SetPrec(prec).SetFloat64(0.5)
+		b.Run(fmt.Sprintf("%v", prec), func(b *testing.B) {
+			b.ReportAllocs()
+			for n := 0; n < b.N; n++ {
+				bigfloat.Pochhammer(x, 1e6)
+			}
+		})
+	}
+}
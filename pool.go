@@ -0,0 +1,221 @@
+package bigfloat
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sync"
+)
+
+// A Job is an evaluation done by a Pool: a function of Eval applied to
+// arguments, a Constant, or an expression of Eval.
+type Job struct {
+	ID   int          // returned with the result, to match them
+	Func string       // a function of Eval, as "exp", or a Constant, as "pi"
+	Args []*big.Float // the arguments of Func, none for a constant
+	Expr string       // an expression, evaluated with Eval if Func is ""
+	Prec uint         // precision of the result (64 if 0)
+
+	// MaxExp, if > 0, is the largest binary exponent of the arguments
+	// of sin, cos and tan, whose cost grows with it, so that the jobs
+	// of untrusted sources complete in a bounded time.
+	MaxExp int
+}
+
+// A Result is the outcome of a Job.
+type Result struct {
+	ID    int        // the ID of the job
+	Value *big.Float // the result, or nil if Err isn't nil, but for a *RangeError
+	Err   error      // a *JobError, or an *EvalError for an Expr, or a *RangeError
+}
+
+// A JobError records a failed Job: an unknown function, a wrong number
+// of arguments, or an argument outside the domain of the function.
+type JobError struct {
+	ID   int    // the ID of the job
+	Func string // the function of the job
+	Msg  string // description of the problem
+}
+
+func (e *JobError) Error() string {
+	return fmt.Sprintf("bigfloat.Pool: job %d: %s: %s", e.ID, e.Func, e.Msg)
+}
+
+// A Pool evaluates jobs with a bounded number of goroutines, and
+// sends their results on a channel as they complete, in any order.
+// The workers share a cache of the constants, so a constant used by
+// many jobs is computed once at the largest precision requested.
+//
+// A Pool is safe for concurrent use: several goroutines can submit
+// jobs while another one receives the results.
+type Pool struct {
+	jobs    chan Job
+	results chan Result
+	wg      sync.WaitGroup
+	consts  [len(constantNames)]constEntry
+}
+
+// constEntry is a constant computed by a Pool, at the largest
+// precision requested.
+type constEntry struct {
+	mu sync.Mutex
+	x  *big.Float
+}
+
+// NewPool returns a Pool with the given number of workers, ready to
+// accept jobs. The function panics if workers < 1.
+func NewPool(workers int) *Pool {
+
+	if workers < 1 {
+		panic("NewPool: workers < 1")
+	}
+
+	p := &Pool{
+		jobs:    make(chan Job),
+		results: make(chan Result, workers),
+	}
+	p.wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go func() {
+			defer p.wg.Done()
+			for j := range p.jobs {
+				p.results <- p.run(j)
+			}
+		}()
+	}
+	go func() {
+		p.wg.Wait()
+		close(p.results)
+	}()
+
+	return p
+}
+
+// Submit adds j to the jobs of p, and blocks until a worker takes it.
+// The workers block when the results aren't received, so Submit must
+// be called concurrently with the receives from Results. The arguments
+// of j must not be modified until its result is received. Submit
+// panics if p is closed.
+func (p *Pool) Submit(j Job) {
+	p.jobs <- j
+}
+
+// SubmitContext is like Submit, but gives up when ctx is done before
+// a worker takes j, and returns the error of ctx.
+func (p *Pool) SubmitContext(ctx context.Context, j Job) error {
+
+	select {
+	case p.jobs <- j:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Results returns the channel of the results of the submitted jobs,
+// which is closed after Close has been called and all the jobs have
+// completed.
+func (p *Pool) Results() <-chan Result {
+	return p.results
+}
+
+// Close tells p that no more jobs will be submitted.
+func (p *Pool) Close() {
+	close(p.jobs)
+}
+
+// RunJobs evaluates jobs with a new Pool of the given number of
+// workers, and returns their results in the order of jobs.
+func RunJobs(jobs []Job, workers int) []Result {
+
+	p := NewPool(workers)
+	go func() {
+		for i, j := range jobs {
+			// the index, to put the result in place
+			j.ID = i
+			p.Submit(j)
+		}
+		p.Close()
+	}()
+
+	res := make([]Result, len(jobs))
+	for r := range p.Results() {
+		i := r.ID
+		r.ID = jobs[i].ID
+		if e, ok := r.Err.(*JobError); ok {
+			e.ID = r.ID
+		}
+		res[i] = r
+	}
+
+	return res
+}
+
+// run evaluates j.
+func (p *Pool) run(j Job) (r Result) {
+
+	prec := j.Prec
+	if prec == 0 {
+		prec = 64
+	}
+
+	r.ID = j.ID
+	if j.Func == "" {
+		r.Value, r.Err = eval(j.Expr, nil, prec, j.MaxExp)
+		return r
+	}
+	if c, ok := ParseConstant(j.Func); ok && len(j.Args) == 0 {
+		r.Value = p.constant(c, prec)
+		return r
+	}
+
+	fail := func(msg string) Result {
+		return Result{ID: j.ID, Err: &JobError{j.ID, j.Func, msg}}
+	}
+	f, ok := evalFuncs[j.Func]
+	switch {
+	case !ok:
+		return fail("unknown function")
+	case len(j.Args) != f.args:
+		return fail(fmt.Sprintf("%d arguments, want %d", len(j.Args), f.args))
+	case argTooLarge(j.Func, j.Args, j.MaxExp):
+		return fail("argument too large")
+	}
+
+	// the functions get copies of the arguments with guard bits, as
+	// in Eval
+	args := make([]*big.Float, len(j.Args))
+	for i, a := range j.Args {
+		args[i] = new(big.Float).SetPrec(prec + 64).Set(a)
+	}
+	defer func() {
+		if e := recover(); e != nil {
+			switch e := e.(type) {
+			case string:
+				r = fail(e)
+			case big.ErrNaN:
+				r = fail(e.Error())
+			default:
+				panic(e)
+			}
+		}
+	}()
+	z := f.f(args)
+	r.Value = new(big.Float).SetPrec(prec).Set(z)
+	r.Err = checkRange(j.Func, z, j.Args...)
+
+	return r
+}
+
+// constant returns c to prec bits, from the cache of p.
+func (p *Pool) constant(c Constant, prec uint) *big.Float {
+
+	e := &p.consts[c]
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.x == nil || e.x.Prec() < prec {
+		e.x = c.Value(prec)
+	}
+
+	return new(big.Float).SetPrec(prec).Set(e.x)
+}
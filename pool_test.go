@@ -0,0 +1,173 @@
+package bigfloat_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ThreeAndTwo/bigfloat"
+)
+
+func TestRunJobs(t *testing.T) {
+	two := big.NewFloat(2)
+	jobs := []bigfloat.Job{
+		{ID: 10, Func: "sqrt", Args: []*big.Float{two}, Prec: 200},
+		{ID: 11, Func: "exp", Args: []*big.Float{two}, Prec: 100},
+		{ID: 12, Func: "pow", Args: []*big.Float{two, big.NewFloat(0.5)}, Prec: 300},
+		{ID: 13, Func: "pi", Prec: 2000},
+		{ID: 14, Func: "pi", Prec: 100},
+		{ID: 15, Func: "sin", Args: []*big.Float{big.NewFloat(1e10)}, Prec: 1500},
+		{ID: 16, Func: "abs", Args: []*big.Float{big.NewFloat(-3)}},
+		{ID: 17, Expr: "1/4 + 2", Prec: 10},
+	}
+	want := []*big.Float{
+		bigfloat.Sqrt(new(big.Float).SetPrec(200).SetInt64(2)),
+		bigfloat.Exp(new(big.Float).SetPrec(100).SetInt64(2)),
+		bigfloat.Sqrt(new(big.Float).SetPrec(300).SetInt64(2)),
+		bigfloat.Pi.Value(2000),
+		bigfloat.Pi.Value(100),
+		nil,
+		new(big.Float).SetPrec(64).SetInt64(3),
+		new(big.Float).SetPrec(10).SetFloat64(2.25),
+	}
+	sin, err := bigfloat.Eval("sin(1e10)", nil, 1500)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want[5] = sin
+
+	res := bigfloat.RunJobs(jobs, 3)
+	for i, r := range res {
+		if r.ID != jobs[i].ID || r.Err != nil {
+			t.Errorf("result %d: ID %d, error %v", i, r.ID, r.Err)
+			continue
+		}
+		if r.Value.Cmp(want[i]) != 0 || r.Value.Prec() != want[i].Prec() {
+			t.Errorf("%s = %g (prec %d); want %g (prec %d)", jobs[i].Func, r.Value, r.Value.Prec(), want[i], want[i].Prec())
+		}
+	}
+
+	// the arguments aren't modified
+	if two.Cmp(big.NewFloat(2)) != 0 || jobs[6].Args[0].Sign() > 0 {
+		t.Error("the arguments were modified")
+	}
+}
+
+func TestRunJobsErrors(t *testing.T) {
+	jobs := []bigfloat.Job{
+		{ID: 1, Func: "frobnicate", Args: []*big.Float{big.NewFloat(1)}},
+		{ID: 2, Func: "pow", Args: []*big.Float{big.NewFloat(1)}},
+		{ID: 3, Func: "sqrt", Args: []*big.Float{big.NewFloat(-1)}},
+		{ID: 4, Func: "log", Args: []*big.Float{big.NewFloat(-1)}},
+		{ID: 5, Func: "pi", Args: []*big.Float{big.NewFloat(1)}},
+	}
+	for i, r := range bigfloat.RunJobs(jobs, 2) {
+		var e *bigfloat.JobError
+		if !errors.As(r.Err, &e) || r.Value != nil {
+			t.Errorf("job %d: error %v, value %v; want a *JobError", jobs[i].ID, r.Err, r.Value)
+			continue
+		}
+		if e.ID != jobs[i].ID || e.Func != jobs[i].Func || r.ID != jobs[i].ID {
+			t.Errorf("job %d: error %v from job %d", jobs[i].ID, e, e.ID)
+		}
+	}
+}
+
+func TestRunJobsEvalError(t *testing.T) {
+	r := bigfloat.RunJobs([]bigfloat.Job{{ID: 7, Expr: "1 +"}}, 1)[0]
+	var e *bigfloat.EvalError
+	if !errors.As(r.Err, &e) || r.ID != 7 || e.Expr != "1 +" {
+		t.Errorf("RunJobs(1 +) = %v, %v; want an *EvalError", r.Value, r.Err)
+	}
+}
+
+func TestRunJobsMaxExp(t *testing.T) {
+	huge := new(big.Float).SetMantExp(big.NewFloat(1), 1e6)
+	jobs := []bigfloat.Job{
+		{ID: 1, Expr: "sin(1e1000000)", MaxExp: 1000},
+		{ID: 2, Expr: "1 + cos(2^1001)", MaxExp: 1000},
+		{ID: 3, Func: "tan", Args: []*big.Float{huge}, MaxExp: 1000},
+		{ID: 4, Expr: "sin(2^999)", MaxExp: 1000},
+		{ID: 5, Func: "sin", Args: []*big.Float{big.NewFloat(-1e300)}, MaxExp: 1000},
+	}
+	res := bigfloat.RunJobs(jobs, 2)
+	var e *bigfloat.EvalError
+	if !errors.As(res[0].Err, &e) || e.Offset != 0 {
+		t.Errorf("sin(1e1000000) = %v, %v; want an *EvalError at offset 0", res[0].Value, res[0].Err)
+	}
+	if !errors.As(res[1].Err, &e) || e.Offset != 4 {
+		t.Errorf("1 + cos(2^1001) = %v, %v; want an *EvalError at offset 4", res[1].Value, res[1].Err)
+	}
+	var je *bigfloat.JobError
+	if !errors.As(res[2].Err, &je) {
+		t.Errorf("tan(2**1e6) = %v, %v; want a *JobError", res[2].Value, res[2].Err)
+	}
+	for _, r := range res[3:] {
+		if r.Err != nil {
+			t.Errorf("job %d: error %v", r.ID, r.Err)
+		}
+	}
+}
+
+func TestPoolSubmitContext(t *testing.T) {
+	p := bigfloat.NewPool(1)
+	defer p.Close()
+
+	// the worker is blocked on the first result, which isn't received,
+	// so the third job is never taken
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	var err error
+	for i := 0; i < 3 && err == nil; i++ {
+		err = p.SubmitContext(ctx, bigfloat.Job{Expr: "1"})
+	}
+	if err != context.DeadlineExceeded {
+		t.Errorf("SubmitContext error = %v; want %v", err, context.DeadlineExceeded)
+	}
+	go func() {
+		for range p.Results() {
+		}
+	}()
+}
+
+func TestPool(t *testing.T) {
+	p := bigfloat.NewPool(4)
+	const n = 100
+	go func() {
+		for i := 0; i < n; i++ {
+			p.Submit(bigfloat.Job{ID: i, Func: "log", Args: []*big.Float{big.NewFloat(float64(i + 1))}, Prec: 1100 + uint(i)})
+		}
+		p.Close()
+	}()
+
+	seen := make(map[int]bool)
+	for r := range p.Results() {
+		x := new(big.Float).SetPrec(1100 + uint(r.ID)).SetInt64(int64(r.ID + 1))
+		if r.Err != nil || r.Value.Cmp(bigfloat.Log(x)) != 0 {
+			t.Errorf("job %d: %g, %v; want %g", r.ID, r.Value, r.Err, bigfloat.Log(x))
+		}
+		seen[r.ID] = true
+	}
+	if len(seen) != n {
+		t.Errorf("got %d results; want %d", len(seen), n)
+	}
+}
+
+// ---------- Benchmarks ----------
+
+func BenchmarkRunJobs(b *testing.B) {
+	jobs := make([]bigfloat.Job, 64)
+	for i := range jobs {
+		jobs[i] = bigfloat.Job{Func: "exp", Args: []*big.Float{big.NewFloat(float64(i))}, Prec: 1000}
+	}
+	for _, workers := range []int{1, 4} {
+		b.Run(fmt.Sprintf("%v", workers), func(b *testing.B) {
+			for n := 0; n < b.N; n++ {
+				_ = bigfloat.RunJobs(jobs, workers)
+			}
+		})
+	}
+}
@@ -0,0 +1,218 @@
+package bigfloat
+
+import "math/big"
+
+// positES is the number of exponent bits of the posits of the Posit
+// Standard (2022), whatever their size.
+const positES = 2
+
+// EncodePosit returns the encoding of x as a posit of n bits with es
+// exponent bits, in the low n bits of the result, and its accuracy.
+// A posit is the sign bit, then a regime of k+1 ones and a zero, or -k
+// zeros and a one, then es bits e and then the fraction f, for the
+// value ±2**(k·2**es + e)·(1 + f), with the negative values in two's
+// complement. The bits beyond the n are rounded to nearest even, as a
+// bit string, and the values out of range saturate: a nonzero x gives
+// at least the smallest posit of its sign, and at most the largest.
+// Infinities give NaR, the posit 100…0 that isn't a real, with an
+// accuracy of Exact. The function panics if n isn't in [2, 64], or es
+// is larger than 32.
+func EncodePosit(x *big.Float, n, es uint) (uint64, big.Accuracy) {
+
+	if n < 2 || n > 64 || es > 32 {
+		panic("EncodePosit: invalid format")
+	}
+
+	nar := uint64(1) << (n - 1)
+	switch {
+	case x.IsInf():
+		return nar, big.Exact
+	case x.Sign() == 0:
+		return 0, big.Exact
+	}
+
+	// |x| = 2**E·(1 + frac/2**(p-1)), with E = k·2**es + e
+	m := int64(n - 1) // the bits after the sign
+	mant := new(big.Float).SetMantExp(x, -x.MantExp(nil))
+	mant.Abs(mant)
+	p := mant.MinPrec()
+	e := int64(x.MantExp(nil) - 1)
+	k := e >> es
+	e -= k << es
+
+	var q *big.Int
+	switch {
+	case k >= m-1:
+		// the largest posit, or more
+		q = new(big.Int).SetUint64(nar - 1)
+	case k < -m:
+		q = big.NewInt(0)
+	default:
+		// the bit string S = regime, exponent, fraction, of L bits
+		s := new(big.Int)
+		var r int64
+		if k >= 0 {
+			r = k + 2
+			s.Lsh(big.NewInt(1), uint(k+1))
+			s.Sub(s, big.NewInt(1))
+			s.Lsh(s, 1)
+		} else {
+			r = -k + 1
+			s.SetInt64(1)
+		}
+		s.Lsh(s, es).Or(s, big.NewInt(e))
+		frac, _ := new(big.Float).SetMantExp(mant, int(p)).Int(nil)
+		frac.SetBit(frac, int(p-1), 0)
+		s.Lsh(s, p-1).Or(s, frac)
+		l := r + int64(es) + int64(p-1)
+
+		// the top m bits of S, rounded to nearest even
+		if l <= m {
+			q = s.Lsh(s, uint(m-l))
+		} else {
+			d := uint(l - m)
+			q = new(big.Int).Rsh(s, d)
+			rem := new(big.Int).Sub(s, new(big.Int).Lsh(q, d))
+			switch rem.Cmp(new(big.Int).Lsh(big.NewInt(1), d-1)) {
+			case 1:
+				q.Add(q, big.NewInt(1))
+			case 0:
+				if q.Bit(0) == 1 {
+					q.Add(q, big.NewInt(1))
+				}
+			}
+		}
+	}
+
+	// no rounding to 0, and none to NaR
+	b := q.Uint64()
+	switch {
+	case q.Sign() == 0:
+		b = 1
+	case b >= nar:
+		b = nar - 1
+	}
+	if x.Sign() < 0 {
+		b = (-b) & (nar<<1 - 1)
+	}
+
+	// the accuracy is found by comparing the encoded value with x
+	z, _ := DecodePosit(b, n, es)
+	var acc big.Accuracy
+	switch c := z.Cmp(x); {
+	case c < 0:
+		acc = big.Below
+	case c > 0:
+		acc = big.Above
+	}
+
+	return b, acc
+}
+
+// DecodePosit returns the value of the posit of n bits with es exponent
+// bits in the low n bits of b, with n bits of precision, which hold all
+// the posits exactly. The function returns ErrNaN if b is NaR, and
+// panics if n isn't in [2, 64], or es is larger than 32.
+func DecodePosit(b uint64, n, es uint) (*big.Float, error) {
+
+	if n < 2 || n > 64 || es > 32 {
+		panic("DecodePosit: invalid format")
+	}
+
+	mask := uint64(1)<<(n-1)<<1 - 1
+	b &= mask
+	z := new(big.Float).SetPrec(n)
+	switch {
+	case b == 0:
+		return z, nil
+	case b == 1<<(n-1):
+		return nil, ErrNaN
+	}
+	neg := b>>(n-1) == 1
+	if neg {
+		b = -b & mask
+	}
+
+	// the regime, from the bit n-2: a run of r bits v, and the bit
+	// that ends it, if there's room for it
+	i := int(n) - 2
+	v := b >> uint(i) & 1
+	r := 0
+	for i >= 0 && b>>uint(i)&1 == v {
+		r++
+		i--
+	}
+	k := int64(-r)
+	if v == 1 {
+		k = int64(r - 1)
+	}
+
+	// the exponent and the fraction, on the bits left after the end of
+	// the regime, with the bits of the exponent beyond them as zeros
+	left := i
+	if left < 0 {
+		left = 0
+	}
+	rest := b & (uint64(1)<<uint(left) - 1)
+	var e int64
+	var frac uint64
+	var fbits int
+	if left >= int(es) {
+		fbits = left - int(es)
+		e = int64(rest >> uint(fbits))
+		frac = rest & (uint64(1)<<uint(fbits) - 1)
+	} else {
+		e = int64(rest << uint(int(es)-left))
+	}
+
+	// 2**(k·2**es + e)·(2**fbits + frac)/2**fbits
+	z.SetUint64(uint64(1)<<uint(fbits) | frac)
+	z.SetMantExp(z, int(k<<es+e)-fbits)
+	if neg {
+		z.Neg(z)
+	}
+
+	return z, nil
+}
+
+// EncodePosit8 returns the encoding of x as a posit8 of the Posit
+// Standard, with 2 exponent bits, rounded as by EncodePosit, and its
+// accuracy.
+func EncodePosit8(x *big.Float) (uint8, big.Accuracy) {
+	b, acc := EncodePosit(x, 8, positES)
+	return uint8(b), acc
+}
+
+// DecodePosit8 returns the value of the posit8 b, with 2 exponent
+// bits. The function returns ErrNaN if b is NaR.
+func DecodePosit8(b uint8) (*big.Float, error) {
+	return DecodePosit(uint64(b), 8, positES)
+}
+
+// EncodePosit16 returns the encoding of x as a posit16 of the Posit
+// Standard, with 2 exponent bits, rounded as by EncodePosit, and its
+// accuracy.
+func EncodePosit16(x *big.Float) (uint16, big.Accuracy) {
+	b, acc := EncodePosit(x, 16, positES)
+	return uint16(b), acc
+}
+
+// DecodePosit16 returns the value of the posit16 b, with 2 exponent
+// bits. The function returns ErrNaN if b is NaR.
+func DecodePosit16(b uint16) (*big.Float, error) {
+	return DecodePosit(uint64(b), 16, positES)
+}
+
+// EncodePosit32 returns the encoding of x as a posit32 of the Posit
+// Standard, with 2 exponent bits, rounded as by EncodePosit, and its
+// accuracy.
+func EncodePosit32(x *big.Float) (uint32, big.Accuracy) {
+	b, acc := EncodePosit(x, 32, positES)
+	return uint32(b), acc
+}
+
+// DecodePosit32 returns the value of the posit32 b, with 2 exponent
+// bits. The function returns ErrNaN if b is NaR.
+func DecodePosit32(b uint32) (*big.Float, error) {
+	return DecodePosit(uint64(b), 32, positES)
+}
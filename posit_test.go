@@ -0,0 +1,113 @@
+package bigfloat_test
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/ThreeAndTwo/bigfloat"
+)
+
+func TestEncodePosit(t *testing.T) {
+	pi, _, _ := new(big.Float).SetPrec(200).Parse(piStr, 10)
+	for _, test := range []struct {
+		x    *big.Float
+		n    uint
+		want uint64
+		acc  big.Accuracy
+	}{
+		{big.NewFloat(0), 8, 0x00, big.Exact},
+		{big.NewFloat(1), 8, 0x40, big.Exact},
+		{big.NewFloat(-1), 8, 0xc0, big.Exact},
+		{big.NewFloat(0.5), 8, 0x38, big.Exact},
+		{big.NewFloat(2), 8, 0x48, big.Exact},
+		{pow2(24), 8, 0x7f, big.Exact},
+		{pow2(100), 8, 0x7f, big.Below},
+		{pow2(-24), 8, 0x01, big.Exact},
+		{pow2(-100), 8, 0x01, big.Above},
+		{new(big.Float).Neg(pow2(-100)), 8, 0xff, big.Below},
+		{new(big.Float).SetInf(true), 8, 0x80, big.Exact},
+		{pow2(56), 16, 0x7fff, big.Exact},
+		{pow2(-56), 16, 0x0001, big.Exact},
+		{big.NewFloat(1), 16, 0x4000, big.Exact},
+		{pi, 32, 0x4c90fdaa, big.Below},
+		{pow2(120), 32, 0x7fffffff, big.Exact},
+		{big.NewFloat(-1), 32, 0xc0000000, big.Exact},
+	} {
+		b, acc := bigfloat.EncodePosit(test.x, test.n, 2)
+		if b != test.want || acc != test.acc {
+			t.Errorf("EncodePosit(%g, %d, 2) = %#x (%v); want %#x (%v)", test.x, test.n, b, acc, test.want, test.acc)
+		}
+	}
+
+	if b, _ := bigfloat.EncodePosit8(big.NewFloat(1)); b != 0x40 {
+		t.Errorf("EncodePosit8(1) = %#x; want 0x40", b)
+	}
+	if b, _ := bigfloat.EncodePosit16(big.NewFloat(-2)); b != 0xb800 {
+		t.Errorf("EncodePosit16(-2) = %#x; want 0xb800", b)
+	}
+	if b, _ := bigfloat.EncodePosit32(big.NewFloat(0.5)); b != 0x38000000 {
+		t.Errorf("EncodePosit32(0.5) = %#x; want 0x38000000", b)
+	}
+}
+
+func TestPositRoundTrip(t *testing.T) {
+	for _, f := range []struct{ n, es uint }{{8, 0}, {8, 2}, {16, 1}, {16, 2}, {12, 3}} {
+		var prev *big.Float
+		// in the order of the signed integers, from the smallest
+		// negative, the posits increase
+		for i := 1; i < 1<<f.n; i++ {
+			b := uint64(i+1<<(f.n-1)) & (1<<f.n - 1)
+			z, err := bigfloat.DecodePosit(b, f.n, f.es)
+			if err != nil {
+				t.Fatalf("DecodePosit(%#x, %d, %d): %v", b, f.n, f.es, err)
+			}
+			if e, acc := bigfloat.EncodePosit(z, f.n, f.es); e != b || acc != big.Exact {
+				t.Fatalf("EncodePosit(DecodePosit(%#x, %d, %d)) = %#x (%v)", b, f.n, f.es, e, acc)
+			}
+			if prev != nil && prev.Cmp(z) >= 0 {
+				t.Fatalf("posit<%d, %d> %#x = %g isn't larger than the one before, %g", f.n, f.es, b, z, prev)
+			}
+			prev = z
+		}
+		if _, err := bigfloat.DecodePosit(1<<(f.n-1), f.n, f.es); err != bigfloat.ErrNaN {
+			t.Errorf("DecodePosit(NaR, %d, %d) = %v; want ErrNaN", f.n, f.es, err)
+		}
+	}
+}
+
+func TestPositRounding(t *testing.T) {
+	// within [1/16, 16], posit16 has at least 9 bits of fraction, and
+	// rounds to nearest
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 10000; i++ {
+		x := big.NewFloat(math.Ldexp(r.Float64()+0.5, r.Intn(8)-3))
+		b, acc := bigfloat.EncodePosit16(x)
+		z, _ := bigfloat.DecodePosit16(b)
+		lo, _ := bigfloat.DecodePosit16(b - 1)
+		hi, _ := bigfloat.DecodePosit16(b + 1)
+		d := new(big.Float).Sub(x, z)
+		d.Abs(d)
+		dlo := new(big.Float).Sub(x, lo)
+		dhi := new(big.Float).Sub(hi, x)
+		if d.Cmp(dlo) > 0 || d.Cmp(dhi) > 0 || acc != big.Accuracy(z.Cmp(x)) {
+			t.Fatalf("EncodePosit16(%g) = %#x = %g (%v), between %g and %g", x, b, z, acc, lo, hi)
+		}
+	}
+}
+
+// ---------- Benchmarks ----------
+
+func BenchmarkEncodePosit(b *testing.B) {
+	x := bigfloat.Sqrt(new(big.Float).SetPrec(200).SetInt64(2))
+	for _, n := range []uint{8, 16, 32} {
+		b.Run(fmt.Sprintf("%v", n), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				bigfloat.EncodePosit(x, n, 2)
+			}
+		})
+	}
+}
@@ -7,8 +7,15 @@ import (
 
 // Pow returns a big.Float representation of z**w. Precision is the same as the one
 // of the first argument. The function panics when z is negative.
+//
+// When z**w is exactly representable at the precision of z, as
+// Pow(4, 0.5) = 2 and Pow(3, 20) are at 53 bits, it's computed
+// exactly, and the accuracy of the result, returned by its Acc method,
+// is big.Exact.
 func Pow(z *big.Float, w *big.Float) *big.Float {
 
+	defer trace("Pow", z.Prec())()
+
 	if z.Sign() < 0 {
 		// z 值为负数，转为 float64
 		zz, _ := z.Float64()
@@ -31,6 +38,15 @@ func Pow(z *big.Float, w *big.Float) *big.Float {
 		return new(big.Float).Copy(z)
 	}
 
+	// Pow(2**k, n) = 2**(k·n), exactly
+	if IsPowerOfTwo(z) && IsInteger(w) {
+		return powTwo(z, w)
+	}
+
+	if x, ok := powExact(z, w); ok {
+		return x
+	}
+
 	// Pow(z, -w) = 1 / Pow(z, w)
 	if w.Sign() < 0 {
 		x := new(big.Float)
@@ -55,6 +71,100 @@ func Pow(z *big.Float, w *big.Float) *big.Float {
 
 }
 
+// powTwo returns z**w for z a power of two and w an integer, at the
+// precision of z, overflowing to +Inf and underflowing to 0.
+func powTwo(z, w *big.Float) *big.Float {
+
+	// z = 0.5·2**e = 2**(e-1)
+	k := int64(z.MantExp(nil) - 1)
+	n, acc := w.Int64()
+
+	x := big.NewFloat(0.5).SetPrec(z.Prec())
+	switch {
+	case k == 0:
+		return x.SetInt64(1)
+	case acc != big.Exact || n > math.MaxInt32 || n < math.MinInt32:
+		// |k·n| is at least 2**31
+		if (k > 0) == (w.Sign() > 0) {
+			return x.SetInf(false)
+		}
+		return x.SetInt64(0)
+	}
+
+	// |k| and |n| are both below 2**32 here
+	e := k*n + 1
+	switch {
+	case e > big.MaxExp:
+		return x.SetInf(false)
+	case e < big.MinExp:
+		return x.SetInt64(0)
+	}
+
+	return x.SetMantExp(x, int(e))
+}
+
+// powExact returns z**w at the precision of z, and true, if it's
+// exactly representable there, for a finite z > 0 and w != 0.
+func powExact(z, w *big.Float) (*big.Float, bool) {
+
+	if z.Sign() <= 0 || w.IsInf() {
+		return nil, false
+	}
+
+	// z = m·2**e and w = p·2**k, with m and p odd, and k < 0 for a
+	// non-integer w
+	m, e := intMantExp(z)
+	p, k := intMantExp(w)
+	if k >= 0 {
+		p.Lsh(p, uint(k))
+		k = 0
+	}
+
+	// z**(1/2**-k) is exact only if m is a perfect square -k times, and
+	// e is even as many times; m halves its bits every time, so the
+	// loop is short unless m is 1
+	for ; k < 0; k++ {
+		if e%2 != 0 {
+			return nil, false
+		}
+		if m.Cmp(big.NewInt(1)) != 0 {
+			s := new(big.Int).Sqrt(m)
+			if new(big.Int).Mul(s, s).Cmp(m) != 0 {
+				return nil, false
+			}
+			m = s
+		} else if e == 0 {
+			break
+		}
+		e /= 2
+	}
+
+	// (m·2**e)**p, where m**p has at least (bits(m) - 1)·p + 1 bits, and
+	// can't be exact for p < 0 unless m is 1
+	prec := z.Prec()
+	mbits := int64(m.BitLen())
+	if p.Sign() < 0 && mbits > 1 || !p.IsInt64() ||
+		mbits > 1 && (abs64(p.Int64()) > int64(prec) || (mbits-1)*abs64(p.Int64()) >= int64(prec)) {
+		return nil, false
+	}
+	n := p.Int64()
+	mp := big.NewInt(1)
+	if mbits > 1 {
+		mp.Exp(m, big.NewInt(n), nil)
+	}
+	ep := new(big.Int).Mul(big.NewInt(int64(e)), p)
+	if mp.BitLen() > int(prec) || !ep.IsInt64() {
+		return nil, false
+	}
+	if x := ep.Int64() + int64(mp.BitLen()); x > big.MaxExp || x < big.MinExp {
+		return nil, false
+	}
+
+	x := new(big.Float).SetPrec(prec).SetInt(mp)
+
+	return x.SetMantExp(x, int(ep.Int64())), true
+}
+
 // fast path for z**w when w is an integer
 func powInt(z *big.Float, w int) *big.Float {
 
@@ -72,6 +72,27 @@ func TestPowIntegers(t *testing.T) {
 	}
 }
 
+func TestPowPowersOfTwo(t *testing.T) {
+	for _, test := range []struct {
+		z, w float64
+		want string
+	}{
+		{2, 100, "0x.8p+101"},
+		{0.25, -3, "0x.8p+7"},
+		{1, 1e15, "0x.8p+1"},
+		{1024, 3e8, "+Inf"},
+		{1024, -3e8, "0"},
+		{0x1p-1000, 0x1p40, "0"},
+		{0x1p-1000, -0x1p40, "+Inf"},
+	} {
+		z := big.NewFloat(test.z).SetPrec(1000)
+		x := bigfloat.Pow(z, big.NewFloat(test.w))
+		if got := x.Text('p', 0); got != test.want || x.Prec() != 1000 {
+			t.Errorf("Pow(%g, %g) = %s (prec %d); want %s", test.z, test.w, got, x.Prec(), test.want)
+		}
+	}
+}
+
 func testPowFloat64(scale float64, nTests int, t *testing.T) {
 	for i := 0; i < nTests; i++ {
 		r1 := math.Abs(rand.Float64() * scale) // base always > 0
@@ -131,6 +152,43 @@ func TestPowSpecialValues(t *testing.T) {
 	}
 }
 
+func TestPowExact(t *testing.T) {
+	for _, test := range []struct {
+		z, w  string
+		prec  uint
+		want  string
+		exact bool
+	}{
+		{"4", "0.5", 53, "2", true},
+		{"2.25", "0.5", 53, "1.5", true},
+		{"6.25", "1.5", 53, "15.625", true},
+		{"16", "0.25", 53, "2", true},
+		{"16", "-0.25", 53, "0.5", true},
+		{"0.25", "1.5", 53, "0.125", true},
+		{"3", "20", 53, "3486784401", true},
+		{"10", "3", 53, "1000", true},
+		{"1.5", "8", 53, "25.62890625", true},
+		{"1", "0.3", 53, "1", true},
+		{"2", "100", 53, "1267650600228229401496703205376", true},
+		{"3", "40", 64, "12157665459056928801", true},
+
+		// not representable
+		{"3", "40", 53, "12157665459056928801", false},
+		{"10", "-3", 200, "0.001", false},
+		{"9", "-0.5", 53, "0.333333333333333333", false},
+		{"2", "0.5", 53, "1.41421356237309504880", false},
+		{"8", "0.3", 53, "1.86606598307361482", false},
+	} {
+		z, _, _ := new(big.Float).SetPrec(test.prec).Parse(test.z, 10)
+		w, _, _ := new(big.Float).SetPrec(test.prec).Parse(test.w, 10)
+		want, _, _ := new(big.Float).SetPrec(test.prec).Parse(test.want, 10)
+		x := bigfloat.Pow(z, w)
+		if x.Cmp(want) != 0 || (x.Acc() == big.Exact) != test.exact {
+			t.Errorf("prec = %d, Pow(%s, %s) = %g (%s); want %g, exact %v", test.prec, test.z, test.w, x, x.Acc(), want, test.exact)
+		}
+	}
+}
+
 // ---------- Benchmarks ----------
 
 func BenchmarkPowInt(b *testing.B) {
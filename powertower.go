@@ -0,0 +1,100 @@
+package bigfloat
+
+import (
+	"math/big"
+)
+
+// PowerTower returns the power tower x↑↑height = x**x**...**x, with
+// height copies of x, evaluated from the top, to prec bits (64 if
+// prec is 0), for x >= 0. x↑↑0 = 1, and 0↑↑n alternates between 1 and
+// 0, since 0**0 = 1.
+//
+// The towers of x > e**(1/e) soon go out of the range of big.Float;
+// then logs is the number of natural logarithms taken of the tower to
+// bring it back in range:
+//
+//	x↑↑height = exp(exp(...exp(z)...))
+//
+// with logs exponentials. logs is 0 when the tower itself is in range.
+// Every exponentiation multiplies the relative error by the size of
+// its exponent, and the tower is computed again with the bits so lost,
+// so z is accurate to about the last bit. The function panics if x is
+// negative or height is negative.
+func PowerTower(x *big.Float, height int, prec uint) (z *big.Float, logs int) {
+
+	if prec == 0 {
+		prec = 64
+	}
+	switch {
+	case x.Sign() < 0:
+		panic("PowerTower: argument is negative")
+	case height < 0:
+		panic("PowerTower: height < 0")
+	}
+
+	z = new(big.Float).SetPrec(prec)
+	switch {
+	case height == 0:
+		return z.SetInt64(1), 0
+	case x.Sign() == 0:
+		return z.SetInt64(int64(1 - height%2)), 0
+	case x.IsInf():
+		return z.SetInf(false), 0
+	}
+
+	wprec := prec + 64
+	for {
+		v, j, lost := powerTower(x, height, wprec)
+		if wprec >= prec+64+lost {
+			return z.Set(v), j
+		}
+		wprec = prec + 64 + lost
+	}
+}
+
+// powerTower returns x↑↑height as exp applied j times to v, computed
+// at precision prec, and the number of bits lost by the
+// exponentiations.
+func powerTower(x *big.Float, height int, prec uint) (v *big.Float, j int, lost uint) {
+
+	c := Log(new(big.Float).SetPrec(prec).Set(x))
+	v = new(big.Float).SetPrec(prec).Set(x)
+	u := new(big.Float).SetPrec(prec)
+	for i := 1; i < height; i++ {
+		switch j {
+		case 0:
+			// x**t = exp(c·t)
+			u.Mul(c, v)
+			if t := expLog(u, prec); !t.IsInf() {
+				lost += expLost(u)
+				v = t
+				continue
+			}
+			v.Set(u)
+			j = 1
+		case 1:
+			// t = exp(v) is out of range, and so is log(x**t) = c·t,
+			// but log(log(x**t)) = log(c) + v is not
+			v.Add(v, Log(c))
+			j = 2
+		default:
+			// log^(j)(x**t) = log^(j-1)(t) + log^(j-2)(1 + log(c)/log(t)),
+			// where log(t) is out of range, and so the correction is
+			// too small for any precision
+			j++
+		}
+	}
+
+	return v, j, lost
+}
+
+// expLost returns the bits of relative accuracy lost by exp(u), whose
+// condition number is |u|.
+func expLost(u *big.Float) uint {
+
+	if e := u.MantExp(nil); e > 0 {
+		return uint(e)
+	}
+
+	return 0
+}
@@ -0,0 +1,101 @@
+package bigfloat_test
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/ThreeAndTwo/bigfloat"
+)
+
+func TestPowerTower(t *testing.T) {
+	for _, test := range []struct {
+		x      float64
+		height int
+		want   float64
+	}{
+		{2, 0, 1},
+		{2, 1, 2},
+		{2, 2, 4},
+		{2, 3, 16},
+		{2, 4, 65536},
+		{3, 3, 7625597484987},
+		{0.5, 2, 0.7071067811865476},
+		{1, 100, 1},
+		{0, 1, 0},
+		{0, 2, 1},
+		{0, 3, 0},
+	} {
+		z, logs := bigfloat.PowerTower(big.NewFloat(test.x), test.height, 53)
+		if got, _ := z.Float64(); got != test.want || logs != 0 {
+			t.Errorf("PowerTower(%g, %d) = %g, %d; want %g, 0", test.x, test.height, got, logs, test.want)
+		}
+	}
+
+	// 2↑↑5 = 2**65536 is in range, 2↑↑6 = 2**2**65536 is not
+	const prec = 200
+	two := new(big.Float).SetPrec(prec).SetInt64(2)
+	if z, logs := bigfloat.PowerTower(two, 5, prec); logs != 0 || z.Cmp(new(big.Float).SetMantExp(big.NewFloat(1), 65536)) != 0 {
+		t.Errorf("PowerTower(2, 5) has exponent %d, %d; want 65537, 0", z.MantExp(nil), logs)
+	}
+	ln2 := bigfloat.Ln2.Value(prec + 64)
+	want := new(big.Float).SetMantExp(ln2, 65536).SetPrec(prec)
+	if z, logs := bigfloat.PowerTower(two, 6, prec); logs != 1 || bigfloat.CmpUlp(z, want, 1) != 0 {
+		t.Errorf("PowerTower(2, 6) = %g, %d; want %g, 1", z, logs, want)
+	}
+
+	// log(log(2↑↑7)) = 2**65536·log(2) + log(log(2)), and it stays
+	// the same further up
+	want = new(big.Float).SetMantExp(ln2, 65536)
+	want.Add(want, bigfloat.Log(bigfloat.Ln2.Value(prec+64))).SetPrec(prec)
+	for h := 7; h < 10; h++ {
+		if z, logs := bigfloat.PowerTower(two, h, prec); logs != h-5 || bigfloat.CmpUlp(z, want, 1) != 0 {
+			t.Errorf("PowerTower(2, %d) = %g, %d; want %g, %d", h, z, logs, want, h-5)
+		}
+	}
+
+	// log(3↑↑4) = 3↑↑3·log(3), with 3↑↑4 = 3**7625597484987
+	three := new(big.Float).SetPrec(prec).SetInt64(3)
+	want = bigfloat.Log(new(big.Float).SetPrec(prec + 64).Set(three))
+	want.Mul(want, big.NewFloat(7625597484987)).SetPrec(prec)
+	if z, logs := bigfloat.PowerTower(three, 4, prec); logs != 1 || bigfloat.CmpUlp(z, want, 1) != 0 {
+		t.Errorf("PowerTower(3, 4) = %g, %d; want %g, 1", z, logs, want)
+	}
+
+	// √2↑↑n converges to 2, with an error of about log(2)**n
+	sqrt2 := bigfloat.Sqrt2.Value(prec)
+	if z, logs := bigfloat.PowerTower(sqrt2, 1000, prec); logs != 0 || bigfloat.CmpUlp(z, two, 1) != 0 {
+		t.Errorf("PowerTower(√2, 1000) = %g, %d; want 2, 0", z, logs)
+	}
+
+	for _, test := range []struct {
+		x      float64
+		height int
+	}{
+		{-1, 2},
+		{2, -1},
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("PowerTower(%g, %d) didn't panic", test.x, test.height)
+				}
+			}()
+			bigfloat.PowerTower(big.NewFloat(test.x), test.height, 53)
+		}()
+	}
+}
+
+// ---------- Benchmarks ----------
+
+func BenchmarkPowerTower(b *testing.B) {
+	for _, prec := range []uint{1e2, 1e3, 1e4} {
+		x := new(big.Float).SetPrec(prec).SetFloat64(1.4)
+		b.Run(fmt.Sprintf("%v", prec), func(b *testing.B) {
+			b.ReportAllocs()
+			for n := 0; n < b.N; n++ {
+				bigfloat.PowerTower(x, 100, prec)
+			}
+		})
+	}
+}
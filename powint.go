@@ -0,0 +1,73 @@
+package bigfloat
+
+import (
+	"math/big"
+)
+
+// PowInt returns x**n for an integer n of any size. Precision is the
+// same as the one of x. The result is computed by repeated squaring,
+// left to right, at a precision increased by the number of bits of n,
+// since every squaring doubles the relative error of the previous
+// steps, so it's accurate to about the last bit. When x**n is exactly
+// representable, it's computed exactly, as in Pow.
+//
+// The result is negative for x < 0 and an odd n. It is 1 for n = 0,
+// even when x is 0 or infinite, and it overflows to ±Inf, or
+// underflows to ±0, when its exponent is outside the range of
+// big.Float.
+func PowInt(x *big.Float, n *big.Int) *big.Float {
+
+	prec := x.Prec()
+	z := new(big.Float).SetPrec(prec)
+	if n.Sign() == 0 {
+		return z.SetInt64(1)
+	}
+	m := new(big.Int).Abs(n)
+	neg := x.Signbit() && m.Bit(0) == 1
+
+	// 0**n and Inf**n are 0 or Inf
+	if x.Sign() == 0 || x.IsInf() {
+		if (x.Sign() == 0) == (n.Sign() < 0) {
+			z.SetInf(false)
+		}
+		return withSign(z, neg)
+	}
+
+	a := new(big.Float).Abs(x)
+	if y, ok := powExact(a, new(big.Float).SetInt(n)); ok {
+		return withSign(z.Set(y), neg)
+	}
+
+	// x**-n = (1/x)**n, where the error of 1/x grows like the ones of
+	// the squarings
+	wprec := prec + uint(m.BitLen()) + 64
+	b := new(big.Float).SetPrec(wprec).Set(a)
+	if n.Sign() < 0 {
+		b.Quo(big.NewFloat(1), b)
+	}
+	y := new(big.Float).SetPrec(wprec).Set(b)
+	for i := m.BitLen() - 2; i >= 0; i-- {
+		y.Mul(y, y)
+		if m.Bit(i) == 1 {
+			y.Mul(y, b)
+		}
+		if y.Sign() == 0 || y.IsInf() {
+			// out of the range of big.Float
+			break
+		}
+	}
+
+	return withSign(z.Set(y), neg)
+}
+
+// withSign returns z with the absolute value of z, negated if neg is
+// true.
+func withSign(z *big.Float, neg bool) *big.Float {
+
+	z.Abs(z)
+	if neg {
+		z.Neg(z)
+	}
+
+	return z
+}
@@ -0,0 +1,147 @@
+package bigfloat_test
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/ThreeAndTwo/bigfloat"
+)
+
+// powRat returns x**n exactly, as a big.Rat.
+func powRat(x *big.Float, n int64) *big.Rat {
+	r, _ := x.Rat(nil)
+	num := new(big.Int).Exp(r.Num(), big.NewInt(absInt64(n)), nil)
+	den := new(big.Int).Exp(r.Denom(), big.NewInt(absInt64(n)), nil)
+	if n < 0 {
+		num, den = den, num
+	}
+	if den.Sign() < 0 {
+		num.Neg(num)
+		den.Neg(den)
+	}
+	return new(big.Rat).SetFrac(num, den)
+}
+
+func absInt64(n int64) int64 {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+func TestPowInt(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for i := 0; i < 300; i++ {
+		prec := uint(24 + rnd.Intn(500))
+		x := new(big.Float).SetPrec(prec).SetFloat64(rnd.NormFloat64() * 10)
+		n := rnd.Int63n(400) - 200
+		got := bigfloat.PowInt(x, big.NewInt(n))
+		want, _ := bigfloat.FromRat(powRat(x, n), prec)
+		if got.Prec() != prec || bigfloat.CmpUlp(got, want, 1) != 0 {
+			t.Errorf("PowInt(%g, %d) at prec %d = %g; want %g", x, n, prec, got, want)
+		}
+	}
+
+	// exact results
+	for _, test := range []struct {
+		x    float64
+		n    int64
+		want float64
+	}{
+		{3, 20, 3486784401},
+		{-3, 3, -27},
+		{-2, -3, -0.125},
+		{0.5, 10, 1.0 / 1024},
+		{1.5, 2, 2.25},
+	} {
+		got := bigfloat.PowInt(big.NewFloat(test.x), big.NewInt(test.n))
+		if f, acc := got.Float64(); f != test.want || acc != big.Exact || got.Acc() != big.Exact {
+			t.Errorf("PowInt(%g, %d) = %g (%v); want %g (Exact)", test.x, test.n, got, got.Acc(), test.want)
+		}
+	}
+}
+
+func TestPowIntHuge(t *testing.T) {
+	// (1 + 2**-100)**(2**80) = exp(2**80·log(1 + 2**-100)), beyond
+	// int64 exponents with a base close to 1
+	const prec = 200
+	x := new(big.Float).SetPrec(prec).SetMantExp(big.NewFloat(1), -100)
+	x.Add(x, big.NewFloat(1))
+	n := new(big.Int).Lsh(big.NewInt(1), 80)
+	got := bigfloat.PowInt(x, n)
+	w := new(big.Float).SetPrec(prec + 200).Set(x)
+	w = bigfloat.Log(w)
+	w.Mul(w, new(big.Float).SetInt(n))
+	want := bigfloat.Exp(w).SetPrec(prec)
+	if bigfloat.CmpUlp(got, want, 1) != 0 {
+		t.Errorf("PowInt(1 + 2**-100, 2**80) = %g; want %g", got, want)
+	}
+
+	// x**(2**100 + 1) overflows or underflows, with the sign of x
+	n.Lsh(big.NewInt(1), 100).Add(n, big.NewInt(1))
+	for _, test := range []struct {
+		x    float64
+		n    *big.Int
+		want float64
+	}{
+		{2, n, math.Inf(+1)},
+		{-1.5, n, math.Inf(-1)},
+		{0.5, n, 0},
+		{-0.5, n, math.Copysign(0, -1)},
+		{-2, new(big.Int).Neg(n), math.Copysign(0, -1)},
+		{1, n, 1},
+		{-1, n, -1},
+		{-1, new(big.Int).Add(n, big.NewInt(1)), 1},
+	} {
+		got, _ := bigfloat.PowInt(big.NewFloat(test.x), test.n).Float64()
+		if got != test.want || math.Signbit(got) != math.Signbit(test.want) {
+			t.Errorf("PowInt(%g, %v) = %g; want %g", test.x, test.n, got, test.want)
+		}
+	}
+}
+
+func TestPowIntSpecial(t *testing.T) {
+	negZero := math.Copysign(0, -1)
+	for _, test := range []struct {
+		x    float64
+		n    int64
+		want float64
+	}{
+		{0, 0, 1},
+		{math.Inf(+1), 0, 1},
+		{0, 3, 0},
+		{negZero, 3, negZero},
+		{negZero, 2, 0},
+		{0, -2, math.Inf(+1)},
+		{negZero, -3, math.Inf(-1)},
+		{math.Inf(+1), 2, math.Inf(+1)},
+		{math.Inf(-1), 3, math.Inf(-1)},
+		{math.Inf(-1), -3, negZero},
+		{math.Inf(+1), -2, 0},
+	} {
+		got, _ := bigfloat.PowInt(big.NewFloat(test.x), big.NewInt(test.n)).Float64()
+		if got != test.want || math.Signbit(got) != math.Signbit(test.want) {
+			t.Errorf("PowInt(%g, %d) = %g; want %g", test.x, test.n, got, test.want)
+		}
+	}
+}
+
+// ---------- Benchmarks ----------
+
+func BenchmarkPowIntBig(b *testing.B) {
+	n := new(big.Int).Lsh(big.NewInt(1), 100)
+	n.Sub(n, big.NewInt(1))
+	for _, prec := range []uint{1e2, 1e3, 1e4} {
+		x := new(big.Float).SetPrec(prec).SetMantExp(big.NewFloat(1), -120)
+		x.Add(x, big.NewFloat(1))
+		b.Run(fmt.Sprintf("%v", prec), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				bigfloat.PowInt(x, n)
+			}
+		})
+	}
+}
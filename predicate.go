@@ -0,0 +1,29 @@
+package bigfloat
+
+import "math/big"
+
+// IsInteger reports whether x is an integer. ±Inf are not integers.
+// Like x.IsInt, it only looks at the exponent and at the trailing
+// zeros of the mantissa.
+func IsInteger(x *big.Float) bool {
+
+	if x.IsInf() {
+		return false
+	}
+	if x.Sign() == 0 {
+		return true
+	}
+
+	// x = mant·2**exp, with 0.5 <= |mant| < 1, and the mantissa has
+	// MinPrec significant bits
+	exp := x.MantExp(nil)
+
+	return exp > 0 && x.MinPrec() <= uint(exp)
+}
+
+// IsPowerOfTwo reports whether x is an integer power of two, 2**k for
+// some integer k, possibly negative. Zero, negative values and ±Inf
+// are not powers of two.
+func IsPowerOfTwo(x *big.Float) bool {
+	return x.Sign() > 0 && !x.IsInf() && x.MinPrec() == 1
+}
@@ -0,0 +1,59 @@
+package bigfloat_test
+
+import (
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/ThreeAndTwo/bigfloat"
+)
+
+func TestIsInteger(t *testing.T) {
+	huge := new(big.Float).SetPrec(10).SetInt64(3)
+	huge.SetMantExp(huge, 100000)
+	intPlusHalf := new(big.Float).SetPrec(200).SetInt64(1)
+	intPlusHalf.SetMantExp(intPlusHalf, 150).Add(intPlusHalf, big.NewFloat(0.5))
+
+	for _, test := range []struct {
+		x    *big.Float
+		want bool
+	}{
+		{big.NewFloat(0), true},
+		{big.NewFloat(math.Copysign(0, -1)), true},
+		{big.NewFloat(1), true},
+		{big.NewFloat(-7), true},
+		{big.NewFloat(0.5), false},
+		{big.NewFloat(-2.5), false},
+		{big.NewFloat(1 << 62), true},
+		{big.NewFloat(1e300), true},
+		{big.NewFloat(1e-300), false},
+		{huge, true},
+		{intPlusHalf, false},
+		{new(big.Float).SetInf(false), false},
+	} {
+		if got := bigfloat.IsInteger(test.x); got != test.want || got != test.x.IsInt() {
+			t.Errorf("IsInteger(%s) = %v; want %v", test.x.Text('p', 0), got, test.want)
+		}
+	}
+}
+
+func TestIsPowerOfTwo(t *testing.T) {
+	for _, test := range []struct {
+		x    *big.Float
+		want bool
+	}{
+		{big.NewFloat(1), true},
+		{big.NewFloat(2), true},
+		{big.NewFloat(0.125), true},
+		{big.NewFloat(0x1p-1000), true},
+		{big.NewFloat(3), false},
+		{big.NewFloat(0.75), false},
+		{big.NewFloat(-2), false},
+		{big.NewFloat(0), false},
+		{new(big.Float).SetInf(false), false},
+	} {
+		if got := bigfloat.IsPowerOfTwo(test.x); got != test.want {
+			t.Errorf("IsPowerOfTwo(%g) = %v; want %v", test.x, got, test.want)
+		}
+	}
+}
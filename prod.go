@@ -0,0 +1,88 @@
+package bigfloat
+
+import (
+	"math/big"
+	"math/bits"
+)
+
+// Prod returns the product of the elements of xs, rounded to the
+// largest precision of the elements of xs. The product of an empty
+// slice is 1. The result is ±Inf or ±0 only if the product itself is
+// out of the range of big.Float, whatever the partial products are;
+// ProdExp gives the exponent of any product. The function panics if
+// xs contains both a zero and an infinity.
+func Prod(xs []*big.Float) *big.Float {
+
+	m, e := ProdExp(xs)
+	if m.Sign() == 0 || m.IsInf() {
+		return m
+	}
+	neg := m.Sign() < 0
+	switch {
+	case e > big.MaxExp:
+		return m.SetInf(neg)
+	case e < big.MinExp:
+		return withSign(m.SetInt64(0), neg)
+	}
+
+	return m.SetMantExp(m, int(e))
+}
+
+// ProdExp returns the product of the elements of xs as m·2**e, where
+// 0.5 <= |m| < 1 is rounded to the largest precision of the elements
+// of xs, and e is an integer, which can be outside the range of the
+// exponents of big.Float; m is ±0 or ±Inf, with e = 0, if the product
+// is. The product of millions of factors of any size can't go out of
+// the range of e.
+//
+// The mantissas are multiplied with guard bits, and the exponents are
+// added separately, so the product is accurate to about the last bit
+// of m. The function panics if xs contains both a zero and an
+// infinity.
+func ProdExp(xs []*big.Float) (m *big.Float, e int64) {
+
+	var prec uint
+	neg, zero, inf := false, false, false
+	for _, x := range xs {
+		if x.Prec() > prec {
+			prec = x.Prec()
+		}
+		neg = neg != x.Signbit()
+		zero = zero || x.Sign() == 0
+		inf = inf || x.IsInf()
+	}
+	if prec == 0 {
+		prec = 64
+	}
+
+	m = new(big.Float).SetPrec(prec)
+	switch {
+	case zero && inf:
+		panic("ProdExp: product of zero and infinity")
+	case zero:
+		return withSign(m, neg), 0
+	case inf:
+		return m.SetInf(neg), 0
+	}
+
+	// each product rounds once, an error of 2**-wprec that adds up
+	wprec := prec + 64 + uint(bits.Len(uint(len(xs))))
+	m.SetPrec(wprec).SetInt64(1)
+	f := new(big.Float)
+	for _, x := range xs {
+		e += int64(x.MantExp(f))
+		m.Mul(m, f)
+		e += int64(m.MantExp(m))
+	}
+	if len(xs) == 0 {
+		// 1 = 0.5·2**1
+		m.SetFloat64(0.5)
+		e = 1
+	}
+
+	// the rounding can carry into the exponent
+	m.SetPrec(prec)
+	e += int64(m.MantExp(m))
+
+	return m, e
+}
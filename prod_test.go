@@ -0,0 +1,112 @@
+package bigfloat_test
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/ThreeAndTwo/bigfloat"
+)
+
+func TestProd(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for i := 0; i < 100; i++ {
+		prec := uint(24 + rnd.Intn(300))
+		xs := make([]*big.Float, 1+rnd.Intn(200))
+		exact := big.NewRat(1, 1)
+		for j := range xs {
+			xs[j] = new(big.Float).SetPrec(prec).SetFloat64(rnd.NormFloat64())
+			r, _ := xs[j].Rat(nil)
+			exact.Mul(exact, r)
+		}
+		want, _ := bigfloat.FromRat(exact, prec)
+		if got := bigfloat.Prod(xs); got.Prec() != prec || bigfloat.CmpUlp(got, want, 1) != 0 {
+			t.Errorf("Prod at prec %d = %g; want %g", prec, got, want)
+		}
+	}
+
+	for _, test := range []struct {
+		xs   []float64
+		want float64
+	}{
+		{nil, 1},
+		{[]float64{2, 3, 0.5}, 3},
+		{[]float64{-2, 3}, -6},
+		{[]float64{-2, -3}, 6},
+		{[]float64{2, math.Copysign(0, -1)}, math.Copysign(0, -1)},
+		{[]float64{-2, math.Inf(+1)}, math.Inf(-1)},
+	} {
+		xs := make([]*big.Float, len(test.xs))
+		for i, x := range test.xs {
+			xs[i] = big.NewFloat(x)
+		}
+		got, acc := bigfloat.Prod(xs).Float64()
+		if got != test.want || math.Signbit(got) != math.Signbit(test.want) || acc != big.Exact {
+			t.Errorf("Prod(%v) = %g (%v); want %g", test.xs, got, acc, test.want)
+		}
+	}
+
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Error("Prod(0, Inf) didn't panic")
+			}
+		}()
+		bigfloat.Prod([]*big.Float{big.NewFloat(0), big.NewFloat(math.Inf(+1))})
+	}()
+}
+
+func TestProdExp(t *testing.T) {
+	// 2**2e9 is close to the largest exponent of big.Float
+	huge := new(big.Float).SetMantExp(big.NewFloat(1), 2e9)
+	tiny := new(big.Float).SetMantExp(big.NewFloat(1), -2e9)
+	three := big.NewFloat(3)
+
+	// the partial products overflow, but the product is 3·2**2e9
+	xs := []*big.Float{huge, huge, three, tiny}
+	want := new(big.Float).SetMantExp(three, 2e9)
+	if got := bigfloat.Prod(xs); got.Cmp(want) != 0 {
+		t.Errorf("Prod(huge, huge, 3, tiny) = %g; want %g", got, want)
+	}
+
+	// 2**8e9, out of range: 0.5·2**(8e9 + 1)
+	xs = []*big.Float{huge, huge, huge, huge}
+	m, e := bigfloat.ProdExp(xs)
+	if m.Cmp(big.NewFloat(0.5)) != 0 || e != 8e9+1 {
+		t.Errorf("ProdExp(4 × 2**2e9) = %g, %d; want 0.5, %d", m, e, int64(8e9+1))
+	}
+	if got := bigfloat.Prod(xs); !got.IsInf() || got.Sign() < 0 {
+		t.Errorf("Prod(4 × 2**2e9) = %g; want +Inf", got)
+	}
+	xs = []*big.Float{tiny, tiny, new(big.Float).Neg(tiny), tiny}
+	if got := bigfloat.Prod(xs); got.Sign() != 0 || !got.Signbit() {
+		t.Errorf("Prod(4 × ±2**-2e9) = %g; want -0", got)
+	}
+
+	// a rounding that carries into the exponent: (1 + ε)(1 - ε)
+	// rounds to 1 = 0.5·2**1 at 53 bits
+	x := big.NewFloat(1 + 0x1p-30)
+	y := big.NewFloat(1 - 0x1p-30)
+	if m, e := bigfloat.ProdExp([]*big.Float{x, y}); m.Cmp(big.NewFloat(0.5)) != 0 || e != 1 {
+		t.Errorf("ProdExp(1+ε, 1-ε) = %g, %d; want 0.5, 1", m, e)
+	}
+}
+
+// ---------- Benchmarks ----------
+
+func BenchmarkProd(b *testing.B) {
+	for _, prec := range []uint{1e2, 1e3, 1e4} {
+		xs := make([]*big.Float, 1000)
+		for i := range xs {
+			xs[i] = new(big.Float).SetPrec(prec).SetInt64(int64(i + 1))
+		}
+		b.Run(fmt.Sprintf("%v", prec), func(b *testing.B) {
+			b.ReportAllocs()
+			for n := 0; n < b.N; n++ {
+				bigfloat.Prod(xs)
+			}
+		})
+	}
+}
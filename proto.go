@@ -0,0 +1,42 @@
+package bigfloat
+
+import (
+	"bytes"
+	"errors"
+	"math/big"
+)
+
+// ToProtoBytes returns the canonical binary encoding of x, meant to
+// be carried in a bytes field of a protocol buffer message, like
+//
+//	message BigFloat {
+//	    bytes value = 1; // bigfloat.ToProtoBytes
+//	}
+//
+// The encoding is the one written by Float.MarshalBinary, version 1,
+// which holds the value exactly, together with its precision and its
+// rounding mode. It's stable: it won't change in future versions of
+// this package, which will keep reading it, and it's simple enough to
+// be implemented in other languages. It's also canonical: equal
+// values, with the same sign, precision and rounding mode, always
+// have the same encoding, so encodings can be compared or hashed.
+func ToProtoBytes(x *big.Float) []byte {
+	b, _ := NewFloat(x).MarshalBinary()
+	return b
+}
+
+// FromProtoBytes returns the value encoded by ToProtoBytes in b, with
+// its precision and rounding mode. It returns an error if b isn't a
+// valid encoding, including non-canonical ones.
+func FromProtoBytes(b []byte) (*big.Float, error) {
+
+	var f Float
+	if err := f.UnmarshalBinary(b); err != nil {
+		return nil, err
+	}
+	if c := ToProtoBytes(&f.Float); !bytes.Equal(c, b) {
+		return nil, errors.New("bigfloat: non-canonical encoding")
+	}
+
+	return &f.Float, nil
+}
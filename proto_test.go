@@ -0,0 +1,60 @@
+package bigfloat_test
+
+import (
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/ThreeAndTwo/bigfloat"
+)
+
+func TestProtoBytes(t *testing.T) {
+	// the encoding is stable: these must never change
+	third := new(big.Float).SetPrec(64).SetMode(big.ToZero)
+	third.Quo(big.NewFloat(1), big.NewFloat(3))
+	for _, test := range []struct {
+		x    *big.Float
+		want string
+	}{
+		{big.NewFloat(1.5), "0110350103"},
+		{big.NewFloat(-1), "0118350001"},
+		{third, "0112407f5555555555555555"},
+		{new(big.Float).SetPrec(113), "010071"},
+		{new(big.Float).SetPrec(53).SetInf(true), "012835"},
+	} {
+		b := bigfloat.ToProtoBytes(test.x)
+		if s := hex.EncodeToString(b); s != test.want {
+			t.Errorf("ToProtoBytes(%g) = %s; want %s", test.x, s, test.want)
+		}
+		z, err := bigfloat.FromProtoBytes(b)
+		if err != nil {
+			t.Errorf("FromProtoBytes(%s) error: %v", test.want, err)
+			continue
+		}
+		if z.Cmp(test.x) != 0 || z.Signbit() != test.x.Signbit() || z.Prec() != test.x.Prec() || z.Mode() != test.x.Mode() {
+			t.Errorf("FromProtoBytes(%s) = %g (prec %d, %v)", test.want, z, z.Prec(), z.Mode())
+		}
+	}
+}
+
+func TestFromProtoBytesNonCanonical(t *testing.T) {
+	for _, data := range []string{
+		"01103501" + "0003", // leading zero in the mantissa
+		"0110b50001" + "03", // non-minimal precision varint
+		"0110358100" + "03", // non-minimal exponent varint
+	} {
+		b, _ := hex.DecodeString(data)
+		if _, err := bigfloat.FromProtoBytes(b); err == nil {
+			t.Errorf("FromProtoBytes(%s) did not fail", data)
+		}
+	}
+}
+
+// ---------- Benchmarks ----------
+
+func BenchmarkFromProtoBytes(b *testing.B) {
+	data := bigfloat.ToProtoBytes(bigfloat.Sqrt(new(big.Float).SetPrec(1000).SetInt64(2)))
+	for n := 0; n < b.N; n++ {
+		bigfloat.FromProtoBytes(data)
+	}
+}
@@ -0,0 +1,179 @@
+package bigfloat
+
+import "math/big"
+
+// A QuantileMethod selects how Quantile computes the quantile of a
+// sample between its order statistics, as one of the definitions of
+// Hyndman and Fan (1996) or of NumPy. With n values x[0] ≤ … ≤ x[n-1],
+// the quantile of the probability p is at the position h in the sorted
+// values, interpolated linearly between x[⌊h⌋] and x[⌊h⌋+1].
+type QuantileMethod int
+
+const (
+	QuantileLinear         QuantileMethod = iota // h = (n-1)·p, type 7 of Hyndman and Fan, the default of R and NumPy
+	QuantileLower                                // x[⌊h⌋], for the h of QuantileLinear
+	QuantileHigher                               // x[⌈h⌉], for the h of QuantileLinear
+	QuantileNearest                              // x[h] for the h of QuantileLinear rounded to nearest even
+	QuantileMidpoint                             // (x[⌊h⌋] + x[⌈h⌉])/2, for the h of QuantileLinear
+	QuantileWeibull                              // h = (n+1)·p - 1, type 6, the one of Excel's PERCENTILE.EXC
+	QuantileHazen                                // h = n·p - 1/2, type 5
+	QuantileMedianUnbiased                       // h = (n+1/3)·p - 2/3, type 8, recommended by Hyndman and Fan
+	QuantileNormalUnbiased                       // h = (n+1/4)·p - 5/8, type 9, unbiased for normal data
+)
+
+// Quantile returns the quantile of the probability p of the elements
+// of xs, with the method m, correctly rounded to nearest even to the
+// largest precision of the elements of xs. The position h, and the
+// interpolation between the order statistics, are computed exactly,
+// for the exact value of p, so the result is rounded once; the methods
+// that give an element of xs return a copy of it, at that precision.
+// The positions below 0 or above n-1 give the smallest or the largest
+// element. xs isn't modified.
+//
+// An interpolation with an infinite element gives that infinity. The
+// function panics if xs is empty or contains a nil, if p isn't in
+// [0, 1], if m isn't a QuantileMethod, or if the interpolation is
+// between infinities of opposite sign.
+func Quantile(xs []*big.Float, p *big.Float, m QuantileMethod) *big.Float {
+
+	if len(xs) == 0 {
+		panic("Quantile: empty slice")
+	}
+	if p.Sign() < 0 || p.Cmp(big.NewFloat(1)) > 0 {
+		panic("Quantile: probability out of [0, 1]")
+	}
+
+	sorted := make([]*big.Float, len(xs))
+	var prec uint
+	for i, x := range xs {
+		if x == nil {
+			panic("Quantile: nil element")
+		}
+		if x.Prec() > prec {
+			prec = x.Prec()
+		}
+		sorted[i] = x
+	}
+	SortSlice(sorted)
+
+	// the position h = a·p + b, exactly
+	n := int64(len(xs))
+	var a, b *big.Rat
+	switch m {
+	case QuantileLinear, QuantileLower, QuantileHigher, QuantileNearest, QuantileMidpoint:
+		a, b = big.NewRat(n-1, 1), new(big.Rat)
+	case QuantileWeibull:
+		a, b = big.NewRat(n+1, 1), big.NewRat(-1, 1)
+	case QuantileHazen:
+		a, b = big.NewRat(n, 1), big.NewRat(-1, 2)
+	case QuantileMedianUnbiased:
+		a, b = big.NewRat(3*n+1, 3), big.NewRat(-2, 3)
+	case QuantileNormalUnbiased:
+		a, b = big.NewRat(4*n+1, 4), big.NewRat(-5, 8)
+	default:
+		panic("Quantile: unknown method")
+	}
+	h := a.Mul(a, ToRat(p))
+	h.Add(h, b)
+
+	// h = j + g, with 0 ≤ g < 1, clamped to [0, n-1]
+	j := new(big.Int).Quo(h.Num(), h.Denom())
+	if h.Sign() < 0 {
+		j.SetInt64(-1)
+	}
+	g := new(big.Rat).Sub(h, new(big.Rat).SetInt(j))
+	switch {
+	case j.Sign() < 0:
+		j.SetInt64(0)
+		g.SetInt64(0)
+	case j.Cmp(big.NewInt(n-1)) >= 0:
+		j.SetInt64(n - 1)
+		g.SetInt64(0)
+	}
+	lo := int(j.Int64())
+	hi := lo
+	if g.Sign() > 0 {
+		hi++
+	}
+
+	switch m {
+	case QuantileLower:
+		g.SetInt64(0)
+	case QuantileHigher:
+		lo = hi
+		g.SetInt64(0)
+	case QuantileNearest:
+		switch g.Cmp(big.NewRat(1, 2)) {
+		case 1:
+			lo = hi
+		case 0:
+			if lo%2 == 1 {
+				lo = hi
+			}
+		}
+		g.SetInt64(0)
+	case QuantileMidpoint:
+		if lo != hi {
+			g.SetFrac64(1, 2)
+		}
+	}
+
+	return interpolate(sorted[lo], sorted[hi], g, prec)
+}
+
+// Median returns the median of the elements of xs, their middle
+// element, or the mean of the two middle ones, correctly rounded to
+// nearest even to the largest precision of the elements of xs. It's
+// the Quantile of 1/2 with QuantileLinear, and panics as it does.
+func Median(xs []*big.Float) *big.Float {
+	return Quantile(xs, big.NewFloat(0.5), QuantileLinear)
+}
+
+// OrderStatistic returns a copy of the k-th smallest element of xs,
+// with its precision, counting from 1, in the order of Compare, so
+// that -0 is smaller than +0. xs isn't modified. The function panics
+// if k isn't in [1, len(xs)], or if xs contains a nil.
+func OrderStatistic(xs []*big.Float, k int) *big.Float {
+
+	if k < 1 || k > len(xs) {
+		panic("OrderStatistic: rank out of range")
+	}
+
+	sorted := make([]*big.Float, len(xs))
+	for i, x := range xs {
+		if x == nil {
+			panic("OrderStatistic: nil element")
+		}
+		sorted[i] = x
+	}
+	SortSlice(sorted)
+
+	return new(big.Float).Copy(sorted[k-1])
+}
+
+// interpolate returns x + g·(y - x), for 0 ≤ g < 1, rounded to prec
+// bits: x itself, at prec bits, if g is 0, and the infinity of x or y
+// if one of them is infinite.
+func interpolate(x, y *big.Float, g *big.Rat, prec uint) *big.Float {
+
+	z := new(big.Float).SetPrec(prec)
+	if g.Sign() == 0 {
+		return z.Set(x)
+	}
+
+	switch {
+	case x.IsInf() && y.IsInf() && x.Signbit() != y.Signbit():
+		panic("Quantile: interpolation between infinities of opposite sign")
+	case x.IsInf():
+		return z.Set(x)
+	case y.IsInf():
+		return z.Set(y)
+	}
+
+	// (1-g)·x + g·y, exactly
+	r := new(big.Rat).Sub(ToRat(y), ToRat(x))
+	r.Mul(r, g)
+	r.Add(r, ToRat(x))
+
+	return z.SetRat(r)
+}
@@ -0,0 +1,194 @@
+package bigfloat_test
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/ThreeAndTwo/bigfloat"
+)
+
+func TestQuantile(t *testing.T) {
+	// the values of numpy.quantile
+	xs := floats(53, "3", "1", "4", "2")
+	for _, test := range []struct {
+		m    bigfloat.QuantileMethod
+		p    float64
+		want string
+	}{
+		{bigfloat.QuantileLinear, 0, "1"},
+		{bigfloat.QuantileLinear, 1, "4"},
+		{bigfloat.QuantileLinear, 0.5, "2.5"},
+		{bigfloat.QuantileLinear, 0.25, "1.75"},
+		{bigfloat.QuantileLinear, 0.1, "1.3"},
+		{bigfloat.QuantileLower, 0.5, "2"},
+		{bigfloat.QuantileLower, 0.9, "3"},
+		{bigfloat.QuantileHigher, 0.5, "3"},
+		{bigfloat.QuantileHigher, 0.1, "2"},
+		{bigfloat.QuantileHigher, 0, "1"},
+		{bigfloat.QuantileNearest, 0.5, "3"},
+		{bigfloat.QuantileNearest, 0.25, "2"},
+		{bigfloat.QuantileNearest, 0.1, "1"},
+		{bigfloat.QuantileMidpoint, 0.5, "2.5"},
+		{bigfloat.QuantileMidpoint, 0.1, "1.5"},
+		{bigfloat.QuantileMidpoint, 1, "4"},
+		{bigfloat.QuantileWeibull, 0.5, "2.5"},
+		{bigfloat.QuantileWeibull, 0.25, "1.25"},
+		{bigfloat.QuantileWeibull, 0.1, "1"},
+		{bigfloat.QuantileWeibull, 0.9, "4"},
+		{bigfloat.QuantileHazen, 0.25, "1.5"},
+		{bigfloat.QuantileHazen, 0.1, "1"},
+		{bigfloat.QuantileHazen, 0.75, "3.5"},
+		{bigfloat.QuantileMedianUnbiased, 0.5, "2.5"},
+		{bigfloat.QuantileMedianUnbiased, 0.25, "1.41666666666666666667"},
+		{bigfloat.QuantileNormalUnbiased, 0.25, "1.4375"},
+		{bigfloat.QuantileNormalUnbiased, 0.75, "3.5625"},
+	} {
+		want, _, _ := new(big.Float).SetPrec(53).Parse(test.want, 10)
+		z := bigfloat.Quantile(xs, big.NewFloat(test.p), test.m)
+		if z.Cmp(want) != 0 || z.Prec() != 53 {
+			t.Errorf("Quantile(%v, %v) = %g (prec %d); want %g", test.p, test.m, z, z.Prec(), want)
+		}
+	}
+
+	// xs isn't modified
+	if xs[0].Cmp(big.NewFloat(3)) != 0 || xs[3].Cmp(big.NewFloat(2)) != 0 {
+		t.Errorf("Quantile modified xs: %v", xs)
+	}
+}
+
+func TestQuantileRounding(t *testing.T) {
+	// the exact interpolation, rounded once, for p of 53 bits and
+	// values of prec bits
+	for _, prec := range []uint{24, 53, 100} {
+		xs := []*big.Float{
+			new(big.Float).SetPrec(prec).SetFloat64(1),
+			new(big.Float).SetPrec(prec).SetFloat64(1),
+		}
+		xs[1].SetMantExp(xs[1], 1)
+		xs[1].Add(xs[1], new(big.Float).SetMantExp(big.NewFloat(1), -int(prec)+2))
+		for _, p := range []float64{0.1, 1.0 / 3, 0.7, 0.999} {
+			for _, m := range []bigfloat.QuantileMethod{
+				bigfloat.QuantileLinear, bigfloat.QuantileMedianUnbiased, bigfloat.QuantileNormalUnbiased,
+			} {
+				// with n = 2, h = p, 7/3·p - 2/3 or 9/4·p - 5/8
+				h := new(big.Rat).SetFloat64(p)
+				switch m {
+				case bigfloat.QuantileMedianUnbiased:
+					h.Mul(h, big.NewRat(7, 3)).Sub(h, big.NewRat(2, 3))
+				case bigfloat.QuantileNormalUnbiased:
+					h.Mul(h, big.NewRat(9, 4)).Sub(h, big.NewRat(5, 8))
+				}
+				switch {
+				case h.Sign() < 0:
+					h.SetInt64(0)
+				case h.Cmp(big.NewRat(1, 1)) > 0:
+					h.SetInt64(1)
+				}
+				x0, x1 := bigfloat.ToRat(xs[0]), bigfloat.ToRat(xs[1])
+				r := new(big.Rat).Sub(x1, x0)
+				r.Mul(r, h).Add(r, x0)
+				want, _ := bigfloat.FromRat(r, prec)
+
+				z := bigfloat.Quantile(xs, big.NewFloat(p), m)
+				if z.Cmp(want) != 0 || z.Prec() != prec {
+					t.Errorf("prec = %d, Quantile(%v, %v) = %g (prec %d); want %g", prec, p, m, z, z.Prec(), want)
+				}
+			}
+		}
+	}
+}
+
+func TestQuantileInf(t *testing.T) {
+	xs := floats(53, "1", "+Inf", "-Inf", "2")
+	for _, test := range []struct {
+		p    float64
+		want string
+	}{
+		{0, "-Inf"},
+		{0.2, "-Inf"},
+		{0.5, "1.5"},
+		{0.8, "+Inf"},
+		{1, "+Inf"},
+	} {
+		want, _, _ := new(big.Float).Parse(test.want, 10)
+		if z := bigfloat.Quantile(xs, big.NewFloat(test.p), bigfloat.QuantileLinear); z.Cmp(want) != 0 {
+			t.Errorf("Quantile(%v) = %g; want %g", test.p, z, want)
+		}
+	}
+}
+
+func TestMedian(t *testing.T) {
+	for _, test := range []struct {
+		xs   []string
+		want string
+	}{
+		{[]string{"7"}, "7"},
+		{[]string{"3", "1", "2"}, "2"},
+		{[]string{"4", "1", "3", "2"}, "2.5"},
+		{[]string{"1e300", "-1", "1", "-1e300"}, "0"},
+	} {
+		want, _, _ := new(big.Float).Parse(test.want, 10)
+		if z := bigfloat.Median(floats(53, test.xs...)); z.Cmp(want) != 0 {
+			t.Errorf("Median(%v) = %g; want %g", test.xs, z, want)
+		}
+	}
+}
+
+func TestOrderStatistic(t *testing.T) {
+	xs := floats(53, "3", "-0", "1", "0", "-2")
+	xs[3].SetPrec(100)
+	for k, want := range []string{"-2", "-0", "0", "1", "3"} {
+		w, _, _ := new(big.Float).Parse(want, 10)
+		z := bigfloat.OrderStatistic(xs, k+1)
+		if z.Cmp(w) != 0 || z.Signbit() != w.Signbit() {
+			t.Errorf("OrderStatistic(%d) = %g; want %s", k+1, z, want)
+		}
+	}
+	if z := bigfloat.OrderStatistic(xs, 3); z.Prec() != 100 || z == xs[3] {
+		t.Errorf("OrderStatistic(3) isn't a copy of the element at 100 bits")
+	}
+}
+
+func TestQuantilePanics(t *testing.T) {
+	xs := floats(53, "1", "2")
+	for name, f := range map[string]func(){
+		"Quantile(empty)":      func() { bigfloat.Quantile(nil, big.NewFloat(0.5), bigfloat.QuantileLinear) },
+		"Quantile(p < 0)":      func() { bigfloat.Quantile(xs, big.NewFloat(-0.1), bigfloat.QuantileLinear) },
+		"Quantile(p > 1)":      func() { bigfloat.Quantile(xs, big.NewFloat(1.5), bigfloat.QuantileLinear) },
+		"Quantile(p = Inf)":    func() { bigfloat.Quantile(xs, new(big.Float).SetInf(false), bigfloat.QuantileLinear) },
+		"Quantile(method)":     func() { bigfloat.Quantile(xs, big.NewFloat(0.5), bigfloat.QuantileMethod(99)) },
+		"Quantile(nil)":        func() { bigfloat.Quantile([]*big.Float{nil}, big.NewFloat(0.5), bigfloat.QuantileLinear) },
+		"Quantile(-Inf, +Inf)": func() { bigfloat.Quantile(floats(53, "-Inf", "+Inf"), big.NewFloat(0.5), bigfloat.QuantileLinear) },
+		"Median(empty)":        func() { bigfloat.Median(nil) },
+		"OrderStatistic(0)":    func() { bigfloat.OrderStatistic(xs, 0) },
+		"OrderStatistic(3)":    func() { bigfloat.OrderStatistic(xs, 3) },
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%s did not panic", name)
+				}
+			}()
+			f()
+		}()
+	}
+}
+
+// ---------- Benchmarks ----------
+
+func BenchmarkQuantile(b *testing.B) {
+	for _, n := range []int{10, 1000} {
+		xs := make([]*big.Float, n)
+		for i := range xs {
+			xs[i] = new(big.Float).SetPrec(256).SetInt64(int64((i * 7919) % n))
+		}
+		p := big.NewFloat(0.3)
+		b.Run(fmt.Sprintf("%v", n), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				bigfloat.Quantile(xs, p, bigfloat.QuantileLinear)
+			}
+		})
+	}
+}
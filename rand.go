@@ -0,0 +1,116 @@
+package bigfloat
+
+import (
+	"math/big"
+	"math/bits"
+	"math/rand"
+)
+
+// randMaxZeros is the number of leading zero bits after which Rand
+// gives up and returns 0. With a working source, that happens with
+// probability 2**(-randMaxZeros).
+const randMaxZeros = 1 << 20
+
+// Rand returns a uniformly distributed random number in [0, 1) with
+// prec bits of precision, using src as the source of randomness. The
+// function panics if prec is 0.
+//
+// The result is the real number drawn uniformly from [0, 1), rounded
+// toward zero to prec significant bits: the exponent has a geometric
+// distribution, as it's given by the number of leading zeros of the
+// binary expansion, and all the prec bits of the mantissa are random,
+// also for values close to zero.
+func Rand(prec uint, src rand.Source) *big.Float {
+
+	if prec == 0 {
+		panic("Rand: zero precision")
+	}
+
+	r := randBits{src: src}
+	z := new(big.Float).SetPrec(prec)
+
+	// count the leading zeros in the binary expansion
+	zeros := 0
+	for {
+		w := r.word()
+		if w != 0 {
+			zeros += bits.LeadingZeros64(w) - 1
+			break
+		}
+		zeros += 63
+		if zeros >= randMaxZeros {
+			return z
+		}
+	}
+
+	// mantissa: the leading 1 followed by prec-1 random bits
+	m := big.NewInt(1)
+	m.Lsh(m, prec-1)
+	m.Or(m, r.int(prec-1))
+	z.SetInt(m)
+
+	return z.SetMantExp(z, -zeros-int(prec))
+}
+
+// randBits draws random bits from a rand.Source.
+type randBits struct {
+	src rand.Source
+}
+
+// word returns 63 random bits.
+func (r randBits) word() uint64 {
+	if s, ok := r.src.(rand.Source64); ok {
+		return s.Uint64() >> 1
+	}
+	return uint64(r.src.Int63())
+}
+
+// int returns a random integer with n bits.
+func (r randBits) int(n uint) *big.Int {
+
+	z := new(big.Int)
+	w := new(big.Int)
+	for ; n >= 63; n -= 63 {
+		z.Lsh(z, 63)
+		z.Or(z, w.SetUint64(r.word()))
+	}
+	if n > 0 {
+		z.Lsh(z, n)
+		z.Or(z, w.SetUint64(r.word()>>(63-n)))
+	}
+
+	return z
+}
+
+// NormalRand returns a normally distributed random number with mean 0
+// and standard deviation 1, with prec bits of precision, using src as
+// the source of randomness. The function panics if prec is 0.
+//
+// The deviate is computed with the Box–Muller transform
+//
+//	z = sqrt(-2·log(u))·cos(2π·v)
+//
+// from two uniform deviates u, v given by Rand, with a few guard
+// digits more than prec. The second deviate sqrt(-2·log(u))·sin(2π·v)
+// the transform produces is discarded, so that NormalRand doesn't
+// need to keep any state between calls.
+func NormalRand(prec uint, src rand.Source) *big.Float {
+
+	if prec == 0 {
+		panic("NormalRand: zero precision")
+	}
+
+	wprec := prec + 32 // guard digits
+
+	// u in (0, 1], so that log(u) is finite
+	u := new(big.Float).SetPrec(wprec).Sub(big.NewFloat(1), Rand(wprec, src))
+	r := Log(u)
+	r.Mul(r, big.NewFloat(-2))
+	r = Sqrt(r)
+
+	t := Rand(wprec, src)
+	t.Mul(t, pi(wprec)).Mul(t, big.NewFloat(2))
+	_, c := sincos(t)
+
+	return r.Mul(r, c).SetPrec(prec)
+}
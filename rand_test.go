@@ -0,0 +1,149 @@
+package bigfloat_test
+
+import (
+	"fmt"
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/ThreeAndTwo/bigfloat"
+)
+
+func TestRand(t *testing.T) {
+	for _, prec := range []uint{1, 24, 53, 64, 100, 1000} {
+		src := rand.NewSource(int64(prec))
+		one := big.NewFloat(1)
+		half := big.NewFloat(0.5)
+		upper := 0
+		const n = 2000
+		for i := 0; i < n; i++ {
+			z := bigfloat.Rand(prec, src)
+			if z.Prec() != prec {
+				t.Fatalf("Rand(%d).Prec() = %d", prec, z.Prec())
+			}
+			if z.Sign() < 0 || z.Cmp(one) >= 0 {
+				t.Fatalf("Rand(%d) = %g, not in [0, 1)", prec, z)
+			}
+			if z.Cmp(half) >= 0 {
+				upper++
+			}
+		}
+
+		// about half of the values are in [0.5, 1)
+		if upper < n*45/100 || upper > n*55/100 {
+			t.Errorf("prec = %d: %d of %d values in [0.5, 1)", prec, upper, n)
+		}
+	}
+}
+
+func TestRandMantissaBits(t *testing.T) {
+	// every bit of the mantissa, down to the last one, must be random
+	const prec = 200
+	src := rand.NewSource(1)
+	ones := make([]int, prec)
+	const n = 2000
+	mant := new(big.Float)
+	for i := 0; i < n; i++ {
+		z := bigfloat.Rand(prec, src)
+		z.MantExp(mant)
+		m, _ := mant.SetMantExp(mant, prec).Int(nil)
+		for j := range ones {
+			ones[j] += int(m.Bit(j))
+		}
+	}
+
+	// the leading bit is always 1
+	if ones[prec-1] != n {
+		t.Errorf("leading bit set %d times of %d", ones[prec-1], n)
+	}
+	for j := 0; j < prec-1; j++ {
+		if ones[j] < n*40/100 || ones[j] > n*60/100 {
+			t.Errorf("bit %d set %d times of %d", j, ones[j], n)
+		}
+	}
+}
+
+func TestRandSmallValues(t *testing.T) {
+	// values below 2**-8 have an exponent that's still random, and a
+	// full precision mantissa
+	src := rand.NewSource(7)
+	lim := big.NewFloat(1.0 / 256)
+	found := 0
+	for i := 0; i < 20000; i++ {
+		z := bigfloat.Rand(100, src)
+		if z.Cmp(lim) < 0 && z.Sign() > 0 {
+			found++
+			if z.MinPrec() < 80 {
+				t.Errorf("Rand(100) = %g has only %d significant bits", z, z.MinPrec())
+			}
+		}
+	}
+	if found < 40 || found > 120 {
+		t.Errorf("%d values below 2**-8 in 20000; want about 78", found)
+	}
+}
+
+func TestRandDeterministic(t *testing.T) {
+	a := bigfloat.Rand(300, rand.NewSource(42))
+	b := bigfloat.Rand(300, rand.NewSource(42))
+	if a.Cmp(b) != 0 {
+		t.Errorf("same seed gave %g and %g", a, b)
+	}
+}
+
+func TestNormalRand(t *testing.T) {
+	for _, prec := range []uint{53, 200} {
+		src := rand.NewSource(int64(prec))
+		const n = 4000
+		var a bigfloat.Accumulator
+		within := 0
+		for i := 0; i < n; i++ {
+			z := bigfloat.NormalRand(prec, src)
+			if z.Prec() != prec {
+				t.Fatalf("NormalRand(%d).Prec() = %d", prec, z.Prec())
+			}
+			if f, _ := z.Float64(); f > -1 && f < 1 {
+				within++
+			}
+			a.Add(z)
+		}
+
+		mean, _ := a.Mean().Float64()
+		variance, _ := a.Variance().Float64()
+		if mean < -0.1 || mean > 0.1 {
+			t.Errorf("prec = %d: mean = %g; want about 0", prec, mean)
+		}
+		if variance < 0.9 || variance > 1.1 {
+			t.Errorf("prec = %d: variance = %g; want about 1", prec, variance)
+		}
+
+		// about 68.3% of the values are within one standard deviation
+		if within < n*65/100 || within > n*72/100 {
+			t.Errorf("prec = %d: %d of %d values in (-1, 1)", prec, within, n)
+		}
+	}
+}
+
+// ---------- Benchmarks ----------
+
+func BenchmarkRand(b *testing.B) {
+	src := rand.NewSource(1)
+	for _, prec := range []uint{53, 1000, 10000} {
+		b.Run(fmt.Sprintf("%v", prec), func(b *testing.B) {
+			for n := 0; n < b.N; n++ {
+				bigfloat.Rand(prec, src)
+			}
+		})
+	}
+}
+
+func BenchmarkNormalRand(b *testing.B) {
+	src := rand.NewSource(1)
+	for _, prec := range []uint{53, 1000} {
+		b.Run(fmt.Sprintf("%v", prec), func(b *testing.B) {
+			for n := 0; n < b.N; n++ {
+				bigfloat.NormalRand(prec, src)
+			}
+		})
+	}
+}
@@ -0,0 +1,89 @@
+package bigfloat
+
+import (
+	"math/big"
+	"sync/atomic"
+)
+
+// A RangeError records a result whose exponent is out of the range of
+// big.Float, from finite arguments: a result that overflows to ±Inf,
+// or one that underflows to ±0.
+type RangeError struct {
+	Func      string // the function or the operator, as "exp" or "*"
+	Underflow bool   // the result underflowed to ±0, rather than overflowing to ±Inf
+}
+
+func (e *RangeError) Error() string {
+
+	if e.Underflow {
+		return "bigfloat: " + e.Func + ": result underflows to 0"
+	}
+
+	return "bigfloat: " + e.Func + ": result overflows to Inf"
+}
+
+// A RangeMode tells what Eval and the jobs of a Pool do when a result
+// is out of the range of big.Float.
+type RangeMode int32
+
+const (
+	// Saturate gives ±Inf or ±0 for the results out of range, as
+	// big.Float does, with no error.
+	Saturate RangeMode = iota
+
+	// ReportRange gives ±Inf or ±0 for the results out of range, like
+	// Saturate, together with a *RangeError for the first operation
+	// whose result was out of range.
+	ReportRange
+)
+
+var rangeMode int32
+
+// SetRangeMode sets the RangeMode of the package, which is initially
+// Saturate, and returns the previous one.
+func SetRangeMode(m RangeMode) RangeMode {
+	return RangeMode(atomic.SwapInt32(&rangeMode, int32(m)))
+}
+
+// checkRange returns a *RangeError if z, the result of the function
+// or the operator name applied to args, is out of range, and the
+// RangeMode is ReportRange. Only the results of the operators and of
+// exp and pow can go out of range.
+func checkRange(name string, z *big.Float, args ...*big.Float) error {
+
+	if RangeMode(atomic.LoadInt32(&rangeMode)) != ReportRange {
+		return nil
+	}
+	for _, a := range args {
+		if a.IsInf() {
+			return nil
+		}
+	}
+
+	switch {
+	case z.IsInf():
+		// a division by zero is exact, and so are log(0) and the like
+		if name == "/" && args[1].Sign() == 0 {
+			return nil
+		}
+		switch name {
+		case "+", "-", "*", "/", "^", "exp", "pow":
+			return &RangeError{name, false}
+		}
+	case z.Sign() == 0:
+		switch name {
+		case "*":
+			if args[0].Sign() != 0 && args[1].Sign() != 0 {
+				return &RangeError{name, true}
+			}
+		case "/", "^", "pow":
+			if args[0].Sign() != 0 {
+				return &RangeError{name, true}
+			}
+		case "exp":
+			return &RangeError{name, true}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,89 @@
+package bigfloat_test
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ThreeAndTwo/bigfloat"
+)
+
+func TestRangeMode(t *testing.T) {
+	defer bigfloat.SetRangeMode(bigfloat.Saturate)
+
+	for _, test := range []struct {
+		expr      string
+		want      string
+		fn        string
+		underflow bool
+	}{
+		{"exp(1e20)", "+Inf", "exp", false},
+		{"-exp(1e20)", "-Inf", "exp", false},
+		{"exp(-1e20)", "0", "exp", true},
+		{"2^1e20", "+Inf", "^", false},
+		{"pow(0.5, 1e20)", "0", "pow", true},
+		{"1e400000000 * 1e400000000", "+Inf", "*", false},
+		{"1e400000000 * 1e400000000 - 1", "+Inf", "*", false},
+		{"-1e-400000000 * 1e-400000000", "-0", "*", true},
+		{"1e-400000000 / 1e400000000", "0", "/", true},
+		{"1e400000000 / 1e-400000000", "+Inf", "/", false},
+		{"exp(1e20) - exp(1e20)", "NaN", "", false},
+	} {
+		for _, mode := range []bigfloat.RangeMode{bigfloat.Saturate, bigfloat.ReportRange} {
+			bigfloat.SetRangeMode(mode)
+			z, err := bigfloat.Eval(test.expr, nil, 53)
+			if test.want == "NaN" {
+				// Inf - Inf, after the first overflow
+				if _, ok := err.(*bigfloat.EvalError); !ok {
+					t.Errorf("Eval(%q) = %v, %v; want an *EvalError", test.expr, z, err)
+				}
+				continue
+			}
+			if z == nil || z.Text('g', 10) != test.want {
+				t.Errorf("Eval(%q) in mode %d = %v; want %s", test.expr, mode, z, test.want)
+			}
+			var re *bigfloat.RangeError
+			switch {
+			case mode == bigfloat.Saturate && err != nil:
+				t.Errorf("Eval(%q) with Saturate returned the error %v", test.expr, err)
+			case mode == bigfloat.ReportRange && !errors.As(err, &re):
+				t.Errorf("Eval(%q) with ReportRange returned the error %v; want a *RangeError", test.expr, err)
+			case re != nil && (re.Func != test.fn || re.Underflow != test.underflow):
+				t.Errorf("Eval(%q) returned %+v; want {%s %v}", test.expr, *re, test.fn, test.underflow)
+			}
+		}
+	}
+}
+
+func TestRangeModeInRange(t *testing.T) {
+	defer bigfloat.SetRangeMode(bigfloat.SetRangeMode(bigfloat.ReportRange))
+
+	// exact zeros and infinities aren't out of range
+	for _, expr := range []string{
+		"1/0", "-1/0", "0*5", "0/3", "pow(0, 2)", "log(0)", "1 - 1",
+		"exp(1e20 * 0)", "sin(0)", "2^1000",
+	} {
+		if _, err := bigfloat.Eval(expr, nil, 53); err != nil {
+			t.Errorf("Eval(%q) returned the error %v", expr, err)
+		}
+	}
+}
+
+func TestRangeModePool(t *testing.T) {
+	defer bigfloat.SetRangeMode(bigfloat.SetRangeMode(bigfloat.ReportRange))
+
+	res := bigfloat.RunJobs([]bigfloat.Job{
+		{ID: 1, Func: "exp", Args: []*big.Float{big.NewFloat(1e20)}},
+		{ID: 2, Expr: "exp(-1e20)"},
+		{ID: 3, Func: "exp", Args: []*big.Float{big.NewFloat(1)}},
+	}, 2)
+	for i, want := range []string{"bigfloat: exp: result overflows to Inf", "bigfloat: exp: result underflows to 0", ""} {
+		got := ""
+		if res[i].Err != nil {
+			got = res[i].Err.Error()
+		}
+		if got != want || res[i].Value == nil {
+			t.Errorf("job %d: %v, %q; want %q", res[i].ID, res[i].Value, got, want)
+		}
+	}
+}
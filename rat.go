@@ -0,0 +1,92 @@
+package bigfloat
+
+import "math/big"
+
+// FromRat returns r rounded to prec bits using the ToNearestEven
+// rounding mode, and the accuracy of the result: big.Exact if the
+// result is exactly r, big.Below or big.Above if it's smaller or
+// larger than r.
+func FromRat(r *big.Rat, prec uint) (*big.Float, big.Accuracy) {
+	z := new(big.Float).SetPrec(prec).SetRat(r)
+	return z, z.Acc()
+}
+
+// ToRat returns the exact value of x as a big.Rat. Since every finite
+// big.Float is a rational number, the conversion is always exact. The
+// function panics if x is infinite.
+func ToRat(x *big.Float) *big.Rat {
+
+	if x.IsInf() {
+		panic("ToRat: argument is infinite")
+	}
+
+	r, _ := x.Rat(nil)
+	return r
+}
+
+// RatApprox returns the simplest rational number r with |r - x| <= tol,
+// that is the one with the smallest denominator, and of those the one
+// with the smallest absolute numerator. If tol is 0, the result is
+// exactly x. The function panics if x or tol are infinite, or if tol
+// is negative.
+//
+// The simplest rational in an interval is found by expanding both
+// endpoints as continued fractions, and stopping at the first term
+// where they differ.
+func RatApprox(x, tol *big.Float) *big.Rat {
+
+	if x.IsInf() || tol.IsInf() {
+		panic("RatApprox: infinite argument")
+	}
+	if tol.Sign() < 0 {
+		panic("RatApprox: negative tolerance")
+	}
+
+	xr, t := ToRat(x), ToRat(tol)
+	lo := new(big.Rat).Sub(xr, t)
+	hi := new(big.Rat).Add(xr, t)
+
+	switch {
+	case lo.Sign() <= 0 && hi.Sign() >= 0:
+		return new(big.Rat)
+	case hi.Sign() < 0:
+		lo, hi = hi.Neg(hi), lo.Neg(lo)
+		r := simplestRat(lo, hi)
+		return r.Neg(r)
+	}
+
+	return simplestRat(lo, hi)
+}
+
+// simplestRat returns the simplest rational number in [lo, hi], with
+// 0 < lo <= hi. lo and hi are overwritten.
+func simplestRat(lo, hi *big.Rat) *big.Rat {
+
+	var terms []*big.Int
+	for {
+		// if there's an integer in [lo, hi], the smallest one is the
+		// last term; otherwise both endpoints share the term floor(lo)
+		fl := new(big.Int).Quo(lo.Num(), lo.Denom())
+		if !lo.IsInt() {
+			c := new(big.Int).Add(fl, big.NewInt(1))
+			if hi.Cmp(new(big.Rat).SetInt(c)) < 0 {
+				terms = append(terms, fl)
+				f := new(big.Rat).SetInt(fl)
+				lo.Sub(lo, f)
+				hi.Sub(hi, f)
+				lo, hi = hi.Inv(hi), lo.Inv(lo)
+				continue
+			}
+			fl = c
+		}
+		terms = append(terms, fl)
+		break
+	}
+
+	r := new(big.Rat).SetInt(terms[len(terms)-1])
+	for i := len(terms) - 2; i >= 0; i-- {
+		r.Inv(r).Add(r, new(big.Rat).SetInt(terms[i]))
+	}
+
+	return r
+}
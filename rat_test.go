@@ -0,0 +1,81 @@
+package bigfloat_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ThreeAndTwo/bigfloat"
+)
+
+func TestFromRat(t *testing.T) {
+	for _, test := range []struct {
+		r    *big.Rat
+		prec uint
+		acc  big.Accuracy
+	}{
+		{big.NewRat(1, 2), 1, big.Exact},
+		{big.NewRat(1, 3), 53, big.Below},
+		{big.NewRat(1, 10), 53, big.Above},
+		{big.NewRat(-1, 3), 53, big.Above},
+		{big.NewRat(7, 1), 2, big.Above},
+		{big.NewRat(0, 1), 10, big.Exact},
+	} {
+		z, acc := bigfloat.FromRat(test.r, test.prec)
+		if acc != test.acc || z.Prec() != test.prec {
+			t.Errorf("FromRat(%v, %d) = %g (%v); want accuracy %v", test.r, test.prec, z, acc, test.acc)
+		}
+		if r := bigfloat.ToRat(z); (r.Cmp(test.r) == 0) != (acc == big.Exact) {
+			t.Errorf("ToRat(FromRat(%v, %d)) = %v, with accuracy %v", test.r, test.prec, r, acc)
+		}
+	}
+}
+
+func TestToRat(t *testing.T) {
+	for _, test := range []struct {
+		x    *big.Float
+		want string
+	}{
+		{big.NewFloat(0.1), "3602879701896397/36028797018963968"},
+		{big.NewFloat(-2.5), "-5/2"},
+		{big.NewFloat(1e20), "100000000000000000000/1"},
+		{new(big.Float).SetMantExp(big.NewFloat(1), -1000), "1/" + new(big.Int).Lsh(big.NewInt(1), 1000).String()},
+	} {
+		if r := bigfloat.ToRat(test.x); r.String() != test.want {
+			t.Errorf("ToRat(%g) = %v; want %s", test.x, r, test.want)
+		}
+	}
+}
+
+func TestRatApprox(t *testing.T) {
+	pi, _, _ := new(big.Float).SetPrec(300).Parse(piStr, 10)
+	for _, test := range []struct {
+		x, tol *big.Float
+		want   string
+	}{
+		{pi, big.NewFloat(0.2), "3/1"},
+		{pi, big.NewFloat(0.01), "22/7"},
+		{pi, big.NewFloat(1e-6), "355/113"},
+		{new(big.Float).Neg(pi), big.NewFloat(1e-6), "-355/113"},
+		{big.NewFloat(0.1), big.NewFloat(1e-10), "1/10"},
+		{big.NewFloat(0.3333), big.NewFloat(0.001), "1/3"},
+		{big.NewFloat(0.4), big.NewFloat(0.5), "0/1"},
+		{big.NewFloat(2.75), big.NewFloat(0.25), "3/1"},
+		{big.NewFloat(2.75), big.NewFloat(0.3), "3/1"},
+		{big.NewFloat(0.1), big.NewFloat(0), "3602879701896397/36028797018963968"},
+	} {
+		r := bigfloat.RatApprox(test.x, test.tol)
+		if r.String() != test.want {
+			t.Errorf("RatApprox(%.10g, %g) = %v; want %s", test.x, test.tol, r, test.want)
+		}
+	}
+}
+
+// ---------- Benchmarks ----------
+
+func BenchmarkRatApprox(b *testing.B) {
+	x, _, _ := new(big.Float).SetPrec(1000).Parse(piStr, 10)
+	tol := new(big.Float).SetMantExp(big.NewFloat(1), -500)
+	for n := 0; n < b.N; n++ {
+		bigfloat.RatApprox(x, tol)
+	}
+}
@@ -0,0 +1,151 @@
+package bigfloat
+
+import (
+	"math/big"
+	"math/bits"
+)
+
+// ratPowMaxBits is the largest size, in bits, of the numerator and
+// the denominator of the exact power computed by PowRat.
+const ratPowMaxBits = 1 << 24
+
+// ExpRat returns exp(r) at the given precision (64 if prec is 0). r
+// is rounded to a working precision large enough for the result to
+// be accurate to prec bits, taking into account that the error of
+// the rounded argument is amplified by |r|.
+func ExpRat(r *big.Rat, prec uint) *big.Float {
+
+	if prec == 0 {
+		prec = 64
+	}
+
+	// exp(r(1+δ)) = exp(r)·(1 + r·δ)
+	wprec := prec + 64 + guardBits(ratLog2(r))
+	x := new(big.Float).SetPrec(wprec).SetRat(r)
+
+	return Exp(x).SetPrec(prec)
+}
+
+// LogRat returns the natural logarithm of r at the given precision
+// (64 if prec is 0). r is rounded to a working precision large enough
+// for the result to be accurate to prec bits, also when r is close
+// to 1, where log(r) is small. The function panics if r is negative,
+// and returns -Inf when r = 0.
+func LogRat(r *big.Rat, prec uint) *big.Float {
+
+	if r.Sign() < 0 {
+		panic("LogRat: argument is negative")
+	}
+	if prec == 0 {
+		prec = 64
+	}
+
+	// log(r(1+δ)) = log(r) + δ, and |log(r)| is about |r-1| when r is
+	// close to 1
+	d := new(big.Rat).Sub(r, big.NewRat(1, 1))
+	if d.Sign() == 0 {
+		return new(big.Float).SetPrec(prec)
+	}
+	wprec := prec + 64
+	if r.Sign() > 0 {
+		wprec += guardBits(-ratLog2(d))
+	}
+	x := new(big.Float).SetPrec(wprec).SetRat(r)
+
+	return Log(x).SetPrec(prec)
+}
+
+// PowRat returns x**y at the given precision (64 if prec is 0). When
+// y is an integer, and the exact rational power isn't too large, the
+// power is computed exactly and rounded a single time; otherwise x
+// and y are rounded to a working precision large enough for the
+// result to be accurate to prec bits. PowRat(x, 0) = 1 for any x,
+// including 0. The function panics if x is negative and y isn't an
+// integer.
+func PowRat(x, y *big.Rat, prec uint) *big.Float {
+
+	if prec == 0 {
+		prec = 64
+	}
+	z := new(big.Float).SetPrec(prec)
+
+	if y.IsInt() && y.Num().IsInt64() {
+		n := y.Num().Int64()
+		if n < 0 && x.Sign() == 0 {
+			return z.SetInf(false)
+		}
+		if size := int64(x.Num().BitLen() + x.Denom().BitLen()); n == 0 || (size*abs64(n) <= ratPowMaxBits) {
+			return z.SetRat(ratPowInt(x, n))
+		}
+	}
+
+	neg := false
+	switch {
+	case x.Sign() < 0 && !y.IsInt():
+		panic("PowRat: negative base with a non-integer exponent")
+	case x.Sign() < 0:
+		// an odd exponent gives a negative result
+		neg = y.Num().Bit(0) == 1
+		x = new(big.Rat).Neg(x)
+	case x.Sign() == 0:
+		if y.Sign() < 0 {
+			return z.SetInf(false)
+		}
+		return z
+	}
+
+	// x**y = exp(y·log(x)), and the error of the rounded arguments is
+	// amplified by |y·log(x)|
+	l := ratLog2(x)
+	if l < 0 {
+		l = -l
+	}
+	wprec := prec + 64 + guardBits(ratLog2(y)+bits.Len(uint(l)))
+	xf := new(big.Float).SetPrec(wprec).SetRat(x)
+	yf := new(big.Float).SetPrec(wprec).SetRat(y)
+	z.Set(Pow(xf, yf))
+	if neg {
+		z.Neg(z)
+	}
+
+	return z
+}
+
+// ratPowInt returns x**n exactly, for n >= 0 or x != 0.
+func ratPowInt(x *big.Rat, n int64) *big.Rat {
+
+	num := new(big.Int).Exp(x.Num(), big.NewInt(abs64(n)), nil)
+	den := new(big.Int).Exp(x.Denom(), big.NewInt(abs64(n)), nil)
+	if n < 0 {
+		num, den = den, num
+	}
+
+	return new(big.Rat).SetFrac(num, den)
+}
+
+// ratLog2 returns an approximation of log₂|r|, within 1 of the true
+// value, for r != 0.
+func ratLog2(r *big.Rat) int {
+	return r.Num().BitLen() - r.Denom().BitLen()
+}
+
+// guardBits returns the number of extra bits needed when an error is
+// amplified by about 2**e: e, if it's positive.
+func guardBits(e int) uint {
+
+	if e < 0 {
+		return 0
+	}
+
+	return uint(e)
+}
+
+// abs64 returns |n|.
+func abs64(n int64) int64 {
+
+	if n < 0 {
+		return -n
+	}
+
+	return n
+}
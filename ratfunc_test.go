@@ -0,0 +1,127 @@
+package bigfloat_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ThreeAndTwo/bigfloat"
+)
+
+// parseRat parses the decimal or fractional number s.
+func parseRat(s string) *big.Rat {
+	r, ok := new(big.Rat).SetString(s)
+	if !ok {
+		panic("bad rational " + s)
+	}
+	return r
+}
+
+// ulpsApart returns |x - y| in ulps of y, as a float64.
+func ulpsApart(x, y *big.Float) float64 {
+	d := new(big.Float).SetPrec(2*y.Prec()).Sub(x, y)
+	d.Quo(d, bigfloat.Ulp(y))
+	f, _ := d.Abs(d).Float64()
+	return f
+}
+
+func TestExpRat(t *testing.T) {
+	for _, test := range []struct {
+		r    string
+		prec uint
+	}{
+		{"1/3", 100},
+		{"-7/2", 200},
+		{"1000000/3", 100},
+		{"1/100000000000000000000000000000000", 100},
+	} {
+		r := parseRat(test.r)
+		z := bigfloat.ExpRat(r, test.prec)
+		want := bigfloat.Exp(new(big.Float).SetPrec(test.prec + 2000).SetRat(r))
+		want.SetPrec(test.prec)
+		if z.Prec() != test.prec || ulpsApart(z, want) > 1 {
+			t.Errorf("ExpRat(%s, %d) = %g; want %g", test.r, test.prec, z, want)
+		}
+	}
+}
+
+func TestLogRat(t *testing.T) {
+	for _, test := range []struct {
+		r    string
+		prec uint
+	}{
+		{"1/3", 100},
+		{"10", 200},
+		{"1000000000000000000000000000000000000000000000000000/3", 100},
+		{"100000000000000000000000000000000000000000000000001/100000000000000000000000000000000000000000000000000", 100},
+		{"99999999999999999999999999999/100000000000000000000000000000", 150},
+	} {
+		r := parseRat(test.r)
+		z := bigfloat.LogRat(r, test.prec)
+		want := bigfloat.Log(new(big.Float).SetPrec(test.prec + 2000).SetRat(r))
+		want.SetPrec(test.prec)
+		if z.Prec() != test.prec || ulpsApart(z, want) > 1 {
+			t.Errorf("LogRat(%s, %d) = %g; want %g", test.r, test.prec, z, want)
+		}
+	}
+
+	if z := bigfloat.LogRat(big.NewRat(1, 1), 10); z.Sign() != 0 || z.Prec() != 10 {
+		t.Errorf("LogRat(1) = %g", z)
+	}
+	if z := bigfloat.LogRat(new(big.Rat), 10); !z.IsInf() || z.Sign() > 0 {
+		t.Errorf("LogRat(0) = %g", z)
+	}
+}
+
+func TestPowRat(t *testing.T) {
+	// integer exponents are exact before the rounding
+	for _, test := range []struct {
+		x, y string
+		want string
+	}{
+		{"2/3", "3", "8/27"},
+		{"-2/3", "3", "-8/27"},
+		{"-2/3", "-2", "9/4"},
+		{"0", "0", "1"},
+		{"5", "0", "1"},
+		{"10", "-20", "1/100000000000000000000"},
+	} {
+		z := bigfloat.PowRat(parseRat(test.x), parseRat(test.y), 100)
+		want := new(big.Float).SetPrec(100).SetRat(parseRat(test.want))
+		if z.Cmp(want) != 0 {
+			t.Errorf("PowRat(%s, %s) = %g; want %g", test.x, test.y, z, want)
+		}
+	}
+
+	if z := bigfloat.PowRat(new(big.Rat), big.NewRat(-1, 1), 10); !z.IsInf() {
+		t.Errorf("PowRat(0, -1) = %g; want +Inf", z)
+	}
+
+	for _, test := range []struct {
+		x, y string
+		prec uint
+	}{
+		{"2", "1/2", 100},
+		{"1/3", "7/3", 200},
+		{"100000", "-1000/7", 100},
+		{"-3/2", "100000001", 100},
+	} {
+		x, y := parseRat(test.x), parseRat(test.y)
+		z := bigfloat.PowRat(x, y, test.prec)
+		xf := new(big.Float).SetPrec(test.prec + 2000).SetRat(new(big.Rat).Abs(x))
+		want := bigfloat.Pow(xf, new(big.Float).SetPrec(test.prec+2000).SetRat(y))
+		if x.Sign() < 0 {
+			want.Neg(want)
+		}
+		want.SetPrec(test.prec)
+		if ulpsApart(z, want) > 1 {
+			t.Errorf("PowRat(%s, %s, %d) = %g; want %g", test.x, test.y, test.prec, z, want)
+		}
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("PowRat(-2, 1/2) did not panic")
+		}
+	}()
+	bigfloat.PowRat(big.NewRat(-2, 1), big.NewRat(1, 2), 53)
+}
@@ -0,0 +1,68 @@
+package bigfloat
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// relErrMaxPrec is the largest precision EvalToRelErr evaluates a
+// computation at.
+const relErrMaxPrec = 1 << 24
+
+// EvalToRelErr evaluates the computation f, which returns its result
+// at about the precision it's given, at increasing precisions, until
+// two successive results agree to the relative error relErr:
+// |z - z′| <= relErr·|z|, where z is the last one. It returns z, at
+// its precision. The first precision is the one of relErr plus 32
+// guard bits, and it doubles at every step, so z is far more accurate
+// than the result before it, and its relative error is within relErr
+// as soon as the error of f shrinks with the precision, whatever the
+// bits it loses to cancellation.
+//
+// Two zeros, or two infinities of the same sign, agree. If the results
+// still disagree at the last precision below 2**24 bits, EvalToRelErr
+// returns the last one, with an error. The function panics if relErr
+// is not positive and finite.
+func EvalToRelErr(f func(prec uint) *big.Float, relErr *big.Float) (*big.Float, error) {
+
+	if relErr.Sign() <= 0 || relErr.IsInf() {
+		panic("EvalToRelErr: relative error is not positive and finite")
+	}
+
+	// relErr >= 2**(e-1) needs about 1-e bits
+	prec := uint(64)
+	if e := relErr.MantExp(nil); e < 0 {
+		prec = uint(-e) + 32
+	}
+	prev := f(prec)
+	for {
+		prec *= 2
+		z := f(prec)
+		if agree(z, prev, relErr) {
+			return z, nil
+		}
+		if 2*prec > relErrMaxPrec {
+			return z, fmt.Errorf("bigfloat: EvalToRelErr: no agreement to %g at %d bits", relErr, prec)
+		}
+		prev = z
+	}
+}
+
+// agree reports whether |z - y| <= relErr·|z|.
+func agree(z, y, relErr *big.Float) bool {
+
+	switch {
+	case z.IsInf() || y.IsInf():
+		return z.IsInf() && y.IsInf() && z.Sign() == y.Sign()
+	case z.Sign() == 0 || y.Sign() == 0:
+		return z.Sign() == 0 && y.Sign() == 0
+	}
+
+	prec := z.Prec() + y.Prec()
+	d := new(big.Float).SetPrec(prec).Sub(z, y)
+	d.Abs(d)
+	t := new(big.Float).SetPrec(prec).Abs(z)
+	t.Mul(t, relErr)
+
+	return d.Cmp(t) <= 0
+}
@@ -0,0 +1,75 @@
+package bigfloat_test
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/ThreeAndTwo/bigfloat"
+)
+
+func TestEvalToRelErr(t *testing.T) {
+	// exp(x) - 1 - x = x²/2 + x³/6 + ..., for x = 2**-100, loses 100
+	// bits to cancellation, and all of them below 200 bits
+	x := new(big.Float).SetMantExp(big.NewFloat(1), -100)
+	f := func(prec uint) *big.Float {
+		y := bigfloat.Exp(new(big.Float).SetPrec(prec).Set(x))
+		return y.Sub(y, big.NewFloat(1)).Sub(y, x)
+	}
+	want := new(big.Float).SetMantExp(big.NewFloat(1), -201)
+	relErr := new(big.Float).SetMantExp(big.NewFloat(1), -50)
+	z, err := bigfloat.EvalToRelErr(f, relErr)
+	if err != nil {
+		t.Fatalf("EvalToRelErr: %v", err)
+	}
+	d := new(big.Float).Sub(z, want)
+	d.Quo(d, want)
+	// x²/2, not the value, but they differ by about 2**-100
+	if d.Abs(d).Cmp(relErr) > 0 || z.Prec() < 300 {
+		t.Errorf("EvalToRelErr = %g at prec %d, with a relative error %g; want %g", z, z.Prec(), d, want)
+	}
+
+	// zeros, infinities, exact results
+	for _, test := range []struct {
+		f    func(prec uint) *big.Float
+		want string
+	}{
+		{func(prec uint) *big.Float { return new(big.Float).SetPrec(prec) }, "0"},
+		{func(prec uint) *big.Float { return new(big.Float).SetPrec(prec).SetInf(true) }, "-Inf"},
+		{func(prec uint) *big.Float { return new(big.Float).SetPrec(prec).SetInt64(3) }, "3"},
+	} {
+		if z, err := bigfloat.EvalToRelErr(test.f, big.NewFloat(1e-10)); err != nil || z.String() != test.want {
+			t.Errorf("EvalToRelErr = %v, %v; want %s", z, err, test.want)
+		}
+	}
+
+	// a precision for 1e-30, from the first one on
+	var precs []uint
+	g := func(prec uint) *big.Float {
+		precs = append(precs, prec)
+		return bigfloat.Pi.Value(prec)
+	}
+	bigfloat.EvalToRelErr(g, big.NewFloat(1e-30))
+	if fmt.Sprint(precs) != "[131 262]" {
+		t.Errorf("EvalToRelErr(π, 1e-30) evaluated at precisions %v; want [131 262]", precs)
+	}
+
+	// a computation that never settles
+	calls := 0
+	h := func(prec uint) *big.Float {
+		calls++
+		return new(big.Float).SetPrec(prec).SetMantExp(big.NewFloat(1), calls)
+	}
+	if z, err := bigfloat.EvalToRelErr(h, big.NewFloat(0.1)); err == nil || z.Prec() > 1<<24 {
+		t.Errorf("EvalToRelErr(never settles) = %g at prec %d, %v; want an error", z, z.Prec(), err)
+	}
+
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Error("EvalToRelErr(f, 0) didn't panic")
+			}
+		}()
+		bigfloat.EvalToRelErr(g, new(big.Float))
+	}()
+}
@@ -0,0 +1,329 @@
+package bigfloat
+
+import "math/big"
+
+// remezMaxIter is the maximum number of exchange iterations done by
+// RemezRational.
+const remezMaxIter = 50
+
+// Remez returns the coefficients p[0], ..., p[n] of the polynomial
+//
+//	p(x) = p[0] + p[1]·x + ... + p[n]·xⁿ
+//
+// of degree n that best approximates f on [a, b] in the minimax sense,
+// together with the maximum absolute error |f(x) - p(x)| on [a, b].
+// Precision is the same as the one of a. The coefficients are found
+// using the Remez exchange algorithm, which requires f to be
+// continuous on [a, b]. The function panics if a >= b or n < 0.
+func Remez(f func(*big.Float) *big.Float, a, b *big.Float, n int) (p []*big.Float, maxErr *big.Float) {
+	p, _, maxErr = RemezRational(f, a, b, n, 0)
+	return p, maxErr
+}
+
+// RemezRational returns the coefficients of the numerator p (of
+// degree m) and of the denominator q (of degree n, with q[0] = 1) of
+// the rational function p(x)/q(x) that best approximates f on
+// [a, b] in the minimax sense, together with the maximum absolute
+// error |f(x) - p(x)/q(x)| on [a, b]. Precision is the same as the
+// one of a. The function panics if a >= b, m < 0 or n < 0.
+//
+// At every exchange step of the Remez algorithm, the nonlinear system
+// for the coefficients and the levelled error E
+//
+//	p(x[i]) - (f(x[i]) + (-1)ⁱ·E)·q(x[i]) = 0
+//
+// is solved by fixing E in the products with q, and iterating. That
+// works well unless the approximation has poles close to [a, b], in
+// which case there's no guarantee of convergence, and the returned
+// coefficients are the best found after a fixed number of steps.
+func RemezRational(f func(*big.Float) *big.Float, a, b *big.Float, m, n int) (p, q []*big.Float, maxErr *big.Float) {
+
+	if a.Cmp(b) >= 0 {
+		panic("RemezRational: a >= b")
+	}
+	if m < 0 || n < 0 {
+		panic("RemezRational: negative degree")
+	}
+
+	prec := a.Prec()
+	wprec := prec + 64 // guard digits
+
+	r := &remez{f: f, m: m, n: n, prec: wprec}
+	r.a = new(big.Float).SetPrec(wprec).Set(a)
+	r.b = new(big.Float).SetPrec(wprec).Set(b)
+
+	// initial reference: the extrema of the Chebyshev polynomial of
+	// degree m+n+1, mapped to [a, b]
+	k := m + n + 2
+	mid := new(big.Float).SetPrec(wprec).Add(r.a, r.b)
+	mid.Mul(mid, big.NewFloat(0.5))
+	rad := new(big.Float).SetPrec(wprec).Sub(r.b, r.a)
+	rad.Mul(rad, big.NewFloat(0.5))
+	x := make([]*big.Float, k)
+	for i := range x {
+		t := new(big.Float).SetPrec(wprec).SetInt64(int64(k - 1 - i))
+		t.Mul(t, pi(wprec)).Quo(t, big.NewFloat(float64(k-1)))
+		_, c := sincos(t)
+		x[i] = c.Mul(c, rad).Add(c, mid)
+	}
+	x[0].Set(r.a)
+	x[k-1].Set(r.b)
+
+	e := new(big.Float).SetPrec(wprec)
+	for iter := 0; iter < remezMaxIter; iter++ {
+		e = r.solve(x, e)
+		var next []*big.Float
+		next, maxErr = r.exchange(x)
+
+		// stop when the error is levelled
+		d := new(big.Float).Abs(e)
+		d.Sub(maxErr, d)
+		if maxErr.Sign() == 0 || d.Sign() <= 0 || d.MantExp(nil) <= maxErr.MantExp(nil)-40 {
+			break
+		}
+		x = next
+	}
+
+	p = make([]*big.Float, m+1)
+	for i := range p {
+		p[i] = r.p[i].SetPrec(prec)
+	}
+	q = make([]*big.Float, n+1)
+	for i := range q {
+		q[i] = r.q[i].SetPrec(prec)
+	}
+
+	return p, q, maxErr.SetPrec(prec)
+}
+
+// remez holds the state of the exchange algorithm.
+type remez struct {
+	f    func(*big.Float) *big.Float
+	a, b *big.Float
+	m, n int
+	prec uint
+	p, q []*big.Float // current approximation
+}
+
+// solve computes the rational function that has errors of alternating
+// sign and equal magnitude at the points x, starting from the guess
+// e for the levelled error, and returns the levelled error.
+func (r *remez) solve(x []*big.Float, e *big.Float) *big.Float {
+
+	k := len(x)
+	fx := make([]*big.Float, k)
+	for i := range x {
+		fx[i] = new(big.Float).SetPrec(r.prec).Set(r.f(x[i]))
+	}
+
+	// without a denominator the system is linear, and a single
+	// iteration is enough
+	iters := 1
+	if r.n > 0 {
+		iters = 20
+	}
+
+	for it := 0; it < iters; it++ {
+		// unknowns: p[0..m], q[1..n], E
+		//     Σ p[j]·xʲ - (f(x) + (-1)ⁱ·e)·Σ q[j]·xʲ - (-1)ⁱ·E = f(x)
+		A := make([][]*big.Float, k)
+		rhs := make([]*big.Float, k)
+		for i := range x {
+			row := make([]*big.Float, k)
+			s := new(big.Float).SetPrec(r.prec).Set(e) // (-1)ⁱ·e
+			if i%2 == 1 {
+				s.Neg(s)
+			}
+			s.Add(s, fx[i])
+
+			xj := big.NewFloat(1).SetPrec(r.prec)
+			for j := 0; j <= r.m; j++ {
+				row[j] = new(big.Float).Copy(xj)
+				xj.Mul(xj, x[i])
+			}
+			xj.SetFloat64(1)
+			for j := 1; j <= r.n; j++ {
+				xj.Mul(xj, x[i])
+				row[r.m+j] = new(big.Float).SetPrec(r.prec).Mul(s, xj)
+				row[r.m+j].Neg(row[r.m+j])
+			}
+			row[k-1] = big.NewFloat(-1).SetPrec(r.prec)
+			if i%2 == 1 {
+				row[k-1].Neg(row[k-1])
+			}
+
+			A[i], rhs[i] = row, new(big.Float).Copy(fx[i])
+		}
+
+		sol := solveLinear(A, rhs)
+
+		r.p = sol[:r.m+1]
+		r.q = append([]*big.Float{big.NewFloat(1).SetPrec(r.prec)}, sol[r.m+1:k-1]...)
+		prev := e
+		e = sol[k-1]
+		if d := new(big.Float).Sub(e, prev); d.Sign() == 0 || (e.Sign() != 0 && d.MantExp(nil) <= e.MantExp(nil)-int(r.prec)) {
+			break
+		}
+	}
+
+	return e
+}
+
+// err returns f(x) - p(x)/q(x).
+func (r *remez) err(x *big.Float) *big.Float {
+
+	horner := func(c []*big.Float) *big.Float {
+		s := new(big.Float).SetPrec(r.prec).Set(c[len(c)-1])
+		for j := len(c) - 2; j >= 0; j-- {
+			s.Mul(s, x).Add(s, c[j])
+		}
+		return s
+	}
+
+	y := horner(r.p)
+	if r.n > 0 {
+		y.Quo(y, horner(r.q))
+	}
+
+	return y.Sub(new(big.Float).SetPrec(r.prec).Set(r.f(x)), y)
+}
+
+// exchange returns a new reference, made of the points where the
+// error of the current approximation has local extrema of
+// alternating sign, and the largest absolute error on [a, b].
+//
+// Since the error alternates in sign at the points of the old
+// reference, it has a zero between any two consecutive points. The
+// zeros are located by bisection, and the new reference is given by
+// the extrema of the error between consecutive zeros (and the
+// endpoints), found by golden section search.
+func (r *remez) exchange(x []*big.Float) ([]*big.Float, *big.Float) {
+
+	k := len(x)
+
+	// zeros of the error, to about half precision
+	bounds := []*big.Float{r.a}
+	for i := 0; i+1 < k; i++ {
+		lo := new(big.Float).SetPrec(r.prec).Set(x[i])
+		hi := new(big.Float).SetPrec(r.prec).Set(x[i+1])
+		slo := r.err(lo).Sign()
+		for it := 0; it < int(r.prec)/2; it++ {
+			mid := new(big.Float).SetPrec(r.prec).Add(lo, hi)
+			mid.Mul(mid, big.NewFloat(0.5))
+			s := r.err(mid).Sign()
+			if s == 0 {
+				lo, hi = mid, mid
+				break
+			}
+			if s == slo {
+				lo = mid
+			} else {
+				hi = mid
+			}
+		}
+		bounds = append(bounds, lo)
+	}
+	bounds = append(bounds, r.b)
+
+	// extrema of |error| between consecutive zeros
+	next := make([]*big.Float, k)
+	maxErr := new(big.Float).SetPrec(r.prec)
+	for i := 0; i < k; i++ {
+		x, e := r.maximize(bounds[i], bounds[i+1])
+		next[i] = x
+		if e.Cmp(maxErr) > 0 {
+			maxErr = e
+		}
+	}
+
+	return next, maxErr
+}
+
+// maximize returns the point in [lo, hi] where |f - p/q| attains its
+// maximum, and the maximum, assuming |f - p/q| is unimodal on the
+// interval. The endpoints are included in the search.
+func (r *remez) maximize(lo, hi *big.Float) (*big.Float, *big.Float) {
+
+	abs := func(x *big.Float) *big.Float { return new(big.Float).Abs(r.err(x)) }
+
+	// golden section search; the location is only needed to about
+	// half precision, since the error is flat near the extremum
+	phi := Sqrt(big.NewFloat(5).SetPrec(r.prec))
+	phi.Sub(phi, big.NewFloat(1)).Mul(phi, big.NewFloat(0.5)) // 1/φ
+
+	a := new(big.Float).SetPrec(r.prec).Set(lo)
+	b := new(big.Float).SetPrec(r.prec).Set(hi)
+	point := func(t *big.Float, u *big.Float) *big.Float {
+		// t + (u-t)/φ
+		d := new(big.Float).SetPrec(r.prec).Sub(u, t)
+		return d.Mul(d, phi).Add(d, t)
+	}
+	c := point(b, a)
+	d := point(a, b)
+	fc, fd := abs(c), abs(d)
+	for it := 0; it < int(r.prec)*3/4; it++ {
+		if fc.Cmp(fd) > 0 {
+			b, d, fd = d, c, fc
+			c = point(b, a)
+			fc = abs(c)
+		} else {
+			a, c, fc = c, d, fd
+			d = point(a, b)
+			fd = abs(d)
+		}
+	}
+
+	x, fx := c, fc
+	if fd.Cmp(fx) > 0 {
+		x, fx = d, fd
+	}
+	for _, t := range []*big.Float{lo, hi} {
+		if ft := abs(t); ft.Cmp(fx) > 0 {
+			x, fx = new(big.Float).SetPrec(r.prec).Set(t), ft
+		}
+	}
+
+	return x, fx
+}
+
+// solveLinear solves the linear system A·x = b using Gaussian
+// elimination with partial pivoting. A and b are overwritten. The
+// function panics if A is singular.
+func solveLinear(A [][]*big.Float, b []*big.Float) []*big.Float {
+
+	n := len(b)
+	t := new(big.Float)
+	for col := 0; col < n; col++ {
+		// pivot
+		piv := col
+		for i := col + 1; i < n; i++ {
+			if new(big.Float).Abs(A[i][col]).Cmp(new(big.Float).Abs(A[piv][col])) > 0 {
+				piv = i
+			}
+		}
+		if A[piv][col].Sign() == 0 {
+			panic("solveLinear: singular matrix")
+		}
+		A[col], A[piv] = A[piv], A[col]
+		b[col], b[piv] = b[piv], b[col]
+
+		for i := col + 1; i < n; i++ {
+			l := new(big.Float).Quo(A[i][col], A[col][col])
+			for j := col; j < n; j++ {
+				A[i][j].Sub(A[i][j], t.Mul(l, A[col][j]))
+			}
+			b[i].Sub(b[i], t.Mul(l, b[col]))
+		}
+	}
+
+	x := make([]*big.Float, n)
+	for i := n - 1; i >= 0; i-- {
+		s := new(big.Float).Copy(b[i])
+		for j := i + 1; j < n; j++ {
+			s.Sub(s, t.Mul(A[i][j], x[j]))
+		}
+		x[i] = s.Quo(s, A[i][i])
+	}
+
+	return x
+}
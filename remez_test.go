@@ -0,0 +1,93 @@
+package bigfloat_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ThreeAndTwo/bigfloat"
+)
+
+func TestRemezLinear(t *testing.T) {
+	// The best linear approximation of exp on [0, 1] is a + b·x, with
+	//     b = e - 1, a = (1 + b - b·log(b))/2
+	// and error E = 1 - a.
+	const prec = 100
+	a := new(big.Float).SetPrec(prec)
+	b := big.NewFloat(1).SetPrec(prec)
+	p, maxErr := bigfloat.Remez(bigfloat.Exp, a, b, 1)
+
+	wb := bigfloat.Exp(b)
+	wb.Sub(wb, big.NewFloat(1))
+	wa := bigfloat.Log(wb)
+	wa.Mul(wa, wb).Sub(wb, wa).Add(wa, big.NewFloat(1)).Mul(wa, big.NewFloat(0.5))
+	we := new(big.Float).Sub(big.NewFloat(1), wa)
+
+	if !closeTo(p[0], wa, 90) || !closeTo(p[1], wb, 90) {
+		t.Errorf("Remez(exp, 0, 1, 1) =\ngot  %g + %g·x;\nwant %g + %g·x", p[0], p[1], wa, wb)
+	}
+	if !closeTo(maxErr, we, 30) {
+		t.Errorf("Remez(exp, 0, 1, 1) error =\ngot  %g;\nwant %g", maxErr, we)
+	}
+}
+
+func TestRemezEquioscillation(t *testing.T) {
+	const prec = 80
+	a := big.NewFloat(-1).SetPrec(prec)
+	b := big.NewFloat(1).SetPrec(prec)
+	p, maxErr := bigfloat.Remez(bigfloat.Exp, a, b, 4)
+	if len(p) != 5 {
+		t.Fatalf("len(p) = %d; want 5", len(p))
+	}
+
+	// The error of the degree-4 minimax approximation of exp on
+	// [-1, 1] is close to 1/(2⁴·5!) ≈ 5.2e-4.
+	if e, _ := maxErr.Float64(); e < 5e-4 || e > 6e-4 {
+		t.Errorf("Remez(exp, -1, 1, 4) error = %g; want ≈ 5.5e-4", e)
+	}
+
+	// sample the error: it must never exceed maxErr (by more than
+	// the levelling tolerance)
+	lim := new(big.Float).Mul(maxErr, big.NewFloat(1+1e-9))
+	for i := 0; i <= 200; i++ {
+		x := big.NewFloat(float64(i)/100 - 1).SetPrec(prec)
+		y := new(big.Float).Set(p[4])
+		for j := 3; j >= 0; j-- {
+			y.Mul(y, x).Add(y, p[j])
+		}
+		y.Sub(bigfloat.Exp(x), y).Abs(y)
+		if y.Cmp(lim) > 0 {
+			t.Errorf("|exp(%g) - p(%g)| = %g > %g", x, x, y, maxErr)
+		}
+	}
+}
+
+func TestRemezRational(t *testing.T) {
+	// 1/(2+x) is a rational function of degree (0, 1), so the best
+	// approximation has no error.
+	f := func(x *big.Float) *big.Float {
+		y := new(big.Float).Add(x, big.NewFloat(2))
+		return y.Quo(big.NewFloat(1), y)
+	}
+
+	const prec = 100
+	a := big.NewFloat(0).SetPrec(prec)
+	b := big.NewFloat(1).SetPrec(prec)
+	p, q, maxErr := bigfloat.RemezRational(f, a, b, 0, 1)
+
+	if !closeTo(p[0], big.NewFloat(0.5), 90) || !closeTo(q[1], big.NewFloat(0.5), 90) {
+		t.Errorf("RemezRational(1/(2+x)) = %g/(%g + %g·x); want 0.5/(1 + 0.5·x)", p[0], q[0], q[1])
+	}
+	if maxErr.Sign() != 0 && maxErr.MantExp(nil) > -90 {
+		t.Errorf("RemezRational(1/(2+x)) error = %g; want 0", maxErr)
+	}
+
+	// a genuine rational approximation: exp ≈ (1 + x/2)/(1 - x/2)
+	// near 0 is the (1,1) Padé approximant; the minimax error on
+	// [-1/2, 1/2] is smaller than the Padé one.
+	a = big.NewFloat(-0.5).SetPrec(prec)
+	b = big.NewFloat(0.5).SetPrec(prec)
+	_, _, maxErr = bigfloat.RemezRational(bigfloat.Exp, a, b, 1, 1)
+	if e, _ := maxErr.Float64(); e <= 0 || e > 5e-3 {
+		t.Errorf("RemezRational(exp, -0.5, 0.5, 1, 1) error = %g", e)
+	}
+}
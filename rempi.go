@@ -0,0 +1,83 @@
+package bigfloat
+
+import (
+	"math"
+	"math/big"
+)
+
+// RemPiOver2 returns r and q with x = n·π/2 + r, where n is the
+// integer nearest to x/(π/2) and q = n mod 4 is the quadrant of x, so
+// that |r| <= π/4. The sign of r tells in which half of the quadrant x
+// is, which gives the octant 2q + (1 + sign(r))/2, modulo 8, of x.
+//
+// r has the precision of x, and is accurate to about its last bit even
+// when x is huge, or close to a multiple of π/2: π is computed with
+// as many more bits as the cancellation in x - n·π/2 takes. The
+// function panics if x is infinite.
+func RemPiOver2(x *big.Float) (r *big.Float, q int) {
+
+	if x.IsInf() {
+		panic("RemPiOver2: infinite argument")
+	}
+
+	r, n := remPi(x, x.Prec(), -1)
+	q = int(new(big.Int).And(n, big.NewInt(3)).Int64())
+
+	return r, q
+}
+
+// Rem2Pi returns r with x = n·2π + r for an integer n and |r| <= π,
+// with the precision and the accuracy of RemPiOver2. The function
+// panics if x is infinite.
+func Rem2Pi(x *big.Float) *big.Float {
+
+	if x.IsInf() {
+		panic("Rem2Pi: infinite argument")
+	}
+
+	r, _ := remPi(x, x.Prec(), 1)
+
+	return r
+}
+
+// remPi returns r and n with x = n·π·2**s + r, for the integer n
+// nearest to x/(π·2**s), and r rounded to prec bits.
+func remPi(x *big.Float, prec uint, s int) (r *big.Float, n *big.Int) {
+
+	n = new(big.Int)
+	ex := x.MantExp(nil)
+	if x.Sign() == 0 || ex < s {
+		// |x| < π·2**(s-1), so n is 0
+		return new(big.Float).SetPrec(prec).Set(x), n
+	}
+
+	// The product n·π·2**s cancels with x in the subtraction, so π
+	// must be precise enough to give an absolute error of 2**(-prec)
+	// in r, plus the bits lost in the cancellation, which are known
+	// after the subtraction.
+	wprec := prec + uint(ex) + 64
+	for {
+		c := pi(wprec)
+		c.SetMantExp(c, s)
+
+		t := new(big.Float).SetPrec(wprec).Quo(x, c)
+		t.Add(t, big.NewFloat(math.Copysign(0.5, float64(t.Sign()))))
+		t.Int(n)
+		r = new(big.Float).SetPrec(wprec).SetInt(n)
+		r.Sub(x, r.Mul(r, c))
+
+		// the absolute error of r is about 2**(ex - wprec)
+		if r.Sign() == 0 {
+			wprec *= 2
+			continue
+		}
+		lost := ex - r.MantExp(nil)
+		if lost < 0 {
+			lost = 0
+		}
+		if wprec >= prec+uint(lost)+32 {
+			return r.SetPrec(prec), n
+		}
+		wprec = prec + uint(lost) + 64
+	}
+}
@@ -0,0 +1,107 @@
+package bigfloat_test
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/ThreeAndTwo/bigfloat"
+)
+
+// refRemPi returns x - n·π·2**s for the integer n nearest to
+// x/(π·2**s), and n mod 4, computed with 5000 bits.
+func refRemPi(x *big.Float, s int) (*big.Float, int) {
+
+	c := bigfloat.Pi.Value(5000)
+	c.SetMantExp(c, s)
+	t := new(big.Float).SetPrec(5000).Quo(x, c)
+	n := bigfloat.RoundInt(t, big.ToNearestAway)
+	r := new(big.Float).SetPrec(5000).SetInt(n)
+	r.Sub(x, r.Mul(r, c))
+
+	return r, int(new(big.Int).And(n, big.NewInt(3)).Int64())
+}
+
+// remPiArgs returns the arguments of the tests: a few small ones, huge
+// ones, and some close to multiples of π/2.
+func remPiArgs(prec uint) []*big.Float {
+
+	var xs []*big.Float
+	for _, f := range []float64{0.5, 1, -1, 10, -10, 3.14159, 1e6 + 0.123, 1e22, -1e300} {
+		xs = append(xs, new(big.Float).SetPrec(prec).SetFloat64(f))
+	}
+
+	// the multiples of π/2 rounded to prec bits are close to them,
+	// and a float64 whose reduction is known to be hard
+	for _, k := range []int64{1, 4, 1000001, 1 << 40} {
+		x := bigfloat.Pi.Value(prec + 64)
+		x.Mul(x, new(big.Float).SetInt64(k)).SetMantExp(x, -1)
+		xs = append(xs, x.SetPrec(prec))
+	}
+	x := new(big.Float).SetPrec(prec).SetInt64(6381956970095103)
+	xs = append(xs, x.SetMantExp(x, 797))
+
+	return xs
+}
+
+func TestRemPiOver2(t *testing.T) {
+	for _, prec := range []uint{53, 100, 1000} {
+		for _, x := range remPiArgs(prec) {
+			r, q := bigfloat.RemPiOver2(x)
+			want, wq := refRemPi(x, -1)
+			if r.Prec() != prec || q != wq || relErr(r, want).Cmp(big.NewFloat(math.Ldexp(1, -int(prec)+1))) > 0 {
+				t.Errorf("prec %d: RemPiOver2(%g) = %g, %d; want %g, %d", prec, x, r, q, want, wq)
+			}
+			if r.Cmp(big.NewFloat(math.Pi/4+1e-15)) > 0 || r.Cmp(big.NewFloat(-math.Pi/4-1e-15)) < 0 {
+				t.Errorf("prec %d: RemPiOver2(%g) = %g isn't in [-π/4, π/4]", prec, x, r)
+			}
+		}
+	}
+
+	// the quadrants of the negative multiples are positive
+	if _, q := bigfloat.RemPiOver2(big.NewFloat(-10)); q != 2 {
+		t.Errorf("RemPiOver2(-10) quadrant = %d; want 2", q)
+	}
+	if r, q := bigfloat.RemPiOver2(new(big.Float)); r.Sign() != 0 || q != 0 {
+		t.Errorf("RemPiOver2(0) = %g, %d; want 0, 0", r, q)
+	}
+}
+
+func TestRem2Pi(t *testing.T) {
+	for _, prec := range []uint{53, 100, 1000} {
+		for _, x := range remPiArgs(prec) {
+			r := bigfloat.Rem2Pi(x)
+			want, _ := refRemPi(x, 1)
+			if r.Prec() != prec || relErr(r, want).Cmp(big.NewFloat(math.Ldexp(1, -int(prec)+1))) > 0 {
+				t.Errorf("prec %d: Rem2Pi(%g) = %g; want %g", prec, x, r, want)
+			}
+		}
+	}
+
+	if r := bigfloat.Rem2Pi(big.NewFloat(3)); r.Cmp(big.NewFloat(3)) != 0 {
+		t.Errorf("Rem2Pi(3) = %g; want 3", r)
+	}
+}
+
+func TestRemPiPanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("RemPiOver2(+Inf) didn't panic")
+		}
+	}()
+	bigfloat.RemPiOver2(new(big.Float).SetInf(false))
+}
+
+// ---------- Benchmarks ----------
+
+func BenchmarkRemPiOver2(b *testing.B) {
+	for _, prec := range []uint{53, 1000, 10000} {
+		x := new(big.Float).SetPrec(prec).SetFloat64(1e22)
+		b.Run(fmt.Sprintf("%v", prec), func(b *testing.B) {
+			for n := 0; n < b.N; n++ {
+				_, _ = bigfloat.RemPiOver2(x)
+			}
+		})
+	}
+}
@@ -0,0 +1,65 @@
+package bigfloat
+
+import (
+	"math"
+	"math/big"
+)
+
+// Root returns the real n-th root of x. Precision is the same as the
+// one of the argument. For x < 0 and an odd n the root is the negative
+// real number -Root(-x, n), as math.Cbrt gives for n = 3, so that
+// Root(-8, 3) = -2. The function returns ±0 when x = ±0, and ±Inf when
+// x = ±Inf. It panics if n < 1, or if x < 0 and n is even.
+func Root(x *big.Float, n int) *big.Float {
+
+	defer trace("Root", x.Prec())()
+
+	if n < 1 {
+		panic("Root: n < 1")
+	}
+	if x.Sign() < 0 && n%2 == 0 {
+		panic("Root: argument is negative and n is even")
+	}
+
+	prec := x.Prec()
+	if n == 1 || x.Sign() == 0 || x.IsInf() {
+		return new(big.Float).SetPrec(prec).Set(x)
+	}
+
+	// x = ±m·2**(n·q + r), for 0 <= r < n, whose root is
+	// ±(m·2**r)**(1/n)·2**q
+	a := new(big.Float).SetPrec(prec + 64)
+	e := x.MantExp(a)
+	a.Abs(a)
+	q, r := e/n, e%n
+	if r < 0 {
+		q, r = q-1, r+n
+	}
+
+	// estimate from float64, on the logarithm since a can be huge
+	mf, _ := a.Float64()
+	guess := big.NewFloat(math.Exp2((math.Log2(mf) + float64(r)) / float64(n)))
+	a.SetMantExp(a, r)
+
+	// f(t)/f'(t) = (t - a/t**(n-1))/n, for f(t) = t**n - a
+	nf := new(big.Float).SetInt64(int64(n))
+	f := func(t *big.Float) *big.Float {
+		y := new(big.Float).SetPrec(t.Prec())
+		y.Quo(a, powInt(t, n-1))
+		y.Sub(t, y)
+		return y.Quo(y, nf)
+	}
+	z := newton(f, guess, prec+64)
+	z.SetMantExp(z, q)
+	if x.Sign() < 0 {
+		z.Neg(z)
+	}
+
+	return z.SetPrec(prec)
+}
+
+// Cbrt returns the real cube root of x, Root(x, 3), which is negative
+// for x < 0. Precision is the same as the one of the argument.
+func Cbrt(x *big.Float) *big.Float {
+	return Root(x, 3)
+}
@@ -0,0 +1,96 @@
+package bigfloat_test
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/ThreeAndTwo/bigfloat"
+)
+
+func TestRoot(t *testing.T) {
+	for _, test := range []struct {
+		x    string
+		n    int
+		want string
+	}{
+		{"8", 3, "2"},
+		{"-8", 3, "-2"},
+		{"-27", 3, "-3"},
+		{"2", 3, "1.2599210498948731647672106072782283505702514647015079800819751121552996765139594837293965624362550941543102560356156652593990240406137372284591103042693552469606426166250009774745265654803068671854055186892458725167641993737096950983827831613991551293136953661839474634485765703031190958959847411059811629070535908164780"},
+		{"-2", 3, "-1.2599210498948731647672106072782283505702514647015079800819751121552996765139594837293965624362550941543102560356156652593990240406137372284591103042693552469606426166250009774745265654803068671854055186892458725167641993737096950983827831613991551293136953661839474634485765703031190958959847411059811629070535908164780"},
+		{"2", 2, "1.4142135623730950488016887242096980785696718753769480731766797379907324784621070388503875343276415727350138462309122970249248360558507372126441214970999358314132226659275055927557999505011527820605714701095599716059702745345968620147285174186408891986095523292304843087143214508397626036279952514079896872533965463318088296406206152583523950547457503"},
+		{"-32", 5, "-2"},
+		{"1e300", 3, "1e100"},
+		{"-1e-300", 3, "-1e-100"},
+		{"1p3003", 7, "1p429"},
+		{"-1p-3003", 7, "-1p-429"},
+		{"3", 1000, "1.0010992159842040529200351348094658788173493815083512834111165726595017078210341880501536032910409892930737107267970051965662045611652150393020023993447343731494049446167320996723659818524569390314598415649522563232648053398871763210294465112718677161265828406602150893127320082663163514169331564687089423488119443856440"},
+		{"5", 1, "5"},
+		{"-5", 1, "-5"},
+	} {
+		for _, prec := range []uint{24, 53, 100, 500, 1000} {
+			x, _, _ := new(big.Float).SetPrec(prec).Parse(test.x, 10)
+			want, _, _ := new(big.Float).SetPrec(prec).Parse(test.want, 10)
+			got := bigfloat.Root(x, test.n)
+			if !closeTo(got, want, int(prec)-1) || got.Prec() != prec {
+				t.Errorf("Root(%s, %d) at prec %d = %g; want %g", test.x, test.n, prec, got, want)
+			}
+		}
+	}
+}
+
+func TestRootSpecial(t *testing.T) {
+	for _, test := range []struct {
+		x float64
+		n int
+	}{
+		{0, 3}, {math.Copysign(0, -1), 3}, {0, 2},
+		{math.Inf(+1), 3}, {math.Inf(-1), 3}, {math.Inf(+1), 4},
+	} {
+		got := bigfloat.Root(big.NewFloat(test.x), test.n)
+		if f, _ := got.Float64(); f != test.x || math.Signbit(f) != math.Signbit(test.x) {
+			t.Errorf("Root(%g, %d) = %g; want %g", test.x, test.n, got, test.x)
+		}
+	}
+
+	for _, test := range []struct {
+		x float64
+		n int
+	}{
+		{-4, 2}, {-1, 4}, {2, 0}, {2, -3},
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("Root(%g, %d) didn't panic", test.x, test.n)
+				}
+			}()
+			bigfloat.Root(big.NewFloat(test.x), test.n)
+		}()
+	}
+}
+
+func TestCbrt(t *testing.T) {
+	// as math.Cbrt, which is correctly rounded on these
+	for _, x := range []float64{-1000, -10, -2, -0.125, 1e-10, 3, 7e20} {
+		if got, _ := bigfloat.Cbrt(big.NewFloat(x)).Float64(); got != math.Cbrt(x) {
+			t.Errorf("Cbrt(%g) = %g; want %g", x, got, math.Cbrt(x))
+		}
+	}
+}
+
+// ---------- Benchmarks ----------
+
+func BenchmarkCbrt(b *testing.B) {
+	for _, prec := range []uint{1e2, 1e3, 1e4} {
+		x := new(big.Float).SetPrec(prec).SetInt64(-2)
+		b.Run(fmt.Sprintf("%v", prec), func(b *testing.B) {
+			b.ReportAllocs()
+			for n := 0; n < b.N; n++ {
+				bigfloat.Cbrt(x)
+			}
+		})
+	}
+}
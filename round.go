@@ -0,0 +1,97 @@
+package bigfloat
+
+import "math/big"
+
+// Floor returns the largest integer value less than or equal to x.
+// Precision is the same as the one of the argument. Floor(±0) = ±0
+// and Floor(±Inf) = ±Inf.
+func Floor(x *big.Float) *big.Float {
+	return roundFloat(x, big.ToNegativeInf)
+}
+
+// Ceil returns the smallest integer value greater than or equal to
+// x. Precision is the same as the one of the argument. Ceil(±0) = ±0,
+// Ceil(±Inf) = ±Inf, and Ceil(x) = -0 for -1 < x < 0.
+func Ceil(x *big.Float) *big.Float {
+	return roundFloat(x, big.ToPositiveInf)
+}
+
+// Trunc returns the integer value of x. Precision is the same as the
+// one of the argument. Trunc(±0) = ±0, Trunc(±Inf) = ±Inf, and the
+// result has the sign of x when it's zero.
+func Trunc(x *big.Float) *big.Float {
+	return roundFloat(x, big.ToZero)
+}
+
+// Round returns the nearest integer to x, rounding half away from
+// zero, like math.Round. Precision is the same as the one of the
+// argument. Round(±0) = ±0, Round(±Inf) = ±Inf, and the result has
+// the sign of x when it's zero.
+func Round(x *big.Float) *big.Float {
+	return roundFloat(x, big.ToNearestAway)
+}
+
+// RoundToEven returns the nearest integer to x, rounding ties to
+// even, like math.RoundToEven. Precision is the same as the one of
+// the argument. RoundToEven(±0) = ±0, RoundToEven(±Inf) = ±Inf, and
+// the result has the sign of x when it's zero.
+func RoundToEven(x *big.Float) *big.Float {
+	return roundFloat(x, big.ToNearestEven)
+}
+
+// roundFloat returns x rounded to an integer with the given rounding
+// mode, at the precision of x.
+func roundFloat(x *big.Float, mode big.RoundingMode) *big.Float {
+
+	z := new(big.Float).SetPrec(x.Prec()).SetMode(x.Mode())
+	if x.IsInf() || x.IsInt() {
+		return z.Set(x)
+	}
+
+	// x has a fractional part, so |x| < 2**prec, and the rounded
+	// value always fits the precision
+	z.SetInt(RoundInt(x, mode))
+	if z.Sign() == 0 && x.Signbit() {
+		z.Neg(z)
+	}
+
+	return z
+}
+
+// RoundInt returns x rounded to an integer with the given rounding
+// mode. The function panics if x is an infinity.
+func RoundInt(x *big.Float, mode big.RoundingMode) *big.Int {
+
+	if x.IsInf() {
+		panic("RoundInt: infinite argument")
+	}
+
+	t, acc := x.Int(nil)
+	if acc == big.Exact {
+		return t
+	}
+
+	// x - t is exact, since it only drops the leading bits of x
+	f := new(big.Float).SetPrec(x.Prec()).SetInt(t)
+	f.Sub(x, f)
+	c := f.Abs(f).Cmp(big.NewFloat(0.5))
+
+	var away bool
+	switch mode {
+	case big.ToNearestEven:
+		away = c > 0 || (c == 0 && t.Bit(0) == 1)
+	case big.ToNearestAway:
+		away = c >= 0
+	case big.AwayFromZero:
+		away = true
+	case big.ToNegativeInf:
+		away = x.Sign() < 0
+	case big.ToPositiveInf:
+		away = x.Sign() > 0
+	}
+	if away {
+		t.Add(t, big.NewInt(int64(x.Sign())))
+	}
+
+	return t
+}
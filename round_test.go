@@ -0,0 +1,91 @@
+package bigfloat_test
+
+import (
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/ThreeAndTwo/bigfloat"
+)
+
+func TestRoundFunctions(t *testing.T) {
+	for _, test := range []struct {
+		name string
+		f    func(*big.Float) *big.Float
+		g    func(float64) float64
+	}{
+		{"Floor", bigfloat.Floor, math.Floor},
+		{"Ceil", bigfloat.Ceil, math.Ceil},
+		{"Trunc", bigfloat.Trunc, math.Trunc},
+		{"Round", bigfloat.Round, math.Round},
+		{"RoundToEven", bigfloat.RoundToEven, math.RoundToEven},
+	} {
+		for _, x := range []float64{
+			0, math.Copysign(0, -1), 0.25, 0.5, 0.75, 1, 1.5, 2.5, 3.49999,
+			-0.25, -0.5, -0.75, -1, -1.5, -2.5, -3.5, 1e15 + 0.5, 1 << 60,
+			math.Inf(1), math.Inf(-1),
+		} {
+			z := test.f(big.NewFloat(x))
+			got, _ := z.Float64()
+			want := test.g(x)
+			if got != want || math.Signbit(got) != math.Signbit(want) {
+				t.Errorf("%s(%v) = %v; want %v", test.name, x, got, want)
+			}
+			if z.Prec() != 53 {
+				t.Errorf("%s(%v) has precision %d", test.name, x, z.Prec())
+			}
+		}
+	}
+}
+
+func TestRoundCarry(t *testing.T) {
+	// 7.5 needs all the 4 bits of precision, and 8 fits them
+	x := new(big.Float).SetPrec(4).SetFloat64(7.5)
+	if z := bigfloat.Round(x); z.Cmp(big.NewFloat(8)) != 0 || z.Prec() != 4 {
+		t.Errorf("Round(7.5) = %g (prec %d)", z, z.Prec())
+	}
+
+	// far beyond the float64 range
+	x = new(big.Float).SetPrec(200).SetInt64(1)
+	x.SetMantExp(x, 150)
+	x.Add(x, big.NewFloat(-0.5))
+	want := new(big.Int).Lsh(big.NewInt(1), 150)
+	if z, _ := bigfloat.Ceil(x).Int(nil); z.Cmp(want) != 0 {
+		t.Errorf("Ceil(2**150 - 0.5) = %v", z)
+	}
+	if z, _ := bigfloat.RoundToEven(x).Int(nil); z.Cmp(want) != 0 {
+		t.Errorf("RoundToEven(2**150 - 0.5) = %v", z)
+	}
+	if z, _ := bigfloat.Floor(x).Int(nil); z.Cmp(want.Sub(want, big.NewInt(1))) != 0 {
+		t.Errorf("Floor(2**150 - 0.5) = %v", z)
+	}
+}
+
+func TestRoundInt(t *testing.T) {
+	for _, test := range []struct {
+		x    float64
+		mode big.RoundingMode
+		want int64
+	}{
+		{2.5, big.ToNearestEven, 2},
+		{3.5, big.ToNearestEven, 4},
+		{-2.5, big.ToNearestAway, -3},
+		{-2.5, big.ToZero, -2},
+		{-2.1, big.AwayFromZero, -3},
+		{-2.1, big.ToNegativeInf, -3},
+		{-2.9, big.ToPositiveInf, -2},
+		{2.1, big.ToPositiveInf, 3},
+		{7, big.AwayFromZero, 7},
+	} {
+		if got := bigfloat.RoundInt(big.NewFloat(test.x), test.mode); got.Int64() != test.want {
+			t.Errorf("RoundInt(%v, %v) = %v; want %d", test.x, test.mode, got, test.want)
+		}
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("RoundInt(+Inf) did not panic")
+		}
+	}()
+	bigfloat.RoundInt(new(big.Float).SetInf(false), big.ToZero)
+}
@@ -0,0 +1,207 @@
+package bigfloat
+
+import (
+	"math"
+	"math/big"
+)
+
+// seriesMaxTerms is the default maximum number of terms summed by
+// SumSeries.
+const seriesMaxTerms = 1 << 20
+
+// A SeriesOption configures SumSeries.
+type SeriesOption func(*seriesConfig)
+
+type seriesConfig struct {
+	maxTerms int
+	p, q     func(n int) *big.Int
+	diag     *Diagnostics
+}
+
+// MaxTerms sets the maximum number of terms summed by SumSeries,
+// 2**20 by default.
+func MaxTerms(n int) SeriesOption {
+	return func(c *seriesConfig) { c.maxTerms = n }
+}
+
+// RatioTerms tells SumSeries that the terms are rational, with
+//
+//	t(0) = p(0)/q(0),  t(n) = t(n-1)·p(n)/q(n)
+//
+// for integers p(n) and q(n) != 0, as in the hypergeometric series,
+// and makes it sum them by binary splitting. The partial sum is then
+// computed exactly, as a fraction, and it's rounded a single time; the
+// term function of SumSeries, which can be nil, is not called. p and
+// q must not modify the integers they return afterwards.
+func RatioTerms(p, q func(n int) *big.Int) SeriesOption {
+	return func(c *seriesConfig) { c.p, c.q = p, q }
+}
+
+// SeriesDiagnostics makes SumSeries report in d how it summed the
+// series: the terms of its last pass, the magnitude of the last one,
+// the working precision, the number of passes, and whether the sum
+// converged. With RatioTerms, the magnitude of the last term is
+// estimated to a few bits.
+func SeriesDiagnostics(d *Diagnostics) SeriesOption {
+	return func(c *seriesConfig) { c.diag = d }
+}
+
+// SumSeries returns Σ term(n, wprec) for n = 0, 1, 2, ..., to prec
+// bits (64 if prec is 0). term is called with a working precision
+// wprec larger than prec, and it should return the n-th term with at
+// least that precision.
+//
+// The number of terms is chosen automatically: the sum stops when two
+// consecutive terms are too small to change it at the working
+// precision, which suits the series whose terms eventually decrease in
+// magnitude. The working precision has 64 guard bits, plus the bits
+// lost to cancellation when the sum is much smaller than its largest
+// term, as for exp(-100) = Σ (-100)**n/n!; in that case the sum is
+// computed again with more bits. If the sum hasn't converged after
+// MaxTerms terms, the last partial sum is returned, as
+// SeriesDiagnostics tells.
+func SumSeries(term func(n int, prec uint) *big.Float, prec uint, opts ...SeriesOption) *big.Float {
+
+	if prec == 0 {
+		prec = 64
+	}
+	defer trace("SumSeries", prec)()
+	c := seriesConfig{maxTerms: seriesMaxTerms}
+	for _, o := range opts {
+		o(&c)
+	}
+	if c.maxTerms < 1 {
+		c.maxTerms = 1
+	}
+
+	if c.p != nil {
+		return sumRatioSeries(c, prec)
+	}
+
+	wprec := prec + 64
+	for passes := 1; ; passes++ {
+		s := new(big.Float).SetPrec(wprec)
+		maxExp := math.MinInt32
+		small := 0
+		var t *big.Float
+		n := 0
+		for ; n < c.maxTerms && small < 2; n++ {
+			t = term(n, wprec)
+			s.Add(s, t)
+			if t.Sign() == 0 {
+				small++
+				continue
+			}
+			e := t.MantExp(nil)
+			if e > maxExp {
+				maxExp = e
+			}
+			if s.Sign() != 0 && e < s.MantExp(nil)-int(wprec) {
+				small++
+			} else {
+				small = 0
+			}
+		}
+
+		// redo with the bits lost to cancellation
+		lost := 0
+		if s.Sign() != 0 && maxExp > math.MinInt32 {
+			lost = maxExp - s.MantExp(nil)
+		}
+		if s.Sign() == 0 || lost <= 32 || wprec >= prec+64+uint(lost) {
+			if c.diag != nil {
+				c.diag.set(n, new(big.Float).Abs(t), wprec, passes, small >= 2)
+			}
+			return s.SetPrec(prec)
+		}
+		wprec = prec + 64 + uint(lost)
+	}
+}
+
+// sumRatioSeries sums the series of RatioTerms by binary splitting.
+func sumRatioSeries(c seriesConfig, prec uint) *big.Float {
+
+	// l = log2 |t(n-1)|, estimated in float64, tells when to stop:
+	// when the terms are decreasing, and below the largest one by
+	// wprec bits
+	wprec := prec + 64
+	l, lmax := 0.0, math.Inf(-1)
+	n, done := 0, false
+	next := func() (d float64) {
+		p, q := c.p(n), c.q(n)
+		n++
+		if p.Sign() == 0 {
+			done = true
+			return 0
+		}
+		d = log2Int(p) - log2Int(q)
+		l += d
+		lmax = math.Max(lmax, l)
+		return d
+	}
+	for !done && n < c.maxTerms {
+		if d := next(); l < lmax-float64(wprec) && d < 0 {
+			break
+		}
+	}
+
+	// the partial sum is exact, but, with cancellation, it's smaller
+	// than the largest term, and more terms are needed
+	s := &ratioSeries{c.p, c.q}
+	for passes := 1; ; passes++ {
+		_, Q, T := s.split(0, n)
+		z := new(big.Float).SetPrec(wprec).SetInt(T)
+		z.Quo(z, new(big.Float).SetPrec(wprec).SetInt(Q))
+		need := float64(z.MantExp(nil)) - float64(wprec)
+		if T.Sign() == 0 || done || n >= c.maxTerms || l < need+32 {
+			if c.diag != nil {
+				last := new(big.Float)
+				if !done {
+					last.SetMantExp(big.NewFloat(1), int(math.Round(l)))
+				}
+				c.diag.set(n, last, wprec, passes, done || l < need+32)
+			}
+			return z.SetPrec(prec)
+		}
+		for !done && n < c.maxTerms && l >= need {
+			next()
+		}
+	}
+}
+
+// log2Int returns an estimate of log2 |x|, for x != 0.
+func log2Int(x *big.Int) float64 {
+
+	f := new(big.Float).SetPrec(53).SetInt(x)
+	m := new(big.Float)
+	e := f.MantExp(m)
+	mf, _ := m.Float64()
+
+	return math.Log2(math.Abs(mf)) + float64(e)
+}
+
+// A ratioSeries is a series of RatioTerms, summed by binary splitting.
+type ratioSeries struct {
+	p, q func(n int) *big.Int
+}
+
+// split returns P = Π p(j) and Q = Π q(j) for j from n1 to n2-1, and
+// T with Σ Π p(i)/q(i) = T/Q, for the sums from n1 to n2-1 of the
+// products from n1 to j.
+func (s *ratioSeries) split(n1, n2 int) (P, Q, T *big.Int) {
+
+	if n2-n1 == 1 {
+		P = new(big.Int).Set(s.p(n1))
+		return P, new(big.Int).Set(s.q(n1)), new(big.Int).Set(P)
+	}
+
+	m := (n1 + n2) / 2
+	P1, Q1, T1 := s.split(n1, m)
+	P2, Q2, T2 := s.split(m, n2)
+
+	// T = T1·Q2 + P1·T2
+	T = T1.Mul(T1, Q2)
+	T.Add(T, T2.Mul(T2, P1))
+
+	return P1.Mul(P1, P2), Q1.Mul(Q1, Q2), T
+}
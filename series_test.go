@@ -0,0 +1,151 @@
+package bigfloat_test
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/ThreeAndTwo/bigfloat"
+)
+
+// expTerm returns the terms x**n/n! of exp(x).
+func expTerm(x int64) func(n int, prec uint) *big.Float {
+	return func(n int, prec uint) *big.Float {
+		t := new(big.Float).SetPrec(prec).SetInt64(1)
+		for i := 1; i <= n; i++ {
+			t.Mul(t, new(big.Float).SetInt64(x))
+			t.Quo(t, new(big.Float).SetInt64(int64(i)))
+		}
+		return t
+	}
+}
+
+// expRatio returns the RatioTerms of exp(x).
+func expRatio(x int64) bigfloat.SeriesOption {
+	return bigfloat.RatioTerms(
+		func(n int) *big.Int {
+			if n == 0 {
+				return big.NewInt(1)
+			}
+			return big.NewInt(x)
+		},
+		func(n int) *big.Int {
+			if n == 0 {
+				return big.NewInt(1)
+			}
+			return big.NewInt(int64(n))
+		})
+}
+
+func TestSumSeries(t *testing.T) {
+	for _, x := range []int64{1, -1, 10, -100} {
+		for _, prec := range []uint{24, 53, 200, 1000} {
+			want := bigfloat.Exp(new(big.Float).SetPrec(prec + 64).SetInt64(x)).SetPrec(prec)
+			if got := bigfloat.SumSeries(expTerm(x), prec); got.Prec() != prec || bigfloat.CmpUlp(got, want, 1) != 0 {
+				t.Errorf("SumSeries(exp(%d), %d) = %g; want %g", x, prec, got, want)
+			}
+			if got := bigfloat.SumSeries(nil, prec, expRatio(x)); got.Prec() != prec || bigfloat.CmpUlp(got, want, 1) != 0 {
+				t.Errorf("SumSeries(exp(%d), %d, RatioTerms) = %g; want %g", x, prec, got, want)
+			}
+		}
+	}
+}
+
+func TestSumSeriesRatio(t *testing.T) {
+	// arctan(1/5), with t(n) = t(n-1)·-(2n-1)/(25·(2n+1))
+	p := func(n int) *big.Int {
+		if n == 0 {
+			return big.NewInt(1)
+		}
+		return big.NewInt(int64(1 - 2*n))
+	}
+	q := func(n int) *big.Int {
+		if n == 0 {
+			return big.NewInt(5)
+		}
+		return big.NewInt(int64(25 * (2*n + 1)))
+	}
+	for _, prec := range []uint{53, 500, 2000} {
+		got := bigfloat.SumSeries(nil, prec, bigfloat.RatioTerms(p, q))
+		if want := bigfloat.ArctanInv(5, prec); got.Cmp(want) != 0 {
+			t.Errorf("SumSeries(arctan(1/5), %d) = %g; want %g", prec, got, want)
+		}
+	}
+
+	// (1 + 1)**5 = Σ C(5, n), a series that terminates
+	p = func(n int) *big.Int {
+		if n == 0 {
+			return big.NewInt(1)
+		}
+		return big.NewInt(int64(6 - n))
+	}
+	q = func(n int) *big.Int {
+		if n == 0 {
+			return big.NewInt(1)
+		}
+		return big.NewInt(int64(n))
+	}
+	if got := bigfloat.SumSeries(nil, 53, bigfloat.RatioTerms(p, q)); got.Cmp(big.NewFloat(32)) != 0 {
+		t.Errorf("SumSeries(Σ C(5, n)) = %g; want 32", got)
+	}
+}
+
+func TestSumSeriesMaxTerms(t *testing.T) {
+	// the harmonic series diverges: the sum stops at MaxTerms
+	calls := 0
+	h := func(n int, prec uint) *big.Float {
+		calls++
+		z := new(big.Float).SetPrec(prec).SetInt64(1)
+		return z.Quo(z, new(big.Float).SetInt64(int64(n+1)))
+	}
+	got := bigfloat.SumSeries(h, 53, bigfloat.MaxTerms(4))
+	if want := big.NewFloat(25.0 / 12); calls != 4 || bigfloat.CmpUlp(got, want, 1) != 0 {
+		t.Errorf("SumSeries(1/(n+1), MaxTerms(4)) = %g in %d calls; want %g in 4", got, calls, want)
+	}
+}
+
+func TestSeriesDiagnostics(t *testing.T) {
+	// exp(-100) loses 144 bits to cancellation, and is summed again
+	var d bigfloat.Diagnostics
+	bigfloat.SumSeries(expTerm(-100), 53, bigfloat.SeriesDiagnostics(&d))
+	if d.Passes < 2 || !d.Converged || d.Prec < 53+64+144 || d.Iterations < 300 {
+		t.Errorf("SumSeries(exp(-100)) diagnostics: %+v", d)
+	}
+	if d.Correction.Sign() != 0 && d.Correction.MantExp(nil) > -144-int(d.Prec) {
+		t.Errorf("SumSeries(exp(-100)): the last term %g is too large", d.Correction)
+	}
+
+	bigfloat.SumSeries(expTerm(1), 53, bigfloat.SeriesDiagnostics(&d))
+	if d.Passes != 1 || !d.Converged || d.Prec != 53+64 {
+		t.Errorf("SumSeries(exp(1)) diagnostics: %+v", d)
+	}
+
+	// RatioTerms, and an estimate of the last term
+	bigfloat.SumSeries(nil, 200, expRatio(1), bigfloat.SeriesDiagnostics(&d))
+	if d.Passes != 1 || !d.Converged || d.Prec != 264 || d.Correction.MantExp(nil) > -264 {
+		t.Errorf("SumSeries(exp(1), RatioTerms) diagnostics: %+v", d)
+	}
+
+	// the harmonic series doesn't converge
+	h := func(n int, prec uint) *big.Float {
+		z := new(big.Float).SetPrec(prec).SetInt64(1)
+		return z.Quo(z, new(big.Float).SetInt64(int64(n+1)))
+	}
+	bigfloat.SumSeries(h, 53, bigfloat.MaxTerms(100), bigfloat.SeriesDiagnostics(&d))
+	if d.Converged || d.Iterations != 100 || d.Correction.MantExp(nil) != -6 {
+		t.Errorf("SumSeries(1/(n+1), MaxTerms(100)) diagnostics: %+v", d)
+	}
+}
+
+// ---------- Benchmarks ----------
+
+func BenchmarkSumSeries(b *testing.B) {
+	for _, prec := range []uint{1e2, 1e3, 1e4} {
+		b.Run(fmt.Sprintf("%v", prec), func(b *testing.B) {
+			b.ReportAllocs()
+			for n := 0; n < b.N; n++ {
+				bigfloat.SumSeries(nil, prec, expRatio(1))
+			}
+		})
+	}
+}
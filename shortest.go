@@ -0,0 +1,165 @@
+package bigfloat
+
+import (
+	"math"
+	"math/big"
+	"strings"
+)
+
+// FormatShortest returns the shortest decimal representation of x
+// that gives back x when parsed at the precision and with the
+// rounding mode of x, for example with
+//
+//	new(big.Float).SetPrec(x.Prec()).SetMode(x.Mode()).Parse(s, 10)
+//
+// Among the numbers with the fewest digits, the one closest to x is
+// chosen. The notation is the one of x.Text('g', -1), but unlike it,
+// which assumes rounding to nearest, FormatShortest takes the
+// rounding mode of x into account, so the result also round trips
+// with the directed rounding modes. ±Inf is formatted as "+Inf" and
+// "-Inf".
+func FormatShortest(x *big.Float) string {
+
+	switch {
+	case x.IsInf():
+		return x.Text('g', 0)
+	case x.Sign() == 0:
+		if x.Signbit() {
+			return "-0"
+		}
+		return "0"
+	}
+
+	d, k := shortestDecimal(x)
+	digits := d.String()
+
+	var b strings.Builder
+	if x.Signbit() {
+		b.WriteByte('-')
+	}
+	// like x.Text('g', -1), use an exponent only for very large and
+	// very small numbers
+	writeDecimal(&b, digits, k, 6)
+
+	return b.String()
+}
+
+// shortestDecimal returns d and k such that d·10**k, with the fewest
+// digits in d, rounds to |x| at the precision and with the rounding
+// mode of x. x must be finite and non-zero.
+func shortestDecimal(x *big.Float) (*big.Int, int) {
+
+	// |x| = M·2**(f+2), with M an integer of exactly prec bits; the
+	// interval of the values that round to x is measured in units of
+	// 2**f, a quarter of the ulp
+	prec := int(x.Prec())
+	mant := new(big.Float)
+	e := x.MantExp(mant)
+	mant.Abs(mant).SetMantExp(mant, prec)
+	m, _ := mant.Int(nil)
+	f := e - prec - 2
+
+	// the distance to the next smaller float is half an ulp when |x|
+	// is a power of two
+	gap := int64(4)
+	if m.TrailingZeroBits() == uint(prec-1) {
+		gap = 2
+	}
+
+	mode := x.Mode()
+	switch {
+	case mode == big.ToNegativeInf && !x.Signbit(), mode == big.ToPositiveInf && x.Signbit():
+		mode = big.ToZero
+	case mode == big.ToNegativeInf, mode == big.ToPositiveInf:
+		mode = big.AwayFromZero
+	}
+
+	m4 := new(big.Int).Lsh(m, 2)
+	lo, hi := new(big.Int).Set(m4), new(big.Int).Set(m4)
+	var loIn, hiIn bool // whether the bounds are included
+	switch mode {
+	case big.ToNearestEven, big.ToNearestAway:
+		lo.Sub(lo, big.NewInt(gap/2))
+		hi.Add(hi, big.NewInt(2))
+		loIn = mode == big.ToNearestAway || m.Bit(0) == 0
+		hiIn = mode == big.ToNearestEven && m.Bit(0) == 0
+	case big.ToZero:
+		hi.Add(hi, big.NewInt(4))
+		loIn = true
+	case big.AwayFromZero:
+		lo.Sub(lo, big.NewInt(gap))
+		hiIn = true
+	}
+
+	// bounds returns the smallest and the largest d such that d·10**k
+	// is in the interval
+	var q, r big.Int
+	bounds := func(k int) (dlo, dhi *big.Int) {
+		num, den := scaledBound(lo, f, k)
+		q.QuoRem(num, den, &r)
+		dlo = new(big.Int).Set(&q)
+		if r.Sign() != 0 || !loIn {
+			dlo.Add(dlo, big.NewInt(1))
+		}
+		num, den = scaledBound(hi, f, k)
+		q.QuoRem(num, den, &r)
+		dhi = new(big.Int).Set(&q)
+		if r.Sign() == 0 && !hiIn {
+			dhi.Sub(dhi, big.NewInt(1))
+		}
+		return dlo, dhi
+	}
+
+	// The interval is at least 2**(f+1) wide, so it contains a
+	// multiple of 10**kLo, and it doesn't contain any non-zero
+	// multiple of 10**kHi, which is larger than |x|. A multiple of
+	// 10**(k+1) is also one of 10**k, so binary search for the
+	// largest k that works.
+	kLo := int(math.Floor(float64(f)*math.Log10(2))) - 1
+	kHi := int(math.Ceil(float64(e)*math.Log10(2))) + 1
+	for kHi-kLo > 1 {
+		k := kLo + (kHi-kLo)/2
+		if dlo, dhi := bounds(k); dlo.Cmp(dhi) <= 0 {
+			kLo = k
+		} else {
+			kHi = k
+		}
+	}
+	k := kLo
+
+	// the candidate closest to x
+	dlo, dhi := bounds(k)
+	num, den := scaledBound(m4, f, k)
+	d := roundQuo(num, den, HalfEven, false)
+	if d.Cmp(dlo) < 0 {
+		d = dlo
+	} else if d.Cmp(dhi) > 0 {
+		d = dhi
+	}
+
+	ten := big.NewInt(10)
+	for r.Mod(d, ten).Sign() == 0 {
+		d.Quo(d, ten)
+		k++
+	}
+
+	return d, k
+}
+
+// scaledBound returns num and den such that num/den = n·2**f/10**k.
+func scaledBound(n *big.Int, f, k int) (num, den *big.Int) {
+
+	num, den = new(big.Int).Set(n), big.NewInt(1)
+	if f >= 0 {
+		num.Lsh(num, uint(f))
+	} else {
+		den.Lsh(den, uint(-f))
+	}
+	if k >= 0 {
+		den.Mul(den, pow10(k))
+	} else {
+		num.Mul(num, pow10(-k))
+	}
+
+	return num, den
+}
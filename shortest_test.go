@@ -0,0 +1,92 @@
+package bigfloat_test
+
+import (
+	"fmt"
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/ThreeAndTwo/bigfloat"
+)
+
+func TestFormatShortest(t *testing.T) {
+	third := func(prec uint, mode big.RoundingMode) *big.Float {
+		x := new(big.Float).SetPrec(prec).SetMode(mode)
+		return x.Quo(big.NewFloat(1), big.NewFloat(3))
+	}
+
+	for _, test := range []struct {
+		x    *big.Float
+		want string
+	}{
+		{big.NewFloat(1.5), "1.5"},
+		{big.NewFloat(0.1), "0.1"},
+		{big.NewFloat(-100), "-100"},
+		{big.NewFloat(161350), "161350"},
+		{big.NewFloat(1234567), "1.234567e+06"},
+		{big.NewFloat(1e23), "1e+23"},
+		{big.NewFloat(0.0001), "0.0001"},
+		{big.NewFloat(0.00001234), "1.234e-05"},
+		{new(big.Float).SetPrec(10).SetFloat64(1000), "1000"},
+		{new(big.Float).SetPrec(10).SetFloat64(1023), "1023"},
+		{new(big.Float).SetPrec(4).SetFloat64(1024), "1000"},
+		{third(53, big.ToNearestEven), "0.3333333333333333"},
+		{third(53, big.ToZero), "0.33333333333333332"},
+		{third(53, big.AwayFromZero), "0.33333333333333337"},
+		{third(24, big.ToNearestEven), "0.33333334"},
+		{third(24, big.ToZero), "0.33333332"},
+		{new(big.Float), "0"},
+		{new(big.Float).Neg(new(big.Float)), "-0"},
+		{new(big.Float).SetInf(true), "-Inf"},
+	} {
+		if got := bigfloat.FormatShortest(test.x); got != test.want {
+			t.Errorf("FormatShortest(%s, %v) = %s; want %s", test.x.Text('p', 0), test.x.Mode(), got, test.want)
+		}
+	}
+}
+
+func TestFormatShortestRoundTrip(t *testing.T) {
+	modes := []big.RoundingMode{
+		big.ToNearestEven, big.ToNearestAway, big.ToZero,
+		big.AwayFromZero, big.ToNegativeInf, big.ToPositiveInf,
+	}
+
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 2000; i++ {
+		prec := uint(1 + r.Intn(300))
+		mode := modes[r.Intn(len(modes))]
+		x := bigfloat.Rand(prec, r)
+		x.SetMantExp(x, r.Intn(2000)-1000)
+		if r.Intn(2) == 0 {
+			x.Neg(x)
+		}
+		x.SetMode(mode)
+
+		s := bigfloat.FormatShortest(x)
+		z, _, err := new(big.Float).SetPrec(prec).SetMode(mode).Parse(s, 10)
+		if err != nil || z.Cmp(x) != 0 {
+			t.Fatalf("FormatShortest(%s, %v) = %s, which parses to %s", x.Text('p', 0), mode, s, z.Text('p', 0))
+		}
+
+		// never longer than the shortest representation for rounding
+		// to nearest
+		if mode == big.ToNearestEven {
+			if g := x.Text('g', -1); len(s) > len(g) {
+				t.Errorf("FormatShortest(%s) = %s, longer than %s", x.Text('p', 0), s, g)
+			}
+		}
+	}
+}
+
+// ---------- Benchmarks ----------
+
+func BenchmarkFormatShortest(b *testing.B) {
+	for _, prec := range []uint{53, 1000, 10000} {
+		x := bigfloat.Sqrt(new(big.Float).SetPrec(prec).SetInt64(2))
+		b.Run(fmt.Sprintf("%v", prec), func(b *testing.B) {
+			for n := 0; n < b.N; n++ {
+				bigfloat.FormatShortest(x)
+			}
+		})
+	}
+}
@@ -0,0 +1,151 @@
+package bigfloat
+
+import (
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// siPrefixes are the SI prefixes for the powers of 1000, from 10**-30
+// to 10**30.
+var siPrefixes = []string{
+	"q", "r", "y", "z", "a", "f", "p", "n", "µ", "m",
+	"",
+	"k", "M", "G", "T", "P", "E", "Z", "Y", "R", "Q",
+}
+
+// iecPrefixes are the binary prefixes for the powers of 1024, from
+// 2**0 to 2**80.
+var iecPrefixes = []string{"", "Ki", "Mi", "Gi", "Ti", "Pi", "Ei", "Zi", "Yi"}
+
+// ParseSI parses s, which must contain a number in the syntax
+// accepted by Parse followed by an optional SI prefix (from q for
+// 10**-30 to Q for 10**30, with u and μ also accepted for µ) or
+// binary prefix (from Ki for 2**10 to Yi for 2**80), with optional
+// white space in between, like "1.5k", "250 µ" or "0x1.8p3Mi". It
+// returns the number rounded to prec bits (64 if prec is 0), like
+// Parse, and exact reports whether the returned value is exactly the
+// number written in s. The multiplication by the power of 10 or 2 is
+// exact, so the result is rounded a single time.
+//
+// An SI prefix can only follow a decimal number without an exponent.
+// A string that's a valid number without a prefix, like "0x1E", is
+// never parsed as one with a prefix. If s isn't valid, the error is a
+// *ParseError.
+func ParseSI(s string, prec uint) (f *big.Float, exact bool, err error) {
+
+	if f, exact, err := Parse(s, prec); err == nil {
+		return f, exact, nil
+	}
+
+	t := strings.TrimRight(s, " \t\n\r")
+	num, exp10, exp2 := t, 0, 0
+	for i, p := range iecPrefixes[1:] {
+		if strings.HasSuffix(t, p) {
+			num, exp2 = t[:len(t)-len(p)], 10*(i+1)
+			break
+		}
+	}
+	if exp2 == 0 {
+		for i, p := range siPrefixes {
+			if p != "" && strings.HasSuffix(t, p) {
+				num, exp10 = t[:len(t)-len(p)], 3*(i-10)
+				break
+			}
+		}
+		switch {
+		case strings.HasSuffix(t, "u"):
+			num, exp10 = t[:len(t)-1], -6
+		case strings.HasSuffix(t, "μ"):
+			num, exp10 = t[:len(t)-len("μ")], -6
+		}
+	}
+	if num == t {
+		_, _, err := Parse(s, prec)
+		return nil, false, err
+	}
+
+	if exp10 != 0 {
+		if i := strings.IndexAny(num, "eEpPxXoObB"); i >= 0 {
+			return nil, false, &ParseError{s, i, 10, "SI prefix after a number with an exponent or a prefix"}
+		}
+		num = strings.TrimRight(num, " \t\n\r") + "e" + strconv.Itoa(exp10)
+	}
+	f, exact, err = Parse(num, prec)
+	if err != nil {
+		if e, ok := err.(*ParseError); ok {
+			e.Input = s
+		}
+		return nil, false, err
+	}
+
+	return f.SetMantExp(f, exp2), exact, nil
+}
+
+// FormatSI returns the shortest decimal representation of x that
+// gives back x when parsed by ParseSI at the precision of x, using
+// the SI prefix that leaves between 1 and 999 before the decimal
+// point, like "1.5k" or "-250µ". Numbers outside of the range of the
+// prefixes, 10**-30 to 10**33, are formatted without a prefix. ±Inf
+// is formatted as "+Inf" and "-Inf".
+func FormatSI(x *big.Float) string {
+
+	if x.IsInf() || x.Sign() == 0 {
+		return FormatShortest(x)
+	}
+
+	// ParseSI rounds to nearest even
+	y := new(big.Float).Copy(x).SetMode(big.ToNearestEven)
+	d, k := shortestDecimal(y)
+	digits := d.String()
+
+	// exponent of the leading digit, rounded down to a multiple of 3
+	e := k + len(digits) - 1
+	e3 := e - ((e%3)+3)%3
+	if e3 < -30 || e3 > 30 {
+		return FormatShortest(y)
+	}
+
+	var b strings.Builder
+	if x.Signbit() {
+		b.WriteByte('-')
+	}
+	writeDecimal(&b, digits, k-e3, 3)
+	b.WriteString(siPrefixes[e3/3+10])
+
+	return b.String()
+}
+
+// FormatSIBinary returns the shortest decimal representation of x
+// that gives back x when parsed by ParseSI at the precision of x,
+// using the largest binary prefix that leaves at least 1 before the
+// decimal point, like "1.5Ki" or "-3Gi". Numbers with an absolute
+// value below 1024 are formatted without a prefix, as are those of
+// 1024 Yi or more. ±Inf is formatted as "+Inf" and "-Inf".
+func FormatSIBinary(x *big.Float) string {
+
+	y := new(big.Float).Copy(x).SetMode(big.ToNearestEven)
+	if x.IsInf() || x.Sign() == 0 {
+		return FormatShortest(y)
+	}
+
+	// |x| >= 1024**i if its exponent is at least 10·i + 1
+	i := (x.MantExp(nil) - 1) / 10
+	if i <= 0 || i >= len(iecPrefixes) {
+		return FormatShortest(y)
+	}
+
+	// scaling by a power of two doesn't change the digits needed to
+	// round trip
+	y.SetMantExp(y, -10*i)
+	d, k := shortestDecimal(y)
+
+	var b strings.Builder
+	if x.Signbit() {
+		b.WriteByte('-')
+	}
+	writeDecimal(&b, d.String(), k, 4)
+	b.WriteString(iecPrefixes[i])
+
+	return b.String()
+}
@@ -0,0 +1,106 @@
+package bigfloat_test
+
+import (
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/ThreeAndTwo/bigfloat"
+)
+
+func TestParseSI(t *testing.T) {
+	for _, test := range []struct {
+		s     string
+		prec  uint
+		want  string
+		exact bool
+		ok    bool
+	}{
+		{"1.5k", 53, "1500", true, true},
+		{"250 µ", 53, "0.00025", false, true},
+		{"250u", 53, "0.00025", false, true},
+		{"250μ", 53, "0.00025", false, true},
+		{"-3M", 53, "-3e+06", true, true},
+		{"1Q", 200, "1e+30", true, true},
+		{"1q", 53, "1e-30", false, true},
+		{"1.5Ki", 53, "1536", true, true},
+		{"0x1.8p3Mi", 53, "1.2582912e+07", true, true},
+		{"1Yi", 53, "1.2089258196146292e+24", true, true},
+		{"0x1E", 53, "30", true, true},
+		{"1E", 53, "1e+18", true, true},
+		{"42", 53, "42", true, true},
+		{"1e3k", 53, "", false, false},
+		{"0x10k", 53, "", false, false},
+		{"1.5x", 53, "", false, false},
+		{"k", 53, "", false, false},
+	} {
+		x, exact, err := bigfloat.ParseSI(test.s, test.prec)
+		if !test.ok {
+			if err == nil {
+				t.Errorf("ParseSI(%q) = %g; want error", test.s, x)
+			} else if _, ok := err.(*bigfloat.ParseError); !ok {
+				t.Errorf("ParseSI(%q) error %v isn't a *ParseError", test.s, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ParseSI(%q) error: %v", test.s, err)
+			continue
+		}
+		if got := x.Text('g', -1); got != test.want || exact != test.exact {
+			t.Errorf("ParseSI(%q) = %s, %v; want %s, %v", test.s, got, exact, test.want, test.exact)
+		}
+	}
+
+	// the scaling doesn't round a second time
+	x, _, _ := bigfloat.ParseSI("1.000000000000000000000000001G", 53)
+	y, _ := new(big.Float).SetPrec(53).SetString("1.000000000000000000000000001e9")
+	if x.Cmp(y) != 0 {
+		t.Errorf("ParseSI rounded twice: %g, want %g", x, y)
+	}
+}
+
+func TestFormatSI(t *testing.T) {
+	for _, test := range []struct {
+		x        *big.Float
+		dec, bin string
+	}{
+		{big.NewFloat(1500), "1.5k", "1.46484375Ki"},
+		{big.NewFloat(1536), "1.536k", "1.5Ki"},
+		{big.NewFloat(-0.00025), "-250µ", "-0.00025"},
+		{big.NewFloat(1), "1", "1"},
+		{big.NewFloat(999), "999", "999"},
+		{big.NewFloat(1 << 30), "1.073741824G", "1Gi"},
+		{big.NewFloat(1e-40), "1e-40", "1e-40"},
+		{big.NewFloat(1e40), "1e+40", "1e+40"},
+		{new(big.Float), "0", "0"},
+		{new(big.Float).SetInf(true), "-Inf", "-Inf"},
+	} {
+		if got := bigfloat.FormatSI(test.x); got != test.dec {
+			t.Errorf("FormatSI(%g) = %s; want %s", test.x, got, test.dec)
+		}
+		if got := bigfloat.FormatSIBinary(test.x); got != test.bin {
+			t.Errorf("FormatSIBinary(%g) = %s; want %s", test.x, got, test.bin)
+		}
+	}
+}
+
+func TestFormatSIRoundTrip(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 1000; i++ {
+		prec := uint(1 + r.Intn(200))
+		x := bigfloat.Rand(prec, r)
+		x.SetMantExp(x, r.Intn(260)-130)
+		if r.Intn(2) == 0 {
+			x.Neg(x)
+		}
+		x.SetMode(big.ToZero)
+
+		for _, s := range []string{bigfloat.FormatSI(x), bigfloat.FormatSIBinary(x)} {
+			z, _, err := bigfloat.ParseSI(s, prec)
+			if err != nil || z.Cmp(x) != 0 {
+				t.Fatalf("%s (prec %d) was formatted as %s, which parses to %v (%v)", x.Text('p', 0), prec, s, z, err)
+			}
+		}
+	}
+}
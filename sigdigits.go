@@ -0,0 +1,215 @@
+package bigfloat
+
+import (
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// RoundSigDigits returns x correctly rounded to n significant decimal
+// digits, with ties rounded to even. Since the rounded decimal value
+// is usually not representable in binary, the result is the closest
+// big.Float to it, whatever the rounding mode of x, with the precision
+// and the mode of x. The function panics if n < 1.
+//
+// The rounding is done on the exact value of x, so, unlike formatting
+// with Text and parsing the result, there's no double rounding.
+func RoundSigDigits(x *big.Float, n int) *big.Float {
+
+	if n < 1 {
+		panic("RoundSigDigits: n < 1")
+	}
+
+	// the mode of x is only given to the result
+	z := new(big.Float).SetPrec(x.Prec())
+	if x.Sign() == 0 || x.IsInf() {
+		return z.Set(x).SetMode(x.Mode())
+	}
+
+	q, e := sigDigits(x, n, HalfEven)
+	if e >= 0 {
+		z.SetInt(q.Mul(q, pow10(e)))
+	} else {
+		t := new(big.Float).SetInt(q)
+		z.Quo(t, new(big.Float).SetInt(pow10(-e)))
+	}
+	if x.Signbit() {
+		z.Neg(z)
+	}
+
+	return z.SetMode(x.Mode())
+}
+
+// RoundSigDigitsString returns x correctly rounded to n significant
+// decimal digits, with ties rounded to even, as a string. Like for
+// the %g verb, the exponent notation d.ddde±dd is used for large and
+// small exponents; unlike it, trailing zeros are kept, since they're
+// significant. ±Inf are formatted as "+Inf" and "-Inf". The function
+// panics if n < 1.
+func RoundSigDigitsString(x *big.Float, n int) string {
+
+	if n < 1 {
+		panic("RoundSigDigitsString: n < 1")
+	}
+
+	if x.IsInf() {
+		return x.Text('g', 0)
+	}
+
+	var b strings.Builder
+	if x.Signbit() {
+		b.WriteByte('-')
+	}
+
+	if x.Sign() == 0 {
+		b.WriteByte('0')
+		if n > 1 {
+			b.WriteByte('.')
+			b.WriteString(strings.Repeat("0", n-1))
+		}
+		return b.String()
+	}
+
+	q, e := sigDigits(x, n, HalfEven)
+	writeDecimal(&b, q.String(), e, n)
+
+	return b.String()
+}
+
+// SigDigits returns the integer q with n digits and the exponent e
+// such that q·10**e is x correctly rounded to n significant decimal
+// digits using the given rule; q has the sign of x. For example,
+// SigDigits of 2.675 (the float64 value, which is slightly below it)
+// with 3 digits is 267, -2. It returns 0, 0 if x is ±0. The function
+// panics if n < 1 or if x is infinite.
+func SigDigits(x *big.Float, n int, rule RoundingRule) (q *big.Int, e int) {
+
+	if n < 1 {
+		panic("SigDigits: n < 1")
+	}
+	if x.IsInf() {
+		panic("SigDigits: infinite argument")
+	}
+	if x.Sign() == 0 {
+		return new(big.Int), 0
+	}
+
+	q, e = sigDigits(x, n, rule)
+	if x.Signbit() {
+		q.Neg(q)
+	}
+
+	return q, e
+}
+
+// sigDigits returns the n-digit integer q and the exponent e such
+// that q·10**e is x rounded to n significant digits using the given
+// rule, in absolute value. x must be finite and non-zero.
+func sigDigits(x *big.Float, n int, rule RoundingRule) (*big.Int, int) {
+
+	// initial guess for the decimal exponent d, with
+	// 10**(d-1) <= |x| < 10**d
+	const log10of2 = 0.30102999566398120
+	d := int(float64(x.MantExp(nil)-1)*log10of2) + 1
+
+	lo := pow10(n - 1)
+	hi := pow10(n)
+	num, den := scaleInt(x, 10, n-d)
+	t := new(big.Int)
+	for {
+		if num.Cmp(t.Mul(hi, den)) >= 0 {
+			d++
+		} else if num.Cmp(t.Mul(lo, den)) < 0 {
+			d--
+		} else {
+			break
+		}
+		num, den = scaleInt(x, 10, n-d)
+	}
+
+	q := roundQuo(num, den, rule, x.Signbit())
+	if q.Cmp(hi) == 0 {
+		// rounded up to the next power of 10
+		q.Set(lo)
+		d++
+	}
+
+	return q, d - n
+}
+
+// scaleInt returns integers num and den with num/den = |x|·base**k,
+// exactly. x must be finite.
+func scaleInt(x *big.Float, base, k int) (num, den *big.Int) {
+
+	num, den = big.NewInt(0), big.NewInt(1)
+	if x.Sign() == 0 {
+		return num, den
+	}
+
+	m, e := intMantExp(x)
+	num.Abs(m)
+	if e >= 0 {
+		num.Lsh(num, uint(e))
+	} else {
+		den.Lsh(den, uint(-e))
+	}
+	if k >= 0 {
+		num.Mul(num, powInt64(base, k))
+	} else {
+		den.Mul(den, powInt64(base, -k))
+	}
+
+	return num, den
+}
+
+// writeDecimal writes the number with the digits in digits, times
+// 10**e, in %g style notation with precision eprec, keeping the
+// trailing zeros.
+func writeDecimal(b *strings.Builder, digits string, e, eprec int) {
+
+	// exponent of the leading digit
+	x := e + len(digits) - 1
+	if x < -4 || x >= eprec {
+		b.WriteString(digits[:1])
+		if len(digits) > 1 {
+			b.WriteByte('.')
+			b.WriteString(digits[1:])
+		}
+		b.WriteByte('e')
+		if x < 0 {
+			b.WriteByte('-')
+			x = -x
+		} else {
+			b.WriteByte('+')
+		}
+		if x < 10 {
+			b.WriteByte('0')
+		}
+		b.WriteString(strconv.Itoa(x))
+		return
+	}
+
+	switch {
+	case e >= 0:
+		b.WriteString(digits)
+		b.WriteString(strings.Repeat("0", e))
+	case x >= 0:
+		b.WriteString(digits[:x+1])
+		b.WriteByte('.')
+		b.WriteString(digits[x+1:])
+	default:
+		b.WriteString("0.")
+		b.WriteString(strings.Repeat("0", -x-1))
+		b.WriteString(digits)
+	}
+}
+
+// pow10 returns 10**n, for n >= 0.
+func pow10(n int) *big.Int {
+	return powInt64(10, n)
+}
+
+// powInt64 returns b**n, for n >= 0.
+func powInt64(b, n int) *big.Int {
+	return new(big.Int).Exp(big.NewInt(int64(b)), big.NewInt(int64(n)), nil)
+}
@@ -0,0 +1,131 @@
+package bigfloat_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ThreeAndTwo/bigfloat"
+)
+
+func TestRoundSigDigits(t *testing.T) {
+	for _, test := range []struct {
+		x    string
+		prec uint
+		n    int
+		want string
+	}{
+		{"123456", 53, 3, "123000"},
+		{"123456", 53, 1, "100000"},
+		{"-0.0012345", 53, 2, "-0.0012"},
+		{"9.99", 53, 2, "10"},
+		{"0.5", 53, 1, "0.5"},
+		{"0.125", 53, 2, "0.12"}, // tie, to even
+		{"0.375", 53, 2, "0.38"}, // tie, to even
+		{"2.5", 53, 1, "2"},
+		{"3.5", 53, 1, "4"},
+		{"1e300", 53, 5, "1e300"},
+		{"1.23456789e-300", 53, 4, "1.235e-300"},
+		{"3.14159265358979323846264338327950288", 200, 30, "3.14159265358979323846264338328"},
+		{"0", 53, 3, "0"},
+	} {
+		x, _, _ := new(big.Float).SetPrec(test.prec).Parse(test.x, 10)
+		want, _, _ := new(big.Float).SetPrec(test.prec).Parse(test.want, 10)
+		z := bigfloat.RoundSigDigits(x, test.n)
+		if z.Cmp(want) != 0 || z.Prec() != test.prec {
+			t.Errorf("RoundSigDigits(%s, %d) = %g (prec %d); want %g", test.x, test.n, z, z.Prec(), want)
+		}
+	}
+}
+
+func TestRoundSigDigitsNoDoubleRounding(t *testing.T) {
+	// 0.15 as a float64 is slightly below 0.15, so it rounds to 0.1;
+	// rounding the shortest decimal representation "0.15" would give
+	// 0.2 instead
+	x := big.NewFloat(0.15)
+	want := big.NewFloat(0.1)
+	if z := bigfloat.RoundSigDigits(x, 1); z.Cmp(want) != 0 {
+		t.Errorf("RoundSigDigits(0.15, 1) = %g; want 0.1", z)
+	}
+	if s := bigfloat.RoundSigDigitsString(x, 1); s != "0.1" {
+		t.Errorf("RoundSigDigitsString(0.15, 1) = %s; want 0.1", s)
+	}
+}
+
+func TestRoundSigDigitsMode(t *testing.T) {
+	// 0.1 is rounded up to the closest float64, even in ToZero mode
+	for _, mode := range []big.RoundingMode{big.ToZero, big.AwayFromZero, big.ToNegativeInf} {
+		x := big.NewFloat(0.123).SetMode(mode)
+		z := bigfloat.RoundSigDigits(x, 1)
+		if z.Cmp(big.NewFloat(0.1)) != 0 || z.Mode() != mode {
+			t.Errorf("RoundSigDigits(0.123, 1) in %v = %g (mode %v); want 0.1", mode, z, z.Mode())
+		}
+	}
+}
+
+func TestRoundSigDigitsString(t *testing.T) {
+	for _, test := range []struct {
+		x    float64
+		n    int
+		want string
+	}{
+		{123456, 3, "1.23e+05"},
+		{123456, 6, "123456"},
+		{1234.5, 6, "1234.50"},
+		{99.96, 3, "100"},
+		{-0.00012345, 3, "-0.000123"},
+		{0.000012345, 3, "1.23e-05"},
+		{1, 1, "1"},
+		{1, 4, "1.000"},
+		{9.5, 1, "1e+01"},
+		{1e100, 2, "1.0e+100"},
+		{0, 3, "0.00"},
+		{-0.0, 1, "-0"},
+	} {
+		x := big.NewFloat(test.x)
+		if test.x == 0 && test.want[0] == '-' {
+			x.Neg(x)
+		}
+		if s := bigfloat.RoundSigDigitsString(x, test.n); s != test.want {
+			t.Errorf("RoundSigDigitsString(%g, %d) = %s; want %s", test.x, test.n, s, test.want)
+		}
+	}
+
+	if s := bigfloat.RoundSigDigitsString(new(big.Float).SetInf(true), 3); s != "-Inf" {
+		t.Errorf("RoundSigDigitsString(-Inf, 3) = %s; want -Inf", s)
+	}
+}
+
+func TestSigDigits(t *testing.T) {
+	for _, test := range []struct {
+		x     float64
+		n     int
+		rule  bigfloat.RoundingRule
+		wantQ int64
+		wantE int
+	}{
+		{2.675, 3, bigfloat.HalfEven, 267, -2},
+		{2.5, 1, bigfloat.HalfEven, 2, 0},
+		{2.5, 1, bigfloat.HalfAwayFromZero, 3, 0},
+		{-2.5, 1, bigfloat.HalfUp, -2, 0},
+		{-2.5, 1, bigfloat.HalfAwayFromZero, -3, 0},
+		{999.9, 3, bigfloat.HalfEven, 100, 1},
+		{999.9, 3, bigfloat.TowardZero, 999, 0},
+		{1e-10, 2, bigfloat.HalfEven, 10, -11},
+		{123456, 2, bigfloat.TowardZero, 12, 4},
+		{0, 5, bigfloat.HalfEven, 0, 0},
+	} {
+		q, e := bigfloat.SigDigits(big.NewFloat(test.x), test.n, test.rule)
+		if q.Int64() != test.wantQ || e != test.wantE {
+			t.Errorf("SigDigits(%g, %d, %v) = %d, %d; want %d, %d", test.x, test.n, test.rule, q, e, test.wantQ, test.wantE)
+		}
+	}
+}
+
+// ---------- Benchmarks ----------
+
+func BenchmarkRoundSigDigits(b *testing.B) {
+	x, _, _ := new(big.Float).SetPrec(1000).Parse(piStr, 10)
+	for n := 0; n < b.N; n++ {
+		bigfloat.RoundSigDigits(x, 100)
+	}
+}
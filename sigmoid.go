@@ -0,0 +1,141 @@
+package bigfloat
+
+import (
+	"math/big"
+)
+
+// Sigmoid returns the logistic function 1/(1 + exp(-x)). Precision is
+// the same as the one of x (64 if it is 0). The exponential is taken
+// of -|x| only, as exp(x)/(1 + exp(x)) for x < 0, so it never
+// overflows, and the tiny results for large negative x keep their
+// relative accuracy. Sigmoid(+Inf) = 1 and Sigmoid(-Inf) = 0.
+func Sigmoid(x *big.Float) *big.Float {
+
+	prec := x.Prec()
+	if prec == 0 {
+		prec = 64
+	}
+	z := new(big.Float).SetPrec(prec)
+	if x.IsInf() {
+		if x.Sign() > 0 {
+			z.SetInt64(1)
+		}
+		return z
+	}
+
+	wprec := prec + 64
+	e := expNegAbs(x, wprec)
+	d := new(big.Float).SetPrec(wprec).Add(e, big.NewFloat(1))
+	if x.Sign() >= 0 {
+		return z.Quo(big.NewFloat(1), d)
+	}
+
+	return z.Quo(e, d)
+}
+
+// Softplus returns log(1 + exp(x)), the smooth maximum of x and 0.
+// Precision is the same as the one of x (64 if it is 0). It's computed
+// as x + log(1 + exp(-x)) for x > 0, so it never overflows, and as
+// log(1 + exp(x)) with the logarithm of 1 + t for a small t otherwise,
+// so the tiny results for large negative x keep their relative
+// accuracy. Softplus(+Inf) = +Inf and Softplus(-Inf) = 0.
+func Softplus(x *big.Float) *big.Float {
+
+	prec := x.Prec()
+	if prec == 0 {
+		prec = 64
+	}
+	z := new(big.Float).SetPrec(prec)
+	if x.IsInf() {
+		if x.Sign() > 0 {
+			z.SetInf(false)
+		}
+		return z
+	}
+
+	wprec := prec + 64
+	l := log1p(expNegAbs(x, wprec), wprec)
+	if x.Sign() > 0 {
+		l.Add(l, x)
+	}
+
+	return z.Set(l)
+}
+
+// Logit returns log(p/(1 - p)), the inverse of Sigmoid. Precision is
+// the same as the one of p (64 if it is 0). Logit(0) = -Inf and
+// Logit(1) = +Inf, and the function panics if p is not in [0, 1].
+//
+// 1 - p is computed exactly, and the cancellation of log(p) and
+// log(1 - p) around p = 1/2 is avoided by taking log(1 + t) for
+// t = (2p - 1)/(1 - p) there, so the result is accurate to the last
+// bit for every p.
+func Logit(p *big.Float) *big.Float {
+
+	prec := p.Prec()
+	if prec == 0 {
+		prec = 64
+	}
+	z := new(big.Float).SetPrec(prec)
+
+	one := big.NewFloat(1)
+	switch {
+	case p.Sign() < 0 || p.Cmp(one) > 0:
+		panic("Logit: argument is not in [0, 1]")
+	case p.Sign() == 0:
+		return z.SetInf(true)
+	case p.Cmp(one) == 0:
+		return z.SetInf(false)
+	}
+
+	// 1 - p and 2p - 1 are exact, with the bits of p down from 2**0
+	wprec := prec + 64
+	ep := p.MantExp(nil)
+	q := new(big.Float).SetPrec(p.Prec()+uint(-ep)+2).Sub(one, p)
+
+	if p.Cmp(big.NewFloat(0.25)) < 0 {
+		// log(p) - log(1 - p), far apart
+		l := Log(new(big.Float).SetPrec(wprec).Set(p))
+		return z.Sub(l, log1p(new(big.Float).Neg(p), wprec))
+	}
+
+	t := new(big.Float).SetPrec(q.Prec()).Set(p)
+	t.SetMantExp(t, 1).Sub(t, one)
+	t.SetPrec(wprec).Quo(t, q)
+
+	return z.Set(log1p(t, wprec))
+}
+
+// expNegAbs returns exp(-|x|) to prec bits, for a finite x.
+func expNegAbs(x *big.Float, prec uint) *big.Float {
+
+	t := new(big.Float).Abs(x)
+	return expLog(t.Neg(t), prec)
+}
+
+// log1p returns log(1 + x) to prec bits, for x > -1, with a relative
+// error that stays small when x is small: 1 + x is computed exactly,
+// or, for |x| below 2**-prec, log(1 + x) is x - x²/2.
+func log1p(x *big.Float, prec uint) *big.Float {
+
+	z := new(big.Float).SetPrec(prec)
+	if x.Sign() == 0 {
+		return z
+	}
+
+	ex := x.MantExp(nil)
+	if ex < -int(prec) {
+		t := new(big.Float).SetPrec(prec).Mul(x, x)
+		t.SetMantExp(t, -1)
+		return z.Sub(x, t)
+	}
+
+	// the rounding of 1 + x would be an absolute error, relative to x
+	wprec := prec + 64
+	if ex < 0 {
+		wprec += uint(-ex)
+	}
+	t := new(big.Float).SetPrec(wprec).Add(x, big.NewFloat(1))
+
+	return z.Set(Log(t))
+}
@@ -0,0 +1,137 @@
+package bigfloat_test
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/ThreeAndTwo/bigfloat"
+)
+
+// sigmoidArgs are arguments of Sigmoid and Softplus, from the tiny to
+// the ones whose exponentials are far out of the float64 range.
+var sigmoidArgs = []string{"0", "1e-30", "-1e-30", "0.5", "-0.5", "3", "-3", "40", "-40", "1000", "-1000", "123456.789", "-123456.789"}
+
+func TestSigmoid(t *testing.T) {
+	for _, prec := range []uint{24, 53, 100, 500} {
+		for _, s := range sigmoidArgs {
+			x, _ := new(big.Float).SetPrec(prec).SetString(s)
+
+			// 1/(1 + exp(-x))
+			wprec := prec + 64
+			e := bigfloat.Exp(new(big.Float).SetPrec(wprec).Neg(x))
+			want := e.Quo(big.NewFloat(1), e.Add(e, big.NewFloat(1))).SetPrec(prec)
+			if got := bigfloat.Sigmoid(x); got.Prec() != prec || bigfloat.CmpUlp(got, want, 1) != 0 {
+				t.Errorf("Sigmoid(%s) at prec %d = %g; want %g", s, prec, got, want)
+			}
+		}
+	}
+
+	for _, test := range []struct {
+		x, want float64
+	}{
+		{math.Inf(+1), 1},
+		{math.Inf(-1), 0},
+		{0, 0.5},
+		{1e300, 1},
+		{-1e300, 0},
+	} {
+		if got, _ := bigfloat.Sigmoid(big.NewFloat(test.x)).Float64(); got != test.want {
+			t.Errorf("Sigmoid(%g) = %g; want %g", test.x, got, test.want)
+		}
+	}
+}
+
+func TestSoftplus(t *testing.T) {
+	for _, prec := range []uint{24, 53, 100, 500} {
+		for _, s := range sigmoidArgs {
+			x, _ := new(big.Float).SetPrec(prec).SetString(s)
+
+			// log(1 + exp(x)), with 1 + exp(x) exact enough, or exp(x)
+			// for the tiny ones
+			wprec := prec + 2000
+			e := bigfloat.Exp(new(big.Float).SetPrec(wprec).Set(x))
+			want := bigfloat.Log(new(big.Float).Add(e, big.NewFloat(1))).SetPrec(prec)
+			if x.Cmp(big.NewFloat(-1000)) < 0 {
+				want = e.SetPrec(prec)
+			}
+			if got := bigfloat.Softplus(x); got.Prec() != prec || bigfloat.CmpUlp(got, want, 1) != 0 {
+				t.Errorf("Softplus(%s) at prec %d = %g; want %g", s, prec, got, want)
+			}
+		}
+	}
+
+	for _, test := range []struct {
+		x, want float64
+	}{
+		{math.Inf(+1), math.Inf(+1)},
+		{math.Inf(-1), 0},
+		{1e300, 1e300},
+		{-1e300, 0},
+	} {
+		if got, _ := bigfloat.Softplus(big.NewFloat(test.x)).Float64(); got != test.want {
+			t.Errorf("Softplus(%g) = %g; want %g", test.x, got, test.want)
+		}
+	}
+}
+
+func TestLogit(t *testing.T) {
+	for _, prec := range []uint{24, 53, 100, 500} {
+		for _, s := range []string{"0.5", "0.25", "0.75", "0.1", "0.9", "0.4999999", "0.5000001", "1e-10", "1e-300", "0.999999999999"} {
+			p, _ := new(big.Float).SetPrec(prec).SetString(s)
+
+			// log(p) - log(1 - p), with 1 - p exact
+			wprec := prec + 2000
+			q := new(big.Float).SetPrec(wprec).Sub(big.NewFloat(1), p)
+			want := bigfloat.Log(new(big.Float).SetPrec(wprec).Set(p))
+			want.Sub(want, bigfloat.Log(q)).SetPrec(prec)
+			if got := bigfloat.Logit(p); got.Prec() != prec || bigfloat.CmpUlp(got, want, 1) != 0 {
+				t.Errorf("Logit(%s) at prec %d = %g; want %g", s, prec, got, want)
+			}
+
+			// the inverse of Sigmoid
+			x := bigfloat.Logit(new(big.Float).SetPrec(prec + 64).Set(p))
+			if got := bigfloat.Sigmoid(x).SetPrec(prec); bigfloat.CmpUlp(got, p, 1) != 0 {
+				t.Errorf("Sigmoid(Logit(%s)) at prec %d = %g", s, prec, got)
+			}
+		}
+	}
+
+	for _, test := range []struct {
+		p, want float64
+	}{
+		{0, math.Inf(-1)},
+		{1, math.Inf(+1)},
+		{0.5, 0},
+	} {
+		if got, _ := bigfloat.Logit(big.NewFloat(test.p)).Float64(); got != test.want {
+			t.Errorf("Logit(%g) = %g; want %g", test.p, got, test.want)
+		}
+	}
+
+	for _, p := range []float64{-0.1, 1.1, math.Inf(+1)} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("Logit(%g) didn't panic", p)
+				}
+			}()
+			bigfloat.Logit(big.NewFloat(p))
+		}()
+	}
+}
+
+// ---------- Benchmarks ----------
+
+func BenchmarkSigmoid(b *testing.B) {
+	for _, prec := range []uint{1e2, 1e3, 1e4} {
+		x := new(big.Float).SetPrec(prec).SetFloat64(-3.5)
+		b.Run(fmt.Sprintf("%v", prec), func(b *testing.B) {
+			b.ReportAllocs()
+			for n := 0; n < b.N; n++ {
+				bigfloat.Sigmoid(x)
+			}
+		})
+	}
+}
@@ -0,0 +1,90 @@
+package bigfloat
+
+import "math/big"
+
+// The functions in this file follow the semantics of the functions
+// of the same name in the math package, with a nil argument playing
+// the part of a NaN. Their results are always new values.
+
+// CopySign returns a value with the magnitude of x and the sign of
+// y. Precision is the same as the one of x. CopySign returns nil if
+// x or y is nil.
+func CopySign(x, y *big.Float) *big.Float {
+
+	if x == nil || y == nil {
+		return nil
+	}
+
+	z := new(big.Float).Copy(x)
+	if z.Signbit() != y.Signbit() {
+		z.Neg(z)
+	}
+
+	return z
+}
+
+// Dim returns the maximum of x - y and 0. Precision is the largest
+// of the ones of x and y. Dim returns nil if x or y is nil, and
+// panics if x and y are infinities with the same sign.
+func Dim(x, y *big.Float) *big.Float {
+
+	if x == nil || y == nil {
+		return nil
+	}
+
+	z := new(big.Float).SetMode(x.Mode())
+	if z.Sub(x, y); z.Sign() <= 0 {
+		// like math.Dim, the zero is positive
+		return z.SetInt64(0)
+	}
+
+	return z
+}
+
+// Max returns a copy of the larger of x and y, with its precision.
+// Max(x, +Inf) = Max(+Inf, x) = +Inf, Max(+0, -0) = Max(-0, +0) = +0,
+// and otherwise Max returns nil if x or y is nil.
+func Max(x, y *big.Float) *big.Float {
+
+	switch {
+	case x != nil && x.IsInf() && x.Sign() > 0:
+		return new(big.Float).Copy(x)
+	case y != nil && y.IsInf() && y.Sign() > 0:
+		return new(big.Float).Copy(y)
+	case x == nil || y == nil:
+		return nil
+	case x.Sign() == 0 && y.Sign() == 0:
+		if x.Signbit() {
+			return new(big.Float).Copy(y)
+		}
+		return new(big.Float).Copy(x)
+	case x.Cmp(y) >= 0:
+		return new(big.Float).Copy(x)
+	}
+
+	return new(big.Float).Copy(y)
+}
+
+// Min returns a copy of the smaller of x and y, with its precision.
+// Min(x, -Inf) = Min(-Inf, x) = -Inf, Min(+0, -0) = Min(-0, +0) = -0,
+// and otherwise Min returns nil if x or y is nil.
+func Min(x, y *big.Float) *big.Float {
+
+	switch {
+	case x != nil && x.IsInf() && x.Sign() < 0:
+		return new(big.Float).Copy(x)
+	case y != nil && y.IsInf() && y.Sign() < 0:
+		return new(big.Float).Copy(y)
+	case x == nil || y == nil:
+		return nil
+	case x.Sign() == 0 && y.Sign() == 0:
+		if x.Signbit() {
+			return new(big.Float).Copy(x)
+		}
+		return new(big.Float).Copy(y)
+	case x.Cmp(y) <= 0:
+		return new(big.Float).Copy(x)
+	}
+
+	return new(big.Float).Copy(y)
+}
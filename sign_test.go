@@ -0,0 +1,94 @@
+package bigfloat_test
+
+import (
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/ThreeAndTwo/bigfloat"
+)
+
+func TestSignFunctions(t *testing.T) {
+	values := []float64{
+		0, math.Copysign(0, -1), 1, -1, 2.5, -2.5, math.Inf(1), math.Inf(-1),
+	}
+
+	for _, test := range []struct {
+		name string
+		f    func(x, y *big.Float) *big.Float
+		g    func(x, y float64) float64
+	}{
+		{"CopySign", bigfloat.CopySign, math.Copysign},
+		{"Dim", bigfloat.Dim, math.Dim},
+		{"Max", bigfloat.Max, math.Max},
+		{"Min", bigfloat.Min, math.Min},
+	} {
+		for _, x := range values {
+			for _, y := range values {
+				want := test.g(x, y)
+				if math.IsNaN(want) {
+					continue
+				}
+				xf, yf := big.NewFloat(x), big.NewFloat(y)
+				z := test.f(xf, yf)
+				got, _ := z.Float64()
+				if got != want || math.Signbit(got) != math.Signbit(want) {
+					t.Errorf("%s(%v, %v) = %v; want %v", test.name, x, y, got, want)
+				}
+				if z == xf || z == yf {
+					t.Errorf("%s(%v, %v) returned an argument", test.name, x, y)
+				}
+			}
+		}
+	}
+}
+
+func TestSignFunctionsNil(t *testing.T) {
+	one := big.NewFloat(1)
+	inf := new(big.Float).SetInf(false)
+	ninf := new(big.Float).SetInf(true)
+
+	for _, test := range []struct {
+		name string
+		z    *big.Float
+		want string // "nil" for a nil result
+	}{
+		{"CopySign(nil, 1)", bigfloat.CopySign(nil, one), "nil"},
+		{"CopySign(1, nil)", bigfloat.CopySign(one, nil), "nil"},
+		{"Dim(nil, 1)", bigfloat.Dim(nil, one), "nil"},
+		{"Max(nil, 1)", bigfloat.Max(nil, one), "nil"},
+		{"Max(1, nil)", bigfloat.Max(one, nil), "nil"},
+		{"Max(nil, +Inf)", bigfloat.Max(nil, inf), "+Inf"},
+		{"Max(+Inf, nil)", bigfloat.Max(inf, nil), "+Inf"},
+		{"Max(-Inf, nil)", bigfloat.Max(ninf, nil), "nil"},
+		{"Min(nil, -Inf)", bigfloat.Min(nil, ninf), "-Inf"},
+		{"Min(+Inf, nil)", bigfloat.Min(inf, nil), "nil"},
+		{"Min(nil, nil)", bigfloat.Min(nil, nil), "nil"},
+	} {
+		got := "nil"
+		if test.z != nil {
+			got = test.z.String()
+		}
+		if got != test.want {
+			t.Errorf("%s = %s; want %s", test.name, got, test.want)
+		}
+	}
+}
+
+func TestSignFunctionsPrecision(t *testing.T) {
+	a := new(big.Float).SetPrec(100).SetInt64(3)
+	b := new(big.Float).SetPrec(10).SetInt64(2)
+
+	if z := bigfloat.Max(a, b); z.Prec() != 100 {
+		t.Errorf("Max precision = %d; want 100", z.Prec())
+	}
+	if z := bigfloat.Min(a, b); z.Prec() != 10 {
+		t.Errorf("Min precision = %d; want 10", z.Prec())
+	}
+	if z := bigfloat.CopySign(b, new(big.Float).Neg(a)); z.Prec() != 10 || z.Cmp(big.NewFloat(-2)) != 0 {
+		t.Errorf("CopySign = %g (prec %d)", z, z.Prec())
+	}
+	if z := bigfloat.Dim(b, a); z.Prec() != 100 || z.Sign() != 0 || z.Signbit() {
+		t.Errorf("Dim(2, 3) = %g (prec %d)", z, z.Prec())
+	}
+}
@@ -0,0 +1,94 @@
+package bigfloat
+
+import (
+	"math"
+	"math/big"
+)
+
+// LogSumExp returns log(Σ exp(xᵢ)) at the largest precision of the
+// elements of xs. The function returns -Inf when every element is
+// -Inf, +Inf when any element is +Inf, and panics if xs is empty.
+//
+// The largest element m is factored out, as in
+//
+//	log(Σ exp(xᵢ)) = m + log(Σ exp(xᵢ - m))
+//
+// so the result is computed accurately even when the exponentials
+// themselves would overflow or underflow.
+func LogSumExp(xs []*big.Float) *big.Float {
+
+	prec := seqPrec("LogSumExp", xs)
+	m := maxElem(xs)
+	if m.IsInf() {
+		return new(big.Float).SetPrec(prec).Set(m)
+	}
+
+	wprec := lseGuard(prec, len(xs))
+	s := expSum(xs, m, wprec)
+	l := Log(s)
+
+	return l.Add(l, m).SetPrec(prec)
+}
+
+// Softmax returns the vector of exp(xᵢ)/Σ exp(xⱼ), at the largest
+// precision of the elements of xs. The function panics if xs is
+// empty, if its elements are all -Inf, or if some element is +Inf.
+func Softmax(xs []*big.Float) []*big.Float {
+
+	prec := seqPrec("Softmax", xs)
+	m := maxElem(xs)
+	if m.IsInf() {
+		panic("Softmax: infinite maximum")
+	}
+
+	wprec := lseGuard(prec, len(xs))
+	s := expSum(xs, m, wprec)
+
+	z := make([]*big.Float, len(xs))
+	t := new(big.Float).SetPrec(wprec)
+	for i, x := range xs {
+		// compute each exponential, even those that are too small to
+		// matter in the sum
+		e := Exp(t.Sub(x, m))
+		z[i] = e.Quo(e, s).SetPrec(prec)
+	}
+
+	return z
+}
+
+// maxElem returns the largest element of xs.
+func maxElem(xs []*big.Float) *big.Float {
+
+	m := xs[0]
+	for _, x := range xs[1:] {
+		if x.Cmp(m) > 0 {
+			m = x
+		}
+	}
+
+	return m
+}
+
+// lseGuard returns the working precision used to sum n exponentials
+// for a result of precision prec.
+func lseGuard(prec uint, n int) uint {
+	return prec + 64 + uint(big.NewInt(int64(n)).BitLen())
+}
+
+// expSum returns Σ exp(xᵢ - m) at precision prec, where m is the
+// largest element of xs. Terms smaller than 2**(-prec) are skipped,
+// since they can't affect the sum, which is at least 1.
+func expSum(xs []*big.Float, m *big.Float, prec uint) *big.Float {
+
+	lim := new(big.Float).SetFloat64(-float64(prec) * math.Ln2)
+	s := new(big.Float).SetPrec(prec)
+	t := new(big.Float).SetPrec(prec)
+	for _, x := range xs {
+		if t.Sub(x, m).Cmp(lim) < 0 {
+			continue
+		}
+		s.Add(s, Exp(t))
+	}
+
+	return s
+}
@@ -0,0 +1,72 @@
+package bigfloat_test
+
+import (
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/ThreeAndTwo/bigfloat"
+)
+
+func TestLogSumExp(t *testing.T) {
+	const prec = 200
+	ln2 := bigfloat.Log(big.NewFloat(2).SetPrec(prec))
+
+	// log(2·exp(x)) = x + log(2), for x far beyond the float64 range
+	for _, x := range []string{"0", "1e6", "-1e6", "1e20"} {
+		xs := floats(prec, x, x)
+		want := new(big.Float).SetPrec(prec).Add(xs[0], ln2)
+		if z := bigfloat.LogSumExp(xs); !closeTo(z, want, prec-2) {
+			t.Errorf("LogSumExp(%s, %s) =\ngot  %g;\nwant %g", x, x, z, want)
+		}
+	}
+
+	// log(exp(0) + exp(-1e6)) = exp(-1e6) to first order, too small
+	// to affect the result
+	if z := bigfloat.LogSumExp(floats(prec, "0", "-1e6")); z.Sign() != 0 {
+		t.Errorf("LogSumExp(0, -1e6) = %g; want 0", z)
+	}
+
+	for _, test := range []struct {
+		xs   []float64
+		want float64
+	}{
+		{[]float64{math.Inf(-1), math.Inf(-1)}, math.Inf(-1)},
+		{[]float64{1, math.Inf(+1)}, math.Inf(+1)},
+		{[]float64{2, math.Inf(-1)}, 2},
+	} {
+		xs := make([]*big.Float, len(test.xs))
+		for i := range xs {
+			xs[i] = big.NewFloat(test.xs[i])
+		}
+		if z, _ := bigfloat.LogSumExp(xs).Float64(); z != test.want {
+			t.Errorf("LogSumExp(%v) = %g; want %g", test.xs, z, test.want)
+		}
+	}
+}
+
+func TestSoftmax(t *testing.T) {
+	const prec = 100
+	xs := floats(prec, "-1e6", "0", "1", "2", "-Inf")
+	z := bigfloat.Softmax(xs)
+
+	if z[4].Sign() != 0 {
+		t.Errorf("Softmax(-Inf) = %g; want 0", z[4])
+	}
+
+	// tiny probabilities are not flushed to zero
+	if z[0].Sign() <= 0 || z[0].MantExp(nil) > -1000000 {
+		t.Errorf("Softmax(-1e6) = %g; want ≈ exp(-1e6)", z[0])
+	}
+
+	// exp(2)/exp(1) = e
+	want := bigfloat.Exp(big.NewFloat(1).SetPrec(prec))
+	if r := new(big.Float).Quo(z[3], z[2]); !closeTo(r, want, prec-4) {
+		t.Errorf("Softmax(2)/Softmax(1) = %g; want %g", r, want)
+	}
+
+	s := bigfloat.Sum(z)
+	if !closeTo(s, big.NewFloat(1), prec-4) {
+		t.Errorf("Σ Softmax = %g; want 1", s)
+	}
+}
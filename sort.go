@@ -0,0 +1,85 @@
+package bigfloat
+
+import (
+	"math/big"
+	"sort"
+)
+
+// Compare returns -1, 0 or +1 as x is less than, equal to or greater
+// than y, in the total order
+//
+//	nil < -Inf < ... < -0 < +0 < ... < +Inf
+//
+// where a nil, standing for a NaN as in Min and Max, is below every
+// value and equal to itself, and -0 is below +0. Unlike with x.Cmp(y),
+// values that Compare as equal are the same number, so Compare is a
+// consistent comparator for slices.SortFunc, and so is
+// Compare(xs[i], xs[j]) < 0 as the less function of sort.Slice.
+func Compare(x, y *big.Float) int {
+
+	switch {
+	case x == nil && y == nil:
+		return 0
+	case x == nil:
+		return -1
+	case y == nil:
+		return +1
+	case x.Sign() == 0 && y.Sign() == 0:
+		switch {
+		case x.Signbit() == y.Signbit():
+			return 0
+		case x.Signbit():
+			return -1
+		}
+		return +1
+	}
+
+	return x.Cmp(y)
+}
+
+// SortSlice sorts xs in increasing order of Compare, with the nils
+// first. The sort is stable, so equal values with different precisions
+// keep their order.
+func SortSlice(xs []*big.Float) {
+	sort.SliceStable(xs, func(i, j int) bool {
+		return Compare(xs[i], xs[j]) < 0
+	})
+}
+
+// MinOf returns a copy of the smallest element of xs, with its
+// precision, with the semantics of Min: it's -Inf if xs contains -Inf,
+// and otherwise nil if xs contains a nil, and -0 is smaller than +0.
+// MinOf returns nil if xs is empty.
+func MinOf(xs []*big.Float) *big.Float {
+	return extremum(xs, -1)
+}
+
+// MaxOf returns a copy of the largest element of xs, with its
+// precision, with the semantics of Max: it's +Inf if xs contains +Inf,
+// and otherwise nil if xs contains a nil, and +0 is larger than -0.
+// MaxOf returns nil if xs is empty.
+func MaxOf(xs []*big.Float) *big.Float {
+	return extremum(xs, +1)
+}
+
+// extremum returns MinOf(xs) for sign -1 and MaxOf(xs) for sign +1.
+func extremum(xs []*big.Float, sign int) *big.Float {
+
+	var m *big.Float
+	nan := false
+	for _, x := range xs {
+		switch {
+		case x == nil:
+			nan = true
+		case x.IsInf() && x.Sign() == sign:
+			return new(big.Float).Copy(x)
+		case m == nil || Compare(x, m) == sign:
+			m = x
+		}
+	}
+	if nan || m == nil {
+		return nil
+	}
+
+	return new(big.Float).Copy(m)
+}
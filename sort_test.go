@@ -0,0 +1,137 @@
+package bigfloat_test
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/ThreeAndTwo/bigfloat"
+)
+
+// nilOr returns a string for x, "nil" for nil.
+func nilOr(x *big.Float) string {
+	if x == nil {
+		return "nil"
+	}
+	return x.Text('g', 10)
+}
+
+func TestCompare(t *testing.T) {
+	// in increasing order
+	values := []*big.Float{
+		nil,
+		new(big.Float).SetInf(true),
+		big.NewFloat(-2.5),
+		big.NewFloat(math.Copysign(0, -1)),
+		big.NewFloat(0),
+		big.NewFloat(1e-300),
+		big.NewFloat(1),
+		new(big.Float).SetInf(false),
+	}
+	for i, x := range values {
+		for j, y := range values {
+			want := 0
+			switch {
+			case i < j:
+				want = -1
+			case i > j:
+				want = +1
+			}
+			if got := bigfloat.Compare(x, y); got != want {
+				t.Errorf("Compare(%s, %s) = %d; want %d", nilOr(x), nilOr(y), got, want)
+			}
+		}
+	}
+
+	// the precision doesn't matter
+	x := new(big.Float).SetPrec(10).SetInt64(3)
+	if got := bigfloat.Compare(x, new(big.Float).SetPrec(1000).SetInt64(3)); got != 0 {
+		t.Errorf("Compare(3, 3) at different precisions = %d; want 0", got)
+	}
+}
+
+func TestSortSlice(t *testing.T) {
+	nz := big.NewFloat(math.Copysign(0, -1))
+	xs := []*big.Float{
+		big.NewFloat(1), big.NewFloat(0), nil, nz, new(big.Float).SetInf(true),
+		big.NewFloat(-3), nil, new(big.Float).SetInf(false), big.NewFloat(0.5),
+	}
+	bigfloat.SortSlice(xs)
+	want := "[nil nil -Inf -3 -0 0 0.5 1 +Inf]"
+	s := make([]string, len(xs))
+	for i, x := range xs {
+		s[i] = nilOr(x)
+	}
+	if got := fmt.Sprint(s); got != want {
+		t.Errorf("SortSlice = %s; want %s", got, want)
+	}
+
+	// stable for equal values
+	a := new(big.Float).SetPrec(10).SetInt64(2)
+	b := new(big.Float).SetPrec(20).SetInt64(2)
+	ys := []*big.Float{a, big.NewFloat(3), b, big.NewFloat(1)}
+	bigfloat.SortSlice(ys)
+	if ys[1] != a || ys[2] != b {
+		t.Errorf("SortSlice isn't stable")
+	}
+}
+
+func TestMinOfMaxOf(t *testing.T) {
+	f := big.NewFloat
+	nz := f(math.Copysign(0, -1))
+	inf := new(big.Float).SetInf(false)
+	ninf := new(big.Float).SetInf(true)
+	for _, test := range []struct {
+		xs       []*big.Float
+		min, max string
+	}{
+		{nil, "nil", "nil"},
+		{[]*big.Float{f(2)}, "2", "2"},
+		{[]*big.Float{f(2), f(-1), f(7), f(3)}, "-1", "7"},
+		{[]*big.Float{f(0), nz}, "-0", "0"},
+		{[]*big.Float{nz, f(0)}, "-0", "0"},
+		{[]*big.Float{f(1), nil, f(2)}, "nil", "nil"},
+		{[]*big.Float{f(1), nil, ninf}, "-Inf", "nil"},
+		{[]*big.Float{inf, nil, f(1)}, "nil", "+Inf"},
+		{[]*big.Float{inf, ninf}, "-Inf", "+Inf"},
+	} {
+		min, max := bigfloat.MinOf(test.xs), bigfloat.MaxOf(test.xs)
+		if got := nilOr(min); got != test.min {
+			t.Errorf("MinOf(%v) = %s; want %s", test.xs, got, test.min)
+		}
+		if got := nilOr(max); got != test.max {
+			t.Errorf("MaxOf(%v) = %s; want %s", test.xs, got, test.max)
+		}
+		for _, x := range test.xs {
+			if x != nil && (x == min || x == max) {
+				t.Errorf("MinOf or MaxOf(%v) returned an element", test.xs)
+			}
+		}
+	}
+
+	// the precision is the one of the element
+	x := new(big.Float).SetPrec(300).SetInt64(-5)
+	if got := bigfloat.MinOf([]*big.Float{f(1), x}); got.Prec() != 300 || got.Cmp(x) != 0 {
+		t.Errorf("MinOf(1, -5) = %g at prec %d; want -5 at prec 300", got, got.Prec())
+	}
+}
+
+// ---------- Benchmarks ----------
+
+func BenchmarkSortSlice(b *testing.B) {
+	for _, n := range []int{1e2, 1e4} {
+		xs := make([]*big.Float, n)
+		for i := range xs {
+			xs[i] = big.NewFloat(float64((i * 7919) % n))
+		}
+		ys := make([]*big.Float, n)
+		b.Run(fmt.Sprintf("%v", n), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				copy(ys, xs)
+				bigfloat.SortSlice(ys)
+			}
+		})
+	}
+}
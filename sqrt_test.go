@@ -115,6 +115,95 @@ func TestSqrtSpecialValues(t *testing.T) {
 	}
 }
 
+func TestSqrtNative(t *testing.T) {
+	old := bigfloat.SetNativeSqrtPrec(0)
+	defer bigfloat.SetNativeSqrtPrec(old)
+	if old != bigfloat.DefaultNativeSqrtPrec {
+		t.Errorf("SetNativeSqrtPrec returned %d; want %d", old, bigfloat.DefaultNativeSqrtPrec)
+	}
+
+	// the algorithms of the package and the one of math/big agree to
+	// the last bit
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 200; i++ {
+		prec := uint(24 + r.Intn(2000))
+		z := new(big.Float).SetPrec(prec).SetFloat64(r.Float64())
+		z.SetMantExp(z, r.Intn(200)-100)
+		bigfloat.SetNativeSqrtPrec(0)
+		x := bigfloat.Sqrt(z)
+		bigfloat.SetNativeSqrtPrec(prec + 1)
+		y := bigfloat.Sqrt(z)
+		if x.Prec() != prec || y.Prec() != prec || bigfloat.CmpUlp(x, y, 1) != 0 {
+			t.Errorf("Sqrt(%g) at prec %d = %g, and %g natively", z, prec, x, y)
+		}
+	}
+}
+
+func TestSqrtInt(t *testing.T) {
+	for _, test := range []struct {
+		n     string
+		prec  uint
+		want  string
+		exact bool
+	}{
+		{"0", 53, "0", true},
+		{"1", 53, "1", true},
+		{"4", 1, "2", true},
+		{"2", 53, "1.4142135623730951", false},
+		{"144", 53, "12", true},
+		{"144", 2, "12", true},
+		{"169", 2, "12", false},
+		{"99999999999999999999999999999999999999999999999999", 53, "1e+25", false},
+		{"100000000000000000000000000000000000000000000000000", 53, "1e+25", false}, // 5**25 has 59 bits
+		{"100000000000000000000000000000000000000000000000000", 64, "1e+25", true},
+	} {
+		n, _ := new(big.Int).SetString(test.n, 10)
+		z, exact := bigfloat.SqrtInt(n, test.prec)
+		if got := z.Text('g', -1); got != test.want || exact != test.exact || z.Prec() != test.prec {
+			t.Errorf("SqrtInt(%s, %d) = %s, %v (prec %d); want %s, %v", test.n, test.prec, got, exact, z.Prec(), test.want, test.exact)
+		}
+	}
+
+	// n = m² ± 1 for a huge m, where converting n to a big.Float
+	// first would lose the ±1
+	m := new(big.Int).Lsh(big.NewInt(1), 1000)
+	m.Add(m, big.NewInt(1))
+	n := new(big.Int).Mul(m, m)
+	for _, d := range []int64{-1, 0, 1} {
+		nd := new(big.Int).Add(n, big.NewInt(d))
+		z, exact := bigfloat.SqrtInt(nd, 2100)
+		if exact != (d == 0) {
+			t.Errorf("SqrtInt(m² %+d) exact = %v", d, exact)
+		}
+		c := z.Cmp(new(big.Float).SetInt(m))
+		if c != int(d) {
+			t.Errorf("SqrtInt(m² %+d) compares %d to m", d, c)
+		}
+	}
+
+	// correct rounding, against the square of neighbouring values
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 500; i++ {
+		n := new(big.Int).Rand(r, new(big.Int).Lsh(big.NewInt(1), uint(1+r.Intn(300))))
+		n.Add(n, big.NewInt(1))
+		prec := uint(1 + r.Intn(100))
+		z, _ := bigfloat.SqrtInt(n, prec)
+
+		// z is the nearest if n lies between the squares of the
+		// midpoints with the neighbours
+		for _, nb := range []*big.Float{bigfloat.NextDown(z), bigfloat.NextUp(z)} {
+			mid := new(big.Float).SetPrec(2*prec+2).Add(z, nb)
+			mid.Mul(mid, big.NewFloat(0.5))
+			mr, _ := mid.Rat(nil)
+			mr.Mul(mr, mr)
+			c := mr.Cmp(new(big.Rat).SetInt(n))
+			if (nb.Cmp(z) < 0 && c > 0) || (nb.Cmp(z) > 0 && c < 0) {
+				t.Fatalf("SqrtInt(%v, %d) = %s is not correctly rounded", n, prec, z.Text('p', 0))
+			}
+		}
+	}
+}
+
 // ---------- Benchmarks ----------
 
 func BenchmarkSqrt(b *testing.B) {
@@ -128,3 +217,31 @@ func BenchmarkSqrt(b *testing.B) {
 		})
 	}
 }
+
+// BenchmarkSqrtCrossover compares the square roots of big.Float.Sqrt
+// and of the algorithms of this package at the powers of 2 around
+// DefaultNativeSqrtPrec, which is the precision from which the second
+// ones are the faster.
+func BenchmarkSqrtCrossover(b *testing.B) {
+	defer bigfloat.SetNativeSqrtPrec(bigfloat.SetNativeSqrtPrec(bigfloat.DefaultNativeSqrtPrec))
+	for prec := uint(1 << 12); prec <= 1<<20; prec *= 2 {
+		z := bigfloat.Sqrt(big.NewFloat(3).SetPrec(prec))
+		for _, native := range []bool{true, false} {
+			name := fmt.Sprintf("package/%v", prec)
+			if native {
+				name = fmt.Sprintf("native/%v", prec)
+			}
+			b.Run(name, func(b *testing.B) {
+				if native {
+					bigfloat.SetNativeSqrtPrec(prec + 1)
+				} else {
+					bigfloat.SetNativeSqrtPrec(0)
+				}
+				b.ReportAllocs()
+				for n := 0; n < b.N; n++ {
+					bigfloat.Sqrt(z)
+				}
+			})
+		}
+	}
+}
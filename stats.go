@@ -0,0 +1,43 @@
+package bigfloat
+
+import "math/big"
+
+// Mean returns the arithmetic mean of the elements of xs, correctly
+// rounded to the largest precision of the elements of xs. The
+// function panics if xs is empty, or if it contains infinities of
+// opposite sign.
+func Mean(xs []*big.Float) *big.Float {
+	return accumulate(xs).mean("Mean")
+}
+
+// Variance returns the sample variance (with Bessel's correction) of
+// the elements of xs, correctly rounded to the largest precision of
+// the elements of xs. The function panics if xs has fewer than two
+// elements, or if it contains infinities.
+//
+// The sums of the elements and of their squares are accumulated
+// exactly, so the result is accurate even when the deviations from
+// the mean are many orders of magnitude smaller than the mean.
+func Variance(xs []*big.Float) *big.Float {
+	a := accumulate(xs)
+	return new(big.Float).SetPrec(a.prec).Quo(a.varianceNum("Variance"), a.varianceDen())
+}
+
+// StdDev returns the sample standard deviation of the elements of xs,
+// that is the square root of their Variance, at the largest precision
+// of the elements of xs. The function panics if xs has fewer than two
+// elements, or if it contains infinities.
+func StdDev(xs []*big.Float) *big.Float {
+	a := accumulate(xs)
+	v := new(big.Float).SetPrec(a.prec+64).Quo(a.varianceNum("StdDev"), a.varianceDen())
+	return Sqrt(v).SetPrec(a.prec)
+}
+
+// accumulate returns an Accumulator holding the elements of xs.
+func accumulate(xs []*big.Float) *Accumulator {
+	a := new(Accumulator)
+	for _, x := range xs {
+		a.Add(x)
+	}
+	return a
+}
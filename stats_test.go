@@ -0,0 +1,80 @@
+package bigfloat_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ThreeAndTwo/bigfloat"
+)
+
+func floats(prec uint, s ...string) []*big.Float {
+	xs := make([]*big.Float, len(s))
+	for i := range s {
+		xs[i], _, _ = new(big.Float).SetPrec(prec).Parse(s[i], 10)
+	}
+	return xs
+}
+
+func TestMean(t *testing.T) {
+	for _, test := range []struct {
+		xs   []string
+		want string
+	}{
+		{[]string{"3"}, "3"},
+		{[]string{"1", "2", "3", "4"}, "2.5"},
+		{[]string{"1e300", "1", "-1e300"}, "0.33333333333333333333333333333333333333333333333333"},
+		{[]string{"-1", "1"}, "0"},
+	} {
+		for _, prec := range []uint{24, 53, 100} {
+			want, _, _ := new(big.Float).SetPrec(prec).Parse(test.want, 10)
+			if z := bigfloat.Mean(floats(2000, test.xs...)); z.SetPrec(prec).Cmp(want) != 0 {
+				t.Errorf("prec = %d, Mean(%v) = %g; want %g", prec, test.xs, z, want)
+			}
+		}
+	}
+}
+
+func TestVariance(t *testing.T) {
+	// Values with a huge mean and a tiny spread: the deviations from
+	// the mean are far below float64 precision.
+	xs := floats(200, "1e30", "1e30", "1e30", "1e30")
+	xs[0].Add(xs[0], big.NewFloat(1))
+	xs[1].Add(xs[1], big.NewFloat(2))
+	xs[2].Add(xs[2], big.NewFloat(3))
+	xs[3].Add(xs[3], big.NewFloat(4))
+
+	// the sample variance of {1, 2, 3, 4} is 5/3
+	want := new(big.Float).SetPrec(200).Quo(big.NewFloat(5), big.NewFloat(3))
+	if v := bigfloat.Variance(xs); v.Cmp(want) != 0 {
+		t.Errorf("Variance =\ngot  %g;\nwant %g", v, want)
+	}
+
+	sd := bigfloat.StdDev(xs)
+	want = bigfloat.Sqrt(want.SetPrec(300)).SetPrec(200)
+	if !closeTo(sd, want, 198) {
+		t.Errorf("StdDev =\ngot  %g;\nwant %g", sd, want)
+	}
+
+	// identical values have zero variance
+	if v := bigfloat.Variance(floats(53, "0.1", "0.1", "0.1")); v.Sign() != 0 {
+		t.Errorf("Variance(0.1, 0.1, 0.1) = %g; want 0", v)
+	}
+}
+
+func TestStatsPanics(t *testing.T) {
+	for name, f := range map[string]func(){
+		"Mean(empty)":        func() { bigfloat.Mean(nil) },
+		"Variance(one)":      func() { bigfloat.Variance(floats(53, "1")) },
+		"StdDev(one)":        func() { bigfloat.StdDev(floats(53, "1")) },
+		"Variance(Inf, one)": func() { bigfloat.Variance(floats(53, "Inf", "1")) },
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%s did not panic", name)
+				}
+			}()
+			f()
+		}()
+	}
+}
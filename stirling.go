@@ -0,0 +1,168 @@
+package bigfloat
+
+import (
+	"math"
+	"math/big"
+	"math/bits"
+	"sync"
+)
+
+// bernoulli holds the Bernoulli numbers B(2k) computed so far, for k
+// from 1, for the Stirling series.
+var bernoulli struct {
+	mu sync.Mutex
+	b  []*big.Rat
+}
+
+// bernoulli2k returns B(2k), for k >= 1.
+func bernoulli2k(k int) *big.Rat {
+
+	bernoulli.mu.Lock()
+	defer bernoulli.mu.Unlock()
+	if k > len(bernoulli.b) {
+		n := 2 * len(bernoulli.b)
+		if n < k {
+			n = k
+		}
+		if n < 16 {
+			n = 16
+		}
+		bernoulli.b = bernoulliNumbers(n)
+	}
+
+	return bernoulli.b[k-1]
+}
+
+// bernoulliNumbers returns B(2k) for k from 1 to n, from the tangent
+// numbers T(k), computed in integers with the algorithm of R. P. Brent
+// and D. Harvey, Fast computation of Bernoulli, Tangent and Secant
+// numbers, 2011:
+//
+//	B(2k) = (-1)**(k-1)·2k·T(k)/(4**k·(4**k - 1))
+func bernoulliNumbers(n int) []*big.Rat {
+
+	t := make([]*big.Int, n+1)
+	t[1] = big.NewInt(1)
+	for k := 2; k <= n; k++ {
+		t[k] = new(big.Int).Mul(t[k-1], big.NewInt(int64(k-1)))
+	}
+	u := new(big.Int)
+	for k := 2; k <= n; k++ {
+		for j := k; j <= n; j++ {
+			u.Mul(t[j-1], big.NewInt(int64(j-k)))
+			t[j].Mul(t[j], big.NewInt(int64(j-k+2)))
+			t[j].Add(t[j], u)
+		}
+	}
+
+	b := make([]*big.Rat, n)
+	for k := 1; k <= n; k++ {
+		num := new(big.Int).Mul(t[k], big.NewInt(int64(2*k)))
+		if k%2 == 0 {
+			num.Neg(num)
+		}
+		den := new(big.Int).Lsh(big.NewInt(1), uint(2*k))
+		den.Mul(den, new(big.Int).Sub(den, big.NewInt(1)))
+		b[k-1] = new(big.Rat).SetFrac(num, den)
+	}
+
+	return b
+}
+
+// lgammaStirling returns log Γ(x) for x > 0, with an absolute error
+// below 2**-acc, from the Stirling series
+//
+//	log Γ(x) = (x - 1/2)·log(x) - x + log(2π)/2 + Σ B(2k)/(2k(2k-1)·x**(2k-1))
+//
+// which is enveloping for x > 0: the error of a partial sum is less
+// than the first term left out. The terms decrease until k ≈ πx, and
+// then diverge, so x is first increased to x + m, for an integer m,
+// until the least term is small enough, with
+//
+//	log Γ(x) = log Γ(x + m) - log(x·(x+1)···(x+m-1))
+//
+// The accuracy acc can be negative, for the huge values of x, whose
+// log Γ(x) needs mostly the first terms: then the series stops at
+// once, with no shift, and the precision is the one of the result.
+func lgammaStirling(x *big.Float, acc int) *big.Float {
+
+	// the least term is about exp(-2πx) = 2**(-9.06x)
+	x0 := float64(acc)/9 + 1
+	xf, _ := x.Float64()
+	m := 0
+	if xf < x0 {
+		m = int(math.Ceil(x0 - xf))
+	}
+
+	y := new(big.Float).SetPrec(x.Prec() + 64).Set(x)
+	y.Add(y, new(big.Float).SetInt64(int64(m)))
+
+	// absolute errors of 2**-acc in a value of about y·log(y) need
+	// the bits of y and of log(y) more
+	ey := y.MantExp(nil)
+	if ey < 1 {
+		ey = 1
+	}
+	w := uint(64)
+	if a := acc + ey + bits.Len(uint(ey)) + 16; a > 64 {
+		w = uint(a)
+	}
+	y.SetPrec(w)
+
+	var shift *big.Float
+	if m > 0 {
+		shift = new(big.Float).SetPrec(w).Set(x)
+		t := new(big.Float).SetPrec(w)
+		for i := 1; i < m; i++ {
+			shift.Mul(shift, t.Add(x, t.SetInt64(int64(i))))
+		}
+	}
+
+	// (y - 1/2)·log(y) - y + log(2π)/2
+	lg := Log(y)
+	z := new(big.Float).SetPrec(w).Sub(y, big.NewFloat(0.5))
+	z.Mul(z, lg).Sub(z, y)
+	z.Add(z, halfLog2Pi(w))
+
+	// the series, with y**-(2k-1) updated by 1/y²
+	iy2 := new(big.Float).SetPrec(w).Mul(y, y)
+	iy2.Quo(big.NewFloat(1), iy2)
+	yp := new(big.Float).SetPrec(w).Quo(big.NewFloat(1), y)
+	t := new(big.Float).SetPrec(w)
+	b := new(big.Float).SetPrec(w)
+	var last *big.Float
+	for k := 1; ; k++ {
+		bk := bernoulli2k(k)
+		b.SetInt(bk.Num())
+		t.SetInt(bk.Denom())
+		b.Quo(b, t)
+		t.SetInt64(int64(2 * k * (2*k - 1)))
+		t.Quo(b, t).Mul(t, yp)
+		if t.Sign() == 0 || t.MantExp(nil) < -acc-1 {
+			break
+		}
+		if last != nil && new(big.Float).Abs(t).Cmp(last) >= 0 {
+			// can't happen with y >= x0
+			panic("lgammaStirling: divergent series")
+		}
+		z.Add(z, t)
+		last = new(big.Float).Abs(t)
+		yp.Mul(yp, iy2)
+	}
+
+	if shift != nil {
+		z.Sub(z, Log(shift))
+	}
+
+	return z
+}
+
+// halfLog2Pi returns log(2π)/2 to prec bits, from the cache.
+func halfLog2Pi(prec uint) *big.Float {
+	return cached("log(2π)/2", prec, func() *big.Float {
+		x := pi(prec + 64)
+		x.SetMantExp(x, 1)
+		x = Log(x)
+		return x.SetMantExp(x, -1).SetPrec(prec)
+	})
+}
@@ -0,0 +1,61 @@
+package bigfloat
+
+import "math/big"
+
+// RoundSubnormal returns x rounded to prec bits with the rounding mode
+// mode, as in a binary format whose normal values have exponents of at
+// least minExp, as the ones of MantExp, and with gradual underflow
+// below it: the values smaller than 2**(minExp-1), the smallest normal
+// value, are multiples of 2**(minExp-prec), as the subnormals of IEEE
+// 754, so they have fewer bits the smaller they are, and the values
+// below the smallest of them are rounded to it or to ±0, as the mode
+// requires. A zero keeps its sign, and so does a result that underflows
+// to zero. The result has the precision prec and the rounding mode
+// mode; the function panics if prec is 0.
+func RoundSubnormal(x *big.Float, prec uint, minExp int, mode big.RoundingMode) *big.Float {
+
+	if prec == 0 {
+		panic("RoundSubnormal: precision 0")
+	}
+
+	z := new(big.Float).SetPrec(prec).SetMode(mode)
+	if x.Sign() == 0 || x.IsInf() || x.MantExp(nil) >= minExp {
+		return z.Set(x)
+	}
+
+	// x rounded to a multiple n of the quantum 2**qe; a carry into
+	// 2**(minExp-1) gives the smallest normal value
+	qe := minExp - int(prec)
+	n := RoundInt(new(big.Float).SetMantExp(x, -qe), mode)
+	if n.Sign() == 0 {
+		z.SetInt64(0)
+		if x.Signbit() {
+			z.Neg(z)
+		}
+		return z
+	}
+	z.SetInt(n)
+
+	return z.SetMantExp(z, qe)
+}
+
+// roundOdd rounds z, which holds a result rounded toward zero, to
+// odd: its last bit is set if the result is inexact. Rounding it again
+// to at least 2 bits less gives the same value as rounding the exact
+// result directly, whatever the mode.
+func roundOdd(z *big.Float) {
+
+	if z.Acc() == big.Exact || z.IsInf() {
+		return
+	}
+
+	// z = n·2**(e-prec), with the last bit of n at 2**(e-prec)
+	e := z.MantExp(nil)
+	prec := int(z.Prec())
+	n, _ := new(big.Float).SetMantExp(z, prec-e).Int(nil)
+	if n.Bit(0) == 1 {
+		return
+	}
+	ulp := new(big.Float).SetMantExp(big.NewFloat(float64(z.Sign())), e-prec)
+	z.Add(z, ulp)
+}
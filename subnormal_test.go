@@ -0,0 +1,117 @@
+package bigfloat_test
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/ThreeAndTwo/bigfloat"
+)
+
+func TestRoundSubnormal(t *testing.T) {
+	tiny := math.SmallestNonzeroFloat64 // 2**-1074
+	for _, test := range []struct {
+		m    float64 // x = m·2**e, which a float64 may not hold
+		e    int
+		mode big.RoundingMode
+		want float64
+	}{
+		{1.5, 0, big.ToNearestEven, 1.5},
+		{1, -1022, big.ToNearestEven, 0x1p-1022},
+		{1.5, -1074, big.ToNearestEven, 2 * tiny},
+		{1.5, -1074, big.ToZero, tiny},
+		{1, -1075, big.ToNearestEven, 0},
+		{1, -1075, big.ToNearestAway, tiny},
+		{1, -1075, big.AwayFromZero, tiny},
+		{1, -1100, big.ToPositiveInf, tiny},
+		{-1, -1100, big.ToPositiveInf, math.Copysign(0, -1)},
+		{-1, -1100, big.ToNegativeInf, -tiny},
+		{0x1.fffffffffffffp-1, -1022, big.ToNearestEven, 0x1p-1022},
+		{0x1.fffffffffffffp-1, -1022, big.ToZero, 0x1.ffffffffffffep-1023},
+		{0, 0, big.ToNearestEven, 0},
+		{math.Copysign(0, -1), 0, big.ToNearestEven, math.Copysign(0, -1)},
+		{math.Inf(-1), 0, big.ToNearestEven, math.Inf(-1)},
+	} {
+		x := new(big.Float).SetFloat64(test.m)
+		x.SetMantExp(x, test.e)
+		z := bigfloat.RoundSubnormal(x, 53, -1021, test.mode)
+		if f, _ := z.Float64(); f != test.want || math.Signbit(f) != math.Signbit(test.want) || z.Prec() != 53 || z.Mode() != test.mode {
+			t.Errorf("RoundSubnormal(%g·2**%d, %v) = %g (prec %d); want %g", test.m, test.e, test.mode, z, z.Prec(), test.want)
+		}
+	}
+}
+
+func TestRoundSubnormalGradual(t *testing.T) {
+	// with 8 bits and normal values from 0.5, the values below are
+	// multiples of 2**-8
+	for _, test := range []struct {
+		x, want string
+	}{
+		{"0.5", "0.5"},
+		{"0.3", "0.30078125"},
+		{"0.1", "0.1015625"},
+		{"0.01", "0.01171875"},
+		{"0.001", "0"},
+		{"0.002", "0.00390625"},
+	} {
+		x, _, _ := new(big.Float).SetPrec(100).Parse(test.x, 10)
+		want, _, _ := new(big.Float).Parse(test.want, 10)
+		if z := bigfloat.RoundSubnormal(x, 8, 0, big.ToNearestEven); z.Cmp(want) != 0 {
+			t.Errorf("RoundSubnormal(%s, 8, 0) = %g; want %s", test.x, z, test.want)
+		}
+	}
+}
+
+func TestContextSubnormal(t *testing.T) {
+	// the operations of float64 round once, and underflow gradually
+	c := bigfloat.Context{Prec: 53, Subnormal: true, MinExp: -1021}
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 10000; i++ {
+		x := math.Ldexp(r.Float64()+0.5, -r.Intn(60)-500)
+		y := math.Ldexp(r.Float64()+0.5, -r.Intn(60)-500)
+		if r.Intn(2) == 0 {
+			y = -y
+		}
+		bx, by := big.NewFloat(x), big.NewFloat(y)
+		for _, test := range []struct {
+			op   string
+			z    *big.Float
+			want float64
+		}{
+			{"*", c.Mul(bx, by), x * y},
+			{"/", c.Quo(bx, new(big.Float).SetFloat64(math.Ldexp(1.1, 600))), x / math.Ldexp(1.1, 600)},
+			{"+", c.Add(c.Mul(bx, by), big.NewFloat(math.Ldexp(x, -560))), x*y + math.Ldexp(x, -560)},
+		} {
+			if f, _ := test.z.Float64(); f != test.want {
+				t.Fatalf("%g %s %g = %g; want %g", x, test.op, y, f, test.want)
+			}
+		}
+	}
+
+	// and the conversions
+	z := c.Round(new(big.Float).SetPrec(100).SetMantExp(big.NewFloat(0.75), -1074))
+	if f, _ := z.Float64(); f != math.SmallestNonzeroFloat64 {
+		t.Errorf("Round(0.75·2**-1074) = %g", f)
+	}
+	c.Prec = 24
+	c.MinExp = -125
+	if f, _ := c.FromFloat64(1e-40).Float64(); f != float64(float32(1e-40)) {
+		t.Errorf("FromFloat64(1e-40) = %g for binary32; want %g", f, float32(1e-40))
+	}
+}
+
+// ---------- Benchmarks ----------
+
+func BenchmarkRoundSubnormal(b *testing.B) {
+	x := new(big.Float).SetPrec(200).SetMantExp(big.NewFloat(1.0/3), -1040)
+	for _, prec := range []uint{24, 53, 113} {
+		b.Run(fmt.Sprintf("%v", prec), func(b *testing.B) {
+			b.ReportAllocs()
+			for n := 0; n < b.N; n++ {
+				bigfloat.RoundSubnormal(x, prec, -1021, big.ToNearestEven)
+			}
+		})
+	}
+}
@@ -0,0 +1,199 @@
+package bigfloat
+
+import (
+	"math"
+	"math/big"
+)
+
+// Sum returns the sum of the elements of xs, correctly rounded to
+// the largest precision of the elements of xs. The sum of an empty
+// slice is 0. The function panics if xs contains infinities of
+// opposite sign.
+//
+// The sum is accumulated exactly, so the result doesn't depend on the
+// order of the elements and it's not affected by cancellation. The
+// memory used is proportional to the difference between the largest
+// and the smallest exponent of the elements.
+func Sum(xs []*big.Float) *big.Float {
+
+	var s exactSum
+	var prec uint
+	for _, x := range xs {
+		if x.Prec() > prec {
+			prec = x.Prec()
+		}
+		s.add(x)
+	}
+
+	return s.float(prec, big.ToNearestEven)
+}
+
+// Dot returns the dot product of xs and ys, the sum of the products
+// xs[i]·ys[i], correctly rounded to the largest precision of the
+// elements of xs and ys. The products are computed exactly and
+// accumulated like in Sum, so the result is rounded a single time.
+// The function panics if xs and ys have different lengths, if a zero
+// is multiplied by an infinity, or if the products contain infinities
+// of opposite sign.
+func Dot(xs, ys []*big.Float) *big.Float {
+
+	if len(xs) != len(ys) {
+		panic("Dot: slices of different lengths")
+	}
+
+	var s exactSum
+	var prec uint
+	for i, x := range xs {
+		y := ys[i]
+		if x.Prec() > prec {
+			prec = x.Prec()
+		}
+		if y.Prec() > prec {
+			prec = y.Prec()
+		}
+		if x.IsInf() || y.IsInf() || x.Sign() == 0 || y.Sign() == 0 {
+			// a signed zero or an infinity; Mul panics for 0·Inf
+			s.add(new(big.Float).Mul(x, y))
+			continue
+		}
+
+		mx, ex := intMantExp(x)
+		my, ey := intMantExp(y)
+		s.n++
+		s.addInt(mx.Mul(mx, my), ex+ey)
+	}
+
+	return s.float(prec, big.ToNearestEven)
+}
+
+// SumFloat64 returns the sum of the elements of xs, correctly rounded
+// to a float64. Like the addition of float64 values, it returns NaN
+// if xs contains a NaN or infinities of opposite sign, and ±Inf when
+// the sum overflows. Unlike it, the sum is accumulated exactly.
+func SumFloat64(xs []float64) float64 {
+
+	var s exactSum
+	x := new(big.Float)
+	for _, f := range xs {
+		if math.IsNaN(f) {
+			return math.NaN()
+		}
+		s.add(x.SetFloat64(f))
+	}
+	if s.posInf && s.negInf {
+		return math.NaN()
+	}
+
+	// Get the exact sum as a big.Float and round it a single time.
+	// Rounding at 53 bits first would be wrong for results in the
+	// float64 subnormal range.
+	z, _ := s.float(s.exactPrec(), big.ToNearestEven).Float64()
+	return z
+}
+
+// exactSum accumulates a sum of big.Float values exactly, as the
+// integer mant times 2**exp. The zero value is an empty sum.
+type exactSum struct {
+	mant big.Int
+	exp  int
+	tmp  big.Int // scratch space for addInt
+
+	n, negZeros    int // number of terms, of which -0
+	posInf, negInf bool
+}
+
+// add adds x to the sum.
+func (s *exactSum) add(x *big.Float) {
+
+	s.n++
+	switch {
+	case x.IsInf():
+		if x.Sign() > 0 {
+			s.posInf = true
+		} else {
+			s.negInf = true
+		}
+		return
+	case x.Sign() == 0:
+		if x.Signbit() {
+			s.negZeros++
+		}
+		return
+	}
+
+	m, e := intMantExp(x)
+	s.addInt(m, e)
+}
+
+// addInt adds m·2**e to the sum. m is not modified.
+func (s *exactSum) addInt(m *big.Int, e int) {
+
+	if s.mant.Sign() == 0 {
+		s.mant.Set(m)
+		s.exp = e
+		return
+	}
+
+	// align the two values to the smaller exponent
+	if e < s.exp {
+		s.mant.Lsh(&s.mant, uint(s.exp-e))
+		s.exp = e
+		s.mant.Add(&s.mant, m)
+	} else {
+		s.mant.Add(&s.mant, s.tmp.Lsh(m, uint(e-s.exp)))
+	}
+}
+
+// exactPrec returns the precision needed to represent the current
+// value of the sum exactly.
+func (s *exactSum) exactPrec() uint {
+
+	prec := uint(s.mant.BitLen())
+	if prec == 0 {
+		prec = 1
+	}
+
+	return prec
+}
+
+// float returns the sum, rounded to prec bits using the given rounding
+// mode. It panics if the sum contains infinities of opposite sign.
+func (s *exactSum) float(prec uint, mode big.RoundingMode) *big.Float {
+
+	z := new(big.Float).SetPrec(prec).SetMode(mode)
+	switch {
+	case s.posInf && s.negInf:
+		panic("Sum: infinities of opposite sign")
+	case s.posInf:
+		return z.SetInf(false)
+	case s.negInf:
+		return z.SetInf(true)
+	}
+
+	if s.mant.Sign() == 0 {
+		// x + (-x) = +0, but -0 + -0 = -0
+		if s.n > 0 && s.negZeros == s.n {
+			z.Neg(z)
+		}
+		return z
+	}
+
+	z.SetInt(&s.mant)
+	return z.SetMantExp(z, s.exp)
+}
+
+// intMantExp returns the integer m and the exponent e such that the
+// finite, non-zero x is exactly m·2**e, with m odd.
+func intMantExp(x *big.Float) (*big.Int, int) {
+
+	mant := new(big.Float)
+	e := x.MantExp(mant)
+
+	// x has at most MinPrec significant bits, so scaling the mantissa
+	// by 2**MinPrec gives an odd integer
+	p := int(x.MinPrec())
+	mant.SetMantExp(mant, p)
+	m, _ := mant.Int(nil)
+
+	return m, e - p
+}
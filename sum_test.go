@@ -0,0 +1,164 @@
+package bigfloat_test
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/ThreeAndTwo/bigfloat"
+)
+
+func TestSum(t *testing.T) {
+	for _, test := range []struct {
+		xs   []float64
+		want float64
+	}{
+		{nil, 0},
+		{[]float64{1}, 1},
+		{[]float64{1e100, 1, -1e100}, 1},
+		{[]float64{1, 1e100, 1, -1e100}, 2},
+		{[]float64{0x1p-1074, 0x1p-1074}, 0x1p-1073},
+		{[]float64{math.MaxFloat64, math.MaxFloat64, -math.MaxFloat64}, math.MaxFloat64},
+		{[]float64{math.Inf(+1), 1, 2}, math.Inf(+1)},
+		{[]float64{-1, math.Inf(-1)}, math.Inf(-1)},
+	} {
+		xs := make([]*big.Float, len(test.xs))
+		for i, f := range test.xs {
+			xs[i] = big.NewFloat(f)
+		}
+		if z, acc := bigfloat.Sum(xs).Float64(); z != test.want || acc != big.Exact {
+			t.Errorf("Sum(%v) = %g (%s); want %g (Exact)", test.xs, z, acc, test.want)
+		}
+		if z := bigfloat.SumFloat64(test.xs); z != test.want {
+			t.Errorf("SumFloat64(%v) = %g; want %g", test.xs, z, test.want)
+		}
+	}
+}
+
+func TestSumSignedZero(t *testing.T) {
+	negZero := math.Copysign(0, -1)
+	for _, test := range []struct {
+		xs      []float64
+		signbit bool
+	}{
+		{[]float64{negZero}, true},
+		{[]float64{negZero, negZero}, true},
+		{[]float64{negZero, 0}, false},
+		{[]float64{1, -1}, false},
+		{[]float64{-1, 1, negZero}, false},
+	} {
+		xs := make([]*big.Float, len(test.xs))
+		for i, f := range test.xs {
+			xs[i] = big.NewFloat(f)
+		}
+		if z := bigfloat.Sum(xs); z.Sign() != 0 || z.Signbit() != test.signbit {
+			t.Errorf("Sum(%v) = %g; want signbit = %v", test.xs, z, test.signbit)
+		}
+		if z := bigfloat.SumFloat64(test.xs); z != 0 || math.Signbit(z) != test.signbit {
+			t.Errorf("SumFloat64(%v) = %g; want signbit = %v", test.xs, z, test.signbit)
+		}
+	}
+}
+
+func TestSumRounding(t *testing.T) {
+	// compare with the exact sum computed using big.Rat
+	for i := 0; i < 500; i++ {
+		xs := make([]*big.Float, 20)
+		r := new(big.Rat)
+		for j := range xs {
+			f := (rand.Float64() - 0.5) * math.Pow(2, float64(rand.Intn(200)-100))
+			xs[j] = big.NewFloat(f).SetPrec(uint(24 + rand.Intn(60)))
+			xr, _ := xs[j].Rat(nil)
+			r.Add(r, xr)
+		}
+		z := bigfloat.Sum(xs)
+		want := new(big.Float).SetPrec(z.Prec()).SetRat(r)
+		if z.Cmp(want) != 0 {
+			t.Fatalf("Sum(%v) =\n got %g;\nwant %g", xs, z, want)
+		}
+	}
+}
+
+func TestDot(t *testing.T) {
+	// compare with the exact dot product computed using big.Rat
+	for i := 0; i < 300; i++ {
+		n := rand.Intn(20)
+		xs, ys := make([]*big.Float, n), make([]*big.Float, n)
+		r := new(big.Rat)
+		for j := range xs {
+			xs[j] = big.NewFloat((rand.Float64() - 0.5) * math.Pow(2, float64(rand.Intn(100)-50))).SetPrec(53)
+			ys[j] = big.NewFloat((rand.Float64() - 0.5) * math.Pow(2, float64(rand.Intn(100)-50))).SetPrec(uint(24 + rand.Intn(60)))
+			xr, _ := xs[j].Rat(nil)
+			yr, _ := ys[j].Rat(nil)
+			r.Add(r, xr.Mul(xr, yr))
+		}
+		z := bigfloat.Dot(xs, ys)
+		want := new(big.Float).SetPrec(z.Prec()).SetRat(r)
+		if z.Cmp(want) != 0 {
+			t.Fatalf("Dot(%v, %v) =\n got %g;\nwant %g", xs, ys, z, want)
+		}
+	}
+
+	// 1e20·1 + 1·1 - 1e20·1 doesn't cancel the 1
+	xs := []*big.Float{big.NewFloat(1e20), big.NewFloat(1), big.NewFloat(-1e20)}
+	ys := []*big.Float{big.NewFloat(1), big.NewFloat(1), big.NewFloat(1)}
+	if z := bigfloat.Dot(xs, ys); z.Cmp(big.NewFloat(1)) != 0 {
+		t.Errorf("Dot = %g; want 1", z)
+	}
+
+	// -0·1 + 0·-1 = -0
+	neg := new(big.Float).Neg(big.NewFloat(0))
+	if z := bigfloat.Dot([]*big.Float{neg, big.NewFloat(0)}, []*big.Float{big.NewFloat(1), big.NewFloat(-1)}); z.Sign() != 0 || !z.Signbit() {
+		t.Errorf("Dot(-0·1 + 0·-1) = %g; want -0", z)
+	}
+
+	inf := new(big.Float).SetInf(false)
+	if z := bigfloat.Dot([]*big.Float{inf, big.NewFloat(1)}, []*big.Float{big.NewFloat(-2), big.NewFloat(3)}); !z.IsInf() || z.Sign() > 0 {
+		t.Errorf("Dot(+Inf·-2 + 1·3) = %g; want -Inf", z)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Dot with different lengths did not panic")
+		}
+	}()
+	bigfloat.Dot(xs, ys[:1])
+}
+
+func TestSumFloat64Special(t *testing.T) {
+	if z := bigfloat.SumFloat64([]float64{1, math.NaN()}); !math.IsNaN(z) {
+		t.Errorf("SumFloat64(1, NaN) = %g; want NaN", z)
+	}
+	if z := bigfloat.SumFloat64([]float64{math.Inf(+1), math.Inf(-1)}); !math.IsNaN(z) {
+		t.Errorf("SumFloat64(+Inf, -Inf) = %g; want NaN", z)
+	}
+	if z := bigfloat.SumFloat64([]float64{math.MaxFloat64, math.MaxFloat64}); !math.IsInf(z, +1) {
+		t.Errorf("SumFloat64(MaxFloat64, MaxFloat64) = %g; want +Inf", z)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Sum(+Inf, -Inf) did not panic")
+		}
+	}()
+	bigfloat.Sum([]*big.Float{big.NewFloat(math.Inf(+1)), big.NewFloat(math.Inf(-1))})
+}
+
+// ---------- Benchmarks ----------
+
+func BenchmarkSumFloat64(b *testing.B) {
+	for _, n := range []int{1e2, 1e4} {
+		xs := make([]float64, n)
+		for i := range xs {
+			xs[i] = rand.NormFloat64() * 1e10
+		}
+		b.Run(fmt.Sprintf("%v", n), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				bigfloat.SumFloat64(xs)
+			}
+		})
+	}
+}
@@ -0,0 +1,109 @@
+package bigfloat
+
+import (
+	_ "embed"
+	"math/big"
+	"sync"
+)
+
+// The tables of the constants hold their first bits, packed 8 to a
+// byte in big-endian order: a table of n bits is the integer
+// ⌊c·2**(n-e)⌋, for the constant c in [2**(e-1), 2**e). This is the
+// compact form of the digits, which no general-purpose compressor
+// shrinks further; they're decoded on the first use of the table.
+// The tables are written, and checked against the computed values,
+// by TestTables.
+var (
+	//go:embed tables/pi.bin
+	piTableData []byte
+
+	//go:embed tables/ln2.bin
+	ln2TableData []byte
+
+	//go:embed tables/gamma.bin
+	gammaTableData []byte
+)
+
+// enableTables turns on the use of the tables, for the tests that
+// compute the constants.
+var enableTables = true
+
+// tableGuard is the number of bits of a table beyond the largest
+// precision it gives its constant to.
+const tableGuard = 64
+
+// A constTable is the table of the first bits of a constant, decoded
+// lazily.
+type constTable struct {
+	data []byte
+	exp  int // the exponent of the constant
+
+	once sync.Once
+	x    *big.Float
+}
+
+var (
+	piTable    = &constTable{data: piTableData, exp: 2}
+	ln2Table   = &constTable{data: ln2TableData, exp: 0}
+	gammaTable = &constTable{data: gammaTableData, exp: 0}
+)
+
+// table returns the table of c, or nil if c has none or the tables
+// are off.
+func (c Constant) table() *constTable {
+
+	if !enableTables {
+		return nil
+	}
+	switch c {
+	case Pi:
+		return piTable
+	case Ln2:
+		return ln2Table
+	case EulerGamma:
+		return gammaTable
+	}
+
+	return nil
+}
+
+// TablePrec returns the largest precision to which c is read from a
+// table built in the package, decoded on the first use, instead of
+// being computed, or 0 if c has no table. Below it, Value returns c at
+// the cost of a rounding.
+func (c Constant) TablePrec() uint {
+
+	t := c.table()
+	if t == nil {
+		return 0
+	}
+
+	return t.prec()
+}
+
+// prec returns the largest precision t gives its constant to: beyond
+// it, the rounding of the truncated bits of t could differ from the
+// one of the constant.
+func (t *constTable) prec() uint {
+
+	bits := uint(8 * len(t.data))
+	if bits <= tableGuard {
+		return 0
+	}
+
+	return bits - tableGuard
+}
+
+// value returns the constant of t rounded to prec bits, which must not
+// be larger than t.prec().
+func (t *constTable) value(prec uint) *big.Float {
+
+	t.once.Do(func() {
+		bits := 8 * len(t.data)
+		m := new(big.Int).SetBytes(t.data)
+		t.x = new(big.Float).SetPrec(uint(bits)).SetInt(m)
+		t.x.SetMantExp(t.x, t.exp-bits)
+	})
+
+	return new(big.Float).SetPrec(prec).Set(t.x)
+}
@@ -0,0 +1,115 @@
+package bigfloat
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"flag"
+	"math/big"
+	"os"
+	"testing"
+)
+
+// go test -run TestTables -tables writes the tables of the constants.
+var writeTables = flag.Bool("tables", false, "compute and write the tables of the constants")
+
+// go test -run TestTablesFull -fulltables also checks γ to the full
+// precision of its table, which takes about 10 minutes.
+var fullTables = flag.Bool("fulltables", false, "check all the tables to their full precision")
+
+// the bits of the tables, and the precision they are checked at
+var tableBits = map[Constant]uint{Pi: 1 << 20, Ln2: 1 << 20, EulerGamma: 1 << 20}
+
+const tableCheckPrec = 1 << 14
+
+var tableFiles = map[Constant]string{Pi: "tables/pi.bin", Ln2: "tables/ln2.bin", EulerGamma: "tables/gamma.bin"}
+
+func TestTables(t *testing.T) {
+	for _, c := range []Constant{Pi, Ln2, EulerGamma} {
+		if *writeTables {
+			enableTables = false
+			data := computeTable(c, tableBits[c])
+			enableTables = true
+			if err := os.WriteFile(tableFiles[c], data, 0o644); err != nil {
+				t.Fatal(err)
+			}
+			tab := c.table()
+			tab.data = data
+			t.Logf("%v: wrote %d bits", c, 8*len(data))
+		}
+
+		if got, want := c.TablePrec(), tableBits[c]-tableGuard; got != want {
+			t.Errorf("%v.TablePrec() = %d, want %d", c, got, want)
+		}
+		for _, prec := range []uint{1, 24, 53, 64, 1000, tableCheckPrec} {
+			z := c.Value(prec)
+			enableTables = false
+			want := c.Value(prec)
+			enableTables = true
+			if z.Prec() != prec || z.Cmp(want) != 0 {
+				t.Errorf("%v.Value(%d) from the table differs from the computed value", c, prec)
+			}
+		}
+	}
+}
+
+// tableHashes are the SHA-256 hashes of the tables, as computed apart
+// from this package, with binary splitting on big.Int values: the
+// series of Chudnovsky for π, the formula of Machin type
+// 18·atanh(1/26) - 2·atanh(1/4801) + 8·atanh(1/8749) for ln 2, and the
+// algorithm B1 of Brent and McMillan for γ, with 256 guard bits.
+var tableHashes = map[Constant]string{
+	Pi:         "41bbb3956fb07205f2efddfe4f5e6b8834bb36895452fdec44adf9e7cd1a1e14",
+	Ln2:        "659c74e988202417b87ee4ad18c09a77f82d2568c0851de2002283a09aafb456",
+	EulerGamma: "b5ce12c92ec04dcc6415d9bb9e6af858072e74b02df772486df5387b09a50b2d",
+}
+
+func TestTablesHash(t *testing.T) {
+	for c, want := range tableHashes {
+		h := sha256.Sum256(c.table().data)
+		if got := hex.EncodeToString(h[:]); got != want {
+			t.Errorf("%v: the table has the hash %s, want %s", c, got, want)
+		}
+	}
+}
+
+func TestTablesFull(t *testing.T) {
+	if testing.Short() {
+		t.Skip("the constants to the precision of the tables take seconds")
+	}
+	for _, c := range []Constant{Pi, Ln2, EulerGamma} {
+		if c == EulerGamma && !*fullTables {
+			continue
+		}
+		prec := c.TablePrec()
+		z := c.Value(prec)
+		enableTables = false
+		want := c.Value(prec)
+		enableTables = true
+		if z.Cmp(want) != 0 {
+			t.Errorf("%v.Value(%d) from the table differs from the computed value", c, prec)
+		}
+	}
+}
+
+func TestTablesOff(t *testing.T) {
+	enableTables = false
+	defer func() { enableTables = true }()
+	if p := Pi.TablePrec(); p != 0 {
+		t.Errorf("Pi.TablePrec() = %d with no tables, want 0", p)
+	}
+	if p := E.TablePrec(); p != 0 {
+		t.Errorf("E.TablePrec() = %d, want 0", p)
+	}
+}
+
+// computeTable returns the table of c of the given bits, computed
+// with 64 guard bits and truncated.
+func computeTable(c Constant, bits uint) []byte {
+
+	x := c.Value(bits + 64)
+	e := x.MantExp(nil)
+	y := new(big.Float).SetMode(big.ToZero).SetPrec(bits).Set(x)
+	m, _ := y.SetMantExp(y, int(bits)-e).Int(nil)
+
+	return m.FillBytes(make([]byte, bits/8))
+}
@@ -0,0 +1,62 @@
+package bigfloat
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// A TraceEvent records a call of a function of the package, for the
+// hook set with SetTraceHook.
+type TraceEvent struct {
+	Func     string        // the function, as "Exp"
+	Prec     uint          // precision of the argument, or the one asked for
+	Duration time.Duration // time spent in the call, with the calls it made
+}
+
+// traceHook holds a func(TraceEvent), nil when tracing is off, in a
+// struct since an atomic.Value can't store a nil.
+var traceHook atomic.Value
+
+type traceFunc struct {
+	f func(TraceEvent)
+}
+
+// SetTraceHook sets the function called at the end of every call of
+// the traced functions of the package, and returns the previous one.
+// The hook is off initially, and a nil h turns it off; the cost of an
+// untraced call is then that of an atomic load. The traced functions
+// are Exp, Log, Pow, Sqrt, Root, Gamma, Lgamma, Erf, Erfc, GammaInc,
+// GammaIncC, BetaInc, BetaIncC, SumSeries and Romberg. The calls they
+// make to each other are traced too, before the calls they're part
+// of, so a nested formula shows where its time goes. The hook can be
+// called by several goroutines at once, and it can be an adapter to a
+// logger, as
+//
+//	bigfloat.SetTraceHook(func(e bigfloat.TraceEvent) {
+//		slog.Debug("bigfloat", "func", e.Func, "prec", e.Prec, "duration", e.Duration)
+//	})
+func SetTraceHook(h func(TraceEvent)) func(TraceEvent) {
+
+	old, _ := traceHook.Swap(traceFunc{h}).(traceFunc)
+
+	return old.f
+}
+
+// trace starts the trace of a call of the function name at precision
+// prec, and returns the function that ends it, to be deferred:
+//
+//	defer trace("Exp", z.Prec())()
+func trace(name string, prec uint) func() {
+
+	h, _ := traceHook.Load().(traceFunc)
+	if h.f == nil {
+		return noTrace
+	}
+	start := time.Now()
+
+	return func() {
+		h.f(TraceEvent{name, prec, time.Since(start)})
+	}
+}
+
+func noTrace() {}
@@ -0,0 +1,62 @@
+package bigfloat_test
+
+import (
+	"math/big"
+	"sync"
+	"testing"
+
+	"github.com/ThreeAndTwo/bigfloat"
+)
+
+func TestTraceHook(t *testing.T) {
+	var mu sync.Mutex
+	var events []bigfloat.TraceEvent
+	hook := func(e bigfloat.TraceEvent) {
+		mu.Lock()
+		events = append(events, e)
+		mu.Unlock()
+	}
+	if old := bigfloat.SetTraceHook(hook); old != nil {
+		t.Errorf("SetTraceHook returned a hook initially")
+	}
+
+	// 2**(1/3) = exp(log(2)/3), with the calls nested in Pow traced
+	// before it
+	x := new(big.Float).SetPrec(300).SetInt64(2)
+	y := new(big.Float).SetPrec(300).Quo(big.NewFloat(1), big.NewFloat(3))
+	bigfloat.Pow(x, y)
+	if len(events) < 3 {
+		t.Fatalf("Pow traced %d events; want at least 3", len(events))
+	}
+	last := events[len(events)-1]
+	if last.Func != "Pow" || last.Prec != 300 {
+		t.Errorf("the last event of Pow is %+v; want Pow at prec 300", last)
+	}
+	seen := map[string]bool{}
+	for _, e := range events[:len(events)-1] {
+		seen[e.Func] = true
+		if e.Duration > last.Duration {
+			t.Errorf("the nested event %+v took longer than Pow, %v", e, last.Duration)
+		}
+	}
+	if !seen["Log"] || !seen["Exp"] {
+		t.Errorf("Pow traced %v; want Log and Exp", seen)
+	}
+
+	// the traces of the functions with two arguments
+	events = nil
+	bigfloat.BetaIncC(big.NewFloat(2), big.NewFloat(3), new(big.Float).SetPrec(100).SetFloat64(0.25))
+	if e := events[len(events)-1]; e.Func != "BetaIncC" || e.Prec != 100 {
+		t.Errorf("the last event of BetaIncC is %+v; want BetaIncC at prec 100", e)
+	}
+
+	// off again
+	if bigfloat.SetTraceHook(nil) == nil {
+		t.Errorf("SetTraceHook(nil) didn't return the hook")
+	}
+	events = nil
+	bigfloat.Exp(x)
+	if len(events) != 0 {
+		t.Errorf("Exp traced %d events with the hook off", len(events))
+	}
+}
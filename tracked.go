@@ -0,0 +1,269 @@
+package bigfloat
+
+import (
+	"math"
+	"math/big"
+)
+
+// trackedErrPrec is the precision of the error bounds of Tracked
+// values, which are always rounded up.
+const trackedErrPrec = 32
+
+// A Tracked is a value together with a bound on its absolute error,
+// the distance between the value and the exact result of the
+// computation that produced it. The operations on Tracked values
+// compute the value as the corresponding function of this package
+// would, and update the bound with both the propagated error of the
+// arguments and the rounding error of the operation, so that after a
+// long computation Digits tells how many of the digits of the value
+// can be trusted.
+//
+// The bounds are rigorous, not estimates: the error bound
+// arithmetic is carried out at a low precision, rounding upward. The
+// bound of a result that's infinite, or that's computed from
+// infinite values, is +Inf.
+type Tracked struct {
+	x   *big.Float
+	err *big.Float
+}
+
+// NewTracked returns a Tracked with the value x, and a bound err on
+// its absolute error. x is copied, with its precision and rounding
+// mode; a nil err means that x is exact. For a value that has been
+// rounded to nearest once, err is Ulp(x)/2. The function panics if
+// err is negative.
+func NewTracked(x, err *big.Float) *Tracked {
+
+	t := &Tracked{x: new(big.Float).Copy(x), err: newErrBound()}
+	if err != nil {
+		if err.Sign() < 0 {
+			panic("NewTracked: negative error bound")
+		}
+		t.err.Set(err)
+	}
+	if x.IsInf() {
+		t.err.SetInf(false)
+	}
+
+	return t
+}
+
+// Value returns the value of t.
+func (t *Tracked) Value() *big.Float {
+	return new(big.Float).Copy(t.x)
+}
+
+// Err returns the bound on the absolute error of the value of t.
+func (t *Tracked) Err() *big.Float {
+	return new(big.Float).Set(t.err)
+}
+
+// RelErr returns the bound on the relative error of the value of t,
+// Err/|Value|. It's +Inf if the value is 0 and the bound isn't.
+func (t *Tracked) RelErr() *big.Float {
+
+	r := newErrBound()
+	switch {
+	case t.err.Sign() == 0:
+		return r
+	case t.err.IsInf() || t.x.Sign() == 0:
+		return r.SetInf(false)
+	}
+
+	return r.Quo(t.err, new(big.Float).Abs(t.x))
+}
+
+// Digits returns the number of significant decimal digits of the
+// value of t that are correct, ⌊-log₁₀(RelErr)⌋, or 0 if there are
+// none. It returns math.MaxInt32 when the value is exact.
+func (t *Tracked) Digits() int {
+
+	r := t.RelErr()
+	switch {
+	case r.Sign() == 0:
+		return math.MaxInt32
+	case r.IsInf():
+		return 0
+	}
+
+	// r = mant·2**e, with 0.5 <= mant < 1
+	mant := new(big.Float)
+	e := r.MantExp(mant)
+	m, _ := mant.Float64()
+	d := math.Floor(-(math.Log10(m) + float64(e)*math.Log10(2)))
+	if d < 0 {
+		return 0
+	}
+
+	return int(d)
+}
+
+// Add returns t + u, at the larger of their precisions.
+func (t *Tracked) Add(u *Tracked) *Tracked {
+
+	z := new(big.Float).SetPrec(maxPrecision(t.x, u.x))
+	z.Add(t.x, u.x)
+
+	err := newErrBound().Add(t.err, u.err)
+	return newTrackedResult(z, err, false, t, u)
+}
+
+// Sub returns t - u, at the larger of their precisions.
+func (t *Tracked) Sub(u *Tracked) *Tracked {
+
+	z := new(big.Float).SetPrec(maxPrecision(t.x, u.x))
+	z.Sub(t.x, u.x)
+
+	err := newErrBound().Add(t.err, u.err)
+	return newTrackedResult(z, err, false, t, u)
+}
+
+// Mul returns t·u, at the larger of their precisions.
+func (t *Tracked) Mul(u *Tracked) *Tracked {
+
+	z := new(big.Float).SetPrec(maxPrecision(t.x, u.x))
+	z.Mul(t.x, u.x)
+	if t.x.IsInf() || u.x.IsInf() {
+		return newTrackedResult(z, nil, false, t, u)
+	}
+
+	// |xy - x'y'| <= |x|·εy + |y|·εx + εx·εy
+	err := newErrBound().Mul(new(big.Float).Abs(t.x), u.err)
+	err.Add(err, newErrBound().Mul(new(big.Float).Abs(u.x), t.err))
+	err.Add(err, newErrBound().Mul(t.err, u.err))
+
+	return newTrackedResult(z, err, false, t, u)
+}
+
+// Quo returns t/u, at the larger of their precisions. The error bound
+// is +Inf if it doesn't exclude that the exact divisor is 0. The
+// method panics if the value of u is 0.
+func (t *Tracked) Quo(u *Tracked) *Tracked {
+
+	if u.x.Sign() == 0 {
+		panic("Quo: division by zero")
+	}
+
+	z := new(big.Float).SetPrec(maxPrecision(t.x, u.x))
+	z.Quo(t.x, u.x)
+	ay := new(big.Float).Abs(u.x)
+	if t.x.IsInf() || u.x.IsInf() || u.err.Cmp(ay) >= 0 {
+		return newTrackedResult(z, nil, false, t, u)
+	}
+
+	// |x/y - x'/y'| <= (|x|·εy + |y|·εx) / (|y|·(|y| - εy))
+	err := newErrBound().Mul(new(big.Float).Abs(t.x), u.err)
+	err.Add(err, newErrBound().Mul(ay, t.err))
+	den := newErrBound().SetMode(big.ToNegativeInf).Sub(ay, u.err)
+	den.Mul(den, ay)
+	err.Quo(err, den)
+
+	return newTrackedResult(z, err, false, t, u)
+}
+
+// Sqrt returns the square root of t, at the precision of t. The
+// method panics if the value of t is negative.
+func (t *Tracked) Sqrt() *Tracked {
+
+	z := Sqrt(t.x)
+	if t.x.IsInf() {
+		return newTrackedResult(z, nil, true, t)
+	}
+
+	// |√x - √x'| <= εx/√x, and <= √εx when x = 0; big.Float.Sqrt
+	// isn't rounded in the direction of its mode, so the roots are
+	// moved by an ulp
+	var err *big.Float
+	if t.x.Sign() == 0 {
+		err = newErrBound().Sqrt(t.err)
+		err.Add(err, Ulp(err))
+	} else {
+		root := newErrBound().SetMode(big.ToNegativeInf).Sqrt(t.x)
+		root.Sub(root, Ulp(root))
+		err = newErrBound().Quo(t.err, root)
+	}
+
+	return newTrackedResult(z, err, true, t)
+}
+
+// Exp returns the exponential of t, at the precision of t.
+func (t *Tracked) Exp() *Tracked {
+
+	z := Exp(t.x)
+	if t.x.IsInf() || z.IsInf() {
+		return newTrackedResult(z, nil, true, t)
+	}
+
+	// |exp(x) - exp(x')| <= exp(x)·(exp(εx) - 1) <= exp(x)·εx·exp(εx),
+	// and exp(x) <= |z| + ulp(z)
+	err := newErrBound().Add(z, Ulp(z))
+	err.Mul(err, t.err)
+	if t.err.Sign() > 0 {
+		f := Exp(newErrBound().Set(t.err))
+		f.SetMode(big.ToPositiveInf).Add(f, Ulp(f))
+		err.Mul(err, f)
+	}
+
+	return newTrackedResult(z, err, true, t)
+}
+
+// Log returns the natural logarithm of t, at the precision of t. The
+// error bound is +Inf if it doesn't exclude that the exact argument
+// is 0 or negative. The method panics if the value of t is negative.
+func (t *Tracked) Log() *Tracked {
+
+	z := Log(t.x)
+	if t.x.IsInf() || z.IsInf() || t.err.Cmp(t.x) >= 0 {
+		return newTrackedResult(z, nil, true, t)
+	}
+
+	// |log(x) - log(x')| <= εx/(x - εx)
+	den := newErrBound().SetMode(big.ToNegativeInf).Sub(t.x, t.err)
+	err := newErrBound().Quo(t.err, den)
+
+	return newTrackedResult(z, err, true, t)
+}
+
+// newTrackedResult returns a Tracked with the value z, and the error
+// bound err plus the rounding error of z: one ulp if ulp is set, and
+// half an ulp otherwise, unless z is exact. A nil err, or an infinite
+// value in z or in args, gives an infinite bound.
+func newTrackedResult(z, err *big.Float, ulp bool, args ...*Tracked) *Tracked {
+
+	t := &Tracked{x: z, err: newErrBound()}
+	inf := err == nil || z.IsInf()
+	for _, a := range args {
+		inf = inf || a.x.IsInf() || a.err.IsInf()
+	}
+	if inf {
+		t.err.SetInf(false)
+		return t
+	}
+
+	t.err.Set(err)
+	if ulp || z.Acc() != big.Exact {
+		r := newErrBound().Set(Ulp(z))
+		if !ulp {
+			r.SetMantExp(r, -1)
+		}
+		t.err.Add(t.err, r)
+	}
+
+	return t
+}
+
+// newErrBound returns a new zero with the precision and the rounding
+// mode of the error bounds.
+func newErrBound() *big.Float {
+	return new(big.Float).SetPrec(trackedErrPrec).SetMode(big.ToPositiveInf)
+}
+
+// maxPrecision returns the larger of the precisions of x and y.
+func maxPrecision(x, y *big.Float) uint {
+
+	if x.Prec() > y.Prec() {
+		return x.Prec()
+	}
+
+	return y.Prec()
+}
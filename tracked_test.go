@@ -0,0 +1,120 @@
+package bigfloat_test
+
+import (
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/ThreeAndTwo/bigfloat"
+)
+
+// rounded returns a Tracked with the value of s rounded to prec bits,
+// and the exact value of s at precision 2000.
+func rounded(s string, prec uint) (*bigfloat.Tracked, *big.Float) {
+
+	r := parseRat(s)
+	x := new(big.Float).SetPrec(prec).SetRat(r)
+	half := bigfloat.Ulp(x)
+	half.SetMantExp(half, -1)
+
+	return bigfloat.NewTracked(x, half), new(big.Float).SetPrec(2000).SetRat(r)
+}
+
+// checkBound checks that the exact value want is within the bound of
+// t, and that t has at least minDigits correct digits.
+func checkBound(t *testing.T, name string, got *bigfloat.Tracked, want *big.Float, minDigits int) {
+
+	t.Helper()
+	d := new(big.Float).SetPrec(2000).Sub(got.Value(), want)
+	if d.Abs(d).Cmp(got.Err()) > 0 {
+		t.Errorf("%s: error %.5g is larger than the bound %.5g", name, d, got.Err())
+	}
+	if got.Digits() < minDigits {
+		t.Errorf("%s: %d correct digits; want at least %d", name, got.Digits(), minDigits)
+	}
+}
+
+func TestTrackedExact(t *testing.T) {
+
+	one := bigfloat.NewTracked(big.NewFloat(1), nil)
+	two := bigfloat.NewTracked(big.NewFloat(2), nil)
+	for _, z := range []*bigfloat.Tracked{one.Add(two), one.Sub(two), one.Mul(two), one.Quo(two)} {
+		if z.Err().Sign() != 0 || z.Digits() != math.MaxInt32 {
+			t.Errorf("%g has error %g and %d digits; want exact", z.Value(), z.Err(), z.Digits())
+		}
+	}
+
+	// 1/3 isn't exact
+	z := one.Quo(bigfloat.NewTracked(big.NewFloat(3), nil))
+	if z.Err().Cmp(bigfloat.Ulp(z.Value())) >= 0 || z.Digits() != 16 {
+		t.Errorf("1/3 has error %g and %d digits", z.Err(), z.Digits())
+	}
+}
+
+func TestTrackedPipeline(t *testing.T) {
+
+	const prec = 200
+	x, xw := rounded("1/3", prec)
+	y, yw := rounded("22/7", prec)
+
+	// the exact values, at 2000 bits
+	ew := bigfloat.Exp(xw)
+	lw := bigfloat.Log(yw)
+	checkBound(t, "exp", x.Exp(), ew, 55)
+	checkBound(t, "log", y.Log(), lw, 55)
+	checkBound(t, "sqrt", y.Sqrt(), bigfloat.Sqrt(yw), 55)
+
+	z := x.Exp().Mul(y.Log()).Add(y.Sqrt()).Quo(x.Sub(y))
+	zw := new(big.Float).SetPrec(2000).Mul(ew, lw)
+	zw.Add(zw, bigfloat.Sqrt(yw))
+	zw.Quo(zw, new(big.Float).SetPrec(2000).Sub(xw, yw))
+	checkBound(t, "pipeline", z, zw, 55)
+
+	// the bound grows with the number of operations, but not wildly
+	for i := 0; i < 100; i++ {
+		z = z.Mul(x).Quo(x).Add(y).Sub(y)
+	}
+	checkBound(t, "loop", z, zw, 50)
+}
+
+func TestTrackedCancellation(t *testing.T) {
+
+	// (1/3·3 - 1) loses all the digits
+	x, _ := rounded("1/3", 100)
+	three := bigfloat.NewTracked(big.NewFloat(3), nil)
+	one := bigfloat.NewTracked(big.NewFloat(1), nil)
+	z := x.Mul(three).Sub(one)
+	if z.Digits() != 0 {
+		t.Errorf("1/3·3 - 1 = %g ± %g has %d digits; want 0", z.Value(), z.Err(), z.Digits())
+	}
+
+	// 1 + 2**-60 - 1 at 100 bits keeps about 12 digits of the ones of x
+	u := bigfloat.NewTracked(new(big.Float).SetPrec(100).SetMantExp(big.NewFloat(1), -60), nil)
+	v := x.Add(one).Add(u).Sub(one).Sub(x)
+	if d := v.Digits(); d < 10 || d > 13 {
+		t.Errorf("1/3 + 1 + 2**-60 - 1 - 1/3 = %g ± %g has %d digits; want 10 to 13", v.Value(), v.Err(), d)
+	}
+}
+
+func TestTrackedInfiniteBounds(t *testing.T) {
+
+	// the divisor may be 0, and so may the argument of log
+	x := bigfloat.NewTracked(big.NewFloat(1e-10), big.NewFloat(1e-9))
+	one := bigfloat.NewTracked(big.NewFloat(1), nil)
+	for name, z := range map[string]*bigfloat.Tracked{
+		"quo": one.Quo(x),
+		"log": x.Log(),
+		"inf": bigfloat.NewTracked(new(big.Float).SetInf(false), nil).Add(one),
+	} {
+		if !z.Err().IsInf() || z.Digits() != 0 {
+			t.Errorf("%s: error %g and %d digits; want +Inf and 0", name, z.Err(), z.Digits())
+		}
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("NewTracked with a negative bound did not panic")
+		}
+	}()
+	bigfloat.NewTracked(big.NewFloat(1), big.NewFloat(-1))
+}
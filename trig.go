@@ -0,0 +1,78 @@
+package bigfloat
+
+import (
+	"math"
+	"math/big"
+)
+
+// sincos returns sin(x) and cos(x). Precision is the same as the one
+// of the argument. x must be finite.
+func sincos(x *big.Float) (sin, cos *big.Float) {
+
+	prec := x.Prec()
+
+	// sin(±0) = ±0, cos(±0) = 1
+	if x.Sign() == 0 {
+		return new(big.Float).SetPrec(prec).Set(x), big.NewFloat(1).SetPrec(prec)
+	}
+
+	// Reduce x to r = x - n·π/2, with |r| <= π/4, then halve r k
+	// times, use the Taylor series on the small argument and then
+	// rebuild the result with the double angle formulas
+	//     sin(2a) = 2·sin(a)·cos(a)
+	//     cos(2a) = 1 - 2·sin²(a)
+	// Each doubling loses about a bit, so add k more guard digits.
+	k := int(math.Sqrt(float64(prec)))/2 + 1
+	wprec := prec + uint(k) + 64
+	r, ni := remPi(x, wprec, -1)
+	r.SetMantExp(r, -k)
+
+	s, c := sincosTaylor(r)
+	t := new(big.Float).SetPrec(wprec)
+	for i := 0; i < k; i++ {
+		t.Mul(s, s)
+		s.Mul(s, c).Mul(s, big.NewFloat(2))
+		c.Sub(big.NewFloat(1), t.Mul(t, big.NewFloat(2)))
+	}
+
+	// undo the reduction by n·π/2
+	switch new(big.Int).And(ni, big.NewInt(3)).Int64() {
+	case 1:
+		s, c = c, s.Neg(s)
+	case 2:
+		s, c = s.Neg(s), c.Neg(c)
+	case 3:
+		s, c = c.Neg(c), s
+	}
+
+	return s.SetPrec(prec), c.SetPrec(prec)
+}
+
+// sincosTaylor returns sin(x) and cos(x) computed using the Taylor
+// series, at the precision of the argument. It's only efficient when
+// |x| is small.
+func sincosTaylor(x *big.Float) (sin, cos *big.Float) {
+
+	prec := x.Prec()
+
+	sin = new(big.Float).SetPrec(prec).Set(x)
+	cos = big.NewFloat(1).SetPrec(prec)
+
+	// term = ±x**j/j!, alternating between the two series
+	term := new(big.Float).SetPrec(prec).Set(x)
+	lim := -int(prec) - 1
+	for j := int64(2); ; j += 2 {
+		// cos term of degree j
+		term.Mul(term, x).Quo(term, big.NewFloat(float64(j))).Neg(term)
+		if term.Sign() == 0 || term.MantExp(nil) < lim {
+			break
+		}
+		cos.Add(cos, term)
+
+		// sin term of degree j+1
+		term.Mul(term, x).Quo(term, big.NewFloat(float64(j+1)))
+		sin.Add(sin, term)
+	}
+
+	return sin, cos
+}
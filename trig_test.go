@@ -0,0 +1,65 @@
+package bigfloat
+
+import (
+	"math"
+	"math/big"
+	"math/rand"
+	"testing"
+)
+
+func TestSincos(t *testing.T) {
+	const piStr = "3.1415926535897932384626433832795028841971693993751058209749445923078164062862089986280348253421170679821480865132823066470938446095505822317253594081284811174502841027019385211055596446229489549303819644288109756659334461284756482337867831652712019091456485669234603486104543266482133936072602491412737245870066063155881748815209209628292540917153644"
+
+	for _, prec := range []uint{24, 53, 64, 100, 200, 500, 1000} {
+		p, _, _ := new(big.Float).SetPrec(prec+64).Parse(piStr, 10)
+
+		// The arguments are rounded, so only require the results to
+		// be within a few ulps.
+		near := func(x *big.Float, want float64) bool {
+			d := new(big.Float).Sub(x, big.NewFloat(want))
+			return d.Sign() == 0 || d.MantExp(nil) <= -int(prec)+4
+		}
+
+		// sin(π/6) = 1/2
+		x := new(big.Float).SetPrec(prec).Quo(p, big.NewFloat(6))
+		s, _ := sincos(x)
+		if !near(s, 0.5) {
+			t.Errorf("prec = %d, sin(π/6) = %g; want 0.5", prec, s)
+		}
+
+		// cos(-7π/3) = 1/2
+		x = new(big.Float).SetPrec(prec).Quo(p, big.NewFloat(-3))
+		x.Mul(x, big.NewFloat(7))
+		_, c := sincos(x)
+		if !near(c, 0.5) {
+			t.Errorf("prec = %d, cos(-7π/3) = %g; want 0.5", prec, c)
+		}
+
+		// sin(-3π/2) = 1
+		x = new(big.Float).SetPrec(prec).Mul(p, big.NewFloat(-1.5))
+		s, _ = sincos(x)
+		if !near(s, 1) {
+			t.Errorf("prec = %d, sin(-3π/2) = %g; want 1", prec, s)
+		}
+
+		// sin²(x) + cos²(x) = 1
+		x = new(big.Float).SetPrec(prec).SetFloat64(1e6 + 0.123)
+		s, c = sincos(x)
+		s.Mul(s, s).Add(s, c.Mul(c, c)).Sub(s, big.NewFloat(1))
+		if s.Sign() != 0 && s.MantExp(nil) > -int(prec)+2 {
+			t.Errorf("prec = %d, sin²(x) + cos²(x) - 1 = %g", prec, s)
+		}
+	}
+}
+
+func TestSincosFloat64(t *testing.T) {
+	for i := 0; i < 2e3; i++ {
+		r := (rand.Float64() - 0.5) * 200
+		s, c := sincos(big.NewFloat(r))
+		s64, _ := s.Float64()
+		c64, _ := c.Float64()
+		if math.Abs(s64-math.Sin(r)) > 1e-15 || math.Abs(c64-math.Cos(r)) > 1e-15 {
+			t.Errorf("sincos(%g) =\n got %g, %g;\nwant %g, %g", r, s64, c64, math.Sin(r), math.Cos(r))
+		}
+	}
+}
@@ -0,0 +1,104 @@
+package bigfloat
+
+import "math/big"
+
+// Ulp returns the unit in the last place of x, the distance between
+// |x| and the next larger value of the same precision. Ulp(±0) is the
+// smallest positive big.Float, and Ulp(±Inf) is +Inf. Precision is
+// the same as the one of the argument.
+func Ulp(x *big.Float) *big.Float {
+
+	z := new(big.Float).SetPrec(x.Prec()).SetMode(x.Mode())
+	switch {
+	case x.IsInf():
+		return z.SetInf(false)
+	case x.Sign() == 0:
+		// 1·2**(MinExp-1) = 0.5·2**MinExp
+		z.SetInt64(1)
+		return z.SetMantExp(z, big.MinExp-1)
+	}
+
+	// x = mant·2**e, with 0.5 <= |mant| < 1
+	e := x.MantExp(nil)
+	z.SetInt64(1)
+
+	return z.SetMantExp(z, e-int(x.Prec()))
+}
+
+// NextUp returns the smallest value of the precision of x that's
+// larger than x. NextUp(±0) is the smallest positive big.Float,
+// NextUp(-Inf) is the finite value with the largest magnitude, and
+// the successor of the largest finite value is +Inf. Precision is
+// the same as the one of the argument.
+func NextUp(x *big.Float) *big.Float {
+	return nextFloat(x, false)
+}
+
+// NextDown returns the largest value of the precision of x that's
+// smaller than x. It's the same as -NextUp(-x). Precision is the
+// same as the one of the argument.
+func NextDown(x *big.Float) *big.Float {
+	return nextFloat(x, true)
+}
+
+// nextFloat returns NextUp(x), or NextDown(x) if down is set.
+func nextFloat(x *big.Float, down bool) *big.Float {
+
+	prec := x.Prec()
+	z := new(big.Float).SetPrec(prec).SetMode(x.Mode())
+
+	// neg is the sign of the result, when x isn't zero
+	neg := x.Signbit()
+	switch {
+	case x.Sign() == 0:
+		z.SetInt64(1)
+		z.SetMantExp(z, big.MinExp-1)
+		if down {
+			z.Neg(z)
+		}
+		return z
+	case x.IsInf():
+		if neg != down {
+			// -Inf up or +Inf down: the largest finite value
+			m := new(big.Int).Lsh(big.NewInt(1), prec)
+			z.SetInt(m.Sub(m, big.NewInt(1)))
+			z.SetMantExp(z, big.MaxExp-int(prec))
+			if neg {
+				z.Neg(z)
+			}
+			return z
+		}
+		return z.SetInf(neg)
+	}
+
+	// |x| = m·2**f, with m an integer of exactly prec bits; moving
+	// away from zero adds 1 to m, moving towards zero subtracts 1,
+	// but at a power of two that gives a number with one bit less,
+	// and the gap below is half an ulp. A carry out of the top bit
+	// gives a power of two, which is also fine.
+	mant := new(big.Float)
+	e := x.MantExp(mant)
+	mant.Abs(mant).SetMantExp(mant, int(prec))
+	m, _ := mant.Int(nil)
+	f := e - int(prec)
+
+	if neg == down {
+		m.Add(m, big.NewInt(1))
+	} else {
+		if m.TrailingZeroBits() == prec-1 {
+			m.Lsh(m, 1)
+			f--
+		}
+		m.Sub(m, big.NewInt(1))
+	}
+
+	// this rounds to ±Inf, and to a zero, when leaving the exponent
+	// range
+	z.SetInt(m)
+	z.SetMantExp(z, f)
+	if neg {
+		z.Neg(z)
+	}
+
+	return z
+}
@@ -0,0 +1,106 @@
+package bigfloat_test
+
+import (
+	"math"
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/ThreeAndTwo/bigfloat"
+)
+
+func TestUlp(t *testing.T) {
+	for _, test := range []struct {
+		x    *big.Float
+		want string
+	}{
+		{big.NewFloat(1), "0x.8p-51"},
+		{big.NewFloat(-1), "0x.8p-51"},
+		{big.NewFloat(1.5), "0x.8p-51"},
+		{big.NewFloat(0.75), "0x.8p-52"},
+		{new(big.Float).SetPrec(4).SetInt64(12), "0x.8p+1"},
+		{new(big.Float).SetInf(true), "+Inf"},
+	} {
+		got := bigfloat.Ulp(test.x)
+		if s := got.Text('p', 0); s != test.want {
+			t.Errorf("Ulp(%g) = %s; want %s", test.x, s, test.want)
+		}
+		if got.Prec() != test.x.Prec() {
+			t.Errorf("Ulp(%g) has precision %d", test.x, got.Prec())
+		}
+	}
+
+	tiny := bigfloat.Ulp(new(big.Float).SetPrec(10))
+	if tiny.Sign() <= 0 || tiny.MantExp(nil) != big.MinExp || tiny.Prec() != 10 {
+		t.Errorf("Ulp(0) = %g", tiny)
+	}
+}
+
+func TestNextUpDown(t *testing.T) {
+	// against math.Nextafter, far from the float64 exponent limits
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 1000; i++ {
+		f := math.Ldexp(r.Float64()+0.5, r.Intn(200)-100)
+		switch i % 4 {
+		case 1:
+			f = -f
+		case 2:
+			f = math.Ldexp(1, r.Intn(200)-100)
+		case 3:
+			f = -math.Ldexp(1, r.Intn(200)-100)
+		}
+		x := big.NewFloat(f)
+		if got, _ := bigfloat.NextUp(x).Float64(); got != math.Nextafter(f, math.Inf(1)) {
+			t.Errorf("NextUp(%v) = %v; want %v", f, got, math.Nextafter(f, math.Inf(1)))
+		}
+		if got, _ := bigfloat.NextDown(x).Float64(); got != math.Nextafter(f, math.Inf(-1)) {
+			t.Errorf("NextDown(%v) = %v; want %v", f, got, math.Nextafter(f, math.Inf(-1)))
+		}
+	}
+
+	for _, test := range []struct {
+		x        *big.Float
+		up, down string
+	}{
+		{new(big.Float).SetPrec(3).SetInt64(4), "0x.ap+3", "0x.ep+2"},
+		{new(big.Float).SetPrec(3).SetInt64(7), "0x.8p+4", "0x.cp+3"},
+		{new(big.Float).SetPrec(3).SetInt64(-4), "-0x.ep+2", "-0x.ap+3"},
+		{new(big.Float).SetPrec(1).SetInt64(1), "0x.8p+2", "0x.8p+0"},
+	} {
+		if got := bigfloat.NextUp(test.x).Text('p', 0); got != test.up {
+			t.Errorf("NextUp(%g) = %s; want %s", test.x, got, test.up)
+		}
+		if got := bigfloat.NextDown(test.x).Text('p', 0); got != test.down {
+			t.Errorf("NextDown(%g) = %s; want %s", test.x, got, test.down)
+		}
+	}
+}
+
+func TestNextUpDownLimits(t *testing.T) {
+	zero := new(big.Float).SetPrec(8)
+	up, down := bigfloat.NextUp(zero), bigfloat.NextDown(zero)
+	if up.Sign() <= 0 || up.MantExp(nil) != big.MinExp || up.Prec() != 8 || down.Cmp(new(big.Float).Neg(up)) != 0 {
+		t.Errorf("NextUp(0) = %g, NextDown(0) = %g", up, down)
+	}
+	if z := bigfloat.NextUp(down); z.Sign() != 0 || !z.Signbit() {
+		t.Errorf("NextUp(NextDown(0)) = %g; want -0", z)
+	}
+	if z := bigfloat.NextDown(up); z.Sign() != 0 || z.Signbit() {
+		t.Errorf("NextDown(NextUp(0)) = %g; want +0", z)
+	}
+
+	inf := new(big.Float).SetPrec(8).SetInf(false)
+	max := bigfloat.NextDown(inf)
+	if max.IsInf() || max.MantExp(nil) != big.MaxExp || max.MinPrec() != 8 {
+		t.Errorf("NextDown(+Inf) = %g", max)
+	}
+	if z := bigfloat.NextUp(max); !z.IsInf() || z.Signbit() {
+		t.Errorf("NextUp(max) = %g; want +Inf", z)
+	}
+	if z := bigfloat.NextUp(inf); !z.IsInf() || z.Signbit() {
+		t.Errorf("NextUp(+Inf) = %g; want +Inf", z)
+	}
+	if z := bigfloat.NextUp(new(big.Float).Neg(inf)); z.Cmp(new(big.Float).Neg(max)) != 0 {
+		t.Errorf("NextUp(-Inf) = %g; want %g", z, new(big.Float).Neg(max))
+	}
+}